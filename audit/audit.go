@@ -0,0 +1,338 @@
+// Package audit writes tamper-evident audit trails. Each entry is a JSON line
+// carrying the hash of the previous entry and its own HMAC or hash, computed
+// with the hash package, so that modifying, inserting, reordering or deleting
+// entries breaks the chain. Closing a log appends a seal entry; a log that
+// does not end with one may have been truncated.
+//
+// Without a key, entries are chained with plain hashes, which detect
+// accidental corruption but not an attacker who rewrites the rest of the
+// file. Use a secret key, kept apart from the log, to detect tampering.
+//
+// A log that is reopened gets a seal per session, so cutting it back to an
+// earlier seal still verifies as sealed. To detect that, record Result.Head
+// elsewhere and pass it as Options.Head when verifying later.
+//
+// Example usage:
+//
+//	log, err := audit.Open("/var/log/app/audit.log", &audit.Options{Key: key})
+//	if err != nil {
+//		return err
+//	}
+//	defer log.Close()
+//	_, err = log.Log("alice", "user.delete", map[string]any{"user_id": 42})
+//
+//	// Later, check that the trail is intact
+//	result, err := audit.VerifyFile("/var/log/app/audit.log", &audit.Options{Key: key, Head: lastHead})
+//	if err == nil && !result.Sealed {
+//		fmt.Println("audit log may be truncated")
+//	}
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bilte-co/toolshed/hash"
+)
+
+// DefaultAlgorithm is the hash algorithm used when Options.Algorithm is empty.
+const DefaultAlgorithm = "sha256"
+
+// SealAction is the action of the entry written by Logger.Close.
+const SealAction = "audit.seal"
+
+var (
+	// ErrTampered is returned when an audit log fails verification.
+	ErrTampered = errors.New("audit log tampered")
+
+	// ErrClosed is returned when logging to a closed Logger.
+	ErrClosed = errors.New("audit log closed")
+)
+
+// Entry is one record of an audit log.
+type Entry struct {
+	// Seq is the position of the entry in the log, starting at 1
+	Seq uint64 `json:"seq"`
+	// Time is when the entry was logged, in UTC
+	Time time.Time `json:"time"`
+	// Actor is who performed the action, such as a user or service name
+	Actor string `json:"actor,omitempty"`
+	// Action is what was done, such as "user.delete"
+	Action string `json:"action"`
+	// Data holds details of the action as JSON
+	Data json.RawMessage `json:"data,omitempty"`
+	// Prev is the hash of the previous entry, empty for the first
+	Prev string `json:"prev"`
+	// Hash is the hex HMAC or hash of the entry without this field
+	Hash string `json:"hash,omitempty"`
+}
+
+// Options configures how entries are chained. Logs must be verified with the
+// key and algorithm they were written with.
+type Options struct {
+	// Key is the HMAC key; without one entries are chained with plain hashes
+	Key []byte
+	// Algorithm is the hash algorithm, DefaultAlgorithm if empty
+	Algorithm string
+	// Head is the Result.Head of an earlier verification; if set, the log
+	// fails verification unless it still contains that entry
+	Head string
+}
+
+// Result describes a verified audit log.
+type Result struct {
+	// Entries is the number of entries, including seals
+	Entries int
+	// Head is the hash of the last entry, which can be recorded elsewhere
+	// and passed as Options.Head to detect the log being truncated
+	Head string
+	// Sealed reports whether the log ends with the seal written by
+	// Logger.Close; it is false if the log is still being written or was
+	// truncated
+	Sealed bool
+}
+
+// Logger appends chained entries to an audit log. It is safe for concurrent use.
+type Logger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	opts   Options
+	seq    uint64
+	prev   string
+	closed bool
+}
+
+// NewLogger returns a Logger that starts a new chain on w. Use Open to append
+// to an existing log file.
+func NewLogger(w io.Writer, opts *Options) (*Logger, error) {
+	o, err := options(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{w: w, opts: o}, nil
+}
+
+// Open returns a Logger appending to the audit log at path, creating it if it
+// does not exist. An existing log is verified first, and an error wrapping
+// ErrTampered is returned if it fails verification.
+func Open(path string, opts *Options) (*Logger, error) {
+	o, err := options(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	result, err := Verify(file, &o)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Logger{
+		w:      file,
+		closer: file,
+		opts:   o,
+		seq:    uint64(result.Entries),
+		prev:   result.Head,
+	}, nil
+}
+
+// Log appends an entry for an action, with data marshalled as JSON unless it
+// is nil, and returns the entry written.
+func (l *Logger) Log(actor, action string, data any) (*Entry, error) {
+	var raw json.RawMessage
+	if data != nil {
+		var err error
+		if raw, err = json.Marshal(data); err != nil {
+			return nil, fmt.Errorf("failed to marshal audit data: %w", err)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, ErrClosed
+	}
+	return l.append(actor, action, raw)
+}
+
+// Close appends a seal entry, marking the log as complete, and closes the
+// file if the Logger was created by Open. A log can be opened again to
+// continue after the seal.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+
+	_, err := l.append("", SealAction, nil)
+	if l.closer != nil {
+		if closeErr := l.closer.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close audit log: %w", closeErr))
+		}
+	}
+	return err
+}
+
+// append writes the next entry of the chain
+func (l *Logger) append(actor, action string, data json.RawMessage) (*Entry, error) {
+	entry := &Entry{
+		Seq:    l.seq + 1,
+		Time:   time.Now().UTC(),
+		Actor:  actor,
+		Action: action,
+		Data:   data,
+		Prev:   l.prev,
+	}
+
+	sum, err := digest(entry, l.opts)
+	if err != nil {
+		return nil, err
+	}
+	entry.Hash = sum
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	if _, err := l.w.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	if syncer, ok := l.w.(interface{ Sync() error }); ok {
+		if err := syncer.Sync(); err != nil {
+			return nil, fmt.Errorf("failed to sync audit log: %w", err)
+		}
+	}
+
+	l.seq, l.prev = entry.Seq, entry.Hash
+	return entry, nil
+}
+
+// Verify reads an audit log from r and checks that its entries are complete,
+// in order and unmodified. It returns an error wrapping ErrTampered for the
+// first entry that fails verification. A log that was cut short at an entry
+// boundary verifies, but its Result is not Sealed, unless it was cut back to
+// an earlier seal; set Options.Head to detect that too.
+func Verify(r io.Reader, opts *Options) (*Result, error) {
+	o, err := options(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	foundHead := o.Head == ""
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if len(data) == 0 && errors.Is(err, io.EOF) {
+			if !foundHead {
+				return nil, fmt.Errorf("%w: expected head %s not found in %d entries, log truncated", ErrTampered, o.Head, result.Entries)
+			}
+			return result, nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+
+		var entry Entry
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid entry: %w", ErrTampered, line, err)
+		}
+
+		if entry.Seq != uint64(result.Entries)+1 {
+			return nil, fmt.Errorf("%w: line %d: sequence %d, expected %d", ErrTampered, line, entry.Seq, result.Entries+1)
+		}
+		if entry.Prev != result.Head {
+			return nil, fmt.Errorf("%w: line %d: chain broken, previous hash does not match", ErrTampered, line)
+		}
+
+		sum, err := digest(&entry, o)
+		if err != nil {
+			return nil, err
+		}
+		expected, _ := hex.DecodeString(sum)
+		actual, err := hex.DecodeString(entry.Hash)
+		if err != nil || !hash.EqualConstantTime(expected, actual) {
+			return nil, fmt.Errorf("%w: line %d: hash mismatch", ErrTampered, line)
+		}
+
+		result.Entries++
+		result.Head = entry.Hash
+		foundHead = foundHead || strings.EqualFold(entry.Hash, o.Head)
+		result.Sealed = entry.Action == SealAction
+	}
+}
+
+// VerifyFile verifies the audit log at path as Verify does.
+func VerifyFile(path string, opts *Options) (*Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+	return Verify(file, opts)
+}
+
+// options returns a copy of opts with defaults, checking the algorithm
+func options(opts *Options) (Options, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Algorithm == "" {
+		o.Algorithm = DefaultAlgorithm
+	}
+	if _, err := sum(nil, o); err != nil {
+		return Options{}, err
+	}
+	return o, nil
+}
+
+// digest returns the hex HMAC or hash of an entry without its Hash field
+func digest(entry *Entry, opts Options) (string, error) {
+	unsigned := *entry
+	unsigned.Hash = ""
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	s, err := sum(data, opts)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(s), nil
+}
+
+// sum returns the HMAC of data if opts has a key, or else its hash
+func sum(data []byte, opts Options) ([]byte, error) {
+	var s []byte
+	var err error
+	if len(opts.Key) > 0 {
+		s, err = hash.HMAC(data, opts.Key, opts.Algorithm)
+	} else {
+		s, err = hash.HashBytes(data, opts.Algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash audit entry: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,233 @@
+package audit_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/audit"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("audit-secret")
+
+// writeLog writes a sealed log of n entries and returns its lines
+func writeLog(t *testing.T, opts *audit.Options, n int) []string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger, err := audit.NewLogger(&buf, opts)
+	require.NoError(t, err)
+	for i := range n {
+		_, err := logger.Log("alice", "user.update", map[string]any{"user_id": i, "note": "<b>&</b>"})
+		require.NoError(t, err)
+	}
+	require.NoError(t, logger.Close())
+
+	return strings.SplitAfter(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+func verify(lines []string, opts *audit.Options) (*audit.Result, error) {
+	return audit.Verify(strings.NewReader(strings.Join(lines, "")), opts)
+}
+
+func TestLogger_WritesVerifiableChain(t *testing.T) {
+	opts := &audit.Options{Key: testKey}
+	lines := writeLog(t, opts, 3)
+	require.Len(t, lines, 4)
+	require.Contains(t, lines[0], `"seq":1`)
+	require.Contains(t, lines[0], `"prev":""`)
+	require.Contains(t, lines[3], `"action":"audit.seal"`)
+
+	result, err := verify(lines, opts)
+	require.NoError(t, err)
+	require.Equal(t, 4, result.Entries)
+	require.True(t, result.Sealed)
+	require.Len(t, result.Head, 64)
+}
+
+func TestLogger_ReturnsEntry(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := audit.NewLogger(&buf, nil)
+	require.NoError(t, err)
+
+	entry, err := logger.Log("bob", "login", nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, entry.Seq)
+	require.Equal(t, "bob", entry.Actor)
+	require.Empty(t, entry.Data)
+	require.NotEmpty(t, entry.Hash)
+
+	require.NoError(t, logger.Close())
+	_, err = logger.Log("bob", "logout", nil)
+	require.ErrorIs(t, err, audit.ErrClosed)
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	opts := &audit.Options{Key: testKey}
+
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+		reason string
+	}{
+		{"modified data", func(l []string) []string {
+			l[1] = strings.Replace(l[1], `"user_id":1`, `"user_id":7`, 1)
+			return l
+		}, "hash mismatch"},
+		{"modified actor", func(l []string) []string {
+			l[0] = strings.Replace(l[0], "alice", "mallory", 1)
+			return l
+		}, "hash mismatch"},
+		{"added field", func(l []string) []string {
+			l[0] = strings.Replace(l[0], `{"seq"`, `{"extra":true,"seq"`, 1)
+			return l
+		}, "invalid entry"},
+		{"deleted entry", func(l []string) []string {
+			return append(l[:1], l[2:]...)
+		}, "sequence 3, expected 2"},
+		{"truncated start", func(l []string) []string {
+			return l[1:]
+		}, "sequence 2, expected 1"},
+		{"reordered", func(l []string) []string {
+			l[0], l[1] = l[1], l[0]
+			return l
+		}, "sequence 2, expected 1"},
+		{"partial line", func(l []string) []string {
+			l[3] = l[3][:len(l[3])/2]
+			return l
+		}, "invalid entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verify(tt.tamper(writeLog(t, opts, 3)), opts)
+			require.ErrorIs(t, err, audit.ErrTampered)
+			require.ErrorContains(t, err, tt.reason)
+		})
+	}
+}
+
+func TestVerify_WrongKey(t *testing.T) {
+	lines := writeLog(t, &audit.Options{Key: testKey}, 2)
+
+	_, err := verify(lines, &audit.Options{Key: []byte("wrong")})
+	require.ErrorContains(t, err, "line 1: hash mismatch")
+
+	_, err = verify(lines, nil)
+	require.ErrorIs(t, err, audit.ErrTampered)
+}
+
+func TestVerify_TruncatedEnd(t *testing.T) {
+	lines := writeLog(t, nil, 3)
+
+	result, err := verify(lines[:2], nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Entries)
+	require.False(t, result.Sealed)
+}
+
+func TestVerify_Empty(t *testing.T) {
+	result, err := audit.Verify(strings.NewReader(""), nil)
+	require.NoError(t, err)
+	require.Zero(t, result.Entries)
+	require.False(t, result.Sealed)
+}
+
+func TestOptions_UnsupportedAlgorithm(t *testing.T) {
+	_, err := audit.NewLogger(&bytes.Buffer{}, &audit.Options{Algorithm: "crc32"})
+	require.Error(t, err)
+
+	_, err = audit.Verify(strings.NewReader(""), &audit.Options{Algorithm: "crc32"})
+	require.Error(t, err)
+}
+
+func TestOptions_Algorithm(t *testing.T) {
+	opts := &audit.Options{Key: testKey, Algorithm: "sha512"}
+	result, err := verify(writeLog(t, opts, 1), opts)
+	require.NoError(t, err)
+	require.Len(t, result.Head, 128)
+}
+
+func TestOpen_AppendsAfterSeal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	opts := &audit.Options{Key: testKey}
+
+	for _, action := range []string{"deploy", "rollback"} {
+		logger, err := audit.Open(path, opts)
+		require.NoError(t, err)
+		_, err = logger.Log("ci", action, nil)
+		require.NoError(t, err)
+		require.NoError(t, logger.Close())
+	}
+
+	result, err := audit.VerifyFile(path, opts)
+	require.NoError(t, err)
+	require.Equal(t, 4, result.Entries)
+	require.True(t, result.Sealed)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestOpen_RefusesTamperedLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	lines := writeLog(t, nil, 2)
+	lines[0] = strings.Replace(lines[0], "alice", "eve", 1)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "")), 0o600))
+
+	_, err := audit.Open(path, nil)
+	require.ErrorIs(t, err, audit.ErrTampered)
+}
+
+func TestVerifyFile_Missing(t *testing.T) {
+	_, err := audit.VerifyFile(filepath.Join(t.TempDir(), "missing.log"), nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestVerify_Head(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	opts := &audit.Options{Key: testKey}
+
+	// Two sessions, each ending with a seal
+	var heads []string
+	var sizes []int64
+	for _, action := range []string{"deploy", "rollback"} {
+		logger, err := audit.Open(path, opts)
+		require.NoError(t, err)
+		_, err = logger.Log("ci", action, nil)
+		require.NoError(t, err)
+		require.NoError(t, logger.Close())
+
+		result, err := audit.VerifyFile(path, opts)
+		require.NoError(t, err)
+		heads = append(heads, result.Head)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		sizes = append(sizes, info.Size())
+	}
+
+	t.Run("matches", func(t *testing.T) {
+		for _, head := range heads {
+			result, err := audit.VerifyFile(path, &audit.Options{Key: testKey, Head: head})
+			require.NoError(t, err)
+			require.Equal(t, heads[1], result.Head)
+		}
+	})
+
+	t.Run("truncated to an earlier seal", func(t *testing.T) {
+		require.NoError(t, os.Truncate(path, sizes[0]))
+
+		// Without the recorded head the truncation goes unnoticed
+		result, err := audit.VerifyFile(path, opts)
+		require.NoError(t, err)
+		require.True(t, result.Sealed)
+
+		_, err = audit.VerifyFile(path, &audit.Options{Key: testKey, Head: heads[1]})
+		require.ErrorIs(t, err, audit.ErrTampered)
+		require.ErrorContains(t, err, "not found in 2 entries")
+	})
+}
@@ -0,0 +1,135 @@
+// Package base32 provides base32 encoding and decoding with the standard
+// alphabet (RFC 4648) and Crockford's alphabet, with or without padding.
+// The standard alphabet is the one used by TOTP secrets; Crockford's avoids
+// easily confused letters and is case-insensitive, which makes it a good fit
+// for DNS labels and tokens that people type.
+//
+// Decoding is lenient: it ignores case and whitespace, and accepts input
+// with or without padding. Crockford decoding also ignores hyphens and reads
+// I and L as 1 and O as 0.
+//
+// Example usage:
+//
+//	// Encode bytes to base32
+//	encoded := base32.StdEncoding.EncodeToString([]byte("Hello, World!"))
+//	fmt.Println(encoded) // JBSWY3DPFQQFO33SNRSCC===
+//
+//	// Decode a TOTP secret
+//	secret, err := base32.Decode("jbsw y3dp ehpk 3pxp")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+package base32
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	encodeStd       = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	encodeCrockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+var (
+	// StdEncoding is the standard base32 encoding, as defined in RFC 4648.
+	StdEncoding = newEncoding(encodeStd, false, true)
+
+	// RawStdEncoding is the standard base32 encoding without padding.
+	RawStdEncoding = StdEncoding.WithPadding(false)
+
+	// CrockfordEncoding is Crockford's base32 encoding, which is unpadded.
+	CrockfordEncoding = newEncoding(encodeCrockford, true, false)
+)
+
+// An Encoding is a base32 encoding/decoding scheme.
+type Encoding struct {
+	encoding  *base32.Encoding // Unpadded; padding is added by EncodeToString
+	crockford bool
+	padding   bool
+}
+
+func newEncoding(alphabet string, crockford, padding bool) *Encoding {
+	return &Encoding{
+		encoding:  base32.NewEncoding(alphabet).WithPadding(base32.NoPadding),
+		crockford: crockford,
+		padding:   padding,
+	}
+}
+
+// WithPadding returns a copy of the encoding that pads its output with '='
+// to a multiple of 8 characters when padding is true, and omits it otherwise.
+func (enc *Encoding) WithPadding(padding bool) *Encoding {
+	copied := *enc
+	copied.padding = padding
+	return &copied
+}
+
+// EncodedLen returns the length in bytes of the encoding of n source bytes.
+func (enc *Encoding) EncodedLen(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	if enc.padding {
+		return (n + 4) / 5 * 8
+	}
+	return enc.encoding.EncodedLen(n)
+}
+
+// EncodeToString returns the base32 encoding of src.
+func (enc *Encoding) EncodeToString(src []byte) string {
+	encoded := enc.encoding.EncodeToString(src)
+	if enc.padding && len(encoded)%8 != 0 {
+		encoded += strings.Repeat("=", 8-len(encoded)%8)
+	}
+	return encoded
+}
+
+// DecodeString returns the bytes represented by the base32 string s. Case,
+// whitespace and padding are ignored.
+func (enc *Encoding) DecodeString(s string) ([]byte, error) {
+	s = enc.normalize(s)
+	switch len(s) % 8 {
+	case 1, 3, 6:
+		// No number of bytes encodes to these lengths
+		return nil, fmt.Errorf("invalid base32 input: %w", base32.CorruptInputError(len(s)))
+	}
+
+	decoded, err := enc.encoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base32 input: %w", err)
+	}
+	return decoded, nil
+}
+
+// normalize converts s to the unpadded, uppercase form of the alphabet
+func (enc *Encoding) normalize(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || (enc.crockford && r == '-') {
+			return -1
+		}
+		r = unicode.ToUpper(r)
+		if enc.crockford {
+			switch r {
+			case 'I', 'L':
+				return '1'
+			case 'O':
+				return '0'
+			}
+		}
+		return r
+	}, s)
+	return strings.TrimRight(s, "=")
+}
+
+// Encode encodes the given data to a padded standard base32 string
+func Encode(data []byte) string {
+	return StdEncoding.EncodeToString(data)
+}
+
+// Decode decodes the given standard base32 string to bytes, with or without padding
+func Decode(encoded string) ([]byte, error) {
+	return StdEncoding.DecodeString(encoded)
+}
@@ -0,0 +1,108 @@
+package base32
+
+import (
+	"encoding/base32"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeToString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encoding *Encoding
+		input    []byte
+		expected string
+	}{
+		{name: "empty data", encoding: StdEncoding, input: []byte{}, expected: ""},
+		{name: "padded", encoding: StdEncoding, input: []byte("hello"), expected: "NBSWY3DP"},
+		{name: "padded partial block", encoding: StdEncoding, input: []byte("Hello, World!"), expected: "JBSWY3DPFQQFO33SNRSCC==="},
+		{name: "raw", encoding: RawStdEncoding, input: []byte("Hello, World!"), expected: "JBSWY3DPFQQFO33SNRSCC"},
+		{name: "crockford", encoding: CrockfordEncoding, input: []byte("Hello, World!"), expected: "91JPRV3F5GG5EVVJDHJ22"},
+		{name: "crockford padded", encoding: CrockfordEncoding.WithPadding(true), input: []byte("Hello, World!"), expected: "91JPRV3F5GG5EVVJDHJ22==="},
+		{name: "binary data", encoding: StdEncoding, input: []byte{0x00, 0xFF}, expected: "AD7Q===="},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded := tc.encoding.EncodeToString(tc.input)
+			require.Equal(t, tc.expected, encoded)
+			require.Equal(t, len(encoded), tc.encoding.EncodedLen(len(tc.input)))
+		})
+	}
+}
+
+func TestDecodeString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encoding *Encoding
+		input    string
+		expected string
+	}{
+		{name: "empty string", encoding: StdEncoding, input: "", expected: ""},
+		{name: "padded", encoding: StdEncoding, input: "JBSWY3DPFQQFO33SNRSCC===", expected: "Hello, World!"},
+		{name: "unpadded", encoding: StdEncoding, input: "JBSWY3DPFQQFO33SNRSCC", expected: "Hello, World!"},
+		{name: "lowercase with spaces", encoding: StdEncoding, input: " jbsw y3dp fqqf o33s nrsc c\n", expected: "Hello, World!"},
+		{name: "raw accepts padding", encoding: RawStdEncoding, input: "JBSWY3DPFQQFO33SNRSCC===", expected: "Hello, World!"},
+		{name: "crockford", encoding: CrockfordEncoding, input: "91JPRV3F5GG5EVVJDHJ22", expected: "Hello, World!"},
+		{name: "crockford lowercase with hyphens", encoding: CrockfordEncoding, input: "91jprv3f-5gg5evvj-dhj22", expected: "Hello, World!"},
+		{name: "crockford confusable letters", encoding: CrockfordEncoding, input: "9IJPRV3F5GG5EVVJDHJ22", expected: "Hello, World!"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := tc.encoding.DecodeString(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(decoded))
+		})
+	}
+
+	t.Run("crockford reads O as zero", func(t *testing.T) {
+		zero, err := CrockfordEncoding.DecodeString("00")
+		require.NoError(t, err)
+		oh, err := CrockfordEncoding.DecodeString("oO")
+		require.NoError(t, err)
+		require.Equal(t, zero, oh)
+	})
+}
+
+func TestDecodeString_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encoding *Encoding
+		input    string
+	}{
+		{name: "invalid character", encoding: StdEncoding, input: "JBSW!3DP"},
+		{name: "digit outside alphabet", encoding: StdEncoding, input: "JBSW13DP"},
+		{name: "truncated", encoding: StdEncoding, input: "J"},
+		{name: "crockford excludes U", encoding: CrockfordEncoding, input: "UU"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.encoding.DecodeString(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid base32 input")
+
+			var corrupt base32.CorruptInputError
+			require.True(t, errors.As(err, &corrupt))
+		})
+	}
+}
+
+func TestWithPadding_DoesNotModifyOriginal(t *testing.T) {
+	raw := StdEncoding.WithPadding(false)
+	require.Equal(t, "NBSWY3DPEE", raw.EncodeToString([]byte("hello!")))
+	require.Equal(t, "NBSWY3DPEE======", StdEncoding.EncodeToString([]byte("hello!")))
+}
+
+func TestEncodeDecode(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	encoded := Encode(secret)
+	require.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", encoded)
+
+	decoded, err := Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, secret, decoded)
+}
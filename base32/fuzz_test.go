@@ -0,0 +1,54 @@
+package base32
+
+import (
+	"bytes"
+	"encoding/base32"
+	"errors"
+	"testing"
+)
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte(""))
+	f.Add([]byte("Hello, World!"))
+	f.Add([]byte{0, 0, 1})
+	f.Add([]byte{0xff, 0xfe, 0xfd})
+
+	encodings := []*Encoding{StdEncoding, RawStdEncoding, CrockfordEncoding, CrockfordEncoding.WithPadding(true)}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, enc := range encodings {
+			encoded := enc.EncodeToString(data)
+			if len(encoded) != enc.EncodedLen(len(data)) {
+				t.Fatalf("encoded length %d does not match EncodedLen(%d) = %d", len(encoded), len(data), enc.EncodedLen(len(data)))
+			}
+
+			decoded, err := enc.DecodeString(encoded)
+			if err != nil {
+				t.Fatalf("failed to decode %q: %v", encoded, err)
+			}
+			if !bytes.Equal(data, decoded) && !(len(data) == 0 && len(decoded) == 0) {
+				t.Fatalf("round trip mismatch: got %x, want %x", decoded, data)
+			}
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add("")
+	f.Add("JBSWY3DPFQQFO33SNRSCC===")
+	f.Add("91jprv3f-5gg5evvj-dhj22")
+	f.Add("========")
+	f.Add("invalid!")
+	f.Add("  \t\n")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, enc := range []*Encoding{StdEncoding, CrockfordEncoding} {
+			if _, err := enc.DecodeString(input); err != nil {
+				var corrupt base32.CorruptInputError
+				if !errors.As(err, &corrupt) {
+					t.Fatalf("expected CorruptInputError, got %T: %v", err, err)
+				}
+			}
+		}
+	})
+}
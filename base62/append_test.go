@@ -0,0 +1,115 @@
+package base62_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bilte-co/toolshed/base62"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendEncode(t *testing.T) {
+	dst := []byte("id:")
+	dst = base62.StdEncoding.AppendEncode(dst, []byte("Hello World!"))
+	require.Equal(t, "id:T8dgcjRGkZ3aysdN", string(dst))
+
+	// Empty input leaves dst unchanged
+	require.Equal(t, []byte("id:"), base62.StdEncoding.AppendEncode([]byte("id:"), nil))
+
+	preserving := base62.StdEncoding.WithLeadingZeros(true)
+	require.Equal(t, "id:001", string(preserving.AppendEncode([]byte("id:"), []byte{0, 0, 1})))
+}
+
+func TestAppendEncode_ReusesBuffer(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	for _, s := range SamplesStd {
+		buf = base62.StdEncoding.AppendEncode(buf[:0], s.sourceBytes)
+		require.Equal(t, s.target, string(buf), "source: %s", s.source)
+	}
+
+	// Stale bytes in the reused capacity must not leak into the result
+	buf = bytes.Repeat([]byte{'z'}, 64)
+	buf = base62.StdEncoding.AppendEncode(buf[:0], []byte("Hello World!"))
+	require.Equal(t, "T8dgcjRGkZ3aysdN", string(buf))
+}
+
+func TestAppendDecode(t *testing.T) {
+	dst := []byte("data:")
+	dst, err := base62.StdEncoding.AppendDecode(dst, []byte("T8dgcjRGkZ3aysdN"))
+	require.NoError(t, err)
+	require.Equal(t, "data:Hello World!", string(dst))
+
+	// Invalid input leaves dst unchanged
+	dst, err = base62.StdEncoding.AppendDecode([]byte("data:"), []byte("T8dg!"))
+	require.Equal(t, base62.CorruptInputError(4), err)
+	require.Equal(t, "data:", string(dst))
+
+	preserving := base62.StdEncoding.WithLeadingZeros(true)
+	dst, err = preserving.AppendDecode([]byte{0xff}, []byte("001"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xff, 0, 0, 1}, dst)
+}
+
+func TestAppendDecode_ReusesBuffer(t *testing.T) {
+	buf := bytes.Repeat([]byte{0xff}, 64)
+	for _, s := range SamplesStd {
+		var err error
+		buf, err = base62.StdEncoding.AppendDecode(buf[:0], s.targetBytes)
+		require.NoError(t, err, "target: %s", s.target)
+		require.Equal(t, s.source, string(buf), "target: %s", s.target)
+	}
+}
+
+func TestAppend_Allocations(t *testing.T) {
+	src := []byte("Hello World!")
+	encoded := []byte("T8dgcjRGkZ3aysdN")
+	buf := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = base62.StdEncoding.AppendEncode(buf[:0], src)
+	})
+	require.Zero(t, allocs)
+
+	allocs = testing.AllocsPerRun(100, func() {
+		buf, _ = base62.StdEncoding.AppendDecode(buf[:0], encoded)
+	})
+	require.Zero(t, allocs)
+}
+
+func BenchmarkEncode(b *testing.B) {
+	data := bytes.Repeat([]byte("benchmark "), 10)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = base62.StdEncoding.Encode(data)
+	}
+}
+
+func BenchmarkAppendEncode(b *testing.B) {
+	data := bytes.Repeat([]byte("benchmark "), 10)
+	buf := make([]byte, 0, base62.MaxEncodedLen(len(data)))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = base62.StdEncoding.AppendEncode(buf[:0], data)
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	encoded := base62.StdEncoding.Encode(bytes.Repeat([]byte("benchmark "), 10))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = base62.StdEncoding.Decode(encoded)
+	}
+}
+
+func BenchmarkAppendDecode(b *testing.B) {
+	encoded := base62.StdEncoding.Encode(bytes.Repeat([]byte("benchmark "), 10))
+	buf := make([]byte, 0, base62.MaxDecodedLen(len(encoded)))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		buf, _ = base62.StdEncoding.AppendDecode(buf[:0], encoded)
+	}
+}
@@ -18,10 +18,17 @@
 package base62
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"slices"
 	"strconv"
 )
 
+// ErrInvalidAlphabet is returned by NewEncodingStrict for an unusable alphabet.
+var ErrInvalidAlphabet = errors.New("go-encoding/base62: invalid alphabet")
+
+// CorruptInputError reports the offset of an illegal byte in base62 input.
 type CorruptInputError int64
 
 func (e CorruptInputError) Error() string {
@@ -30,6 +37,13 @@ func (e CorruptInputError) Error() string {
 
 const encodeStd = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 
+var (
+	// encodeRatio is the number of base62 digits needed per input byte.
+	encodeRatio = math.Log(256) / math.Log(62)
+	// decodeRatio is the number of output bytes produced per base62 digit.
+	decodeRatio = math.Log(62) / math.Log(256)
+)
+
 // StdEncoding is the standard base62 encoding.
 var StdEncoding = NewEncoding(encodeStd)
 
@@ -39,8 +53,9 @@ var StdEncoding = NewEncoding(encodeStd)
 
 // An Encoding is a radix 62 encoding/decoding scheme, defined by a 62-character alphabet.
 type Encoding struct {
-	encode    [62]byte
-	decodeMap [256]byte
+	encode       [62]byte
+	decodeMap    [256]byte
+	leadingZeros bool
 }
 
 // NewEncoding returns a new padded Encoding defined by the given alphabet,
@@ -57,6 +72,42 @@ func NewEncoding(encoder string) *Encoding {
 		}
 	}
 
+	return newEncoding(encoder)
+}
+
+// NewEncodingStrict returns a new Encoding defined by the given alphabet, or
+// an error wrapping ErrInvalidAlphabet instead of panicking like NewEncoding.
+// It is meant for alphabets that come from configuration. The alphabet must
+// be 62 distinct printable ASCII characters other than space.
+//
+// Example usage:
+//
+//	enc, err := base62.NewEncodingStrict(cfg.Alphabet)
+//	if err != nil {
+//		return fmt.Errorf("invalid ID alphabet: %w", err)
+//	}
+func NewEncodingStrict(alphabet string) (*Encoding, error) {
+	if len(alphabet) != 62 {
+		return nil, fmt.Errorf("%w: %d bytes long, want 62", ErrInvalidAlphabet, len(alphabet))
+	}
+
+	var seen [256]bool
+	for i := range len(alphabet) {
+		c := alphabet[i]
+		if c <= ' ' || c > '~' {
+			return nil, fmt.Errorf("%w: character %q at byte %d is not printable ASCII", ErrInvalidAlphabet, c, i)
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("%w: character %q at byte %d is repeated", ErrInvalidAlphabet, c, i)
+		}
+		seen[c] = true
+	}
+
+	return newEncoding(alphabet), nil
+}
+
+// newEncoding returns the Encoding of a validated alphabet
+func newEncoding(encoder string) *Encoding {
 	e := new(Encoding)
 	copy(e.encode[:], encoder)
 
@@ -71,14 +122,68 @@ func NewEncoding(encoder string) *Encoding {
 	return e
 }
 
+// MaxEncodedLen returns the maximum length in bytes of the base62 encoding
+// of n source bytes. Leading zero bytes encode to nothing, so the actual
+// encoding may be shorter.
+func MaxEncodedLen(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return int(math.Ceil(encodeRatio * float64(n)))
+}
+
+// MaxDecodedLen returns the maximum length in bytes of the data decoded from
+// n bytes of base62 input. Callers handling untrusted input can use it to
+// reject oversized data before decoding, since both encoding and decoding
+// take time quadratic in the input length.
+func MaxDecodedLen(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return int(math.Ceil(decodeRatio * float64(n)))
+}
+
+// WithLeadingZeros returns a copy of the encoding that preserves leading zero
+// bytes when preserve is true. Encoding converts its input to one number,
+// which drops leading zero bytes, so by default hashes and keys that start
+// with them do not survive a round trip. In this mode, like base58, each
+// leading zero byte encodes to a leading zero digit and decodes back.
+//
+// Each leading zero digit then decodes to a whole byte, so decoded data can
+// exceed MaxDecodedLen, up to one byte per input character.
+//
+// Example usage:
+//
+//	enc := base62.StdEncoding.WithLeadingZeros(true)
+//	encoded := enc.EncodeToString([]byte{0, 0, 1})
+//	fmt.Println(encoded) // 001
+func (enc *Encoding) WithLeadingZeros(preserve bool) *Encoding {
+	copied := *enc
+	copied.leadingZeros = preserve
+	return &copied
+}
+
 /*
  * Encoder
  */
 
 // Encode encodes src using the encoding enc.
 func (enc *Encoding) Encode(src []byte) []byte {
+	return enc.AppendEncode(nil, src)
+}
+
+// AppendEncode appends the base62 encoding of src to dst and returns the
+// extended buffer. It only allocates if dst lacks the capacity for
+// MaxEncodedLen(len(src)) more bytes, so hot paths can reuse a buffer.
+func (enc *Encoding) AppendEncode(dst, src []byte) []byte {
+	if enc.leadingZeros {
+		for len(src) > 0 && src[0] == 0 {
+			dst = append(dst, enc.encode[0])
+			src = src[1:]
+		}
+	}
 	if len(src) == 0 {
-		return nil
+		return dst
 	}
 
 	// enc is a pointer receiver, so the use of enc.encode within the hot
@@ -86,17 +191,21 @@ func (enc *Encoding) Encode(src []byte) []byte {
 	// outside of the loop to speed up the encoder.
 	_ = enc.encode
 
+	// Convert in the spare capacity of dst, holding digit values until the
+	// number is complete
 	rs := 0
-	cs := int(math.Ceil(math.Log(256) / math.Log(62) * float64(len(src))))
-	dst := make([]byte, cs)
+	cs := MaxEncodedLen(len(src))
+	dst = slices.Grow(dst, cs)
+	buf := dst[len(dst) : len(dst)+cs]
+	clear(buf)
 
 	for i := range src {
 		c := 0
 		v := int(src[i])
 
 		for j := cs - 1; j >= 0 && (v != 0 || c < rs); j-- {
-			v += 256 * int(dst[j])
-			dst[j] = byte(v % 62)
+			v += 256 * int(buf[j])
+			buf[j] = byte(v % 62)
 			v /= 62
 			c++
 		}
@@ -104,15 +213,13 @@ func (enc *Encoding) Encode(src []byte) []byte {
 		rs = c
 	}
 
-	for i := range dst {
-		dst[i] = enc.encode[dst[i]]
+	// Move the digits to the start of buf, dropping unused leading space
+	copy(buf, buf[cs-rs:])
+	for i := range buf[:rs] {
+		buf[i] = enc.encode[buf[i]]
 	}
 
-	if cs > rs {
-		return dst[cs-rs:]
-	}
-
-	return dst
+	return dst[:len(dst)+rs]
 }
 
 // EncodeToString returns the base62 encoding of src.
@@ -126,12 +233,34 @@ func (enc *Encoding) EncodeToString(src []byte) string {
  */
 
 // Decode decodes src using the encoding enc.
-// If src contains invalid base62 data, it will return the
-// number of bytes successfully written and CorruptInputError.
+// If src contains invalid base62 data, it returns a CorruptInputError
+// holding the offset of the first illegal byte.
 // New line characters (\r and \n) are ignored.
 func (enc *Encoding) Decode(src []byte) ([]byte, error) {
-	if len(src) == 0 {
-		return nil, nil
+	decoded, err := enc.AppendDecode(nil, src)
+	if err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// AppendDecode appends the bytes represented by the base62 src to dst and
+// returns the extended buffer. It only allocates if dst lacks the capacity
+// for the decoded bytes, so hot paths can reuse a buffer. If src is invalid,
+// it returns dst unchanged and a CorruptInputError.
+func (enc *Encoding) AppendDecode(dst, src []byte) ([]byte, error) {
+	n, start := len(dst), 0
+	if enc.leadingZeros {
+		for ; start < len(src); start++ {
+			if c := src[start]; c == enc.encode[0] {
+				dst = append(dst, 0)
+			} else if c != '\n' && c != '\r' {
+				break
+			}
+		}
+	}
+	if start == len(src) {
+		return dst, nil
 	}
 
 	// Lift the nil check outside of the loop. enc.decodeMap is directly
@@ -139,10 +268,14 @@ func (enc *Encoding) Decode(src []byte) ([]byte, error) {
 	// receiver can't be nil.
 	_ = enc.decodeMap
 
+	// Convert in the spare capacity of dst, as in AppendEncode
 	rs := 0
-	cs := int(math.Ceil(math.Log(62) / math.Log(256) * float64(len(src))))
-	dst := make([]byte, cs)
-	for i := range src {
+	cs := MaxDecodedLen(len(src) - start)
+	dst = slices.Grow(dst, cs)
+	buf := dst[len(dst) : len(dst)+cs]
+	clear(buf)
+
+	for i := start; i < len(src); i++ {
 		if src[i] == '\n' || src[i] == '\r' {
 			continue
 		}
@@ -150,12 +283,12 @@ func (enc *Encoding) Decode(src []byte) ([]byte, error) {
 		c := 0
 		v := int(enc.decodeMap[src[i]])
 		if v == 255 {
-			return nil, CorruptInputError(src[i])
+			return dst[:n], CorruptInputError(i)
 		}
 
 		for j := cs - 1; j >= 0 && (v != 0 || c < rs); j-- {
-			v += 62 * int(dst[j])
-			dst[j] = byte(v % 256)
+			v += 62 * int(buf[j])
+			buf[j] = byte(v % 256)
 			v /= 256
 			c++
 		}
@@ -163,11 +296,8 @@ func (enc *Encoding) Decode(src []byte) ([]byte, error) {
 		rs = c
 	}
 
-	if cs > rs {
-		return dst[cs-rs:], nil
-	}
-
-	return dst, nil
+	copy(buf, buf[cs-rs:])
+	return dst[:len(dst)+rs], nil
 }
 
 // DecodeString returns the bytes represented by the base62 string s.
@@ -418,3 +418,99 @@ func TestStdEncoding_Availability(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, input, string(decoded))
 }
+
+func TestCorruptInputError_Offset(t *testing.T) {
+	_, err := base62.StdEncoding.DecodeString("abc!def")
+	var corruptErr base62.CorruptInputError
+	require.ErrorAs(t, err, &corruptErr)
+	require.Equal(t, base62.CorruptInputError(3), corruptErr)
+}
+
+func TestMaxLen(t *testing.T) {
+	require.Zero(t, base62.MaxEncodedLen(0))
+	require.Zero(t, base62.MaxEncodedLen(-1))
+	require.Zero(t, base62.MaxDecodedLen(0))
+	require.Zero(t, base62.MaxDecodedLen(-1))
+
+	for _, n := range []int{1, 2, 16, 100, 1000} {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = 0xff
+		}
+
+		encoded := base62.StdEncoding.Encode(data)
+		require.LessOrEqual(t, len(encoded), base62.MaxEncodedLen(n))
+
+		decoded, err := base62.StdEncoding.Decode(encoded)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(decoded), base62.MaxDecodedLen(len(encoded)))
+	}
+}
+
+func TestWithLeadingZeros(t *testing.T) {
+	enc := base62.StdEncoding.WithLeadingZeros(true)
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{name: "no leading zeros", data: []byte("Hello World!"), expected: "T8dgcjRGkZ3aysdN"},
+		{name: "leading zeros", data: []byte{0, 0, 1}, expected: "001"},
+		{name: "only zeros", data: []byte{0, 0, 0}, expected: "000"},
+		{name: "zero hash prefix", data: append([]byte{0}, "Hello World!"...), expected: "0T8dgcjRGkZ3aysdN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded := enc.EncodeToString(tc.data)
+			require.Equal(t, tc.expected, encoded)
+
+			decoded, err := enc.DecodeString(encoded)
+			require.NoError(t, err)
+			require.Equal(t, tc.data, decoded)
+		})
+	}
+
+	// The default encoding is unchanged
+	require.Equal(t, "1", base62.StdEncoding.EncodeToString([]byte{0, 0, 1}))
+}
+
+func TestWithLeadingZeros_Decode(t *testing.T) {
+	enc := base62.StdEncoding.WithLeadingZeros(true)
+
+	decoded, err := enc.DecodeString("0\r\n01")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 1}, decoded)
+
+	_, err = enc.DecodeString("00ab!c")
+	require.Equal(t, base62.CorruptInputError(4), err)
+}
+
+func TestNewEncodingStrict(t *testing.T) {
+	enc, err := base62.NewEncodingStrict("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789")
+	require.NoError(t, err)
+	require.Equal(t, base62.NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").EncodeToString([]byte("test")), enc.EncodeToString([]byte("test")))
+
+	testCases := []struct {
+		name     string
+		alphabet string
+		message  string
+	}{
+		{name: "too short", alphabet: "0123456789", message: "10 bytes long, want 62"},
+		{name: "too long", alphabet: "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz123", message: "65 bytes long, want 62"},
+		{name: "newline", alphabet: "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ\nabcdefghijklmnopqrstuvwxy", message: `'\n' at byte 36 is not printable ASCII`},
+		{name: "space", alphabet: "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ abcdefghijklmnopqrstuvwxy", message: `' ' at byte 36 is not printable ASCII`},
+		{name: "non-ASCII", alphabet: "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZé" + "bcdefghijklmnopqrstuvwxy", message: "is not printable ASCII"},
+		{name: "repeated", alphabet: "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxya", message: `'a' at byte 61 is repeated`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			enc, err := base62.NewEncodingStrict(tc.alphabet)
+			require.Nil(t, enc)
+			require.ErrorIs(t, err, base62.ErrInvalidAlphabet)
+			require.ErrorContains(t, err, tc.message)
+		})
+	}
+}
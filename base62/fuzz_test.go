@@ -0,0 +1,80 @@
+package base62_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bilte-co/toolshed/base62"
+)
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte(""))
+	f.Add([]byte("Hello, World!"))
+	f.Add([]byte{0, 0, 1})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff})
+	f.Add(bytes.Repeat([]byte{0}, 32))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		encoded := base62.StdEncoding.Encode(data)
+		if len(encoded) > base62.MaxEncodedLen(len(data)) {
+			t.Fatalf("encoded length %d exceeds MaxEncodedLen(%d) = %d", len(encoded), len(data), base62.MaxEncodedLen(len(data)))
+		}
+
+		decoded, err := base62.StdEncoding.Decode(encoded)
+		if err != nil {
+			t.Fatalf("failed to decode %q: %v", encoded, err)
+		}
+
+		// Leading zero bytes are not preserved by the numeric encoding
+		if expected := bytes.TrimLeft(data, "\x00"); !bytes.Equal(expected, decoded) {
+			t.Fatalf("round trip mismatch: got %x, want %x", decoded, expected)
+		}
+
+		// Unless the encoding preserves them
+		preserving := base62.StdEncoding.WithLeadingZeros(true)
+		encoded = preserving.Encode(data)
+		if len(encoded) > base62.MaxEncodedLen(len(data)) {
+			t.Fatalf("encoded length %d exceeds MaxEncodedLen(%d) = %d", len(encoded), len(data), base62.MaxEncodedLen(len(data)))
+		}
+		decoded, err = preserving.Decode(encoded)
+		if err != nil {
+			t.Fatalf("failed to decode %q: %v", encoded, err)
+		}
+		if !bytes.Equal(data, decoded) && !(len(data) == 0 && len(decoded) == 0) {
+			t.Fatalf("leading zero round trip mismatch: got %x, want %x", decoded, data)
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add("")
+	f.Add("T8dgcjRGuYUueWht")
+	f.Add("0000")
+	f.Add("abc\r\ndef")
+	f.Add("invalid!input")
+	f.Add("\xff\x00")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		decoded, err := base62.StdEncoding.DecodeString(input)
+		if err != nil {
+			offset := int(err.(base62.CorruptInputError))
+			if offset < 0 || offset >= len(input) {
+				t.Fatalf("error offset %d out of range for input of length %d", offset, len(input))
+			}
+			return
+		}
+
+		if len(decoded) > base62.MaxDecodedLen(len(input)) {
+			t.Fatalf("decoded length %d exceeds MaxDecodedLen(%d) = %d", len(decoded), len(input), base62.MaxDecodedLen(len(input)))
+		}
+
+		// Re-encoding decoded data must decode to the same bytes
+		again, err := base62.StdEncoding.Decode(base62.StdEncoding.Encode(decoded))
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded data: %v", err)
+		}
+		if !bytes.Equal(bytes.TrimLeft(decoded, "\x00"), again) {
+			t.Fatalf("re-encode mismatch: got %x, want %x", again, decoded)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package base62
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IDLen is the length of the base62 encoding of a 128-bit ID by EncodeID.
+const IDLen = 22
+
+// ErrInvalidID is returned by DecodeID for strings that do not encode a
+// 128-bit ID.
+var ErrInvalidID = errors.New("go-encoding/base62: invalid ID")
+
+// EncodeID returns the IDLen-character base62 encoding of a 128-bit ID such
+// as a UUID or ULID, for shorter identifiers in URLs. The encoding is padded
+// with leading zero digits to a fixed width, so encoded IDs sort in the same
+// order as the IDs themselves.
+//
+// Example usage:
+//
+//	id, _ := uuid.NewV7()
+//	short := base62.EncodeID(id)
+//	fmt.Println(short) // e.g. 02y0yGz3Hr1be8pYQHxNHS
+//
+//	parsed, err := base62.DecodeID[uuid.UUID](short)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func EncodeID[T ~[16]byte](id T) string {
+	var buf [IDLen]byte
+	encoded := StdEncoding.AppendEncode(buf[:0], id[:])
+	return StdEncoding.PadLeft(string(encoded), IDLen)
+}
+
+// DecodeID returns the 128-bit ID encoded by EncodeID as s. It returns an
+// error wrapping ErrInvalidID unless s is exactly IDLen base62 characters
+// encoding a value that fits in 128 bits.
+func DecodeID[T ~[16]byte](s string) (T, error) {
+	var id T
+	if len(s) != IDLen {
+		return id, fmt.Errorf("%w: got %d characters, expected %d", ErrInvalidID, len(s), IDLen)
+	}
+	for i := range len(s) {
+		if StdEncoding.decodeMap[s[i]] == 0xFF {
+			return id, fmt.Errorf("%w: %w", ErrInvalidID, CorruptInputError(i))
+		}
+	}
+
+	var buf [17]byte // MaxDecodedLen(IDLen)
+	decoded, err := StdEncoding.AppendDecode(buf[:0], []byte(s))
+	if err != nil {
+		return id, fmt.Errorf("%w: %w", ErrInvalidID, err)
+	}
+	if len(decoded) > len(id) {
+		return id, fmt.Errorf("%w: value exceeds 128 bits", ErrInvalidID)
+	}
+
+	// Leading zero bytes are dropped by decoding; right-align the rest
+	copy(id[len(id)-len(decoded):], decoded)
+	return id, nil
+}
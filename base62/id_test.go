@@ -0,0 +1,72 @@
+package base62_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bilte-co/toolshed/base62"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type testID [16]byte
+
+func TestEncodeID(t *testing.T) {
+	testCases := []struct {
+		name     string
+		id       testID
+		expected string
+	}{
+		{name: "uuid", id: testID{0x01, 0x92, 0xa8, 0xb4, 0x9c, 0x3f, 0x7b, 0x1a, 0x8e, 0x2d, 0x3f, 0x4a, 0x5b, 0x6c, 0x7d, 0x8e}, expected: "02y0yGz3Hr1be8pYQHxNHS"},
+		{name: "zero", id: testID{}, expected: "0000000000000000000000"},
+		{name: "max", id: testID(bytes.Repeat([]byte{0xff}, 16)), expected: "7n42DGM5Tflk9n8mt7Fhc7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded := base62.EncodeID(tc.id)
+			require.Equal(t, tc.expected, encoded)
+			require.Len(t, encoded, base62.IDLen)
+
+			decoded, err := base62.DecodeID[testID](encoded)
+			require.NoError(t, err)
+			require.Equal(t, tc.id, decoded)
+		})
+	}
+}
+
+func TestEncodeID_ULID(t *testing.T) {
+	id := ulid.Make()
+
+	decoded, err := base62.DecodeID[ulid.ULID](base62.EncodeID(id))
+	require.NoError(t, err)
+	require.Equal(t, id, decoded)
+}
+
+func TestEncodeID_Sorts(t *testing.T) {
+	small := testID{15: 1}
+	large := testID{0: 1}
+	require.Less(t, base62.EncodeID(small), base62.EncodeID(large))
+}
+
+func TestDecodeID_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{name: "too short", input: "2y0yGz3Hr1be8pYQHxNHS", message: "got 21 characters, expected 22"},
+		{name: "too long", input: "002y0yGz3Hr1be8pYQHxNHS", message: "got 23 characters, expected 22"},
+		{name: "invalid character", input: "02y0yGz3Hr1-e8pYQHxNHS", message: "input byte 11"},
+		{name: "newline", input: "02y0yGz3Hr1\ne8pYQHxNHS", message: "input byte 11"},
+		{name: "overflow", input: "7n42DGM5Tflk9n8mt7Fhc8", message: "value exceeds 128 bits"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := base62.DecodeID[testID](tc.input)
+			require.ErrorIs(t, err, base62.ErrInvalidID)
+			require.ErrorContains(t, err, tc.message)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package base62
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+var (
+	// ErrEmptyInput is returned when decoding a number from an empty string.
+	ErrEmptyInput = errors.New("go-encoding/base62: empty input")
+
+	// ErrOverflow is returned when a decoded number does not fit in a uint64.
+	ErrOverflow = errors.New("go-encoding/base62: value overflows uint64")
+
+	// ErrNegative is returned when encoding a negative number.
+	ErrNegative = errors.New("go-encoding/base62: negative number")
+)
+
+// bigDigits is the digit order of big.Int's base 62 text format
+const bigDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// EncodeUint64 returns the base62 representation of n, the shortest string
+// for numeric IDs such as those of URL shorteners. Zero encodes to the zero
+// digit. Use PadLeft for fixed-width IDs.
+//
+// Example usage:
+//
+//	id := base62.StdEncoding.EncodeUint64(1234567890)
+//	fmt.Println(id) // 1LY7VK
+func (enc *Encoding) EncodeUint64(n uint64) string {
+	var buf [11]byte // 62^11 > 2^64
+	i := len(buf)
+	for {
+		i--
+		buf[i] = enc.encode[n%62]
+		n /= 62
+		if n == 0 {
+			break
+		}
+	}
+	return string(buf[i:])
+}
+
+// DecodeUint64 returns the number represented by the base62 string s. It
+// returns a CorruptInputError for invalid characters and ErrOverflow if the
+// number does not fit in a uint64.
+func (enc *Encoding) DecodeUint64(s string) (uint64, error) {
+	if s == "" {
+		return 0, ErrEmptyInput
+	}
+
+	var n uint64
+	for i := range len(s) {
+		digit := enc.decodeMap[s[i]]
+		if digit == 0xFF {
+			return 0, CorruptInputError(i)
+		}
+		hi, lo := bits.Mul64(n, 62)
+		lo, carry := bits.Add64(lo, uint64(digit), 0)
+		if hi != 0 || carry != 0 {
+			return 0, ErrOverflow
+		}
+		n = lo
+	}
+	return n, nil
+}
+
+// EncodeBigInt returns the base62 representation of n, for numbers too large
+// for EncodeUint64 such as 128-bit IDs. It returns ErrNegative if n is
+// negative.
+func (enc *Encoding) EncodeBigInt(n *big.Int) (string, error) {
+	if n.Sign() < 0 {
+		return "", ErrNegative
+	}
+
+	text := []byte(n.Text(62))
+	for i, c := range text {
+		text[i] = enc.encode[strings.IndexByte(bigDigits, c)]
+	}
+	return string(text), nil
+}
+
+// DecodeBigInt returns the number represented by the base62 string s. It
+// returns a CorruptInputError for invalid characters.
+func (enc *Encoding) DecodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, ErrEmptyInput
+	}
+
+	text := []byte(s)
+	for i := range text {
+		digit := enc.decodeMap[text[i]]
+		if digit == 0xFF {
+			return nil, CorruptInputError(i)
+		}
+		text[i] = bigDigits[digit]
+	}
+
+	n, ok := new(big.Int).SetString(string(text), 62)
+	if !ok {
+		// Unreachable: every character is a valid digit
+		return nil, CorruptInputError(0)
+	}
+	return n, nil
+}
+
+// PadLeft pads the encoding of a number with leading zero digits to width
+// characters, for fixed-width IDs that sort in numeric order. Strings that
+// are already width characters or longer are returned unchanged.
+//
+// Example usage:
+//
+//	id := base62.StdEncoding.PadLeft(base62.StdEncoding.EncodeUint64(42), 6)
+//	fmt.Println(id) // 00000g
+func (enc *Encoding) PadLeft(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return strings.Repeat(string(enc.encode[0]), width-len(s)) + s
+}
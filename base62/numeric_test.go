@@ -0,0 +1,104 @@
+package base62_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/bilte-co/toolshed/base62"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeUint64(t *testing.T) {
+	testCases := []struct {
+		n        uint64
+		expected string
+	}{
+		{0, "0"},
+		{61, "z"},
+		{62, "10"},
+		{1234567890, "1LY7VK"},
+		{math.MaxUint64, "LygHa16AHYF"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			require.Equal(t, tc.expected, base62.StdEncoding.EncodeUint64(tc.n))
+
+			decoded, err := base62.StdEncoding.DecodeUint64(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, tc.n, decoded)
+		})
+	}
+}
+
+func TestDecodeUint64_Errors(t *testing.T) {
+	_, err := base62.StdEncoding.DecodeUint64("")
+	require.ErrorIs(t, err, base62.ErrEmptyInput)
+
+	_, err = base62.StdEncoding.DecodeUint64("LygHa16AHYG")
+	require.ErrorIs(t, err, base62.ErrOverflow)
+
+	_, err = base62.StdEncoding.DecodeUint64("1LY-VK")
+	var corrupt base62.CorruptInputError
+	require.True(t, errors.As(err, &corrupt))
+	require.Equal(t, base62.CorruptInputError(3), corrupt)
+}
+
+func TestEncodeBigInt(t *testing.T) {
+	maxUint128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+	testCases := []struct {
+		n        *big.Int
+		expected string
+	}{
+		{big.NewInt(0), "0"},
+		{big.NewInt(1234567890), "1LY7VK"},
+		{maxUint128, "7n42DGM5Tflk9n8mt7Fhc7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			encoded, err := base62.StdEncoding.EncodeBigInt(tc.n)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, encoded)
+
+			decoded, err := base62.StdEncoding.DecodeBigInt(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, 0, tc.n.Cmp(decoded))
+		})
+	}
+}
+
+func TestBigInt_Errors(t *testing.T) {
+	_, err := base62.StdEncoding.EncodeBigInt(big.NewInt(-1))
+	require.ErrorIs(t, err, base62.ErrNegative)
+
+	_, err = base62.StdEncoding.DecodeBigInt("")
+	require.ErrorIs(t, err, base62.ErrEmptyInput)
+
+	_, err = base62.StdEncoding.DecodeBigInt("abc+")
+	require.Equal(t, base62.CorruptInputError(3), err)
+}
+
+func TestNumeric_CustomAlphabet(t *testing.T) {
+	enc := base62.NewEncoding("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+	require.Equal(t, "bvIhFu", enc.EncodeUint64(1234567890))
+	encoded, err := enc.EncodeBigInt(big.NewInt(1234567890))
+	require.NoError(t, err)
+	require.Equal(t, "bvIhFu", encoded)
+	require.Equal(t, "aaaaabvIhFu", enc.PadLeft(encoded, 11))
+}
+
+func TestPadLeft(t *testing.T) {
+	id := base62.StdEncoding.PadLeft(base62.StdEncoding.EncodeUint64(42), 6)
+	require.Equal(t, "00000g", id)
+
+	decoded, err := base62.StdEncoding.DecodeUint64(id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), decoded)
+
+	require.Equal(t, "1LY7VK", base62.StdEncoding.PadLeft("1LY7VK", 4))
+}
@@ -0,0 +1,218 @@
+package base62
+
+import (
+	"bufio"
+	"io"
+	"math/bits"
+)
+
+// The stream format encodes each 8-byte block as the 11-digit base62 number
+// of its big-endian value. A final partial block uses the fewest digits
+// that can hold any value of its length.
+const (
+	blockBytes = 8
+	blockChars = 11
+)
+
+// partialBlockChars is the number of digits encoding a final block of n
+// bytes. The lengths are distinct, so a decoder can tell n from them.
+var partialBlockChars = [blockBytes + 1]int{0, 2, 3, 5, 6, 7, 9, 10, 11}
+
+// NewEncoder returns a stream encoder that writes the base62 encoding of the
+// data written to it to w, with StdEncoding. See (*Encoding).NewEncoder.
+func NewEncoder(w io.Writer) io.WriteCloser {
+	return StdEncoding.NewEncoder(w)
+}
+
+// NewDecoder returns a stream decoder that reads base62 written by a stream
+// encoder from r, with StdEncoding. See (*Encoding).NewDecoder.
+func NewDecoder(r io.Reader) io.Reader {
+	return StdEncoding.NewDecoder(r)
+}
+
+// NewEncoder returns a stream encoder that writes the base62 encoding of the
+// data written to it to w, using constant memory. The caller must Close the
+// encoder to flush the final partial block.
+//
+// Encode converts its whole input to one big number, which takes time
+// quadratic in its length and cannot be done incrementally. The stream
+// encoder instead encodes 8-byte blocks as 11 digits each, so its output
+// differs from Encode's and must be read back with NewDecoder. Unlike
+// Encode, it preserves leading zero bytes.
+//
+// Example usage:
+//
+//	encoder := base62.NewEncoder(os.Stdout)
+//	if _, err := io.Copy(encoder, file); err != nil {
+//		log.Fatal(err)
+//	}
+//	encoder.Close()
+func (enc *Encoding) NewEncoder(w io.Writer) io.WriteCloser {
+	return &encoder{enc: enc, w: w}
+}
+
+// NewDecoder returns a stream decoder that reads the output of a stream
+// encoder from r, using constant memory. Like Decode, it ignores new line
+// characters and returns a CorruptInputError for invalid input.
+func (enc *Encoding) NewDecoder(r io.Reader) io.Reader {
+	return &decoder{enc: enc, r: bufio.NewReader(r)}
+}
+
+type encoder struct {
+	enc    *Encoding
+	w      io.Writer
+	err    error
+	buf    [blockBytes]byte // Pending partial block
+	nbuf   int
+	out    [1024]byte // Encoded output of whole blocks
+	closed bool
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	if e.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	n := 0
+	for len(p) > 0 {
+		copied := copy(e.buf[e.nbuf:], p)
+		e.nbuf += copied
+		p = p[copied:]
+		n += copied
+		if e.nbuf < blockBytes {
+			break
+		}
+
+		// Encode whole blocks straight from p while the output buffer has room
+		out := e.enc.encodeBlock(e.out[:0], e.buf[:])
+		e.nbuf = 0
+		for len(p) >= blockBytes && len(out)+blockChars <= len(e.out) {
+			out = e.enc.encodeBlock(out, p[:blockBytes])
+			p = p[blockBytes:]
+			n += blockBytes
+		}
+		if _, e.err = e.w.Write(out); e.err != nil {
+			return n, e.err
+		}
+	}
+	return n, nil
+}
+
+// Close flushes the final partial block to the underlying writer. It does
+// not close the underlying writer.
+func (e *encoder) Close() error {
+	if e.err == nil && !e.closed && e.nbuf > 0 {
+		_, e.err = e.w.Write(e.enc.encodeBlock(e.out[:0], e.buf[:e.nbuf]))
+		e.nbuf = 0
+	}
+	e.closed = true
+	return e.err
+}
+
+// encodeBlock appends the encoding of a block of at most 8 bytes to dst
+func (enc *Encoding) encodeBlock(dst, block []byte) []byte {
+	var v uint64
+	for _, b := range block {
+		v = v<<8 | uint64(b)
+	}
+
+	n := partialBlockChars[len(block)]
+	for range n {
+		dst = append(dst, 0)
+	}
+	digits := dst[len(dst)-n:]
+	for i := n - 1; i >= 0; i-- {
+		digits[i] = enc.encode[v%62]
+		v /= 62
+	}
+	return dst
+}
+
+type decoder struct {
+	enc    *Encoding
+	r      *bufio.Reader
+	err    error
+	offset int64 // Offset of the next input byte
+	in     [blockChars]byte
+	pos    [blockChars]int64 // Input offsets of in, for errors
+	nin    int
+	buf    [blockBytes]byte
+	out    []byte // Decoded data not yet returned
+}
+
+func (d *decoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+
+		for d.nin < blockChars {
+			c, err := d.r.ReadByte()
+			if err != nil {
+				d.err = err
+				break
+			}
+			d.offset++
+			if c == '\n' || c == '\r' {
+				continue
+			}
+			d.in[d.nin] = c
+			d.pos[d.nin] = d.offset - 1
+			d.nin++
+		}
+
+		switch {
+		case d.nin == blockChars:
+			d.out, d.err = d.decodeBlock(blockBytes, d.err)
+		case d.nin > 0 && d.err == io.EOF:
+			n := -1
+			for i, chars := range partialBlockChars {
+				if chars == d.nin {
+					n = i
+				}
+			}
+			if n < 0 {
+				// No block length encodes to this many digits
+				d.err = CorruptInputError(d.pos[0])
+				break
+			}
+			d.out, d.err = d.decodeBlock(n, d.err)
+		}
+		d.nin = 0
+	}
+
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// decodeBlock decodes the pending digits into n bytes, returning err on
+// success
+func (d *decoder) decodeBlock(n int, err error) ([]byte, error) {
+	var v uint64
+	for i, c := range d.in[:d.nin] {
+		digit := d.enc.decodeMap[c]
+		if digit == 0xFF {
+			return nil, CorruptInputError(d.pos[i])
+		}
+		hi, lo := bits.Mul64(v, 62)
+		lo, carry := bits.Add64(lo, uint64(digit), 0)
+		if hi != 0 || carry != 0 {
+			return nil, CorruptInputError(d.pos[0])
+		}
+		v = lo
+	}
+	if n < blockBytes && v>>(8*n) != 0 {
+		// Too large for a block of n bytes
+		return nil, CorruptInputError(d.pos[0])
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		d.buf[i] = byte(v)
+		v >>= 8
+	}
+	return d.buf[:n], err
+}
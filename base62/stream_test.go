@@ -0,0 +1,96 @@
+package base62_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/base62"
+	"github.com/stretchr/testify/require"
+)
+
+func streamEncode(t *testing.T, data []byte, chunk int) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := base62.NewEncoder(&buf)
+	for i := 0; i < len(data); i += chunk {
+		_, err := encoder.Write(data[i:min(i+chunk, len(data))])
+		require.NoError(t, err)
+	}
+	require.NoError(t, encoder.Close())
+	return buf.String()
+}
+
+func TestNewEncoder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{name: "empty", data: nil, expected: ""},
+		{name: "one byte", data: []byte{0xff}, expected: "47"},
+		{name: "leading zeros", data: []byte{0, 0, 1}, expected: "00001"},
+		{name: "whole block", data: []byte("toolshed"), expected: "9zmVIvYvPS0"},
+		{name: "block and partial block", data: []byte("toolshed!"), expected: "9zmVIvYvPS00X"},
+		{name: "max block", data: bytes.Repeat([]byte{0xff}, 8), expected: "LygHa16AHYF"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, streamEncode(t, tc.data, 3))
+		})
+	}
+}
+
+func TestNewDecoder(t *testing.T) {
+	decoded, err := io.ReadAll(base62.NewDecoder(strings.NewReader("9zmVIvYvPS0\n0X\r\n")))
+	require.NoError(t, err)
+	require.Equal(t, "toolshed!", string(decoded))
+
+	decoded, err = io.ReadAll(base62.NewDecoder(strings.NewReader("")))
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+}
+
+func TestNewDecoder_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		offset int64
+	}{
+		{name: "illegal character", input: "AJkNyvIq9B!", offset: 10},
+		{name: "impossible final length", input: "AJkNyvIq9BOXYZa", offset: 11},
+		{name: "one character", input: "A", offset: 0},
+		{name: "block overflow", input: "zzzzzzzzzzz", offset: 0},
+		{name: "partial block overflow", input: "zz", offset: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := io.ReadAll(base62.NewDecoder(strings.NewReader(tc.input)))
+
+			var corrupt base62.CorruptInputError
+			require.True(t, errors.As(err, &corrupt), "error: %v", err)
+			require.Equal(t, base62.CorruptInputError(tc.offset), corrupt)
+		})
+	}
+}
+
+func TestStream_RoundTrip(t *testing.T) {
+	data := make([]byte, 100_003)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	data[0], data[1] = 0, 0
+
+	for _, chunk := range []int{1, 7, 8, 4096, len(data)} {
+		encoded := streamEncode(t, data, chunk)
+
+		decoded, err := io.ReadAll(base62.NewDecoder(strings.NewReader(encoded)))
+		require.NoError(t, err)
+		require.Equal(t, data, decoded, "chunk size %d", chunk)
+	}
+}
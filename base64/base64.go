@@ -1,6 +1,7 @@
 // Package base64 provides convenient wrapper functions for base64 encoding and decoding.
-// All functions use the standard base64 encoding (RFC 4648) which is URL-safe and
-// widely compatible across systems.
+// Encode uses the standard base64 encoding (RFC 4648), which is widely compatible
+// across systems; EncodeURL, EncodeRaw and EncodeRawURL produce the URL-safe and
+// unpadded variants used by JWTs and many APIs. Decode accepts any of the four.
 //
 // Example usage:
 //
@@ -14,14 +15,35 @@
 //		log.Fatal(err)
 //	}
 //	fmt.Println(decoded) // Hello, World!
+//
+//	// Encode with the URL-safe alphabet and no padding
+//	token := base64.RawURLEncoding.EncodeToString([]byte{0xfb, 0xff})
+//	fmt.Println(token) // -_8
 package base64
 
 import (
 	"encoding/base64"
-	"fmt"
 	"strings"
 )
 
+// MaxEncodedLen returns the length in bytes of the base64 encoding of n source bytes.
+func MaxEncodedLen(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return base64.StdEncoding.EncodedLen(n)
+}
+
+// MaxDecodedLen returns the maximum length in bytes of the data decoded from
+// n bytes of base64 input, padded or not. Callers handling untrusted input
+// can use it to reject oversized data before decoding.
+func MaxDecodedLen(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return base64.RawStdEncoding.DecodedLen(n)
+}
+
 // Encode encodes the given data to base64 string
 func Encode(data []byte) string {
 	return base64.StdEncoding.EncodeToString(data)
@@ -32,13 +54,44 @@ func EncodeString(s string) string {
 	return Encode([]byte(s))
 }
 
-// Decode decodes the given base64 string to bytes
+// EncodeURL encodes the given data to a URL-safe base64 string
+func EncodeURL(data []byte) string {
+	return URLEncoding.EncodeToString(data)
+}
+
+// EncodeRaw encodes the given data to a base64 string without padding
+func EncodeRaw(data []byte) string {
+	return RawStdEncoding.EncodeToString(data)
+}
+
+// EncodeRawURL encodes the given data to a URL-safe base64 string without
+// padding, as used by JWTs
+func EncodeRawURL(data []byte) string {
+	return RawURLEncoding.EncodeToString(data)
+}
+
+// EncodeMIME encodes the given data to a base64 string wrapped at 76 columns
+// with CRLF line breaks, for email bodies and attachments (RFC 2045)
+func EncodeMIME(data []byte) string {
+	return MIMEEncoding.EncodeToString(data)
+}
+
+// Decode decodes the given base64 string to bytes. It accepts any of the
+// four standard variants: the standard or URL-safe alphabet, with or without
+// padding. Whitespace is ignored, including the line breaks of wrapped
+// MIME output.
 func Decode(encoded string) ([]byte, error) {
-	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
-	if err != nil {
-		return nil, fmt.Errorf("invalid base64 input: %v", err)
+	if strings.ContainsAny(encoded, "-_") {
+		return URLEncoding.DecodeString(encoded)
 	}
-	return decoded, nil
+	return StdEncoding.DecodeString(encoded)
+}
+
+// DecodeURL decodes the given URL-safe base64 string to bytes, with or
+// without padding. Unlike Decode, it rejects the standard alphabet's '+'
+// and '/'.
+func DecodeURL(encoded string) ([]byte, error) {
+	return URLEncoding.DecodeString(encoded)
 }
 
 // DecodeToString decodes the given base64 string to string
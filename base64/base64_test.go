@@ -153,8 +153,32 @@ func TestDecode(t *testing.T) {
 			hasError: true,
 		},
 		{
-			name:     "invalid base64 - wrong padding",
+			name:     "unpadded input",
 			input:    "aGVsbG8",
+			expected: []byte("hello"),
+			hasError: false,
+		},
+		{
+			name:     "url-safe alphabet",
+			input:    "-_8BAg==",
+			expected: []byte{0xfb, 0xff, 0x01, 0x02},
+			hasError: false,
+		},
+		{
+			name:     "unpadded url-safe alphabet",
+			input:    "-_8BAg",
+			expected: []byte{0xfb, 0xff, 0x01, 0x02},
+			hasError: false,
+		},
+		{
+			name:     "invalid base64 - truncated",
+			input:    "aGVsb",
+			expected: nil,
+			hasError: true,
+		},
+		{
+			name:     "invalid base64 - mixed alphabets",
+			input:    "+_8BAg==",
 			expected: nil,
 			hasError: true,
 		},
@@ -466,3 +490,18 @@ func TestConsistencyWithStandardLibrary(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxLen(t *testing.T) {
+	assert.Zero(t, MaxEncodedLen(0))
+	assert.Zero(t, MaxDecodedLen(-4))
+	assert.Equal(t, 4, MaxEncodedLen(1))
+	assert.Equal(t, 8, MaxEncodedLen(4))
+	assert.Equal(t, 3, MaxDecodedLen(4))
+	assert.Equal(t, len(Encode(make([]byte, 100))), MaxEncodedLen(100))
+}
+
+func TestDecode_WrapsCorruptInputError(t *testing.T) {
+	_, err := Decode("not*base64")
+	var corrupt base64.CorruptInputError
+	assert.ErrorAs(t, err, &corrupt)
+}
@@ -0,0 +1,88 @@
+package base64
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidDataURI is returned when a string is not a data URI
+var ErrInvalidDataURI = errors.New("invalid data URI")
+
+// defaultDataURIType is the media type of data URIs that omit one (RFC 2397)
+const defaultDataURIType = "text/plain;charset=US-ASCII"
+
+// EncodeDataURI returns a base64 data URI (RFC 2397) holding data, such as
+// data:image/png;base64,iVBORw0KGgo... If mediaType is empty, it is sniffed
+// from the content of data.
+//
+// Example usage:
+//
+//	logo, err := os.ReadFile("logo.png")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(base64.EncodeDataURI("", logo)) // data:image/png;base64,...
+func EncodeDataURI(mediaType string, data []byte) string {
+	if mediaType == "" {
+		mediaType = DetectMediaType(data)
+	}
+	// Spaces are not allowed in URIs
+	mediaType = strings.ReplaceAll(mediaType, "; ", ";")
+	return "data:" + mediaType + ";base64," + Encode(data)
+}
+
+// DecodeDataURI returns the media type and data of a data URI, with the data
+// either base64 or percent-encoded. The media type defaults to
+// text/plain;charset=US-ASCII when the URI omits it.
+func DecodeDataURI(uri string) (string, []byte, error) {
+	uri = strings.TrimSpace(uri)
+	if len(uri) < len("data:") || !strings.EqualFold(uri[:len("data:")], "data:") {
+		return "", nil, fmt.Errorf("%w: missing data: scheme", ErrInvalidDataURI)
+	}
+	header, payload, ok := strings.Cut(uri[len("data:"):], ",")
+	if !ok {
+		return "", nil, fmt.Errorf("%w: missing ',' before the data", ErrInvalidDataURI)
+	}
+
+	mediaType, isBase64 := header, false
+	if i := strings.LastIndex(header, ";"); i >= 0 && strings.EqualFold(header[i+1:], "base64") {
+		mediaType, isBase64 = header[:i], true
+	}
+	if mediaType == "" {
+		mediaType = defaultDataURIType
+	} else if strings.HasPrefix(mediaType, ";") {
+		// Parameters without a type, such as ;charset=utf-8
+		mediaType = "text/plain" + mediaType
+	}
+
+	if !isBase64 {
+		decoded, err := url.PathUnescape(payload)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %w", ErrInvalidDataURI, err)
+		}
+		return mediaType, []byte(decoded), nil
+	}
+
+	// Base64 in URIs is sometimes percent-encoded, e.g. '+' as %2B
+	if strings.Contains(payload, "%") {
+		unescaped, err := url.PathUnescape(payload)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %w", ErrInvalidDataURI, err)
+		}
+		payload = unescaped
+	}
+	decoded, err := StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrInvalidDataURI, err)
+	}
+	return mediaType, decoded, nil
+}
+
+// DetectMediaType returns the MIME type of data sniffed from its first bytes,
+// using the WHATWG algorithm. It falls back to application/octet-stream.
+func DetectMediaType(data []byte) string {
+	return http.DetectContentType(data)
+}
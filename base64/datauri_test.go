@@ -0,0 +1,72 @@
+package base64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestEncodeDataURI(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mediaType string
+		data      []byte
+		expected  string
+	}{
+		{name: "sniffed png", data: pngHeader, expected: "data:image/png;base64,iVBORw0KGgoAAAANSUhEUg=="},
+		{name: "sniffed text", data: []byte("hi"), expected: "data:text/plain;charset=utf-8;base64,aGk="},
+		{name: "explicit type", mediaType: "image/svg+xml", data: []byte("<svg/>"), expected: "data:image/svg+xml;base64,PHN2Zy8+"},
+		{name: "explicit type with parameters", mediaType: "text/csv; charset=utf-8", data: []byte("a,b"), expected: "data:text/csv;charset=utf-8;base64,YSxi"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, EncodeDataURI(tc.mediaType, tc.data))
+		})
+	}
+}
+
+func TestDecodeDataURI(t *testing.T) {
+	testCases := []struct {
+		name      string
+		uri       string
+		mediaType string
+		data      string
+	}{
+		{name: "base64", uri: "data:image/svg+xml;base64,PHN2Zy8+", mediaType: "image/svg+xml", data: "<svg/>"},
+		{name: "unpadded base64", uri: "data:text/plain;base64,aGk", mediaType: "text/plain", data: "hi"},
+		{name: "percent-encoded base64", uri: "data:image/svg+xml;base64,PHN2Zy8%2B", mediaType: "image/svg+xml", data: "<svg/>"},
+		{name: "percent-encoded", uri: "data:text/plain;charset=utf-8,Hello%2C%20World", mediaType: "text/plain;charset=utf-8", data: "Hello, World"},
+		{name: "default type", uri: "data:,hi", mediaType: "text/plain;charset=US-ASCII", data: "hi"},
+		{name: "default type with base64", uri: "data:;base64,aGk=", mediaType: "text/plain;charset=US-ASCII", data: "hi"},
+		{name: "parameters only", uri: "data:;charset=utf-8,hi", mediaType: "text/plain;charset=utf-8", data: "hi"},
+		{name: "uppercase scheme", uri: "DATA:text/plain;BASE64,aGk=", mediaType: "text/plain", data: "hi"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mediaType, data, err := DecodeDataURI(tc.uri)
+			require.NoError(t, err)
+			require.Equal(t, tc.mediaType, mediaType)
+			require.Equal(t, tc.data, string(data))
+		})
+	}
+}
+
+func TestDecodeDataURI_Invalid(t *testing.T) {
+	for _, uri := range []string{"", "aGk=", "https://example.com", "data:text/plain;base64", "data:text/plain;base64,aG!k", "data:,%zz"} {
+		t.Run(uri, func(t *testing.T) {
+			_, _, err := DecodeDataURI(uri)
+			require.ErrorIs(t, err, ErrInvalidDataURI)
+		})
+	}
+}
+
+func TestDataURI_RoundTrip(t *testing.T) {
+	mediaType, data, err := DecodeDataURI(EncodeDataURI("", pngHeader))
+	require.NoError(t, err)
+	require.Equal(t, "image/png", mediaType)
+	require.Equal(t, pngHeader, data)
+}
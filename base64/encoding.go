@@ -0,0 +1,133 @@
+package base64
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+var (
+	// StdEncoding is the standard base64 encoding, as defined in RFC 4648.
+	StdEncoding = newEncoding(base64.StdEncoding, true)
+
+	// URLEncoding is the URL and filename safe base64 encoding, as defined
+	// in RFC 4648. It replaces '+' and '/' with '-' and '_'.
+	URLEncoding = newEncoding(base64.URLEncoding, true)
+
+	// RawStdEncoding is the standard base64 encoding without padding.
+	RawStdEncoding = StdEncoding.WithPadding(false)
+
+	// RawURLEncoding is the URL-safe base64 encoding without padding, as
+	// used by JWTs.
+	RawURLEncoding = URLEncoding.WithPadding(false)
+
+	// MIMEEncoding is the standard base64 encoding wrapped at MIMELineLength
+	// columns, as used for email bodies and attachments (RFC 2045).
+	MIMEEncoding = StdEncoding.WithWrap(MIMELineLength)
+)
+
+// MIMELineLength is the longest line allowed in base64 MIME bodies (RFC 2045).
+const MIMELineLength = 76
+
+// An Encoding is a base64 encoding/decoding scheme. Its padding only affects
+// encoding: decoding accepts input with or without padding.
+type Encoding struct {
+	encoding *base64.Encoding // Padded
+	padding  bool
+	wrap     int // Line length, or 0 for a single line
+}
+
+func newEncoding(encoding *base64.Encoding, padding bool) *Encoding {
+	return &Encoding{encoding: encoding, padding: padding}
+}
+
+// WithPadding returns a copy of the encoding that pads its output with '='
+// to a multiple of 4 characters when padding is true, and omits it otherwise.
+func (enc *Encoding) WithPadding(padding bool) *Encoding {
+	copied := *enc
+	copied.padding = padding
+	return &copied
+}
+
+// WithWrap returns a copy of the encoding that breaks its output into lines
+// of width characters, separated by CRLF as MIME requires. The last line is
+// not terminated. A width of 0 or less disables wrapping.
+func (enc *Encoding) WithWrap(width int) *Encoding {
+	copied := *enc
+	copied.wrap = max(width, 0)
+	return &copied
+}
+
+// EncodedLen returns the length in bytes of the encoding of n source bytes.
+func (enc *Encoding) EncodedLen(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	encodedLen := enc.output().EncodedLen(n)
+	if enc.wrap > 0 {
+		encodedLen += (encodedLen - 1) / enc.wrap * len(lineBreak)
+	}
+	return encodedLen
+}
+
+// EncodeToString returns the base64 encoding of src.
+func (enc *Encoding) EncodeToString(src []byte) string {
+	encoded := enc.output().EncodeToString(src)
+	if enc.wrap == 0 || len(encoded) <= enc.wrap {
+		return encoded
+	}
+
+	var b strings.Builder
+	b.Grow(enc.EncodedLen(len(src)))
+	for len(encoded) > enc.wrap {
+		b.WriteString(encoded[:enc.wrap])
+		b.WriteString(lineBreak)
+		encoded = encoded[enc.wrap:]
+	}
+	b.WriteString(encoded)
+	return b.String()
+}
+
+// DecodeString returns the bytes represented by the base64 string s.
+// Whitespace and padding are ignored.
+func (enc *Encoding) DecodeString(s string) ([]byte, error) {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	decoded, err := enc.encoding.WithPadding(base64.NoPadding).DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 input: %w", err)
+	}
+	return decoded, nil
+}
+
+// NewEncoder returns a stream encoder that writes the encoding of the data
+// written to it to w. The caller must Close the encoder to flush any partial
+// block.
+func (enc *Encoding) NewEncoder(w io.Writer) io.WriteCloser {
+	if enc.wrap > 0 {
+		w = &lineWrapper{w: w, width: enc.wrap}
+	}
+	return base64.NewEncoder(enc.output(), w)
+}
+
+// NewDecoder returns a stream decoder that reads base64 from r. Like
+// DecodeString, it ignores whitespace and accepts input with or without
+// padding.
+func (enc *Encoding) NewDecoder(r io.Reader) io.Reader {
+	return base64.NewDecoder(enc.encoding, &padder{r: &spaceSkipper{r: r}})
+}
+
+// output returns the standard library encoding used to encode
+func (enc *Encoding) output() *base64.Encoding {
+	if enc.padding {
+		return enc.encoding
+	}
+	return enc.encoding.WithPadding(base64.NoPadding)
+}
@@ -0,0 +1,170 @@
+package base64
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoding_EncodeToString(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x01, 0x02}
+
+	testCases := []struct {
+		name     string
+		encoding *Encoding
+		expected string
+	}{
+		{name: "std", encoding: StdEncoding, expected: "+/8BAg=="},
+		{name: "url", encoding: URLEncoding, expected: "-_8BAg=="},
+		{name: "raw std", encoding: RawStdEncoding, expected: "+/8BAg"},
+		{name: "raw url", encoding: RawURLEncoding, expected: "-_8BAg"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.encoding.EncodeToString(data))
+			require.Equal(t, len(tc.expected), tc.encoding.EncodedLen(len(data)))
+
+			decoded, err := tc.encoding.DecodeString(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, data, decoded)
+		})
+	}
+}
+
+func TestEncoding_DecodeString_Padding(t *testing.T) {
+	for _, input := range []string{"aGVsbG8=", "aGVsbG8", " aGVs\nbG8= "} {
+		decoded, err := RawStdEncoding.DecodeString(input)
+		require.NoError(t, err, input)
+		require.Equal(t, "hello", string(decoded))
+
+		decoded, err = StdEncoding.DecodeString(input)
+		require.NoError(t, err, input)
+		require.Equal(t, "hello", string(decoded))
+	}
+}
+
+func TestEncoding_DecodeString_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encoding *Encoding
+		input    string
+	}{
+		{name: "url characters in std", encoding: StdEncoding, input: "-_8BAg"},
+		{name: "std characters in url", encoding: URLEncoding, input: "+/8BAg"},
+		{name: "truncated", encoding: StdEncoding, input: "aGVsb"},
+		{name: "padding in the middle", encoding: StdEncoding, input: "aG==VsbG8"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.encoding.DecodeString(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid base64 input")
+		})
+	}
+}
+
+func TestEncoding_Stream(t *testing.T) {
+	data := bytes.Repeat([]byte{0xfb, 0xff, 0x01}, 1000)
+	data = append(data, 0x02)
+
+	for _, enc := range []*Encoding{StdEncoding, URLEncoding, RawStdEncoding, RawURLEncoding} {
+		var buf bytes.Buffer
+		encoder := enc.NewEncoder(&buf)
+		_, err := encoder.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, encoder.Close())
+		require.Equal(t, enc.EncodeToString(data), buf.String())
+
+		decoded, err := io.ReadAll(enc.NewDecoder(&buf))
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+}
+
+func TestEncoding_NewDecoder_Unpadded(t *testing.T) {
+	for _, input := range []string{"aGVsbG8", "aGVsbG8=", "aGVs\nbG8\n"} {
+		decoded, err := io.ReadAll(URLEncoding.NewDecoder(strings.NewReader(input)))
+		require.NoError(t, err, input)
+		require.Equal(t, "hello", string(decoded))
+	}
+
+	_, err := io.ReadAll(URLEncoding.NewDecoder(strings.NewReader("aGVsb")))
+	require.Error(t, err)
+}
+
+func TestVariantFunctions(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x01, 0x02}
+
+	require.Equal(t, "-_8BAg==", EncodeURL(data))
+	require.Equal(t, "+/8BAg", EncodeRaw(data))
+	require.Equal(t, "-_8BAg", EncodeRawURL(data))
+
+	for _, encoded := range []string{EncodeURL(data), EncodeRawURL(data)} {
+		decoded, err := DecodeURL(encoded)
+		require.NoError(t, err, encoded)
+		require.Equal(t, data, decoded)
+	}
+
+	// Decode accepts all four variants
+	for _, encoded := range []string{Encode(data), EncodeURL(data), EncodeRaw(data), EncodeRawURL(data)} {
+		decoded, err := Decode(encoded)
+		require.NoError(t, err, encoded)
+		require.Equal(t, data, decoded)
+	}
+
+	_, err := DecodeURL(EncodeRaw(data))
+	require.ErrorContains(t, err, "invalid base64 input")
+}
+
+func TestEncodeMIME(t *testing.T) {
+	data := bytes.Repeat([]byte("MIME line wrapping "), 10)
+
+	encoded := EncodeMIME(data)
+	lines := strings.Split(encoded, "\r\n")
+	require.Len(t, lines, 4)
+	for _, line := range lines[:3] {
+		require.Len(t, line, MIMELineLength)
+	}
+	require.Equal(t, Encode(data), strings.Join(lines, ""))
+	require.Equal(t, len(encoded), MIMEEncoding.EncodedLen(len(data)))
+
+	decoded, err := Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+
+	// Short output is not wrapped
+	require.Equal(t, "aGk=", EncodeMIME([]byte("hi")))
+}
+
+func TestEncoding_WithWrap(t *testing.T) {
+	enc := RawURLEncoding.WithWrap(4)
+	data := []byte{0xfb, 0xff, 0x01, 0x02, 0x03, 0x04}
+
+	require.Equal(t, "-_8B\r\nAgME", enc.EncodeToString(data))
+	require.Equal(t, 10, enc.EncodedLen(len(data)))
+	require.Equal(t, "-_8BAgME", enc.WithWrap(0).EncodeToString(data))
+}
+
+func TestEncoding_NewEncoder_Wrap(t *testing.T) {
+	data := bytes.Repeat([]byte{0xfb, 0xff, 0x01}, 500)
+
+	for _, chunk := range []int{1, 57, 100, len(data)} {
+		var buf bytes.Buffer
+		encoder := MIMEEncoding.NewEncoder(&buf)
+		for i := 0; i < len(data); i += chunk {
+			_, err := encoder.Write(data[i:min(i+chunk, len(data))])
+			require.NoError(t, err)
+		}
+		require.NoError(t, encoder.Close())
+		require.Equal(t, EncodeMIME(data), buf.String(), "chunk size %d", chunk)
+
+		decoded, err := io.ReadAll(NewDecoder(&buf))
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+}
@@ -0,0 +1,89 @@
+package base64
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte(""))
+	f.Add([]byte("Hello, World!"))
+	f.Add([]byte{0, 0, 1})
+	f.Add([]byte{0xff, 0xfe, 0xfd})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		encoded := Encode(data)
+		if len(encoded) > MaxEncodedLen(len(data)) {
+			t.Fatalf("encoded length %d exceeds MaxEncodedLen(%d) = %d", len(encoded), len(data), MaxEncodedLen(len(data)))
+		}
+
+		decoded, err := Decode(encoded)
+		if err != nil {
+			t.Fatalf("failed to decode %q: %v", encoded, err)
+		}
+		if !bytes.Equal(data, decoded) && !(len(data) == 0 && len(decoded) == 0) {
+			t.Fatalf("round trip mismatch: got %x, want %x", decoded, data)
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add("")
+	f.Add("SGVsbG8sIFdvcmxkIQ==")
+	f.Add("SGVsbG8\r\nsIFdvcmxkIQ==")
+	f.Add("====")
+	f.Add("invalid!")
+	f.Add("  \t\n")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		decoded, err := Decode(input)
+		if err != nil {
+			var corrupt base64.CorruptInputError
+			if !errors.As(err, &corrupt) {
+				t.Fatalf("expected CorruptInputError, got %T: %v", err, err)
+			}
+			return
+		}
+
+		if len(decoded) > MaxDecodedLen(len(input)) {
+			t.Fatalf("decoded length %d exceeds MaxDecodedLen(%d) = %d", len(decoded), len(input), MaxDecodedLen(len(input)))
+		}
+	})
+}
+
+func FuzzDataURI(f *testing.F) {
+	f.Add("", []byte("Hello, World!"))
+	f.Add("image/png", []byte("\x89PNG\r\n\x1a\n"))
+	f.Add("text/plain; charset=utf-8", []byte{0xff, 0x00})
+
+	f.Fuzz(func(t *testing.T, mediaType string, data []byte) {
+		if strings.ContainsAny(mediaType, ",") {
+			t.Skip("a comma ends the media type")
+		}
+
+		uri := EncodeDataURI(mediaType, data)
+		_, decoded, err := DecodeDataURI(uri)
+		if err != nil {
+			t.Fatalf("failed to decode %q: %v", uri, err)
+		}
+		if !bytes.Equal(data, decoded) && !(len(data) == 0 && len(decoded) == 0) {
+			t.Fatalf("round trip mismatch: got %x, want %x", decoded, data)
+		}
+	})
+}
+
+func FuzzDecodeDataURI(f *testing.F) {
+	f.Add("data:,Hello%2C%20World")
+	f.Add("data:image/png;base64,iVBORw0KGgo=")
+	f.Add("data:;base64,%zz")
+	f.Add("data:text/plain")
+
+	f.Fuzz(func(t *testing.T, uri string) {
+		if _, _, err := DecodeDataURI(uri); err != nil && !errors.Is(err, ErrInvalidDataURI) {
+			t.Fatalf("expected ErrInvalidDataURI, got %v", err)
+		}
+	})
+}
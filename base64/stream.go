@@ -0,0 +1,143 @@
+package base64
+
+import "io"
+
+// NewEncoder returns a stream encoder that writes the base64 encoding of the
+// data written to it to w. Memory use is constant however much is written.
+// The caller must Close the encoder to flush any partial block.
+//
+// Example usage:
+//
+//	encoder := base64.NewEncoder(os.Stdout)
+//	if _, err := io.Copy(encoder, file); err != nil {
+//		log.Fatal(err)
+//	}
+//	encoder.Close()
+func NewEncoder(w io.Writer) io.WriteCloser {
+	return StdEncoding.NewEncoder(w)
+}
+
+// NewDecoder returns a stream decoder that reads base64 from r. Like Decode,
+// it accepts the standard and URL-safe alphabets, with or without padding,
+// and ignores whitespace, including line breaks in wrapped input. As it
+// cannot look ahead, it also accepts input mixing the two alphabets.
+//
+// Example usage:
+//
+//	decoder := base64.NewDecoder(os.Stdin)
+//	if _, err := io.Copy(file, decoder); err != nil {
+//		log.Fatal(err)
+//	}
+func NewDecoder(r io.Reader) io.Reader {
+	return StdEncoding.NewDecoder(&urlTranslator{r: r})
+}
+
+// spaceSkipper drops ASCII whitespace from an io.Reader
+type spaceSkipper struct {
+	r io.Reader
+}
+
+func (s *spaceSkipper) Read(p []byte) (int, error) {
+	for {
+		n, err := s.r.Read(p)
+		kept := 0
+		for _, b := range p[:n] {
+			switch b {
+			case ' ', '\t', '\n', '\r', '\f', '\v':
+			default:
+				p[kept] = b
+				kept++
+			}
+		}
+		// Avoid returning 0, nil when a read was all whitespace
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+// lineBreak separates the lines of wrapped output
+const lineBreak = "\r\n"
+
+// lineWrapper breaks the data written to it into lines of width bytes,
+// without terminating the last line
+type lineWrapper struct {
+	w      io.Writer
+	width  int
+	column int
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if l.column == l.width {
+			if _, err := io.WriteString(l.w, lineBreak); err != nil {
+				return n, err
+			}
+			l.column = 0
+		}
+
+		chunk := p[:min(len(p), l.width-l.column)]
+		written, err := l.w.Write(chunk)
+		n += written
+		l.column += written
+		if err != nil {
+			return n, err
+		}
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+// urlTranslator maps the URL-safe alphabet's '-' and '_' to the standard
+// alphabet's '+' and '/'
+type urlTranslator struct {
+	r io.Reader
+}
+
+func (u *urlTranslator) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	for i, b := range p[:n] {
+		switch b {
+		case '-':
+			p[i] = '+'
+		case '_':
+			p[i] = '/'
+		}
+	}
+	return n, err
+}
+
+// padder adds the '=' padding missing at the end of base64 input, so that a
+// padded decoder also reads unpadded input
+type padder struct {
+	r       io.Reader
+	n       int // Bytes read so far
+	padding int // Padding left to write at the end
+	eof     bool
+}
+
+func (p *padder) Read(b []byte) (int, error) {
+	if !p.eof {
+		n, err := p.r.Read(b)
+		p.n += n
+		if err != io.EOF {
+			return n, err
+		}
+		p.eof = true
+		p.padding = (4 - p.n%4) % 4
+		if n > 0 {
+			return n, nil
+		}
+	}
+
+	if p.padding == 0 {
+		return 0, io.EOF
+	}
+	n := min(p.padding, len(b))
+	for i := range n {
+		b[i] = '='
+	}
+	p.padding -= n
+	return n, nil
+}
@@ -0,0 +1,77 @@
+package base64
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEncoder(t *testing.T) {
+	data := make([]byte, 100_000)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	encoder := NewEncoder(&buf)
+	// Write in uneven chunks to exercise partial blocks
+	for i := 0; i < len(data); i += 7001 {
+		_, err := encoder.Write(data[i:min(i+7001, len(data))])
+		require.NoError(t, err)
+	}
+	require.NoError(t, encoder.Close())
+
+	require.Equal(t, Encode(data), buf.String())
+}
+
+func TestNewDecoder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "simple", input: "aGVsbG8=", expected: "hello"},
+		{name: "empty", input: "", expected: ""},
+		{name: "wrapped lines", input: "aGVs\r\nbG8g\nd29y\nbGQ=\n", expected: "hello world"},
+		{name: "spaces and tabs", input: " aGVs bG8g\td29y bGQ= ", expected: "hello world"},
+		{name: "whitespace only", input: " \n\t ", expected: ""},
+		{name: "unpadded", input: "aGVsbG8", expected: "hello"},
+		{name: "url-safe alphabet", input: "PDw_Pz8-Pg==", expected: "<<???>>"},
+		{name: "unpadded url-safe alphabet", input: "PDw_Pz8-Pg", expected: "<<???>>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := io.ReadAll(NewDecoder(strings.NewReader(tc.input)))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(decoded))
+		})
+	}
+}
+
+func TestNewDecoder_Invalid(t *testing.T) {
+	_, err := io.ReadAll(NewDecoder(strings.NewReader("aGV!bG8=")))
+	require.Error(t, err)
+
+	var corrupt base64.CorruptInputError
+	require.True(t, errors.As(err, &corrupt))
+}
+
+func TestStream_RoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("streaming round trip "), 1000)
+
+	var buf bytes.Buffer
+	encoder := NewEncoder(&buf)
+	_, err := encoder.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, encoder.Close())
+
+	decoded, err := io.ReadAll(NewDecoder(&buf))
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+}
@@ -0,0 +1,121 @@
+// Package base85 provides the two common base85 encodings: Ascii85, used by
+// PostScript and PDF, and Z85, the ZeroMQ variant used for CURVE keys whose
+// alphabet is safe to embed in source code and JSON strings.
+//
+// Example usage:
+//
+//	// Ascii85, as found in PDF streams
+//	encoded := base85.Encode([]byte("Hello"))
+//	fmt.Println(encoded) // 87cURDZ
+//
+//	// Z85 requires input lengths that are a multiple of 4
+//	key, err := base85.EncodeZ85(publicKey)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+package base85
+
+import (
+	"encoding/ascii85"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const encodeZ85 = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#"
+
+var (
+	// ErrZ85Length is returned when Z85 input is not a whole number of blocks:
+	// data to encode must be a multiple of 4 bytes, and text to decode a
+	// multiple of 5 characters.
+	ErrZ85Length = errors.New("invalid Z85 length")
+
+	// ErrZ85Character is returned when Z85 input contains a character outside its alphabet.
+	ErrZ85Character = errors.New("invalid Z85 character")
+)
+
+var decodeZ85 [256]byte
+
+func init() {
+	for i := range decodeZ85 {
+		decodeZ85[i] = 0xFF
+	}
+	for i := 0; i < len(encodeZ85); i++ {
+		decodeZ85[encodeZ85[i]] = byte(i)
+	}
+}
+
+// Encode encodes data to Ascii85, without the <~ ~> delimiters
+func Encode(data []byte) string {
+	dst := make([]byte, ascii85.MaxEncodedLen(len(data)))
+	n := ascii85.Encode(dst, data)
+	return string(dst[:n])
+}
+
+// Decode decodes Ascii85 to bytes. Whitespace and the <~ ~> delimiters
+// used by PostScript and Adobe tools are ignored.
+func Decode(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	encoded = strings.TrimPrefix(encoded, "<~")
+	encoded = strings.TrimSuffix(encoded, "~>")
+
+	// Every 5 characters decode to at most 4 bytes, and 'z' to 4 zero bytes
+	dst := make([]byte, 4*len(encoded))
+	n, _, err := ascii85.Decode(dst, []byte(encoded), true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ascii85 input: %w", err)
+	}
+	return dst[:n], nil
+}
+
+// EncodeZ85 encodes data to Z85. The length of data must be a multiple of 4.
+func EncodeZ85(data []byte) (string, error) {
+	if len(data)%4 != 0 {
+		return "", fmt.Errorf("%w: %d bytes is not a multiple of 4", ErrZ85Length, len(data))
+	}
+
+	dst := make([]byte, 0, len(data)/4*5)
+	for i := 0; i < len(data); i += 4 {
+		value := uint32(data[i])<<24 | uint32(data[i+1])<<16 | uint32(data[i+2])<<8 | uint32(data[i+3])
+		var block [5]byte
+		for j := 4; j >= 0; j-- {
+			block[j] = encodeZ85[value%85]
+			value /= 85
+		}
+		dst = append(dst, block[:]...)
+	}
+	return string(dst), nil
+}
+
+// DecodeZ85 decodes Z85 to bytes. Whitespace is ignored; the remaining
+// length must be a multiple of 5.
+func DecodeZ85(encoded string) ([]byte, error) {
+	encoded = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, encoded)
+
+	if len(encoded)%5 != 0 {
+		return nil, fmt.Errorf("%w: %d characters is not a multiple of 5", ErrZ85Length, len(encoded))
+	}
+
+	dst := make([]byte, 0, len(encoded)/5*4)
+	for i := 0; i < len(encoded); i += 5 {
+		var value uint64
+		for j := 0; j < 5; j++ {
+			digit := decodeZ85[encoded[i+j]]
+			if digit == 0xFF {
+				return nil, fmt.Errorf("%w %q at input byte %d", ErrZ85Character, encoded[i+j], i+j)
+			}
+			value = value*85 + uint64(digit)
+		}
+		if value > 0xFFFFFFFF {
+			return nil, fmt.Errorf("%w: block at input byte %d overflows 32 bits", ErrZ85Character, i)
+		}
+		dst = append(dst, byte(value>>24), byte(value>>16), byte(value>>8), byte(value))
+	}
+	return dst, nil
+}
@@ -0,0 +1,95 @@
+package base85
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{
+		{name: "empty", input: []byte{}, expected: ""},
+		{name: "text", input: []byte("Hello"), expected: "87cURDZ"},
+		{name: "zero block", input: []byte{0, 0, 0, 0}, expected: "z"},
+		{name: "sentence", input: []byte("Man is distinguished"), expected: "9jqo^BlbD-BleB1DJ+*+F(f,q"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Encode(tc.input))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []byte
+	}{
+		{name: "plain", input: "87cURDZ", expected: []byte("Hello")},
+		{name: "delimited", input: "<~87cURDZ~>", expected: []byte("Hello")},
+		{name: "whitespace", input: " 87cU\nRDZ \n", expected: []byte("Hello")},
+		{name: "zero block", input: "z", expected: []byte{0, 0, 0, 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := Decode(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, decoded)
+		})
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	_, err := Decode("87cU{RDZ")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid ascii85 input")
+}
+
+func TestZ85(t *testing.T) {
+	// Test vector from the Z85 specification (ZeroMQ RFC 32)
+	data := []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}
+
+	encoded, err := EncodeZ85(data)
+	require.NoError(t, err)
+	require.Equal(t, "HelloWorld", encoded)
+
+	decoded, err := DecodeZ85("Hello World\n")
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+}
+
+func TestZ85_RoundTrip(t *testing.T) {
+	data := []byte{0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0x01, 0x02, 0x03, 0x04}
+
+	encoded, err := EncodeZ85(data)
+	require.NoError(t, err)
+
+	decoded, err := DecodeZ85(encoded)
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+}
+
+func TestZ85_Invalid(t *testing.T) {
+	_, err := EncodeZ85([]byte("abc"))
+	require.ErrorIs(t, err, ErrZ85Length)
+
+	_, err = DecodeZ85("Hello")
+	require.NoError(t, err)
+
+	_, err = DecodeZ85("Hell")
+	require.ErrorIs(t, err, ErrZ85Length)
+
+	_, err = DecodeZ85("Hell~")
+	require.ErrorIs(t, err, ErrZ85Character)
+
+	// The largest 5 character value exceeds 32 bits
+	_, err = DecodeZ85("#####")
+	require.ErrorIs(t, err, ErrZ85Character)
+}
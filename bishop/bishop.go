@@ -6,6 +6,7 @@ import (
 	"crypto/md5"
 	"crypto/sha256"
 	"strings"
+	"unicode/utf8"
 )
 
 // DefaultSymbols contains the character mapping used by OpenSSH ssh-keygen
@@ -17,7 +18,8 @@ type Options struct {
 	Width int
 	// Height of the grid (default: 9)
 	Height int
-	// Symbols to use for different visit counts (default: DefaultSymbols)
+	// Symbols to use for different visit counts (default: DefaultSymbols,
+	// see Charset for alternatives)
 	Symbols []rune
 	// StartChar overrides the start position marker (default: 'S')
 	StartChar rune
@@ -25,6 +27,16 @@ type Options struct {
 	EndChar rune
 	// ShowBorder adds a decorative border around the output (default: true)
 	ShowBorder bool
+	// Title is shown in brackets in the top border, like ssh-keygen's key type
+	// and size (default: none)
+	Title string
+	// Footer is shown in brackets in the bottom border, like ssh-keygen's hash
+	// algorithm (default: none)
+	Footer string
+	// Color wraps each visited cell in ANSI escape codes, coloring it along a
+	// blue to red gradient by visit count, for easier visual comparison
+	// (default: false)
+	Color bool
 }
 
 // DefaultOptions returns the standard ssh-keygen configuration
@@ -146,9 +158,8 @@ func (b *Board) Render() string {
 
 	if b.opts.ShowBorder {
 		// Top border
-		result.WriteString("+")
-		result.WriteString(strings.Repeat("-", b.opts.Width))
-		result.WriteString("+\n")
+		result.WriteString(b.border(b.opts.Title))
+		result.WriteString("\n")
 	}
 
 	// Render each row
@@ -158,8 +169,7 @@ func (b *Board) Render() string {
 		}
 
 		for x := 0; x < b.opts.Width; x++ {
-			char := b.getCharForPosition(x, y)
-			result.WriteRune(char)
+			b.writeCell(&result, x, y, false)
 		}
 
 		if b.opts.ShowBorder {
@@ -172,14 +182,48 @@ func (b *Board) Render() string {
 
 	if b.opts.ShowBorder {
 		// Bottom border
-		result.WriteString("+")
-		result.WriteString(strings.Repeat("-", b.opts.Width))
-		result.WriteString("+")
+		result.WriteString(b.border(b.opts.Footer))
 	}
 
 	return result.String()
 }
 
+// writeCell writes the symbol of a grid position, in reverse video if
+// highlight is set and colors are enabled
+func (b *Board) writeCell(sb *strings.Builder, x, y int, highlight bool) {
+	char := b.getCharForPosition(x, y)
+	if !b.opts.Color {
+		sb.WriteRune(char)
+		return
+	}
+
+	if highlight {
+		sb.WriteString(ansiReverse)
+	}
+	b.writeColored(sb, char, x, y)
+	if highlight {
+		sb.WriteString(ansiReset)
+	}
+}
+
+// border returns a horizontal border with a label centered in brackets as
+// ssh-keygen does, truncating the label to fit
+func (b *Board) border(label string) string {
+	if label == "" {
+		return "+" + strings.Repeat("-", b.opts.Width) + "+"
+	}
+
+	runes := []rune(label)
+	if len(runes)+2 > b.opts.Width {
+		runes = runes[:max(b.opts.Width-2, 0)]
+	}
+	label = "[" + string(runes) + "]"
+
+	left := (b.opts.Width - utf8.RuneCountInString(label)) / 2
+	right := b.opts.Width - utf8.RuneCountInString(label) - left
+	return "+" + strings.Repeat("-", left) + label + strings.Repeat("-", right) + "+"
+}
+
 // getCharForPosition returns the appropriate character for a grid position
 func (b *Board) getCharForPosition(x, y int) rune {
 	// Check for special positions
@@ -204,6 +248,11 @@ func (b *Board) getCharForPosition(x, y int) rune {
 	return b.opts.Symbols[visits]
 }
 
+// isMarker reports whether a grid position shows the start or end marker
+func (b *Board) isMarker(x, y int) bool {
+	return x == b.startX && y == b.startY || x == b.currentX && y == b.currentY
+}
+
 // GenerateFromString creates ASCII art from a string using MD5 hash
 func GenerateFromString(input string, opts *Options) string {
 	hash := md5.Sum([]byte(input))
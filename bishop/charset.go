@@ -0,0 +1,40 @@
+package bishop
+
+import (
+	"slices"
+	"strings"
+)
+
+// charsets are the named symbol sets, from fewest to most visits
+var charsets = map[string][]rune{
+	// OpenSSH ssh-keygen randomart
+	"openssh": DefaultSymbols,
+	// Classic ASCII brightness ramp, for fonts where OpenSSH's letters look alike
+	"ascii": []rune(" .:-=+*#%@"),
+	// Unicode shade blocks, for a heat map look
+	"blocks": []rune(" ░▒▓█"),
+	// Visit counts up to 9, for debugging walks
+	"digits": []rune(" 123456789"),
+	// Visit counts up to 15
+	"hex": []rune(" 123456789abcdef"),
+}
+
+// Charset returns a copy of the named symbol set for Options.Symbols, and
+// whether the name is known. Names are case insensitive; see CharsetNames.
+func Charset(name string) ([]rune, bool) {
+	symbols, ok := charsets[strings.ToLower(name)]
+	if !ok {
+		return nil, false
+	}
+	return slices.Clone(symbols), true
+}
+
+// CharsetNames returns the names of the symbol sets known to Charset, sorted.
+func CharsetNames() []string {
+	names := make([]string, 0, len(charsets))
+	for name := range charsets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
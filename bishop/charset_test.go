@@ -0,0 +1,48 @@
+package bishop_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/bishop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharset(t *testing.T) {
+	symbols, ok := bishop.Charset("openssh")
+	require.True(t, ok)
+	require.Equal(t, bishop.DefaultSymbols, symbols)
+
+	// Copies can be changed without affecting the set
+	symbols[0] = '!'
+	symbols, ok = bishop.Charset("OpenSSH")
+	require.True(t, ok)
+	require.Equal(t, ' ', symbols[0])
+
+	_, ok = bishop.Charset("unknown")
+	require.False(t, ok)
+}
+
+func TestCharsetNames(t *testing.T) {
+	names := bishop.CharsetNames()
+	require.Equal(t, []string{"ascii", "blocks", "digits", "hex", "openssh"}, names)
+
+	for _, name := range names {
+		symbols, ok := bishop.Charset(name)
+		require.True(t, ok, name)
+		require.Equal(t, ' ', symbols[0], "%s should leave unvisited cells blank", name)
+	}
+}
+
+func TestCharset_Render(t *testing.T) {
+	symbols, ok := bishop.Charset("digits")
+	require.True(t, ok)
+
+	opts := bishop.DefaultOptions()
+	opts.Symbols = symbols
+	result := bishop.GenerateFromString("hello world", opts)
+
+	for _, line := range strings.Split(result, "\n")[1:10] {
+		require.Regexp(t, `^\|[ 1-9SE]{17}\|$`, line)
+	}
+}
@@ -0,0 +1,52 @@
+package bishop
+
+import (
+	"strconv"
+	"strings"
+)
+
+// gradient is the xterm 256-color ramp for visit counts, from blue for
+// cells visited once to red for the most visited
+var gradient = []int{33, 39, 45, 51, 50, 49, 48, 46, 82, 118, 154, 190, 226, 220, 214, 208, 202, 196}
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiReverse = "\x1b[7m"
+)
+
+// GradientColor returns the xterm 256-color code for a cell visited visits
+// times on a board whose symbols show at most maxVisits, or -1 for an
+// unvisited cell.
+func GradientColor(visits, maxVisits int) int {
+	if visits <= 0 {
+		return -1
+	}
+	if maxVisits <= 1 {
+		return gradient[len(gradient)-1]
+	}
+	visits = min(visits, maxVisits)
+	return gradient[(visits-1)*(len(gradient)-1)/(maxVisits-1)]
+}
+
+// writeColored writes a cell symbol in the gradient color of its visit count,
+// or in bold for the start and end markers
+func (b *Board) writeColored(sb *strings.Builder, char rune, x, y int) {
+	if b.isMarker(x, y) {
+		sb.WriteString(ansiBold)
+		sb.WriteRune(char)
+		sb.WriteString(ansiReset)
+		return
+	}
+
+	color := GradientColor(b.grid[y][x], len(b.opts.Symbols)-1)
+	if color < 0 {
+		sb.WriteRune(char)
+		return
+	}
+	sb.WriteString("\x1b[38;5;")
+	sb.WriteString(strconv.Itoa(color))
+	sb.WriteString("m")
+	sb.WriteRune(char)
+	sb.WriteString(ansiReset)
+}
@@ -0,0 +1,51 @@
+package bishop_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/bishop"
+	"github.com/stretchr/testify/require"
+)
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestGradientColor(t *testing.T) {
+	require.Equal(t, -1, bishop.GradientColor(0, 14))
+	require.Equal(t, 33, bishop.GradientColor(1, 14))
+	require.Equal(t, 196, bishop.GradientColor(14, 14))
+	require.Equal(t, 196, bishop.GradientColor(100, 14))
+	require.Equal(t, 196, bishop.GradientColor(1, 1))
+
+	// Colors warm up with visits
+	previous := 0
+	for visits := 1; visits <= 14; visits++ {
+		color := bishop.GradientColor(visits, 14)
+		require.NotEqual(t, -1, color)
+		if visits > 1 {
+			require.NotEqual(t, previous, color, "visits %d", visits)
+		}
+		previous = color
+	}
+}
+
+func TestRender_Color(t *testing.T) {
+	opts := bishop.DefaultOptions()
+	plain := bishop.GenerateFromString("hello world", opts)
+
+	opts = bishop.DefaultOptions()
+	opts.Color = true
+	colored := bishop.GenerateFromString("hello world", opts)
+
+	require.NotEqual(t, plain, colored)
+	require.Equal(t, plain, ansiPattern.ReplaceAllString(colored, ""))
+	require.Contains(t, colored, "\x1b[1mS\x1b[0m")
+	require.Contains(t, colored, "\x1b[1mE\x1b[0m")
+	require.Contains(t, colored, "\x1b[38;5;33m.\x1b[0m")
+
+	// Borders and unvisited cells stay plain
+	lines := strings.Split(colored, "\n")
+	require.Equal(t, "+-----------------+", lines[0])
+	require.Equal(t, "|                 |", lines[1])
+}
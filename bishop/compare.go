@@ -0,0 +1,132 @@
+package bishop
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hashSizes are the digest sizes of the hashes ssh-keygen fingerprints with
+var hashSizes = map[int]string{16: "MD5", 20: "SHA1", 32: "SHA256", 64: "SHA512"}
+
+// comparisonGap separates the boards of a comparison
+const comparisonGap = "  "
+
+// ParseFingerprint parses a fingerprint as printed by ssh-keygen -l, such as
+// "SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKI" or
+// "MD5:46:a0:f2:ab:...", or a plain hex digest with or without colons. The
+// hash of a plain digest is named from its length. The key type is unknown.
+func ParseFingerprint(s string) (*Fingerprint, error) {
+	s = strings.TrimSpace(s)
+	hashName, encoded, prefixed := strings.Cut(s, ":")
+	if !prefixed || isHexPair(hashName) {
+		hashName, encoded = "", s
+	}
+	hashName = strings.ToUpper(hashName)
+
+	// ssh-keygen prints MD5 as hex pairs and other hashes as unpadded base64
+	var digest []byte
+	var err error
+	switch {
+	case hashName == "" || hashName == "MD5" || strings.Contains(encoded, ":"):
+		digest, err = hex.DecodeString(strings.ReplaceAll(encoded, ":", ""))
+	default:
+		digest, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is not a fingerprint: %w", ErrInvalidFingerprint, s, err)
+	}
+	if len(digest) == 0 {
+		return nil, fmt.Errorf("%w: empty fingerprint", ErrInvalidFingerprint)
+	}
+
+	if hashName == "" {
+		hashName = hashSizes[len(digest)]
+	}
+	return &Fingerprint{Digest: digest, Hash: hashName}, nil
+}
+
+// Compare draws the boards of two fingerprints side by side, followed by a
+// third board marking with '*' the cells that differ, to help humans confirm
+// whether two fingerprints match. With Options.Color, differing cells are
+// also shown in reverse video. It returns the art and the number of cells
+// that differ; fingerprints match only if their digests are equal, which
+// Equal reports.
+func Compare(a, b *Fingerprint, opts *Options) (string, int) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	left := a.Board(opts)
+	right := b.Board(opts)
+
+	width, height := left.opts.Width, left.opts.Height
+	differs := make([][]bool, height)
+	count := 0
+	for y := range height {
+		differs[y] = make([]bool, width)
+		for x := range width {
+			if left.getCharForPosition(x, y) != right.getCharForPosition(x, y) {
+				differs[y][x] = true
+				count++
+			}
+		}
+	}
+
+	var result strings.Builder
+	writeLine := func(leftLine, rightLine, diffLine string) {
+		result.WriteString(leftLine)
+		result.WriteString(comparisonGap)
+		result.WriteString(rightLine)
+		result.WriteString(comparisonGap)
+		result.WriteString(diffLine)
+	}
+
+	diffBorder := &Board{opts: &Options{Width: width}}
+	if opts.ShowBorder {
+		writeLine(left.border(left.opts.Title), right.border(right.opts.Title), diffBorder.border("diff"))
+		result.WriteString("\n")
+	}
+
+	for y := range height {
+		var leftRow, rightRow, diffRow strings.Builder
+		for x := range width {
+			left.writeCell(&leftRow, x, y, differs[y][x])
+			right.writeCell(&rightRow, x, y, differs[y][x])
+			if differs[y][x] {
+				diffRow.WriteByte('*')
+			} else {
+				diffRow.WriteByte(' ')
+			}
+		}
+		if opts.ShowBorder {
+			writeLine("|"+leftRow.String()+"|", "|"+rightRow.String()+"|", "|"+diffRow.String()+"|")
+		} else {
+			writeLine(leftRow.String(), rightRow.String(), diffRow.String())
+		}
+		if y < height-1 || opts.ShowBorder {
+			result.WriteString("\n")
+		}
+	}
+
+	if opts.ShowBorder {
+		writeLine(left.border(left.opts.Footer), right.border(right.opts.Footer), diffBorder.border(strconv.Itoa(count)+" differ"))
+	}
+
+	return result.String(), count
+}
+
+// Equal reports whether two fingerprints have the same digest.
+func (fp *Fingerprint) Equal(other *Fingerprint) bool {
+	return bytes.Equal(fp.Digest, other.Digest)
+}
+
+// isHexPair reports whether s is two hex digits, the first pair of a
+// fingerprint written with colons
+func isHexPair(s string) bool {
+	_, err := hex.DecodeString(s)
+	return len(s) == 2 && err == nil
+}
@@ -0,0 +1,104 @@
+package bishop_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/bishop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFingerprint(t *testing.T) {
+	key, err := bishop.FromSSHPublicKey([]byte(testEd25519Key))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input string
+		hash  string
+		size  int
+	}{
+		{"ssh-keygen SHA256", "SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKI", "SHA256", 32},
+		{"padded base64", "SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKI=", "SHA256", 32},
+		{"ssh-keygen MD5", "MD5:6d:fe:76:ca:0b:8a:44:bb:a4:73:49:52:fc:de:6e:52", "MD5", 16},
+		{"hex pairs", "6d:fe:76:ca:0b:8a:44:bb:a4:73:49:52:fc:de:6e:52", "MD5", 16},
+		{"plain hex", "3179851d1a9b04bf3aac7341e7c69a907eaa17f4f97836450f9bceba371818a2", "SHA256", 32},
+		{"unknown size", "0102030405", "", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp, err := bishop.ParseFingerprint(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.hash, fp.Hash)
+			require.Len(t, fp.Digest, tt.size)
+			require.Empty(t, fp.KeyType)
+		})
+	}
+
+	fp, err := bishop.ParseFingerprint("SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKI")
+	require.NoError(t, err)
+	require.True(t, fp.Equal(key))
+}
+
+func TestParseFingerprint_Invalid(t *testing.T) {
+	for _, input := range []string{"", "SHA256:", "SHA256:not base64!", "MD5:zz:zz", "abc"} {
+		_, err := bishop.ParseFingerprint(input)
+		require.ErrorIs(t, err, bishop.ErrInvalidFingerprint, input)
+	}
+}
+
+func TestCompare_Equal(t *testing.T) {
+	key, err := bishop.FromSSHPublicKey([]byte(testEd25519Key))
+	require.NoError(t, err)
+	fp, err := bishop.ParseFingerprint("SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKI")
+	require.NoError(t, err)
+
+	art, differences := bishop.Compare(key, fp, nil)
+	require.Zero(t, differences)
+
+	lines := strings.Split(art, "\n")
+	require.Len(t, lines, 11)
+	require.Equal(t, "+--[ED25519 256]--+  +-----------------+  +-----[diff]------+", lines[0])
+	require.Equal(t, "|     o =SB.. =   |  |     o =SB.. =   |  |                 |", lines[5])
+	require.Equal(t, "+----[SHA256]-----+  +----[SHA256]-----+  +---[0 differ]----+", lines[10])
+}
+
+func TestCompare_Different(t *testing.T) {
+	a, err := bishop.ParseFingerprint("SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKI")
+	require.NoError(t, err)
+	b, err := bishop.ParseFingerprint("SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKA")
+	require.NoError(t, err)
+	require.False(t, a.Equal(b))
+
+	opts := bishop.DefaultOptions()
+	opts.ShowBorder = false
+	art, differences := bishop.Compare(a, b, opts)
+	require.Positive(t, differences)
+
+	// Every differing cell is marked in the diff board
+	lines := strings.Split(art, "\n")
+	require.Len(t, lines, opts.Height)
+	marked := 0
+	for _, line := range lines {
+		marked += strings.Count(line[2*(opts.Width+2):], "*")
+	}
+	require.Equal(t, differences, marked)
+}
+
+func TestCompare_ColorHighlightsDifferences(t *testing.T) {
+	a, err := bishop.ParseFingerprint("MD5:6d:fe:76:ca:0b:8a:44:bb:a4:73:49:52:fc:de:6e:52")
+	require.NoError(t, err)
+	b, err := bishop.ParseFingerprint("MD5:6d:fe:76:ca:0b:8a:44:bb:a4:73:49:52:fc:de:6e:53")
+	require.NoError(t, err)
+
+	opts := bishop.DefaultOptions()
+	opts.Color = true
+	art, differences := bishop.Compare(a, b, opts)
+	require.Positive(t, differences)
+	require.Equal(t, 2*differences, strings.Count(art, "\x1b[7m"))
+
+	art, differences = bishop.Compare(a, a, opts)
+	require.Zero(t, differences)
+	require.NotContains(t, art, "\x1b[7m")
+}
@@ -0,0 +1,207 @@
+package bishop
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrInvalidFingerprint is returned when a key, record or certificate
+// cannot be parsed into a fingerprint.
+var ErrInvalidFingerprint = errors.New("invalid fingerprint input")
+
+// Fingerprint is the digest of a public key, with the labels ssh-keygen
+// shows around its randomart.
+type Fingerprint struct {
+	// Digest is the hash of the key that the bishop walks
+	Digest []byte
+	// KeyType is the key type, such as "ED25519" or "RSA-CERT"
+	KeyType string
+	// Bits is the key size, or 0 if unknown
+	Bits int
+	// Hash is the name of the digest algorithm, such as "SHA256"
+	Hash string
+}
+
+// sshfpAlgorithms are the key types of SSHFP records (RFC 4255, 6594, 7479,
+// 8709)
+var sshfpAlgorithms = map[int]string{1: "RSA", 2: "DSA", 3: "ECDSA", 4: "ED25519", 6: "ED448"}
+
+// sshfpHashes are the fingerprint types of SSHFP records and their sizes
+var sshfpHashes = map[int]struct {
+	name string
+	size int
+}{1: {"SHA1", 20}, 2: {"SHA256", 32}}
+
+// FromSSHPublicKey returns the SHA-256 fingerprint of an SSH public key in
+// the authorized_keys format of .pub files, as drawn by ssh-keygen -lv. For
+// an OpenSSH certificate it is the fingerprint of the certified key.
+func FromSSHPublicKey(data []byte) (*Fingerprint, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFingerprint, err)
+	}
+
+	keyType := ""
+	plain := key
+	if cert, ok := key.(*ssh.Certificate); ok {
+		plain = cert.Key
+		keyType = "-CERT"
+	}
+
+	digest := sha256.Sum256(plain.Marshal())
+	name, bits := sshKeyInfo(plain)
+	return &Fingerprint{Digest: digest[:], KeyType: name + keyType, Bits: bits, Hash: "SHA256"}, nil
+}
+
+// FromSSHFP returns the fingerprint of an SSHFP DNS record, either a whole
+// zone file line as printed by ssh-keygen -r ("host IN SSHFP 4 2 3179...")
+// or just its data ("4 2 3179..."). The key size is unknown.
+func FromSSHFP(record string) (*Fingerprint, error) {
+	fields := strings.Fields(record)
+	for i, field := range fields {
+		if strings.EqualFold(field, "SSHFP") {
+			fields = fields[i+1:]
+			break
+		}
+	}
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("%w: SSHFP record needs algorithm, type and fingerprint", ErrInvalidFingerprint)
+	}
+
+	algorithm, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid SSHFP algorithm %q", ErrInvalidFingerprint, fields[0])
+	}
+	fpType, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid SSHFP fingerprint type %q", ErrInvalidFingerprint, fields[1])
+	}
+	hash, ok := sshfpHashes[fpType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported SSHFP fingerprint type %d", ErrInvalidFingerprint, fpType)
+	}
+
+	// Long fingerprints may be split into several fields
+	digest, err := hex.DecodeString(strings.Join(fields[2:], ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid SSHFP fingerprint: %w", ErrInvalidFingerprint, err)
+	}
+	if len(digest) != hash.size {
+		return nil, fmt.Errorf("%w: %s fingerprint has %d bytes, expected %d", ErrInvalidFingerprint, hash.name, len(digest), hash.size)
+	}
+
+	keyType, ok := sshfpAlgorithms[algorithm]
+	if !ok {
+		keyType = "UNKNOWN"
+	}
+	return &Fingerprint{Digest: digest, KeyType: keyType, Hash: hash.name}, nil
+}
+
+// FromCertificate returns the SHA-256 fingerprint of an X.509 certificate in
+// PEM or DER form, the digest of its DER encoding as shown by browsers and
+// openssl x509 -fingerprint -sha256. Only the first PEM certificate is used.
+func FromCertificate(data []byte) (*Fingerprint, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("%w: PEM block is %s, not CERTIFICATE", ErrInvalidFingerprint, block.Type)
+		}
+		der = block.Bytes
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFingerprint, err)
+	}
+
+	digest := sha256.Sum256(cert.Raw)
+	fp := &Fingerprint{Digest: digest[:], KeyType: cert.PublicKeyAlgorithm.String(), Hash: "SHA256"}
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		fp.Bits = key.N.BitLen()
+	case *ecdsa.PublicKey:
+		fp.Bits = key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		fp.Bits = 256
+	}
+	return fp, nil
+}
+
+// Title returns the label ssh-keygen draws in the top border, such as
+// "ED25519 256".
+func (fp *Fingerprint) Title() string {
+	if fp.Bits == 0 {
+		return fp.KeyType
+	}
+	return fp.KeyType + " " + strconv.Itoa(fp.Bits)
+}
+
+// GenerateFromFingerprint creates ASCII art from a fingerprint, labelled like
+// the randomart of ssh-keygen -lv unless opts already has a title or footer.
+func GenerateFromFingerprint(fp *Fingerprint, opts *Options) string {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	return fp.Board(opts).Render()
+}
+
+// Board returns the board walked by the fingerprint, labelled like
+// GenerateFromFingerprint, for rendering in other formats such as SVG.
+func (fp *Fingerprint) Board(opts *Options) *Board {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	board := NewBoard(fp.labelled(opts))
+	board.Walk(fp.Digest)
+	return board
+}
+
+// labelled returns a copy of opts with the fingerprint's labels, unless opts
+// already has a title or footer
+func (fp *Fingerprint) labelled(opts *Options) *Options {
+	labelled := *opts
+	if labelled.Title == "" && labelled.Footer == "" {
+		labelled.Title = fp.Title()
+		labelled.Footer = fp.Hash
+	}
+	return &labelled
+}
+
+// sshKeyInfo returns the ssh-keygen name and size of a plain SSH key
+func sshKeyInfo(key ssh.PublicKey) (string, int) {
+	switch key.Type() {
+	case ssh.KeyAlgoED25519:
+		return "ED25519", 256
+	case ssh.KeyAlgoSKED25519:
+		return "ED25519-SK", 256
+	case ssh.KeyAlgoSKECDSA256:
+		return "ECDSA-SK", 256
+	}
+
+	cryptoKey, ok := key.(ssh.CryptoPublicKey)
+	if !ok {
+		return strings.ToUpper(key.Type()), 0
+	}
+	switch k := cryptoKey.CryptoPublicKey().(type) {
+	case *rsa.PublicKey:
+		return "RSA", k.N.BitLen()
+	case *ecdsa.PublicKey:
+		return "ECDSA", k.Curve.Params().BitSize
+	default:
+		if key.Type() == ssh.KeyAlgoDSA {
+			return "DSA", 1024
+		}
+		return strings.ToUpper(key.Type()), 0
+	}
+}
@@ -0,0 +1,174 @@
+package bishop_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/bishop"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEd25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBxWa53YPLyL7M/gN91olxM67hxIZ0JeqZ3eRq4KPyxW test"
+
+	// Signed with testEd25519Key's private key
+	testECDSACert = "ecdsa-sha2-nistp256-cert-v01@openssh.com AAAAKGVjZHNhLXNoYTItbmlzdHAyNTYtY2VydC12MDFAb3BlbnNzaC5jb20AAAAgJ+jn3WISTIwMPGNCO6OsCVv3AoPOm6/uhI/PqSo9UUAAAAAIbmlzdHAyNTYAAABBBJqyUVxfbkTC7BmrrFIuHC+Md1gHmxnjPDRIZYmZMld1xRhUA4uUvu3n7ybnIPFb1d6RrGoKzK0NY0MvDmeOdvAAAAAAAAAAAAAAAAEAAAACaWQAAAAIAAAABHVzZXIAAAAAAAAAAP//////////AAAAAAAAAIIAAAAVcGVybWl0LVgxMS1mb3J3YXJkaW5nAAAAAAAAABdwZXJtaXQtYWdlbnQtZm9yd2FyZGluZwAAAAAAAAAWcGVybWl0LXBvcnQtZm9yd2FyZGluZwAAAAAAAAAKcGVybWl0LXB0eQAAAAAAAAAOcGVybWl0LXVzZXItcmMAAAAAAAAAAAAAADMAAAALc3NoLWVkMjU1MTkAAAAgHFZrndg8vIvsz+A33WiXEzruHEhnQl6pnd5Grgo/LFYAAABTAAAAC3NzaC1lZDI1NTE5AAAAQDB7DzSSA0W2HA5yMO2yuiyBciwhdi7mPL3W91JFrfZ5tGofjnQsmRe3AW9/bO6AqI8UZZ0bssslMqESHHJjJgM= root@vm"
+
+	testCertificate = `-----BEGIN CERTIFICATE-----
+MIIBgjCCASegAwIBAgIUGKhGb+y/XxkstMjfe0VT+MGJdTIwCgYIKoZIzj0EAwIw
+FjEUMBIGA1UEAwwLZXhhbXBsZS5jb20wHhcNMjYxMDE3MjExMzM3WhcNMzYxMDE0
+MjExMzM3WjAWMRQwEgYDVQQDDAtleGFtcGxlLmNvbTBZMBMGByqGSM49AgEGCCqG
+SM49AwEHA0IABN0QLIyDAREX1JnHM88rPUwRZlec4ODQ05w8aeA0t5IyWkh25xIl
+GLxLKUkxvxT/eusZuw80d3SeqV7s+8/OrgqjUzBRMB0GA1UdDgQWBBQ2nJzEVSah
+B9OyC8t98h5fFaEj/DAfBgNVHSMEGDAWgBQ2nJzEVSahB9OyC8t98h5fFaEj/DAP
+BgNVHRMBAf8EBTADAQH/MAoGCCqGSM49BAMCA0kAMEYCIQDHz2uD/tMk7Jb2Sj4+
+GYunOGpWwH9nwIa64BUmyPaBOQIhAIutwMPfjq1s56hgryxMqVSWcsuSnHGzP+Jc
+Cznwkyoq
+-----END CERTIFICATE-----
+`
+)
+
+func TestFromSSHPublicKey(t *testing.T) {
+	fp, err := bishop.FromSSHPublicKey([]byte(testEd25519Key))
+	require.NoError(t, err)
+	require.Equal(t, "ED25519", fp.KeyType)
+	require.Equal(t, 256, fp.Bits)
+	require.Equal(t, "SHA256", fp.Hash)
+	require.Equal(t, "ED25519 256", fp.Title())
+
+	// Output of ssh-keygen -lvf
+	expected := strings.Join([]string{
+		"+--[ED25519 256]--+",
+		"|        ..ooo.   |",
+		"|         +.*.    |",
+		"|        + *      |",
+		"|      o o+..o    |",
+		"|     o =SB.. =   |",
+		"|    E +.=.= + .  |",
+		"|     . o+B *     |",
+		"|      +.*.* =    |",
+		"|    .oo= oo= .   |",
+		"+----[SHA256]-----+",
+	}, "\n")
+	require.Equal(t, expected, bishop.GenerateFromFingerprint(fp, nil))
+}
+
+func TestFromSSHPublicKey_Certificate(t *testing.T) {
+	fp, err := bishop.FromSSHPublicKey([]byte(testECDSACert))
+	require.NoError(t, err)
+	require.Equal(t, "ECDSA-CERT", fp.KeyType)
+	require.Equal(t, 256, fp.Bits)
+
+	// Output of ssh-keygen -lvf, the fingerprint of the certified key
+	expected := strings.Join([]string{
+		"+[ECDSA-CERT 256]-+",
+		"|        ...O*=++o|",
+		"|       o  +o=oE=+|",
+		"|      +. o.+o=+oo|",
+		"|     . +o+ .=o+ .|",
+		"|      o S   o=  .|",
+		"|       . . .. =. |",
+		"|             = o.|",
+		"|              . .|",
+		"|                 |",
+		"+----[SHA256]-----+",
+	}, "\n")
+	require.Equal(t, expected, bishop.GenerateFromFingerprint(fp, nil))
+}
+
+func TestFromSSHPublicKey_Invalid(t *testing.T) {
+	_, err := bishop.FromSSHPublicKey([]byte("ssh-ed25519 not-base64"))
+	require.ErrorIs(t, err, bishop.ErrInvalidFingerprint)
+
+	_, err = bishop.FromSSHPublicKey([]byte(testCertificate))
+	require.ErrorIs(t, err, bishop.ErrInvalidFingerprint)
+}
+
+func TestFromSSHFP(t *testing.T) {
+	key, err := bishop.FromSSHPublicKey([]byte(testEd25519Key))
+	require.NoError(t, err)
+
+	// Output of ssh-keygen -r host -f
+	for _, record := range []string{
+		"host IN SSHFP 4 2 3179851d1a9b04bf3aac7341e7c69a907eaa17f4f97836450f9bceba371818a2",
+		"4 2 3179851D1A9B04BF3AAC7341E7C69A90 7EAA17F4F97836450F9BCEBA371818A2",
+	} {
+		fp, err := bishop.FromSSHFP(record)
+		require.NoError(t, err)
+		require.Equal(t, key.Digest, fp.Digest)
+		require.Equal(t, "ED25519", fp.Title())
+		require.Equal(t, "SHA256", fp.Hash)
+	}
+
+	fp, err := bishop.FromSSHFP("host IN SSHFP 4 1 2e61cef24b9a9625f5530fa2c2985e0c21ed1cc4")
+	require.NoError(t, err)
+	require.Equal(t, "SHA1", fp.Hash)
+	require.Len(t, fp.Digest, 20)
+	require.True(t, strings.HasSuffix(bishop.GenerateFromFingerprint(fp, nil), "+-----[SHA1]------+"))
+}
+
+func TestFromSSHFP_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		want   string
+	}{
+		{"too few fields", "host IN SSHFP 4 2", "needs algorithm, type and fingerprint"},
+		{"bad algorithm", "x 2 3179", "invalid SSHFP algorithm"},
+		{"bad type", "4 x 3179", "invalid SSHFP fingerprint type"},
+		{"unsupported type", "4 3 3179", "unsupported SSHFP fingerprint type 3"},
+		{"bad hex", "4 1 zz", "invalid SSHFP fingerprint"},
+		{"wrong length", "4 2 3179", "SHA256 fingerprint has 2 bytes, expected 32"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bishop.FromSSHFP(tt.record)
+			require.ErrorIs(t, err, bishop.ErrInvalidFingerprint)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestFromCertificate(t *testing.T) {
+	fp, err := bishop.FromCertificate([]byte(testCertificate))
+	require.NoError(t, err)
+
+	// openssl x509 -noout -fingerprint -sha256
+	require.Equal(t, "2914df7dab9d85282e7bde6bb797b6195ac81096dddcc20d6f7e1461cf9e25de", hex.EncodeToString(fp.Digest))
+	require.Equal(t, "ECDSA 256", fp.Title())
+	require.Equal(t, "SHA256", fp.Hash)
+}
+
+func TestFromCertificate_Invalid(t *testing.T) {
+	_, err := bishop.FromCertificate([]byte("not a certificate"))
+	require.ErrorIs(t, err, bishop.ErrInvalidFingerprint)
+
+	_, err = bishop.FromCertificate([]byte("-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----\n"))
+	require.ErrorIs(t, err, bishop.ErrInvalidFingerprint)
+	require.Contains(t, err.Error(), "not CERTIFICATE")
+}
+
+func TestGenerateFromFingerprint_CustomLabels(t *testing.T) {
+	fp := &bishop.Fingerprint{Digest: []byte{0x5A, 0xA5}, KeyType: "RSA", Bits: 4096, Hash: "SHA256"}
+
+	opts := bishop.DefaultOptions()
+	opts.Title = "host key"
+	result := bishop.GenerateFromFingerprint(fp, opts)
+	require.True(t, strings.HasPrefix(result, "+---[host key]----+\n"))
+	require.True(t, strings.HasSuffix(result, "\n+-----------------+"))
+
+	// The caller's options are not changed
+	require.Empty(t, bishop.DefaultOptions().Title)
+	result = bishop.GenerateFromFingerprint(fp, nil)
+	require.True(t, strings.HasPrefix(result, "+---[RSA 4096]----+\n"))
+}
+
+func TestRender_LabelTruncated(t *testing.T) {
+	opts := &bishop.Options{Width: 7, Height: 3, ShowBorder: true, Title: "ED25519 256", Footer: "SHA256"}
+
+	lines := strings.Split(bishop.GenerateFromBytes([]byte{0x00}, opts), "\n")
+	require.Equal(t, "+[ED255]+", lines[0])
+	require.Equal(t, "+[SHA25]+", lines[len(lines)-1])
+}
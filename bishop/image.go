@@ -0,0 +1,239 @@
+package bishop
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"unicode/utf8"
+)
+
+// SVG layout in pixels: each character sits in a cell of a monospace grid
+const (
+	svgCellWidth  = 10
+	svgCellHeight = 20
+	svgFontSize   = 16
+	svgBaseline   = 15
+	svgMargin     = 10
+)
+
+// PNG layout in pixels: each board cell is a square
+const (
+	pngCellSize    = 16
+	pngMargin      = 8
+	pngBorderWidth = 2
+	pngMarkerInset = 4
+)
+
+const (
+	svgBackground = "#ffffff"
+	svgForeground = "#000000"
+	svgBorder     = "#808080"
+)
+
+var (
+	pngBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	pngForeground = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	pngBorder     = color.RGBA{0x80, 0x80, 0x80, 0xff}
+)
+
+// xtermBasic are the RGB values of the 16 basic xterm colors
+var xtermBasic = [16][3]uint8{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0}, {0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// glyph is one character of the rendered art with what it shows
+type glyph struct {
+	char   rune
+	visits int
+	marker bool
+	border bool
+}
+
+// glyphs lays out the characters of Render, without colors, as rows
+func (b *Board) glyphs() [][]glyph {
+	var rows [][]glyph
+	borderRow := func(label string) []glyph {
+		var row []glyph
+		for _, char := range b.border(label) {
+			row = append(row, glyph{char: char, border: true})
+		}
+		return row
+	}
+
+	if b.opts.ShowBorder {
+		rows = append(rows, borderRow(b.opts.Title))
+	}
+	for y := 0; y < b.opts.Height; y++ {
+		var row []glyph
+		if b.opts.ShowBorder {
+			row = append(row, glyph{char: '|', border: true})
+		}
+		for x := 0; x < b.opts.Width; x++ {
+			row = append(row, glyph{
+				char:   b.getCharForPosition(x, y),
+				visits: b.grid[y][x],
+				marker: b.isMarker(x, y),
+			})
+		}
+		if b.opts.ShowBorder {
+			row = append(row, glyph{char: '|', border: true})
+		}
+		rows = append(rows, row)
+	}
+	if b.opts.ShowBorder {
+		rows = append(rows, borderRow(b.opts.Footer))
+	}
+	return rows
+}
+
+// WriteSVG writes the art as an SVG image of the same characters in a
+// monospace font, for embedding in documentation and key verification
+// pages. With Options.Color, cells take the colors of the terminal output.
+func (b *Board) WriteSVG(w io.Writer) error {
+	rows := b.glyphs()
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	width := 2*svgMargin + columns*svgCellWidth
+	height := 2*svgMargin + len(rows)*svgCellHeight
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="%d" text-anchor="middle">`+"\n",
+		width, height, width, height, svgFontSize)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgBackground)
+
+	for y, row := range rows {
+		for x, g := range row {
+			if g.char == ' ' {
+				continue
+			}
+
+			fill, weight := svgForeground, ""
+			switch {
+			case g.border:
+				fill = svgBorder
+			case b.opts.Color && g.marker:
+				weight = ` font-weight="bold"`
+			case b.opts.Color:
+				if code := GradientColor(g.visits, len(b.opts.Symbols)-1); code >= 0 {
+					fill = hexColor(xtermColor(code))
+				}
+			}
+
+			fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s"%s>`,
+				svgMargin+x*svgCellWidth+svgCellWidth/2, svgMargin+y*svgCellHeight+svgBaseline, fill, weight)
+			var buf [utf8.UTFMax]byte
+			if err := xml.EscapeText(bw, buf[:utf8.EncodeRune(buf[:], g.char)]); err != nil {
+				return fmt.Errorf("failed to write SVG: %w", err)
+			}
+			bw.WriteString("</text>\n")
+		}
+	}
+
+	bw.WriteString("</svg>\n")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write SVG: %w", err)
+	}
+	return nil
+}
+
+// WritePNG writes the board as a PNG image. As the standard library has no
+// fonts, cells are drawn as squares shaded by visit count instead of
+// symbols: in the gradient colors with Options.Color and in grays otherwise.
+// The start cell is marked with a filled square and the end cell with a
+// hollow one; the title and footer are not drawn.
+func (b *Board) WritePNG(w io.Writer) error {
+	inset := pngMargin
+	if b.opts.ShowBorder {
+		inset += pngBorderWidth
+	}
+	width := 2*inset + b.opts.Width*pngCellSize
+	height := 2*inset + b.opts.Height*pngCellSize
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(pngBackground), image.Point{}, draw.Src)
+	if b.opts.ShowBorder {
+		frame := image.Rect(pngMargin, pngMargin, width-pngMargin, height-pngMargin)
+		fillRect(img, frame, pngBorder)
+		fillRect(img, frame.Inset(pngBorderWidth), pngBackground)
+	}
+
+	maxVisits := len(b.opts.Symbols) - 1
+	for y := 0; y < b.opts.Height; y++ {
+		for x := 0; x < b.opts.Width; x++ {
+			cell := image.Rect(0, 0, pngCellSize, pngCellSize).Add(image.Pt(inset+x*pngCellSize, inset+y*pngCellSize))
+			if visits := b.grid[y][x]; visits > 0 {
+				fillRect(img, cell, b.shade(visits, maxVisits))
+			}
+
+			switch {
+			case x == b.startX && y == b.startY:
+				fillRect(img, cell.Inset(pngMarkerInset), pngForeground)
+			case b.isMarker(x, y):
+				marker := cell.Inset(pngMarkerInset)
+				fillRect(img, marker, pngForeground)
+				fillRect(img, marker.Inset(pngBorderWidth), b.shade(b.grid[y][x], maxVisits))
+			}
+		}
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("failed to write PNG: %w", err)
+	}
+	return nil
+}
+
+// shade returns the PNG color of a cell visited visits times
+func (b *Board) shade(visits, maxVisits int) color.RGBA {
+	if visits <= 0 {
+		return pngBackground
+	}
+	if b.opts.Color {
+		return xtermColor(GradientColor(visits, maxVisits))
+	}
+
+	// From light gray for one visit to black for the most visited
+	visits = min(visits, max(maxVisits, 1))
+	level := uint8(0xd0 - (visits-1)*0xd0/max(maxVisits, 1))
+	return color.RGBA{level, level, level, 0xff}
+}
+
+// fillRect fills r with c
+func fillRect(img draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// xtermColor returns the RGB value of an xterm 256-color code
+func xtermColor(code int) color.RGBA {
+	switch {
+	case code < 16:
+		c := xtermBasic[max(code, 0)]
+		return color.RGBA{c[0], c[1], c[2], 0xff}
+	case code < 232:
+		// 6x6x6 color cube
+		level := func(i int) uint8 {
+			if i == 0 {
+				return 0
+			}
+			return uint8(55 + 40*i)
+		}
+		i := code - 16
+		return color.RGBA{level(i / 36), level(i / 6 % 6), level(i % 6), 0xff}
+	default:
+		// Grayscale ramp
+		gray := uint8(8 + 10*(min(code, 255)-232))
+		return color.RGBA{gray, gray, gray, 0xff}
+	}
+}
+
+// hexColor formats a color as an SVG "#rrggbb" value
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
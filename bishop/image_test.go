@@ -0,0 +1,103 @@
+package bishop_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/bishop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSVG(t *testing.T) {
+	fp, err := bishop.FromSSHPublicKey([]byte(testEd25519Key))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, fp.Board(nil).WriteSVG(&buf))
+	svg := buf.String()
+
+	// Well-formed XML, with the same characters as the text art
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if data, ok := token.(xml.CharData); ok {
+			text.WriteString(strings.TrimSpace(string(data)))
+		}
+	}
+	art := strings.NewReplacer(" ", "", "\n", "").Replace(bishop.GenerateFromFingerprint(fp, nil))
+	require.Equal(t, art, text.String())
+
+	require.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="210" height="240"`))
+	require.NotContains(t, svg, "font-weight")
+}
+
+func TestWriteSVG_EscapesSymbols(t *testing.T) {
+	opts := bishop.DefaultOptions()
+	opts.Symbols = []rune(" &<")
+	board := bishop.NewBoard(opts)
+	board.Walk([]byte("walk the board"))
+
+	var buf bytes.Buffer
+	require.NoError(t, board.WriteSVG(&buf))
+	require.Contains(t, buf.String(), ">&amp;</text>")
+	require.Contains(t, buf.String(), ">&lt;</text>")
+}
+
+func TestWriteSVG_Color(t *testing.T) {
+	opts := bishop.DefaultOptions()
+	opts.Color = true
+	board := bishop.NewBoard(opts)
+	board.Walk([]byte("hello world"))
+
+	var buf bytes.Buffer
+	require.NoError(t, board.WriteSVG(&buf))
+
+	// xterm color 33 is the gradient start for cells visited once
+	require.Contains(t, buf.String(), `fill="#0087ff"`)
+	require.Equal(t, 2, strings.Count(buf.String(), `font-weight="bold"`))
+}
+
+func TestWritePNG(t *testing.T) {
+	tests := []struct {
+		name          string
+		border        bool
+		width, height int
+	}{
+		{"border", true, 2*8 + 2*2 + 17*16, 2*8 + 2*2 + 9*16},
+		{"no border", false, 2*8 + 17*16, 2*8 + 9*16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := bishop.DefaultOptions()
+			opts.ShowBorder = tt.border
+			board := bishop.NewBoard(opts)
+			board.Walk([]byte("hello world"))
+
+			var buf bytes.Buffer
+			require.NoError(t, board.WritePNG(&buf))
+
+			img, err := png.Decode(&buf)
+			require.NoError(t, err)
+			require.Equal(t, tt.width, img.Bounds().Dx())
+			require.Equal(t, tt.height, img.Bounds().Dy())
+
+			// The start marker is a black square in the center cell
+			inset := 8
+			if tt.border {
+				inset += 2
+			}
+			r, g, b, _ := img.At(inset+8*16+8, inset+4*16+8).RGBA()
+			require.Zero(t, r+g+b)
+		})
+	}
+}
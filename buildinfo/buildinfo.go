@@ -0,0 +1,157 @@
+// Package buildinfo reports how the running binary was built and computes a
+// checksum of the binary itself, so that deployed binaries can be verified
+// against release manifests.
+//
+// Example usage:
+//
+//	info, err := buildinfo.Read()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(info.Main.Version, info.Revision)
+//
+//	// Compute the SHA-256 of the running executable
+//	path, sum, err := buildinfo.SelfHash()
+//	if err == nil {
+//		fmt.Printf("%s  %s\n", sum, path)
+//	}
+package buildinfo
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"github.com/bilte-co/toolshed/hash"
+)
+
+// SelfHashAlgorithm is the algorithm used by SelfHash.
+const SelfHashAlgorithm = "sha256"
+
+// ErrUnavailable is returned when the binary was built without module support
+// and carries no build information.
+var ErrUnavailable = errors.New("build information not available")
+
+// Module describes a module compiled into the binary.
+type Module struct {
+	Path    string  `json:"path"`
+	Version string  `json:"version"`
+	Sum     string  `json:"sum,omitempty"`
+	Replace *Module `json:"replace,omitempty"`
+}
+
+// Info describes how the running binary was built.
+type Info struct {
+	// Path is the import path of the main package.
+	Path string `json:"path"`
+	// Main is the module containing the main package.
+	Main Module `json:"main"`
+	// GoVersion is the Go toolchain that built the binary.
+	GoVersion string `json:"go_version"`
+	// Revision, Time and Modified come from the version control settings
+	// recorded by the go command, if any.
+	Revision string `json:"revision,omitempty"`
+	Time     string `json:"time,omitempty"`
+	Modified bool   `json:"modified"`
+	// Settings holds all build settings, such as -tags and CGO_ENABLED.
+	Settings map[string]string `json:"settings"`
+	// Deps lists the dependency modules compiled into the binary.
+	Deps []Module `json:"deps"`
+}
+
+// Read returns the build information embedded in the running binary.
+func Read() (*Info, error) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil, ErrUnavailable
+	}
+	return FromBuildInfo(bi), nil
+}
+
+// FromBuildInfo converts build information from runtime/debug to an Info.
+func FromBuildInfo(bi *debug.BuildInfo) *Info {
+	info := &Info{
+		Path:      bi.Path,
+		Main:      fromModule(&bi.Main),
+		GoVersion: bi.GoVersion,
+		Settings:  make(map[string]string, len(bi.Settings)),
+		Deps:      make([]Module, 0, len(bi.Deps)),
+	}
+
+	for _, setting := range bi.Settings {
+		info.Settings[setting.Key] = setting.Value
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.Time = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	for _, dep := range bi.Deps {
+		info.Deps = append(info.Deps, fromModule(dep))
+	}
+
+	return info
+}
+
+// Tags returns the build tags the binary was built with.
+func (i *Info) Tags() []string {
+	tags := i.Settings["-tags"]
+	if tags == "" {
+		return []string{}
+	}
+	return strings.Split(tags, ",")
+}
+
+// CGO reports whether the binary was built with cgo enabled.
+func (i *Info) CGO() bool {
+	return i.Settings["CGO_ENABLED"] == "1"
+}
+
+// fromModule converts a runtime/debug module, following replacements.
+func fromModule(m *debug.Module) Module {
+	module := Module{
+		Path:    m.Path,
+		Version: m.Version,
+		Sum:     m.Sum,
+	}
+	if m.Replace != nil {
+		replace := fromModule(m.Replace)
+		module.Replace = &replace
+	}
+	return module
+}
+
+// Executable returns the path of the running binary with symlinks resolved.
+func Executable() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate executable: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve executable path %s: %w", path, err)
+	}
+	return resolved, nil
+}
+
+// SelfHash returns the path of the running binary and the hex-encoded
+// SHA-256 of its contents.
+func SelfHash() (string, string, error) {
+	path, err := Executable()
+	if err != nil {
+		return "", "", err
+	}
+	sum, err := hash.HashFile(path, SelfHashAlgorithm)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to hash executable: %w", err)
+	}
+	return path, hex.EncodeToString(sum), nil
+}
@@ -0,0 +1,72 @@
+package buildinfo_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/buildinfo"
+)
+
+func TestFromBuildInfo(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.24.4",
+		Path:      "github.com/bilte-co/toolshed",
+		Main:      debug.Module{Path: "github.com/bilte-co/toolshed", Version: "v1.2.3"},
+		Deps: []*debug.Module{
+			{Path: "github.com/oklog/ulid/v2", Version: "v2.1.0", Sum: "h1:abc="},
+			{Path: "example.com/old", Version: "v0.1.0", Replace: &debug.Module{Path: "example.com/new", Version: "v0.2.0"}},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "-tags", Value: "netgo,osusergo"},
+			{Key: "CGO_ENABLED", Value: "0"},
+			{Key: "vcs.revision", Value: "0123456789abcdef"},
+			{Key: "vcs.time", Value: "2025-01-01T00:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	info := buildinfo.FromBuildInfo(bi)
+	require.Equal(t, "github.com/bilte-co/toolshed", info.Path)
+	require.Equal(t, "v1.2.3", info.Main.Version)
+	require.Equal(t, "go1.24.4", info.GoVersion)
+	require.Equal(t, "0123456789abcdef", info.Revision)
+	require.Equal(t, "2025-01-01T00:00:00Z", info.Time)
+	require.True(t, info.Modified)
+	require.False(t, info.CGO())
+	require.Equal(t, []string{"netgo", "osusergo"}, info.Tags())
+
+	require.Len(t, info.Deps, 2)
+	require.Equal(t, "h1:abc=", info.Deps[0].Sum)
+	require.NotNil(t, info.Deps[1].Replace)
+	require.Equal(t, "example.com/new", info.Deps[1].Replace.Path)
+}
+
+func TestFromBuildInfo_NoSettings(t *testing.T) {
+	info := buildinfo.FromBuildInfo(&debug.BuildInfo{})
+	require.Empty(t, info.Revision)
+	require.False(t, info.Modified)
+	require.NotNil(t, info.Tags())
+	require.Empty(t, info.Tags())
+	require.NotNil(t, info.Deps)
+}
+
+func TestRead(t *testing.T) {
+	info, err := buildinfo.Read()
+	require.NoError(t, err)
+	require.NotEmpty(t, info.GoVersion)
+}
+
+func TestSelfHash(t *testing.T) {
+	path, sum, err := buildinfo.SelfHash()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	expected := sha256.Sum256(data)
+	require.Equal(t, hex.EncodeToString(expected[:]), sum)
+}
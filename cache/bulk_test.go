@@ -0,0 +1,87 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkOperations(t *testing.T) {
+	for name, opts := range map[string][]cache.Option{
+		"otter": nil,
+		"lru":   {cache.WithMaxEntries(100)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, err := cache.NewCache(context.Background(), opts...)
+			require.NoError(t, err)
+
+			failed := c.SetMany(map[string]any{"a": 1, "b": 2, "c": 3})
+			require.Empty(t, failed)
+
+			found := c.GetMany([]string{"a", "b", "missing"})
+			require.Equal(t, map[string]any{"a": 1, "b": 2}, found)
+
+			c.DeleteMany([]string{"a", "c", "missing"})
+			found = c.GetMany([]string{"a", "b", "c"})
+			require.Equal(t, map[string]any{"b": 2}, found)
+
+			require.Empty(t, c.GetMany(nil))
+			require.Empty(t, c.SetMany(nil))
+		})
+	}
+}
+
+func TestBulkOperations_PartialSet(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxBytes(20))
+	require.NoError(t, err)
+
+	failed := c.SetMany(map[string]any{
+		"small": "x",
+		"large": strings.Repeat("x", 100),
+	})
+	require.Equal(t, []string{"large"}, failed)
+
+	found := c.GetMany([]string{"small", "large"})
+	require.Equal(t, map[string]any{"small": "x"}, found)
+}
+
+func TestBulkOperations_Namespace(t *testing.T) {
+	shared, err := cache.NewCache(context.Background(), cache.WithMaxBytes(30))
+	require.NoError(t, err)
+	ns := cache.Namespace(shared, "ns")
+
+	failed := ns.SetMany(map[string]any{"a": "1", "big": strings.Repeat("x", 100)})
+	require.Equal(t, []string{"big"}, failed)
+
+	require.Equal(t, map[string]any{"a": "1"}, ns.GetMany([]string{"a", "big"}))
+	require.Equal(t, map[string]any{"ns:a": "1"}, shared.GetMany([]string{"ns:a"}))
+
+	ns.DeleteMany([]string{"a"})
+	require.Empty(t, shared.GetMany([]string{"ns:a"}))
+}
+
+func BenchmarkLRU_SetManyVsSet(b *testing.B) {
+	entries := make(map[string]any, 100)
+	for i := 0; i < 100; i++ {
+		entries[fmt.Sprintf("key-%d", i)] = i
+	}
+
+	b.Run("Set", func(b *testing.B) {
+		c, _ := cache.NewCache(context.Background(), cache.WithMaxEntries(1000))
+		for i := 0; i < b.N; i++ {
+			for key, value := range entries {
+				c.Set(key, value)
+			}
+		}
+	})
+	b.Run("SetMany", func(b *testing.B) {
+		c, _ := cache.NewCache(context.Background(), cache.WithMaxEntries(1000))
+		for i := 0; i < b.N; i++ {
+			c.SetMany(entries)
+		}
+	})
+}
@@ -14,6 +14,29 @@
 //	// Store a value
 //	cache.Set("user:123", userObject)
 //
+//	// Store a short-lived value alongside the default TTL entries
+//	cache.SetWithTTL("otp:123", code, 30*time.Second)
+//
+//	// Bound memory use for large values, evicting least recently used entries
+//	blobs, err := cache.NewCache(ctx, cache.WithMaxBytes(64<<20))
+//
+//	// Share one cache between subsystems without key collisions
+//	sessions := cache.Namespace(shared, "sessions")
+//	sessions.Set("abc", session)
+//	sessions.FlushNamespace()
+//
+//	// Inspect and clear the cache
+//	fmt.Println(cache.Len(), cache.Keys())
+//	cache.Flush()
+//
+//	// Serve stale values while reloading them in the background after 30s,
+//	// dropping them after 5 minutes
+//	users, err := cache.NewLoadingCache(ctx, loadUser, 30*time.Second, cache.WithDefaultTTL(5*time.Minute))
+//	user, err := users.Get(ctx, "123")
+//
+//	// Keep a warm cache across restarts, snapshotting every 5 minutes
+//	warm, err := cache.NewCache(ctx, cache.WithSnapshotFile("cache.snapshot", 5*time.Minute))
+//
 //	// Retrieve a value
 //	if value, exists := cache.Get("user:123"); exists {
 //		user := value.(User)
@@ -26,10 +49,11 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"sync"
 	"time"
-
-	"github.com/maypok86/otter"
 )
 
 // Cache defines the interface for cache implementations.
@@ -49,6 +73,54 @@ type Cache interface {
 	Delete(key string)
 }
 
+// Store is a Cache with the per-key TTL, bulk, inspection, context-aware and
+// snapshot operations of the caches created by NewCache.
+type Store interface {
+	Cache
+
+	// SetWithTTL stores a value that expires ttl after it was written,
+	// regardless of the cache's default TTL.
+	// Returns false if ttl is not positive or the value could not be stored.
+	SetWithTTL(key string, value any, ttl time.Duration) bool
+
+	// GetMany retrieves several keys at once. The result holds only the keys
+	// that were found.
+	GetMany(keys []string) map[string]any
+
+	// SetMany stores several values at once using the cache's default TTL.
+	// It returns the keys that could not be stored, if any.
+	SetMany(entries map[string]any) []string
+
+	// DeleteMany removes several keys at once.
+	DeleteMany(keys []string)
+
+	// Keys returns a snapshot of the keys of all unexpired entries, in no particular order.
+	Keys() []string
+
+	// Len returns the number of unexpired entries.
+	Len() int
+
+	// Flush removes all entries.
+	Flush()
+
+	// GetCtx is like Get but first checks ctx, returning its error if it is
+	// already done. Remote backends also use ctx to bound the request.
+	GetCtx(ctx context.Context, key string) (any, bool, error)
+
+	// SetCtx is like Set but first checks ctx, returning its error if it is
+	// already done. It returns ErrNotStored if the value was not stored.
+	SetCtx(ctx context.Context, key string, value any) error
+
+	// Save writes a snapshot of all unexpired entries and their expiry times to w
+	// using encoding/gob. Values of types other than Go's basic types must be
+	// registered with gob.Register before saving or loading.
+	Save(w io.Writer) error
+
+	// Load adds the entries of a snapshot written by Save, keeping their
+	// original expiry times. Entries that have expired since are skipped.
+	Load(r io.Reader) error
+}
+
 // InMemoryCache is a simple thread-safe in-memory cache implementation.
 // It uses a map with read-write mutex for concurrent access protection.
 // This implementation does not support TTL or automatic eviction.
@@ -57,22 +129,212 @@ type InMemoryCache struct {
 	data map[string]any // Internal storage for key-value pairs
 }
 
+// DefaultTTL is the time-to-live of entries in a cache created by NewCache
+// without the WithDefaultTTL option.
+const DefaultTTL = time.Minute
+
+// DefaultCleanupInterval is how often a size-bounded cache removes expired
+// entries in the background, unless changed with WithCleanupInterval.
+const DefaultCleanupInterval = time.Minute
+
+// ErrNotStored is returned by SetCtx when the cache did not store the value,
+// for example because it was closed or the value exceeds WithMaxBytes.
+var ErrNotStored = errors.New("cache value not stored")
+
+// defaultCapacity is the maximum number of entries in a cache created by NewCache.
+const defaultCapacity = 1_000
+
+// Option configures a cache created by NewCache.
+type Option func(*options)
+
+// options holds the configuration applied by Option functions.
+type options struct {
+	defaultTTL time.Duration
+	maxEntries int
+	maxBytes   int
+	sizeFunc   SizeFunc
+
+	cleanupInterval time.Duration
+	shards          int
+
+	snapshotPath     string
+	snapshotInterval time.Duration
+}
+
+// WithDefaultTTL sets the time-to-live of entries stored with Set.
+// Entries stored with SetWithTTL use their own TTL instead.
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.defaultTTL = ttl
+	}
+}
+
+// WithMaxEntries bounds the number of entries. When a Set would exceed the
+// limit, the least recently used entries are evicted.
+func WithMaxEntries(n int) Option {
+	return func(o *options) {
+		o.maxEntries = n
+	}
+}
+
+// WithMaxBytes bounds the total size of all entries as reported by the size
+// function. When a Set would exceed the limit, the least recently used entries
+// are evicted; a single entry larger than the limit is not stored.
+func WithMaxBytes(n int) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+// WithSizeFunc sets the function used by WithMaxBytes to measure entries.
+// By default strings and byte slices count their length plus the key length,
+// and any other value counts as 64 bytes.
+func WithSizeFunc(fn SizeFunc) Option {
+	return func(o *options) {
+		o.sizeFunc = fn
+	}
+}
+
+// WithCleanupInterval sets how often a size-bounded cache created with
+// WithMaxEntries or WithMaxBytes removes expired entries in the background.
+func WithCleanupInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.cleanupInterval = interval
+	}
+}
+
+// WithShards splits the cache into n independently locked shards chosen by
+// key hash, reducing lock contention under heavy concurrent use. Entry and
+// byte limits are divided evenly between the shards, so least recently used
+// eviction happens per shard, and a single entry must fit in one shard's share
+// of WithMaxBytes. A sharded cache is unbounded unless WithMaxEntries or
+// WithMaxBytes is also given.
+func WithShards(n int) Option {
+	return func(o *options) {
+		o.shards = n
+	}
+}
+
+// WithSnapshotFile restores the cache from path when it is created, if the
+// file exists, and saves a snapshot to path every interval and when the
+// context passed to NewCache is done. This lets warm caches survive restarts.
+func WithSnapshotFile(path string, interval time.Duration) Option {
+	return func(o *options) {
+		o.snapshotPath = path
+		o.snapshotInterval = interval
+	}
+}
+
 // NewCache creates a new high-performance cache instance using the otter library.
-// The cache is configured with a maximum capacity of 1,000 entries, 1-minute TTL,
-// and statistics collection enabled. All entries have equal cost (1) for eviction purposes.
-// The context parameter is reserved for future use and cancellation support.
-// Returns an error if cache initialization fails, though this is unlikely with current configuration.
-func NewCache(ctx context.Context) (Cache, error) {
-	cache, err := otter.MustBuilder[string, any](1_000).
-		CollectStats().
-		Cost(func(key string, value any) uint32 {
-			return 1
-		}).
-		WithTTL(time.Minute).
-		Build()
+// The cache is configured with a maximum capacity of 1,000 entries, a 1-minute
+// default TTL unless changed with WithDefaultTTL, and statistics collection enabled.
+// All entries have equal cost (1) for eviction purposes.
+// If WithMaxEntries or WithMaxBytes is given, a size-bounded cache with strict
+// least recently used eviction is created instead, or with per-shard least
+// recently used eviction if WithShards is also given.
+//
+// The cache lives as long as ctx: once ctx is done, a final snapshot is saved if
+// WithSnapshotFile was given, background goroutines stop, entries are released,
+// and the cache ignores further writes and reports every key as missing.
+// Pass context.Background for a cache that lives as long as the process.
+// Returns an error if an option is invalid, cache initialization fails, or an
+// existing snapshot file cannot be loaded.
+func NewCache(ctx context.Context, opts ...Option) (Store, error) {
+	o := options{defaultTTL: DefaultTTL, cleanupInterval: DefaultCleanupInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c, err := newCache(o)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	if o.snapshotPath != "" {
+		if err := loadSnapshotFile(c, o.snapshotPath); err != nil {
+			c.close()
+			return nil, err
+		}
+	}
+
+	// Close the cache once the context is done, saving a final snapshot first
+	if ctx.Done() != nil {
+		go func() {
+			if o.snapshotPath != "" {
+				runSnapshots(ctx, c, o.snapshotPath, o.snapshotInterval)
+			} else {
+				<-ctx.Done()
+			}
+			c.close()
+		}()
+	} else if o.snapshotPath != "" {
+		go runSnapshots(ctx, c, o.snapshotPath, o.snapshotInterval)
+	}
+
+	return c, nil
+}
+
+// newCache builds the cache implementation selected by the options.
+func newCache(o options) (closer, error) {
+	if o.defaultTTL <= 0 {
+		return nil, fmt.Errorf("cache ttl must be positive, got %s", o.defaultTTL)
+	}
+	if o.maxEntries < 0 {
+		return nil, fmt.Errorf("cache max entries cannot be negative, got %d", o.maxEntries)
+	}
+	if o.maxBytes < 0 {
+		return nil, fmt.Errorf("cache max bytes cannot be negative, got %d", o.maxBytes)
+	}
+	if o.snapshotPath != "" && o.snapshotInterval <= 0 {
+		return nil, fmt.Errorf("cache snapshot interval must be positive, got %s", o.snapshotInterval)
+	}
+	if o.shards < 0 {
+		return nil, fmt.Errorf("cache shards cannot be negative, got %d", o.shards)
+	}
+	if o.cleanupInterval <= 0 {
+		return nil, fmt.Errorf("cache cleanup interval must be positive, got %s", o.cleanupInterval)
+	}
+
+	if o.shards > 1 {
+		return newShardedCache(o, o.shards), nil
+	}
+	if o.maxEntries > 0 || o.maxBytes > 0 {
+		return newLRUCache(o), nil
+	}
+
+	return newOtterCache(o)
+}
+
+// NewCacheWithTTL creates a cache configured like NewCache whose entries expire
+// ttl after they were last written. It is shorthand for NewCache(ctx, WithDefaultTTL(ttl)).
+// Returns an error if ttl is not positive.
+func NewCacheWithTTL(ctx context.Context, ttl time.Duration) (Store, error) {
+	return NewCache(ctx, WithDefaultTTL(ttl))
+}
+
+// closer is implemented by the caches created by NewCache.
+type closer interface {
+	Store
+	// close releases the cache's entries and stops its background goroutines.
+	close()
+}
+
+// getCtx implements GetCtx for in-memory caches.
+func getCtx(ctx context.Context, c Cache, key string) (any, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
 	}
+	value, ok := c.Get(key)
+	return value, ok, nil
+}
 
-	return cache, nil
+// setCtx implements SetCtx for in-memory caches.
+func setCtx(ctx context.Context, c Cache, key string, value any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !c.Set(key, value) {
+		return ErrNotStored
+	}
+	return nil
 }
@@ -17,14 +17,119 @@ func TestNewCache(t *testing.T) {
 	require.NotNil(t, cache)
 }
 
+// mapCache is a minimal Cache implementation, like those written by users of
+// the package.
+type mapCache map[string]any
+
+func (m mapCache) Get(key string) (any, bool)     { v, ok := m[key]; return v, ok }
+func (m mapCache) Set(key string, value any) bool { m[key] = value; return true }
+func (m mapCache) Delete(key string)              { delete(m, key) }
+
+func TestCache_MinimalImplementation(t *testing.T) {
+	var c cache.Cache = mapCache{}
+	require.True(t, c.Set("key", "value"))
+
+	// Caches created by NewCache are Stores, and so also Caches
+	store, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+	c = store
+	require.True(t, c.Set("key", "value"))
+}
+
 func TestNewCache_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
-	// Should still work since context is reserved for future use
+	// Creation succeeds, but the cache closes as soon as it notices the context is done
 	cache, err := cache.NewCache(ctx)
 	require.NoError(t, err)
 	require.NotNil(t, cache)
+
+	require.Eventually(t, func() bool {
+		return !cache.Set("key", "value")
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestNewCacheWithTTL(t *testing.T) {
+	ctx := context.Background()
+	c, err := cache.NewCacheWithTTL(ctx, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	require.True(t, c.Set("short", "value"))
+	value, exists := c.Get("short")
+	require.True(t, exists)
+	require.Equal(t, "value", value)
+
+	require.Eventually(t, func() bool {
+		_, exists := c.Get("short")
+		return !exists
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestNewCacheWithTTL_Invalid(t *testing.T) {
+	for _, ttl := range []time.Duration{0, -time.Second} {
+		c, err := cache.NewCacheWithTTL(context.Background(), ttl)
+		require.Error(t, err)
+		require.Nil(t, c)
+		require.Contains(t, err.Error(), "ttl must be positive")
+	}
+}
+
+func TestNewCache_WithDefaultTTL(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithDefaultTTL(50*time.Millisecond))
+	require.NoError(t, err)
+
+	require.True(t, c.Set("key", "value"))
+	require.Eventually(t, func() bool {
+		_, exists := c.Get("key")
+		return !exists
+	}, 2*time.Second, 10*time.Millisecond)
+
+	_, err = cache.NewCache(context.Background(), cache.WithDefaultTTL(0))
+	require.ErrorContains(t, err, "ttl must be positive")
+}
+
+func TestCache_SetWithTTL(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithDefaultTTL(time.Hour))
+	require.NoError(t, err)
+
+	require.True(t, c.Set("long", "default ttl"))
+	require.True(t, c.SetWithTTL("short", "short ttl", 50*time.Millisecond))
+
+	value, exists := c.Get("short")
+	require.True(t, exists)
+	require.Equal(t, "short ttl", value)
+
+	require.Eventually(t, func() bool {
+		_, exists := c.Get("short")
+		return !exists
+	}, 2*time.Second, 10*time.Millisecond)
+
+	value, exists = c.Get("long")
+	require.True(t, exists)
+	require.Equal(t, "default ttl", value)
+}
+
+func TestCache_SetWithTTL_OverridesDefault(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithDefaultTTL(50*time.Millisecond))
+	require.NoError(t, err)
+
+	require.True(t, c.SetWithTTL("long", "value", time.Hour))
+	time.Sleep(200 * time.Millisecond)
+
+	_, exists := c.Get("long")
+	require.True(t, exists)
+}
+
+func TestCache_SetWithTTL_Invalid(t *testing.T) {
+	c, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+
+	require.False(t, c.SetWithTTL("zero", "value", 0))
+	require.False(t, c.SetWithTTL("negative", "value", -time.Second))
+
+	_, exists := c.Get("zero")
+	require.False(t, exists)
 }
 
 func TestCache_BasicOperations(t *testing.T) {
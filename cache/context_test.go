@@ -0,0 +1,97 @@
+package cache_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCtxOperations(t *testing.T) {
+	for name, opts := range map[string][]cache.Option{
+		"otter": nil,
+		"lru":   {cache.WithMaxEntries(100)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, err := cache.NewCache(context.Background(), opts...)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			require.NoError(t, c.SetCtx(ctx, "key", "value"))
+
+			value, exists, err := c.GetCtx(ctx, "key")
+			require.NoError(t, err)
+			require.True(t, exists)
+			require.Equal(t, "value", value)
+
+			_, exists, err = c.GetCtx(ctx, "missing")
+			require.NoError(t, err)
+			require.False(t, exists)
+
+			expired, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+			defer cancel()
+
+			_, _, err = c.GetCtx(expired, "key")
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.ErrorIs(t, c.SetCtx(expired, "other", "value"), context.DeadlineExceeded)
+
+			_, exists = c.Get("other")
+			require.False(t, exists)
+		})
+	}
+}
+
+func TestSetCtx_NotStored(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxBytes(10))
+	require.NoError(t, err)
+
+	err = c.SetCtx(context.Background(), "big", strings.Repeat("x", 100))
+	require.ErrorIs(t, err, cache.ErrNotStored)
+}
+
+func TestCtxOperations_Namespace(t *testing.T) {
+	shared, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+	ns := cache.Namespace(shared, "ns")
+
+	require.NoError(t, ns.SetCtx(context.Background(), "key", "value"))
+	value, exists, err := shared.GetCtx(context.Background(), "ns:key")
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, "value", value)
+}
+
+func TestCache_ClosedWhenContextDone(t *testing.T) {
+	for name, opts := range map[string][]cache.Option{
+		"otter": nil,
+		"lru":   {cache.WithMaxEntries(100)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			c, err := cache.NewCache(ctx, opts...)
+			require.NoError(t, err)
+
+			require.True(t, c.Set("key", "value"))
+			cancel()
+
+			require.Eventually(t, func() bool {
+				_, exists := c.Get("key")
+				return !exists
+			}, 2*time.Second, 10*time.Millisecond)
+
+			require.False(t, c.Set("key", "value"))
+			require.Equal(t, []string{"a"}, c.SetMany(map[string]any{"a": 1}))
+			require.Empty(t, c.GetMany([]string{"key"}))
+			c.Delete("key")
+			c.DeleteMany([]string{"key"})
+		})
+	}
+}
+
+func TestWithCleanupInterval_Invalid(t *testing.T) {
+	_, err := cache.NewCache(context.Background(), cache.WithCleanupInterval(0))
+	require.ErrorContains(t, err, "cleanup interval must be positive")
+}
@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU_JanitorRemovesExpired(t *testing.T) {
+	c, err := NewCache(context.Background(),
+		WithMaxEntries(10),
+		WithCleanupInterval(10*time.Millisecond),
+		WithDefaultTTL(20*time.Millisecond),
+	)
+	require.NoError(t, err)
+	lru := c.(*lruCache)
+
+	require.True(t, c.Set("a", "value"))
+	require.True(t, c.SetWithTTL("b", "value", time.Hour))
+
+	require.Eventually(t, func() bool {
+		lru.mu.Lock()
+		defer lru.mu.Unlock()
+		return len(lru.items) == 1 && lru.bytes == defaultSize("b", "value")
+	}, 2*time.Second, 10*time.Millisecond)
+
+	lru.close()
+	select {
+	case <-lru.stop:
+	default:
+		t.Fatal("janitor should be stopped after close")
+	}
+}
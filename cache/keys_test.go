@@ -0,0 +1,71 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeysLenFlush(t *testing.T) {
+	for name, opts := range map[string][]cache.Option{
+		"otter":   nil,
+		"lru":     {cache.WithMaxEntries(100)},
+		"sharded": {cache.WithShards(4)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, err := cache.NewCache(context.Background(), opts...)
+			require.NoError(t, err)
+
+			require.Empty(t, c.Keys())
+			require.Zero(t, c.Len())
+
+			require.Empty(t, c.SetMany(map[string]any{"a": 1, "b": 2, "c": 3}))
+			require.ElementsMatch(t, []string{"a", "b", "c"}, c.Keys())
+			require.Equal(t, 3, c.Len())
+
+			c.Delete("b")
+			require.ElementsMatch(t, []string{"a", "c"}, c.Keys())
+			require.Equal(t, 2, c.Len())
+
+			c.Flush()
+			require.Empty(t, c.Keys())
+			require.Zero(t, c.Len())
+			_, ok := c.Get("a")
+			require.False(t, ok)
+
+			require.True(t, c.Set("d", 4))
+			require.Equal(t, []string{"d"}, c.Keys())
+		})
+	}
+}
+
+func TestKeysLen_SkipsExpired(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxEntries(100))
+	require.NoError(t, err)
+
+	require.True(t, c.Set("kept", 1))
+	require.True(t, c.SetWithTTL("expired", 2, 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+
+	require.Equal(t, []string{"kept"}, c.Keys())
+	require.Equal(t, 1, c.Len())
+}
+
+func TestKeysLenFlush_Namespace(t *testing.T) {
+	shared, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+	users := cache.Namespace(shared, "users")
+	require.True(t, shared.Set("other", 0))
+	require.Empty(t, users.SetMany(map[string]any{"1": "alice", "2": "bob"}))
+
+	require.ElementsMatch(t, []string{"1", "2"}, users.Keys())
+	require.Equal(t, 2, users.Len())
+	require.Equal(t, 3, shared.Len())
+
+	users.Flush()
+	require.Zero(t, users.Len())
+	require.Equal(t, []string{"other"}, shared.Keys())
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bilte-co/toolshed/logging"
+)
+
+// Loader loads the current value for key, for example from a database.
+type Loader func(ctx context.Context, key string) (any, error)
+
+// LoadingCache is a stale-while-revalidate cache that fills itself using a
+// Loader. An entry older than the soft TTL is still returned immediately, while
+// a background goroutine reloads it; an entry older than the hard TTL (the
+// cache's default TTL) is dropped and the next Get loads it synchronously.
+type LoadingCache struct {
+	ctx     context.Context
+	cache   Store
+	loader  Loader
+	softTTL time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	inflight map[string]*loadCall
+}
+
+// loadingEntry is stored in the underlying cache for each loaded value.
+type loadingEntry struct {
+	value     any
+	refreshAt time.Time
+}
+
+// loadCall tracks a load in progress so that concurrent loads of a key share it.
+type loadCall struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// NewLoadingCache creates a LoadingCache whose entries are reloaded in the
+// background once they are older than softTTL. The options configure the
+// underlying cache as for NewCache; WithDefaultTTL sets the hard TTL, which
+// must be longer than softTTL. The loader is called with ctx, so loads and
+// background reloads stop once ctx is done.
+// Returns an error if softTTL or an option is invalid.
+func NewLoadingCache(ctx context.Context, loader Loader, softTTL time.Duration, opts ...Option) (*LoadingCache, error) {
+	if loader == nil {
+		return nil, fmt.Errorf("cache loader cannot be nil")
+	}
+
+	o := options{defaultTTL: DefaultTTL}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if softTTL <= 0 || softTTL >= o.defaultTTL {
+		return nil, fmt.Errorf("cache soft ttl must be positive and shorter than the ttl %s, got %s", o.defaultTTL, softTTL)
+	}
+
+	c, err := NewCache(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoadingCache{
+		ctx:      ctx,
+		cache:    c,
+		loader:   loader,
+		softTTL:  softTTL,
+		now:      time.Now,
+		inflight: make(map[string]*loadCall),
+	}, nil
+}
+
+// Get returns the value for key. A missing or expired entry is loaded before
+// Get returns, and concurrent Gets of the same key share a single load. A stale
+// entry is returned as is while it is reloaded in the background; if that
+// reload fails, the error is logged and the stale value is kept until the hard
+// TTL. The load is not tied to ctx, so a caller giving up does not fail the
+// other Gets waiting for it. Returns the loader's error, or ctx's error if ctx
+// is done first.
+func (l *LoadingCache) Get(ctx context.Context, key string) (any, error) {
+	if value, ok := l.cache.Get(key); ok {
+		entry := value.(loadingEntry)
+		if !l.now().Before(entry.refreshAt) {
+			l.refresh(key)
+		}
+		return entry.value, nil
+	}
+
+	call := l.load(key, false)
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Delete removes key, so that the next Get loads it again.
+func (l *LoadingCache) Delete(key string) {
+	l.cache.Delete(key)
+}
+
+// refresh reloads key in the background, unless it is already being loaded.
+func (l *LoadingCache) refresh(key string) {
+	if l.ctx.Err() != nil {
+		return
+	}
+
+	l.load(key, true)
+}
+
+// load starts loading key, or joins the load already in progress. Errors of
+// loads started in the background are logged, as nobody waits for them.
+func (l *LoadingCache) load(key string, background bool) *loadCall {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if call, ok := l.inflight[key]; ok {
+		return call
+	}
+
+	call := &loadCall{done: make(chan struct{})}
+	l.inflight[key] = call
+
+	go func() {
+		call.value, call.err = l.loader(l.ctx, key)
+		if call.err == nil {
+			l.cache.Set(key, loadingEntry{value: call.value, refreshAt: l.now().Add(l.softTTL)})
+		} else if background {
+			logging.FromContext(l.ctx).Error("Failed to refresh cache entry", "key", key, "error", call.err)
+		}
+
+		l.mu.Lock()
+		delete(l.inflight, key)
+		l.mu.Unlock()
+		close(call.done)
+	}()
+
+	return call
+}
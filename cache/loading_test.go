@@ -0,0 +1,188 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadingCache_LoadsOnMiss(t *testing.T) {
+	var calls atomic.Int32
+	c, err := cache.NewLoadingCache(context.Background(), func(ctx context.Context, key string) (any, error) {
+		calls.Add(1)
+		return "value:" + key, nil
+	}, time.Minute, cache.WithDefaultTTL(time.Hour))
+	require.NoError(t, err)
+
+	value, err := c.Get(context.Background(), "a")
+	require.NoError(t, err)
+	require.Equal(t, "value:a", value)
+
+	value, err = c.Get(context.Background(), "a")
+	require.NoError(t, err)
+	require.Equal(t, "value:a", value)
+	require.Equal(t, int32(1), calls.Load())
+
+	c.Delete("a")
+	_, err = c.Get(context.Background(), "a")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestLoadingCache_StaleWhileRevalidate(t *testing.T) {
+	var version atomic.Int32
+	c, err := cache.NewLoadingCache(context.Background(), func(ctx context.Context, key string) (any, error) {
+		return version.Add(1), nil
+	}, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	value, err := c.Get(context.Background(), "k")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), value)
+
+	time.Sleep(20 * time.Millisecond)
+
+	// The stale value is returned immediately while it is reloaded
+	value, err = c.Get(context.Background(), "k")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), value)
+
+	require.Eventually(t, func() bool {
+		value, err := c.Get(context.Background(), "k")
+		return err == nil && value == int32(2)
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestLoadingCache_RefreshErrorKeepsStaleValue(t *testing.T) {
+	var calls atomic.Int32
+	c, err := cache.NewLoadingCache(context.Background(), func(ctx context.Context, key string) (any, error) {
+		if calls.Add(1) > 1 {
+			return nil, errors.New("backend down")
+		}
+		return "stale", nil
+	}, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	_, err = c.Get(context.Background(), "k")
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	for range 3 {
+		value, err := c.Get(context.Background(), "k")
+		require.NoError(t, err)
+		require.Equal(t, "stale", value)
+		time.Sleep(5 * time.Millisecond)
+	}
+	require.Greater(t, calls.Load(), int32(1))
+}
+
+func TestLoadingCache_SharesConcurrentLoads(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c, err := cache.NewLoadingCache(context.Background(), func(ctx context.Context, key string) (any, error) {
+		calls.Add(1)
+		<-release
+		return "v", nil
+	}, time.Second)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := c.Get(context.Background(), "k")
+			require.NoError(t, err)
+			require.Equal(t, "v", value)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestLoadingCache_CanceledCallerDoesNotFailSharedLoad(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	c, err := cache.NewLoadingCache(context.Background(), func(ctx context.Context, key string) (any, error) {
+		calls.Add(1)
+		select {
+		case <-release:
+			return "value", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}, time.Minute, cache.WithDefaultTTL(time.Hour))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.Get(ctx, "k")
+		first <- err
+	}()
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	var value any
+	second := make(chan error, 1)
+	go func() {
+		var err error
+		value, err = c.Get(context.Background(), "k")
+		second <- err
+	}()
+
+	cancel()
+	require.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-second)
+	require.Equal(t, "value", value)
+
+	// The shared load was cached despite the first caller giving up
+	value, err = c.Get(context.Background(), "k")
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestLoadingCache_Errors(t *testing.T) {
+	loadErr := errors.New("not found")
+	c, err := cache.NewLoadingCache(context.Background(), func(ctx context.Context, key string) (any, error) {
+		return nil, loadErr
+	}, time.Second)
+	require.NoError(t, err)
+
+	_, err = c.Get(context.Background(), "k")
+	require.ErrorIs(t, err, loadErr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	blocked, err := cache.NewLoadingCache(context.Background(), func(ctx context.Context, key string) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, time.Second)
+	require.NoError(t, err)
+	_, err = blocked.Get(ctx, "k")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewLoadingCache_InvalidOptions(t *testing.T) {
+	loader := func(ctx context.Context, key string) (any, error) { return nil, nil }
+
+	_, err := cache.NewLoadingCache(context.Background(), nil, time.Second)
+	require.Error(t, err)
+
+	_, err = cache.NewLoadingCache(context.Background(), loader, 0)
+	require.Error(t, err)
+
+	_, err = cache.NewLoadingCache(context.Background(), loader, time.Hour, cache.WithDefaultTTL(time.Minute))
+	require.Error(t, err)
+}
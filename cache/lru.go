@@ -0,0 +1,320 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultValueSize is the size WithMaxBytes assumes for values other than
+// strings and byte slices when no SizeFunc is given.
+const defaultValueSize = 64
+
+// SizeFunc reports the approximate number of bytes an entry occupies.
+type SizeFunc func(key string, value any) int
+
+// defaultSize counts the key and the length of string and []byte values,
+// and defaultValueSize for any other value.
+func defaultSize(key string, value any) int {
+	switch v := value.(type) {
+	case []byte:
+		return len(key) + len(v)
+	case string:
+		return len(key) + len(v)
+	default:
+		return len(key) + defaultValueSize
+	}
+}
+
+// lruEntry is an element of the LRU list.
+type lruEntry struct {
+	key       string
+	value     any
+	size      int
+	expiresAt time.Time
+}
+
+// lruCache is a size-bounded cache that evicts the least recently used
+// entries once the entry or byte limit is exceeded.
+type lruCache struct {
+	mu         sync.Mutex
+	items      map[string]*list.Element
+	order      *list.List // Front is most recently used
+	bytes      int
+	maxEntries int
+	maxBytes   int
+	sizeOf     SizeFunc
+	defaultTTL time.Duration
+	now        func() time.Time
+	closed     bool
+	stop       chan struct{} // Closed to stop the janitor
+}
+
+// newLRUCache creates an LRU cache from validated options.
+func newLRUCache(o options) *lruCache {
+	sizeOf := o.sizeFunc
+	if sizeOf == nil {
+		sizeOf = defaultSize
+	}
+	c := &lruCache{
+		items:      make(map[string]*list.Element),
+		order:      list.New(),
+		maxEntries: o.maxEntries,
+		maxBytes:   o.maxBytes,
+		sizeOf:     sizeOf,
+		defaultTTL: o.defaultTTL,
+		now:        time.Now,
+		stop:       make(chan struct{}),
+	}
+	go c.janitor(o.cleanupInterval)
+	return c
+}
+
+// janitor removes expired entries every interval until the cache is closed.
+func (c *lruCache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.removeExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// removeExpired removes all expired entries.
+func (c *lruCache) removeExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeExpiredLocked()
+}
+
+// removeExpiredLocked removes all expired entries. The caller must hold mu.
+func (c *lruCache) removeExpiredLocked() {
+	now := c.now()
+	for _, elem := range c.items {
+		if !now.Before(elem.Value.(*lruEntry).expiresAt) {
+			c.remove(elem)
+		}
+	}
+}
+
+// close stops the janitor and releases all entries.
+func (c *lruCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.stop)
+	c.clear()
+}
+
+// clear removes all entries. The caller must hold mu.
+func (c *lruCache) clear() {
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+	c.bytes = 0
+}
+
+func (c *lruCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.get(key)
+}
+
+// get looks up a key and marks it as recently used. The caller must hold mu.
+func (c *lruCache) get(key string) (any, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *lruCache) Set(key string, value any) bool {
+	return c.SetWithTTL(key, value, c.defaultTTL)
+}
+
+func (c *lruCache) SetWithTTL(key string, value any, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.set(key, value, ttl)
+}
+
+// set stores an entry and evicts entries over the limits. The caller must hold mu.
+func (c *lruCache) set(key string, value any, ttl time.Duration) bool {
+	if c.closed || ttl <= 0 {
+		return false
+	}
+
+	size := c.sizeOf(key, value)
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return false
+	}
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+
+	entry := &lruEntry{key: key, value: value, size: size, expiresAt: c.now().Add(ttl)}
+	c.items[key] = c.order.PushFront(entry)
+	c.bytes += size
+	c.evict()
+	return true
+}
+
+func (c *lruCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+}
+
+func (c *lruCache) GetMany(keys []string) map[string]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	found := make(map[string]any, len(keys))
+	for _, key := range keys {
+		if value, ok := c.get(key); ok {
+			found[key] = value
+		}
+	}
+	return found
+}
+
+func (c *lruCache) SetMany(entries map[string]any) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var failed []string
+	for key, value := range entries {
+		if !c.set(key, value, c.defaultTTL) {
+			failed = append(failed, key)
+		}
+	}
+	return failed
+}
+
+func (c *lruCache) DeleteMany(keys []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.items[key]; ok {
+			c.remove(elem)
+		}
+	}
+}
+
+func (c *lruCache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeExpiredLocked()
+	keys := make([]string, 0, len(c.items))
+	for key := range c.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (c *lruCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeExpiredLocked()
+	return len(c.items)
+}
+
+func (c *lruCache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clear()
+}
+
+func (c *lruCache) GetCtx(ctx context.Context, key string) (any, bool, error) {
+	return getCtx(ctx, c, key)
+}
+
+func (c *lruCache) SetCtx(ctx context.Context, key string, value any) error {
+	return setCtx(ctx, c, key, value)
+}
+
+func (c *lruCache) Save(w io.Writer) error {
+	return saveSnapshot(w, c.entries())
+}
+
+func (c *lruCache) Load(r io.Reader) error {
+	return loadSnapshot(r, c)
+}
+
+// entries returns the unexpired entries, least recently used first so that
+// loading them preserves the recency order.
+func (c *lruCache) entries() []snapshotEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	entries := make([]snapshotEntry, 0, c.order.Len())
+	for elem := c.order.Back(); elem != nil; elem = elem.Prev() {
+		entry := elem.Value.(*lruEntry)
+		if now.Before(entry.expiresAt) {
+			entries = append(entries, snapshotEntry{Key: entry.key, Value: entry.value, ExpiresAt: entry.expiresAt})
+		}
+	}
+	return entries
+}
+
+// deletePrefix removes all entries whose key starts with prefix.
+func (c *lruCache) deletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, elem := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(elem)
+		}
+	}
+}
+
+// evict removes least recently used entries until the cache is within its limits.
+func (c *lruCache) evict() {
+	for c.overLimit() {
+		c.remove(c.order.Back())
+	}
+}
+
+// overLimit reports whether the cache holds more entries or bytes than allowed.
+func (c *lruCache) overLimit() bool {
+	return (c.maxEntries > 0 && c.order.Len() > c.maxEntries) ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+// remove deletes an element from the list and index. The caller must hold mu.
+func (c *lruCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*lruEntry)
+	delete(c.items, entry.key)
+	c.bytes -= entry.size
+}
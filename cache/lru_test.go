@@ -0,0 +1,154 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU_MaxEntries(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxEntries(3))
+	require.NoError(t, err)
+
+	require.True(t, c.Set("a", 1))
+	require.True(t, c.Set("b", 2))
+	require.True(t, c.Set("c", 3))
+
+	// Touch "a" so "b" becomes the least recently used entry
+	_, exists := c.Get("a")
+	require.True(t, exists)
+
+	require.True(t, c.Set("d", 4))
+
+	_, exists = c.Get("b")
+	require.False(t, exists, "least recently used entry should be evicted")
+	for _, key := range []string{"a", "c", "d"} {
+		_, exists := c.Get(key)
+		require.True(t, exists, key)
+	}
+}
+
+func TestLRU_MaxBytes(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxBytes(100))
+	require.NoError(t, err)
+
+	// Each entry is 1 byte of key plus 40 bytes of value
+	blob := []byte(strings.Repeat("x", 40))
+	require.True(t, c.Set("a", blob))
+	require.True(t, c.Set("b", blob))
+	require.True(t, c.Set("c", blob))
+
+	_, exists := c.Get("a")
+	require.False(t, exists)
+	_, exists = c.Get("b")
+	require.True(t, exists)
+	_, exists = c.Get("c")
+	require.True(t, exists)
+}
+
+func TestLRU_EntryLargerThanMaxBytes(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxBytes(10))
+	require.NoError(t, err)
+
+	require.True(t, c.Set("small", "x"))
+	require.False(t, c.Set("big", strings.Repeat("x", 100)))
+
+	_, exists := c.Get("big")
+	require.False(t, exists)
+	_, exists = c.Get("small")
+	require.True(t, exists, "rejected entry should not evict others")
+}
+
+func TestLRU_OverwriteUpdatesSize(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxBytes(50))
+	require.NoError(t, err)
+
+	require.True(t, c.Set("a", strings.Repeat("x", 40)))
+	require.True(t, c.Set("a", "small"))
+	require.True(t, c.Set("b", strings.Repeat("y", 30)))
+
+	value, exists := c.Get("a")
+	require.True(t, exists)
+	require.Equal(t, "small", value)
+}
+
+func TestLRU_SizeFunc(t *testing.T) {
+	c, err := cache.NewCache(context.Background(),
+		cache.WithMaxBytes(10),
+		cache.WithSizeFunc(func(key string, value any) int { return value.(int) }),
+	)
+	require.NoError(t, err)
+
+	require.True(t, c.Set("a", 6))
+	require.True(t, c.Set("b", 6))
+
+	_, exists := c.Get("a")
+	require.False(t, exists)
+	_, exists = c.Get("b")
+	require.True(t, exists)
+}
+
+func TestLRU_TTL(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxEntries(10), cache.WithDefaultTTL(50*time.Millisecond))
+	require.NoError(t, err)
+
+	require.True(t, c.Set("short", "value"))
+	require.True(t, c.SetWithTTL("long", "value", time.Hour))
+	require.False(t, c.SetWithTTL("invalid", "value", 0))
+
+	require.Eventually(t, func() bool {
+		_, exists := c.Get("short")
+		return !exists
+	}, 2*time.Second, 10*time.Millisecond)
+
+	_, exists := c.Get("long")
+	require.True(t, exists)
+}
+
+func TestLRU_Delete(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxEntries(2))
+	require.NoError(t, err)
+
+	require.True(t, c.Set("a", 1))
+	c.Delete("a")
+	c.Delete("missing")
+
+	_, exists := c.Get("a")
+	require.False(t, exists)
+}
+
+func TestLRU_InvalidOptions(t *testing.T) {
+	_, err := cache.NewCache(context.Background(), cache.WithMaxEntries(-1))
+	require.ErrorContains(t, err, "max entries cannot be negative")
+
+	_, err = cache.NewCache(context.Background(), cache.WithMaxBytes(-1))
+	require.ErrorContains(t, err, "max bytes cannot be negative")
+}
+
+func TestLRU_ConcurrentAccess(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithMaxEntries(50))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprintf("key-%d-%d", g, i%100)
+				c.Set(key, i)
+				c.Get(key)
+				if i%7 == 0 {
+					c.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// NamespaceSeparator separates a namespace from the keys stored in it.
+const NamespaceSeparator = ":"
+
+// enumerable is implemented by the caches in this package, which can list
+// and remove entries by key prefix.
+type enumerable interface {
+	Store
+	entries() []snapshotEntry
+	deletePrefix(prefix string)
+}
+
+// Namespaced is a view of a cache that stores its keys under a prefix, so
+// that several subsystems can share one cache without key collisions.
+type Namespaced struct {
+	parent Store
+	prefix string
+}
+
+// Namespace returns a view of c whose keys are transparently stored as
+// name + ":" + key. Namespaces can be nested. FlushNamespace, Save and Load
+// require c to be a cache created by this package or another namespace of one.
+func Namespace(c Store, name string) *Namespaced {
+	return &Namespaced{parent: c, prefix: name + NamespaceSeparator}
+}
+
+// Prefix returns the prefix added to keys in this namespace.
+func (n *Namespaced) Prefix() string {
+	return n.prefix
+}
+
+func (n *Namespaced) Get(key string) (any, bool) {
+	return n.parent.Get(n.prefix + key)
+}
+
+func (n *Namespaced) Set(key string, value any) bool {
+	return n.parent.Set(n.prefix+key, value)
+}
+
+func (n *Namespaced) SetWithTTL(key string, value any, ttl time.Duration) bool {
+	return n.parent.SetWithTTL(n.prefix+key, value, ttl)
+}
+
+func (n *Namespaced) Delete(key string) {
+	n.parent.Delete(n.prefix + key)
+}
+
+func (n *Namespaced) GetMany(keys []string) map[string]any {
+	found := make(map[string]any, len(keys))
+	for key, value := range n.parent.GetMany(n.prefixed(keys)) {
+		found[strings.TrimPrefix(key, n.prefix)] = value
+	}
+	return found
+}
+
+func (n *Namespaced) SetMany(entries map[string]any) []string {
+	prefixed := make(map[string]any, len(entries))
+	for key, value := range entries {
+		prefixed[n.prefix+key] = value
+	}
+
+	var failed []string
+	for _, key := range n.parent.SetMany(prefixed) {
+		failed = append(failed, strings.TrimPrefix(key, n.prefix))
+	}
+	return failed
+}
+
+func (n *Namespaced) DeleteMany(keys []string) {
+	n.parent.DeleteMany(n.prefixed(keys))
+}
+
+// Keys returns the keys in the namespace, without the prefix.
+func (n *Namespaced) Keys() []string {
+	keys := []string{}
+	for _, key := range n.parent.Keys() {
+		if key, found := strings.CutPrefix(key, n.prefix); found {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of unexpired entries in the namespace.
+func (n *Namespaced) Len() int {
+	return len(n.Keys())
+}
+
+// Flush removes every entry in the namespace; it is the same as FlushNamespace.
+func (n *Namespaced) Flush() {
+	n.FlushNamespace()
+}
+
+func (n *Namespaced) GetCtx(ctx context.Context, key string) (any, bool, error) {
+	return n.parent.GetCtx(ctx, n.prefix+key)
+}
+
+func (n *Namespaced) SetCtx(ctx context.Context, key string, value any) error {
+	return n.parent.SetCtx(ctx, n.prefix+key, value)
+}
+
+// prefixed returns keys with the namespace prefix added.
+func (n *Namespaced) prefixed(keys []string) []string {
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		result[i] = n.prefix + key
+	}
+	return result
+}
+
+// FlushNamespace removes every entry in the namespace, leaving other
+// entries of the shared cache untouched.
+func (n *Namespaced) FlushNamespace() {
+	if parent, ok := n.parent.(enumerable); ok {
+		parent.deletePrefix(n.prefix)
+	}
+}
+
+// Save writes a snapshot of the entries in the namespace, with the prefix
+// removed from their keys, so it can be loaded into any cache or namespace.
+func (n *Namespaced) Save(w io.Writer) error {
+	if _, ok := n.parent.(enumerable); !ok {
+		return fmt.Errorf("cache of type %T cannot be enumerated", n.parent)
+	}
+	return saveSnapshot(w, n.entries())
+}
+
+// Load adds the entries of a snapshot to the namespace.
+func (n *Namespaced) Load(r io.Reader) error {
+	return loadSnapshot(r, n)
+}
+
+// entries returns the unexpired entries of the namespace without the prefix.
+func (n *Namespaced) entries() []snapshotEntry {
+	parent, ok := n.parent.(enumerable)
+	if !ok {
+		return nil
+	}
+
+	var entries []snapshotEntry
+	for _, entry := range parent.entries() {
+		if key, found := strings.CutPrefix(entry.Key, n.prefix); found {
+			entry.Key = key
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// deletePrefix removes entries in the namespace whose key starts with prefix.
+func (n *Namespaced) deletePrefix(prefix string) {
+	if parent, ok := n.parent.(enumerable); ok {
+		parent.deletePrefix(n.prefix + prefix)
+	}
+}
@@ -0,0 +1,107 @@
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespace_Isolation(t *testing.T) {
+	for name, opts := range map[string][]cache.Option{
+		"otter": nil,
+		"lru":   {cache.WithMaxEntries(100)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			shared, err := cache.NewCache(context.Background(), opts...)
+			require.NoError(t, err)
+
+			users := cache.Namespace(shared, "users")
+			sessions := cache.Namespace(shared, "sessions")
+
+			require.True(t, users.Set("1", "alice"))
+			require.True(t, sessions.Set("1", "token"))
+			require.True(t, sessions.SetWithTTL("2", "other", time.Hour))
+
+			value, exists := users.Get("1")
+			require.True(t, exists)
+			require.Equal(t, "alice", value)
+
+			value, exists = sessions.Get("1")
+			require.True(t, exists)
+			require.Equal(t, "token", value)
+
+			// Keys are stored with the prefix in the shared cache
+			value, exists = shared.Get("users:1")
+			require.True(t, exists)
+			require.Equal(t, "alice", value)
+
+			sessions.FlushNamespace()
+			_, exists = sessions.Get("1")
+			require.False(t, exists)
+			_, exists = sessions.Get("2")
+			require.False(t, exists)
+
+			_, exists = users.Get("1")
+			require.True(t, exists, "flush must not touch other namespaces")
+
+			users.Delete("1")
+			_, exists = shared.Get("users:1")
+			require.False(t, exists)
+		})
+	}
+}
+
+func TestNamespace_Nested(t *testing.T) {
+	shared, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+
+	api := cache.Namespace(shared, "api")
+	v1 := cache.Namespace(api, "v1")
+	v2 := cache.Namespace(api, "v2")
+	require.Equal(t, "v1:", v1.Prefix())
+
+	require.True(t, v1.Set("key", "one"))
+	require.True(t, v2.Set("key", "two"))
+
+	value, exists := shared.Get("api:v1:key")
+	require.True(t, exists)
+	require.Equal(t, "one", value)
+
+	v1.FlushNamespace()
+	_, exists = v1.Get("key")
+	require.False(t, exists)
+	_, exists = v2.Get("key")
+	require.True(t, exists)
+
+	api.FlushNamespace()
+	_, exists = v2.Get("key")
+	require.False(t, exists)
+}
+
+func TestNamespace_SaveLoad(t *testing.T) {
+	shared, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+
+	users := cache.Namespace(shared, "users")
+	require.True(t, users.Set("1", "alice"))
+	require.True(t, shared.Set("unrelated", "value"))
+
+	var buf bytes.Buffer
+	require.NoError(t, users.Save(&buf))
+
+	// The snapshot holds unprefixed keys, so it loads into another namespace
+	other, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+	archive := cache.Namespace(other, "archive")
+	require.NoError(t, archive.Load(&buf))
+
+	value, exists := other.Get("archive:1")
+	require.True(t, exists)
+	require.Equal(t, "alice", value)
+	_, exists = other.Get("archive:unrelated")
+	require.False(t, exists)
+}
@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maypok86/otter"
+)
+
+// otterCache adapts an otter cache with per-entry TTLs to the Cache interface.
+type otterCache struct {
+	store      otter.CacheWithVariableTTL[string, any]
+	defaultTTL time.Duration
+
+	// mu guards closed; otter must not be used while or after it is closed
+	mu     sync.RWMutex
+	closed bool
+}
+
+// newOtterCache creates an otter-backed cache with the default capacity.
+func newOtterCache(o options) (*otterCache, error) {
+	store, err := otter.MustBuilder[string, any](defaultCapacity).
+		CollectStats().
+		Cost(func(key string, value any) uint32 {
+			return 1
+		}).
+		WithVariableTTL().
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cache: %w", err)
+	}
+
+	return &otterCache{store: store, defaultTTL: o.defaultTTL}, nil
+}
+
+func (c *otterCache) Get(key string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, false
+	}
+	return c.store.Get(key)
+}
+
+func (c *otterCache) Set(key string, value any) bool {
+	return c.SetWithTTL(key, value, c.defaultTTL)
+}
+
+func (c *otterCache) SetWithTTL(key string, value any, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return false
+	}
+	return c.store.Set(key, value, ttl)
+}
+
+func (c *otterCache) Delete(key string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.closed {
+		c.store.Delete(key)
+	}
+}
+
+func (c *otterCache) GetMany(keys []string) map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	found := make(map[string]any, len(keys))
+	if c.closed {
+		return found
+	}
+	for _, key := range keys {
+		if value, ok := c.store.Get(key); ok {
+			found[key] = value
+		}
+	}
+	return found
+}
+
+func (c *otterCache) SetMany(entries map[string]any) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var failed []string
+	for key, value := range entries {
+		if c.closed || !c.store.Set(key, value, c.defaultTTL) {
+			failed = append(failed, key)
+		}
+	}
+	return failed
+}
+
+func (c *otterCache) DeleteMany(keys []string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return
+	}
+	for _, key := range keys {
+		c.store.Delete(key)
+	}
+}
+
+func (c *otterCache) Keys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := []string{}
+	if c.closed {
+		return keys
+	}
+	c.store.Range(func(key string, value any) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Len counts the unexpired entries, which takes time proportional to the cache size.
+func (c *otterCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	n := 0
+	if c.closed {
+		return n
+	}
+	c.store.Range(func(key string, value any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Flush takes the lock exclusively, since otter must not be used while it is cleared.
+func (c *otterCache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.store.Clear()
+	}
+}
+
+func (c *otterCache) GetCtx(ctx context.Context, key string) (any, bool, error) {
+	return getCtx(ctx, c, key)
+}
+
+func (c *otterCache) SetCtx(ctx context.Context, key string, value any) error {
+	return setCtx(ctx, c, key, value)
+}
+
+func (c *otterCache) Save(w io.Writer) error {
+	return saveSnapshot(w, c.entries())
+}
+
+func (c *otterCache) Load(r io.Reader) error {
+	return loadSnapshot(r, c)
+}
+
+// entries returns the unexpired entries of the cache.
+func (c *otterCache) entries() []snapshotEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil
+	}
+
+	keys := make([]string, 0, c.store.Size())
+	c.store.Range(func(key string, value any) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	entries := make([]snapshotEntry, 0, len(keys))
+	for _, key := range keys {
+		entry, ok := c.store.Extension().GetEntryQuietly(key)
+		if !ok || entry.HasExpired() {
+			continue
+		}
+		entries = append(entries, snapshotEntry{
+			Key:       key,
+			Value:     entry.Value(),
+			ExpiresAt: time.Unix(entry.Expiration(), 0),
+		})
+	}
+	return entries
+}
+
+// deletePrefix removes all entries whose key starts with prefix.
+func (c *otterCache) deletePrefix(prefix string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return
+	}
+	c.store.DeleteByFunc(func(key string, value any) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// close stops otter's cleanup goroutine and releases all entries.
+func (c *otterCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		c.store.Close()
+	}
+}
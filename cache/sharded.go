@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"hash/maphash"
+	"io"
+	"time"
+)
+
+// shardedCache spreads entries over several LRU caches by key hash, so that
+// concurrent operations on different keys rarely contend for the same lock.
+type shardedCache struct {
+	seed   maphash.Seed
+	shards []*lruCache
+}
+
+// newShardedCache creates a cache of n LRU shards, dividing the entry and
+// byte limits evenly between them.
+func newShardedCache(o options, n int) *shardedCache {
+	shard := o
+	shard.maxEntries = ceilDiv(o.maxEntries, n)
+	shard.maxBytes = ceilDiv(o.maxBytes, n)
+
+	c := &shardedCache{seed: maphash.MakeSeed(), shards: make([]*lruCache, n)}
+	for i := range c.shards {
+		c.shards[i] = newLRUCache(shard)
+	}
+	return c
+}
+
+// ceilDiv divides a by b, rounding up.
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
+
+// shard returns the shard responsible for key.
+func (c *shardedCache) shard(key string) *lruCache {
+	return c.shards[maphash.String(c.seed, key)%uint64(len(c.shards))]
+}
+
+func (c *shardedCache) Get(key string) (any, bool) {
+	return c.shard(key).Get(key)
+}
+
+func (c *shardedCache) Set(key string, value any) bool {
+	return c.shard(key).Set(key, value)
+}
+
+func (c *shardedCache) SetWithTTL(key string, value any, ttl time.Duration) bool {
+	return c.shard(key).SetWithTTL(key, value, ttl)
+}
+
+func (c *shardedCache) Delete(key string) {
+	c.shard(key).Delete(key)
+}
+
+// GetMany groups keys by shard so that each shard is locked once.
+func (c *shardedCache) GetMany(keys []string) map[string]any {
+	found := make(map[string]any, len(keys))
+	for shard, shardKeys := range c.groupKeys(keys) {
+		for key, value := range shard.GetMany(shardKeys) {
+			found[key] = value
+		}
+	}
+	return found
+}
+
+// SetMany groups entries by shard so that each shard is locked once.
+func (c *shardedCache) SetMany(entries map[string]any) []string {
+	groups := make(map[*lruCache]map[string]any)
+	for key, value := range entries {
+		shard := c.shard(key)
+		if groups[shard] == nil {
+			groups[shard] = make(map[string]any)
+		}
+		groups[shard][key] = value
+	}
+
+	var failed []string
+	for shard, group := range groups {
+		failed = append(failed, shard.SetMany(group)...)
+	}
+	return failed
+}
+
+// DeleteMany groups keys by shard so that each shard is locked once.
+func (c *shardedCache) DeleteMany(keys []string) {
+	for shard, shardKeys := range c.groupKeys(keys) {
+		shard.DeleteMany(shardKeys)
+	}
+}
+
+// groupKeys splits keys by the shard responsible for them.
+func (c *shardedCache) groupKeys(keys []string) map[*lruCache][]string {
+	groups := make(map[*lruCache][]string)
+	for _, key := range keys {
+		shard := c.shard(key)
+		groups[shard] = append(groups[shard], key)
+	}
+	return groups
+}
+
+func (c *shardedCache) Keys() []string {
+	keys := []string{}
+	for _, shard := range c.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+func (c *shardedCache) Len() int {
+	n := 0
+	for _, shard := range c.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+func (c *shardedCache) Flush() {
+	for _, shard := range c.shards {
+		shard.Flush()
+	}
+}
+
+func (c *shardedCache) GetCtx(ctx context.Context, key string) (any, bool, error) {
+	return getCtx(ctx, c, key)
+}
+
+func (c *shardedCache) SetCtx(ctx context.Context, key string, value any) error {
+	return setCtx(ctx, c, key, value)
+}
+
+func (c *shardedCache) Save(w io.Writer) error {
+	return saveSnapshot(w, c.entries())
+}
+
+func (c *shardedCache) Load(r io.Reader) error {
+	return loadSnapshot(r, c)
+}
+
+// entries returns the unexpired entries of all shards.
+func (c *shardedCache) entries() []snapshotEntry {
+	var entries []snapshotEntry
+	for _, shard := range c.shards {
+		entries = append(entries, shard.entries()...)
+	}
+	return entries
+}
+
+// deletePrefix removes all entries whose key starts with prefix.
+func (c *shardedCache) deletePrefix(prefix string) {
+	for _, shard := range c.shards {
+		shard.deletePrefix(prefix)
+	}
+}
+
+// close closes every shard.
+func (c *shardedCache) close() {
+	for _, shard := range c.shards {
+		shard.close()
+	}
+}
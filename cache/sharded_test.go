@@ -0,0 +1,138 @@
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharded_Operations(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithShards(8), cache.WithMaxEntries(1000))
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		require.True(t, c.Set(strconv.Itoa(i), i))
+	}
+	for i := 0; i < 100; i++ {
+		value, exists := c.Get(strconv.Itoa(i))
+		require.True(t, exists)
+		require.Equal(t, i, value)
+	}
+
+	found := c.GetMany([]string{"1", "2", "missing"})
+	require.Equal(t, map[string]any{"1": 1, "2": 2}, found)
+
+	require.Empty(t, c.SetMany(map[string]any{"a": "x", "b": "y"}))
+	c.DeleteMany([]string{"a", "1"})
+	require.Equal(t, map[string]any{"b": "y"}, c.GetMany([]string{"a", "b", "1"}))
+
+	c.Delete("2")
+	_, exists := c.Get("2")
+	require.False(t, exists)
+
+	var buf bytes.Buffer
+	require.NoError(t, c.Save(&buf))
+	restored, err := cache.NewCache(context.Background(), cache.WithShards(4))
+	require.NoError(t, err)
+	require.NoError(t, restored.Load(&buf))
+	value, exists := restored.Get("50")
+	require.True(t, exists)
+	require.Equal(t, 50, value)
+
+	ns := cache.Namespace(c, "ns")
+	require.True(t, ns.Set("key", "value"))
+	ns.FlushNamespace()
+	_, exists = ns.Get("key")
+	require.False(t, exists)
+}
+
+func TestSharded_MaxEntries(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithShards(4), cache.WithMaxEntries(40))
+	require.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	require.LessOrEqual(t, len(c.GetMany(keys)), 40)
+}
+
+func TestSharded_InvalidShards(t *testing.T) {
+	_, err := cache.NewCache(context.Background(), cache.WithShards(-1))
+	require.ErrorContains(t, err, "shards cannot be negative")
+}
+
+func TestSharded_ConcurrentAccess(t *testing.T) {
+	c, err := cache.NewCache(context.Background(), cache.WithShards(16), cache.WithMaxEntries(500))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := fmt.Sprintf("%d-%d", g, i%200)
+				c.Set(key, i)
+				c.Get(key)
+				if i%10 == 0 {
+					c.DeleteMany([]string{key})
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// BenchmarkCache_Parallel compares the single-mutex LRU cache against the
+// sharded cache and the otter-backed default under a 90% read workload.
+func BenchmarkCache_Parallel(b *testing.B) {
+	keys := make([]string, 4096)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	configs := []struct {
+		name string
+		opts []cache.Option
+	}{
+		{"otter", nil},
+		{"lru-1-shard", []cache.Option{cache.WithMaxEntries(10_000)}},
+		{"lru-16-shards", []cache.Option{cache.WithMaxEntries(10_000), cache.WithShards(16)}},
+		{"lru-64-shards", []cache.Option{cache.WithMaxEntries(10_000), cache.WithShards(64)}},
+	}
+
+	for _, config := range configs {
+		b.Run(config.name, func(b *testing.B) {
+			c, err := cache.NewCache(context.Background(), config.opts...)
+			require.NoError(b, err)
+			for _, key := range keys {
+				c.Set(key, key)
+			}
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					key := keys[i%len(keys)]
+					if i%10 == 0 {
+						c.Set(key, key)
+					} else {
+						c.Get(key)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotVersion identifies the snapshot format written by Save.
+const snapshotVersion = 1
+
+// snapshotHeader precedes the entries in a snapshot.
+type snapshotHeader struct {
+	Version int
+	Count   int
+}
+
+// snapshotEntry is a cache entry as written to a snapshot. The absolute expiry
+// is stored so that time spent between Save and Load counts against the TTL.
+type snapshotEntry struct {
+	Key       string
+	Value     any
+	ExpiresAt time.Time
+}
+
+// saveSnapshot writes entries to w as a gob stream.
+func saveSnapshot(w io.Writer, entries []snapshotEntry) error {
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(snapshotHeader{Version: snapshotVersion, Count: len(entries)}); err != nil {
+		return fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write snapshot entry %q: %w", entry.Key, err)
+		}
+	}
+	return nil
+}
+
+// loadSnapshot reads a snapshot written by saveSnapshot into c, skipping
+// entries that have expired since.
+func loadSnapshot(r io.Reader, c Store) error {
+	decoder := gob.NewDecoder(r)
+
+	var header snapshotHeader
+	if err := decoder.Decode(&header); err != nil {
+		return fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	if header.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", header.Version)
+	}
+
+	now := time.Now()
+	for i := 0; i < header.Count; i++ {
+		var entry snapshotEntry
+		if err := decoder.Decode(&entry); err != nil {
+			return fmt.Errorf("failed to read snapshot entry %d: %w", i, err)
+		}
+		if ttl := entry.ExpiresAt.Sub(now); ttl > 0 {
+			c.SetWithTTL(entry.Key, entry.Value, ttl)
+		}
+	}
+	return nil
+}
+
+// saveSnapshotFile atomically replaces path with a snapshot of c.
+func saveSnapshotFile(c Store, path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := c.Save(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot file: %w", err)
+	}
+	return nil
+}
+
+// loadSnapshotFile restores c from path. A missing file is not an error.
+func loadSnapshotFile(c Store, path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer file.Close()
+
+	return c.Load(file)
+}
+
+// runSnapshots saves c to path every interval until ctx is done, then saves
+// once more so the latest entries survive a clean shutdown.
+func runSnapshots(ctx context.Context, c Store, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if err := saveSnapshotFile(c, path); err != nil {
+				slog.Error("Failed to save final cache snapshot", "path", path, "error", err)
+			}
+			return
+		}
+		if err := saveSnapshotFile(c, path); err != nil {
+			slog.Error("Failed to save cache snapshot", "path", path, "error", err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/stretchr/testify/require"
+)
+
+type snapshotUser struct {
+	Name string
+	Age  int
+}
+
+func init() {
+	gob.Register(snapshotUser{})
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	for name, opts := range map[string][]cache.Option{
+		"otter": nil,
+		"lru":   {cache.WithMaxEntries(100)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			src, err := cache.NewCache(ctx, opts...)
+			require.NoError(t, err)
+
+			require.True(t, src.Set("string", "value"))
+			require.True(t, src.Set("bytes", []byte{1, 2, 3}))
+			require.True(t, src.Set("int", 42))
+			require.True(t, src.SetWithTTL("user", snapshotUser{Name: "Ada", Age: 36}, time.Hour))
+
+			var buf bytes.Buffer
+			require.NoError(t, src.Save(&buf))
+
+			dst, err := cache.NewCache(ctx, opts...)
+			require.NoError(t, err)
+			require.NoError(t, dst.Load(&buf))
+
+			expected := map[string]any{
+				"string": "value",
+				"bytes":  []byte{1, 2, 3},
+				"int":    42,
+				"user":   snapshotUser{Name: "Ada", Age: 36},
+			}
+			for key, want := range expected {
+				got, exists := dst.Get(key)
+				require.True(t, exists, key)
+				require.Equal(t, want, got, key)
+			}
+		})
+	}
+}
+
+func TestSnapshot_SkipsExpired(t *testing.T) {
+	ctx := context.Background()
+	src, err := cache.NewCache(ctx, cache.WithMaxEntries(10))
+	require.NoError(t, err)
+
+	require.True(t, src.SetWithTTL("short", "value", 50*time.Millisecond))
+	require.True(t, src.SetWithTTL("long", "value", time.Hour))
+
+	var buf bytes.Buffer
+	require.NoError(t, src.Save(&buf))
+	time.Sleep(100 * time.Millisecond)
+
+	dst, err := cache.NewCache(ctx)
+	require.NoError(t, err)
+	require.NoError(t, dst.Load(&buf))
+
+	_, exists := dst.Get("short")
+	require.False(t, exists)
+	_, exists = dst.Get("long")
+	require.True(t, exists)
+}
+
+func TestSnapshot_UnregisteredType(t *testing.T) {
+	type unregistered struct{ Field string }
+
+	c, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+	require.True(t, c.Set("bad", unregistered{Field: "x"}))
+
+	err = c.Save(&bytes.Buffer{})
+	require.ErrorContains(t, err, `snapshot entry "bad"`)
+}
+
+func TestSnapshot_InvalidInput(t *testing.T) {
+	c, err := cache.NewCache(context.Background())
+	require.NoError(t, err)
+
+	require.Error(t, c.Load(bytes.NewReader([]byte("not a snapshot"))))
+}
+
+func TestSnapshotFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.snapshot")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := cache.NewCache(ctx, cache.WithSnapshotFile(path, 20*time.Millisecond))
+	require.NoError(t, err)
+	require.True(t, c.Set("warm", "value"))
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	cancel()
+
+	// Wait for the final snapshot written on shutdown
+	time.Sleep(50 * time.Millisecond)
+
+	restored, err := cache.NewCache(context.Background(), cache.WithSnapshotFile(path, time.Hour))
+	require.NoError(t, err)
+
+	value, exists := restored.Get("warm")
+	require.True(t, exists)
+	require.Equal(t, "value", value)
+}
+
+func TestSnapshotFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := cache.NewCache(context.Background(), cache.WithSnapshotFile(filepath.Join(dir, "cache"), 0))
+	require.ErrorContains(t, err, "snapshot interval must be positive")
+
+	corrupt := filepath.Join(dir, "corrupt")
+	require.NoError(t, os.WriteFile(corrupt, []byte("garbage"), 0o600))
+	_, err = cache.NewCache(context.Background(), cache.WithSnapshotFile(corrupt, time.Minute))
+	require.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolStats is a snapshot of connection pool usage, taken when an acquire failed
+// or a health check ran.
+type PoolStats struct {
+	MaxConns          int32 `json:"max_conns"`          // Configured maximum number of connections
+	TotalConns        int32 `json:"total_conns"`        // Connections currently open or being opened
+	AcquiredConns     int32 `json:"acquired_conns"`     // Connections currently in use
+	IdleConns         int32 `json:"idle_conns"`         // Connections open and available
+	ConstructingConns int32 `json:"constructing_conns"` // Connections currently being established
+	Waiters           int64 `json:"waiters"`            // Acquires through DB.Acquire currently waiting, including this one
+}
+
+// Exhausted reports whether every connection allowed by the pool was in use.
+func (s PoolStats) Exhausted() bool {
+	return s.MaxConns > 0 && s.AcquiredConns >= s.MaxConns
+}
+
+// AcquireError is returned by DB.Acquire when a connection could not be obtained.
+// It records the pool state at the time of failure so that pool exhaustion can be
+// told apart from slow or failing connection attempts.
+type AcquireError struct {
+	Err    error         // Underlying error, e.g. context.DeadlineExceeded
+	Waited time.Duration // How long the acquire waited before failing
+	Stats  PoolStats     // Pool usage when the acquire failed
+}
+
+func (e *AcquireError) Error() string {
+	s := e.Stats
+	return fmt.Sprintf("failed to acquire connection after %s: %v (%s: in-use %d/%d, idle %d, connecting %d, waiters %d)",
+		e.Waited.Round(time.Millisecond), e.Err, e.Cause(), s.AcquiredConns, s.MaxConns, s.IdleConns, s.ConstructingConns, s.Waiters)
+}
+
+func (e *AcquireError) Unwrap() error {
+	return e.Err
+}
+
+// Cause gives a short diagnosis of why the acquire failed.
+func (e *AcquireError) Cause() string {
+	switch {
+	case e.Stats.Exhausted():
+		return "pool exhausted"
+	case e.Stats.ConstructingConns > 0:
+		return "waiting for new connection, check network and server"
+	case errors.Is(e.Err, context.DeadlineExceeded), errors.Is(e.Err, context.Canceled):
+		return "context ended while waiting"
+	default:
+		return "connection failed"
+	}
+}
+
+// Acquire obtains a connection from the pool. Unlike Pool.Acquire, a failure is
+// reported as an *AcquireError carrying the pool stats and configured maximum,
+// so that a deadline caused by pool exhaustion can be told apart from network trouble.
+// The connection must be released with Release when no longer needed.
+func (db *DB) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	db.waiters.Add(1)
+	defer db.waiters.Add(-1)
+
+	start := time.Now()
+	conn, err := db.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, db.acquireError(err, time.Since(start))
+	}
+	return conn, nil
+}
+
+// acquireError wraps err with the current pool stats.
+func (db *DB) acquireError(err error, waited time.Duration) *AcquireError {
+	return &AcquireError{
+		Err:    err,
+		Waited: waited,
+		Stats:  db.poolStats(),
+	}
+}
+
+// poolStats takes a snapshot of the pool usage.
+func (db *DB) poolStats() PoolStats {
+	stat := db.Pool.Stat()
+	return PoolStats{
+		MaxConns:          stat.MaxConns(),
+		TotalConns:        stat.TotalConns(),
+		AcquiredConns:     stat.AcquiredConns(),
+		IdleConns:         stat.IdleConns(),
+		ConstructingConns: stat.ConstructingConns(),
+		Waiters:           db.waiters.Load(),
+	}
+}
@@ -0,0 +1,134 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/database"
+)
+
+// newSilentServer returns the address of a TCP server that accepts connections
+// but never responds, simulating a hung network path to the database.
+func newSilentServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var conns []net.Conn
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+	}()
+	t.Cleanup(func() {
+		listener.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+
+	return listener.Addr().String()
+}
+
+func TestDB_Acquire_TimeoutIncludesPoolStats(t *testing.T) {
+	addr := newSilentServer(t)
+	dsn := fmt.Sprintf("postgres://user:pass@%s/db?sslmode=disable&pool_max_conns=3", addr)
+
+	pool, err := pgxpool.New(context.Background(), dsn)
+	require.NoError(t, err)
+	db := &database.DB{Pool: pool}
+	defer db.Close(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	conn, err := db.Acquire(ctx)
+	require.Nil(t, conn)
+	require.Error(t, err)
+
+	var acquireErr *database.AcquireError
+	require.True(t, errors.As(err, &acquireErr))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(3), acquireErr.Stats.MaxConns)
+	assert.Equal(t, int64(1), acquireErr.Stats.Waiters)
+	assert.False(t, acquireErr.Stats.Exhausted())
+	assert.GreaterOrEqual(t, acquireErr.Waited, 50*time.Millisecond)
+
+	assert.Contains(t, err.Error(), "failed to acquire connection after")
+	assert.Contains(t, err.Error(), "/3")
+	assert.Contains(t, err.Error(), "waiters 1")
+}
+
+func TestAcquireError_Cause(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		stats database.PoolStats
+		cause string
+	}{
+		{
+			name:  "exhausted",
+			err:   context.DeadlineExceeded,
+			stats: database.PoolStats{MaxConns: 10, AcquiredConns: 10, Waiters: 4},
+			cause: "pool exhausted",
+		},
+		{
+			name:  "connecting",
+			err:   context.DeadlineExceeded,
+			stats: database.PoolStats{MaxConns: 10, AcquiredConns: 2, ConstructingConns: 1},
+			cause: "waiting for new connection",
+		},
+		{
+			name:  "deadline",
+			err:   context.DeadlineExceeded,
+			stats: database.PoolStats{MaxConns: 10},
+			cause: "context ended while waiting",
+		},
+		{
+			name:  "connection error",
+			err:   errors.New("connection refused"),
+			stats: database.PoolStats{MaxConns: 10},
+			cause: "connection failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &database.AcquireError{Err: tt.err, Waited: 5 * time.Second, Stats: tt.stats}
+			assert.Contains(t, err.Cause(), tt.cause)
+			assert.Contains(t, err.Error(), tt.cause)
+			assert.True(t, errors.Is(err, tt.err))
+		})
+	}
+}
+
+func TestAcquireError_Message(t *testing.T) {
+	err := &database.AcquireError{
+		Err:    context.DeadlineExceeded,
+		Waited: 5 * time.Second,
+		Stats:  database.PoolStats{MaxConns: 10, TotalConns: 10, AcquiredConns: 10, Waiters: 3},
+	}
+
+	assert.Equal(t,
+		"failed to acquire connection after 5s: context deadline exceeded (pool exhausted: in-use 10/10, idle 0, connecting 0, waiters 3)",
+		err.Error())
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MaintenanceDatabase is the database connected to when creating or dropping
+// the configured database, since a database cannot drop itself.
+const MaintenanceDatabase = "postgres"
+
+// CreateDatabase creates the database named by cfg.Name, connecting to
+// MaintenanceDatabase with the rest of cfg.
+// Returns an error if cfg has no database name or the database already exists.
+func CreateDatabase(ctx context.Context, cfg *Config) error {
+	return maintenanceExec(ctx, cfg, "CREATE DATABASE")
+}
+
+// DropDatabase drops the database named by cfg.Name if it exists, connecting
+// to MaintenanceDatabase with the rest of cfg.
+// Returns an error if cfg has no database name or the database is in use.
+func DropDatabase(ctx context.Context, cfg *Config) error {
+	return maintenanceExec(ctx, cfg, "DROP DATABASE IF EXISTS")
+}
+
+// maintenanceExec runs "<statement> <cfg.Name>" on the maintenance database.
+func maintenanceExec(ctx context.Context, cfg *Config, statement string) error {
+	if cfg == nil || cfg.Name == "" {
+		return fmt.Errorf("database name is required")
+	}
+
+	maintenance := *cfg
+	maintenance.Name = MaintenanceDatabase
+
+	conn, err := pgx.Connect(ctx, maintenance.ConnectionURL())
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s database: %w", MaintenanceDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	// Identifiers cannot be passed as query parameters, so quote the name instead
+	if _, err := conn.Exec(ctx, statement+" "+pgx.Identifier{cfg.Name}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to run %s %s: %w", statement, cfg.Name, err)
+	}
+	return nil
+}
+
+// TruncateTables empties every table in schema except MigrationsTable, resetting
+// sequences and cascading to referencing tables, and returns the truncated tables.
+// It is meant for resetting development and test databases between runs.
+func TruncateTables(ctx context.Context, db *DB, schema string) ([]string, error) {
+	rows, err := db.Pool.Query(ctx,
+		"SELECT tablename FROM pg_tables WHERE schemaname = $1 AND tablename <> $2 ORDER BY tablename",
+		schema, MigrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	if len(tables) == 0 {
+		return tables, nil
+	}
+
+	sql := "TRUNCATE TABLE "
+	for i, table := range tables {
+		if i > 0 {
+			sql += ", "
+		}
+		sql += pgx.Identifier{schema, table}.Sanitize()
+	}
+	if _, err := db.Pool.Exec(ctx, sql+" RESTART IDENTITY CASCADE"); err != nil {
+		return nil, fmt.Errorf("failed to truncate tables: %w", err)
+	}
+	return tables, nil
+}
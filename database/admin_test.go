@@ -0,0 +1,58 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/database"
+)
+
+func TestCreateDropDatabase_RequireName(t *testing.T) {
+	ctx := context.Background()
+
+	require.ErrorContains(t, database.CreateDatabase(ctx, &database.Config{Host: "localhost"}), "database name is required")
+	require.ErrorContains(t, database.DropDatabase(ctx, nil), "database name is required")
+}
+
+func TestNewFromConfig_Nil(t *testing.T) {
+	db, err := database.NewFromConfig(context.Background(), nil)
+	require.Error(t, err)
+	assert.Nil(t, db)
+}
+
+func TestAdmin_WithRealDB(t *testing.T) {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN not set")
+	}
+
+	ctx := context.Background()
+	cfg, err := database.NewFromDSN(dsn)
+	require.NoError(t, err)
+	cfg.Name = "toolshed_admin_test"
+
+	require.NoError(t, database.DropDatabase(ctx, cfg))
+	require.NoError(t, database.CreateDatabase(ctx, cfg))
+	t.Cleanup(func() {
+		_ = database.DropDatabase(ctx, cfg)
+	})
+
+	db, err := database.NewFromConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer db.Close(ctx)
+
+	_, err = db.Pool.Exec(ctx, "CREATE TABLE items (id serial PRIMARY KEY); INSERT INTO items DEFAULT VALUES")
+	require.NoError(t, err)
+
+	tables, err := database.TruncateTables(ctx, db, "public")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"items"}, tables)
+
+	var count int
+	require.NoError(t, db.Pool.QueryRow(ctx, "SELECT count(*) FROM items").Scan(&count))
+	assert.Zero(t, count)
+}
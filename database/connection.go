@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/bilte-co/toolshed/logging"
@@ -12,41 +13,152 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// DefaultConnectBackoff is the initial delay between connection attempts when
+// Config.ConnectRetries is set without Config.ConnectBackoff.
+const DefaultConnectBackoff = time.Second
+
+// maxConnectBackoff caps the delay between connection attempts.
+const maxConnectBackoff = 30 * time.Second
+
 // DB represents a database connection with connection pooling.
 // It wraps a pgxpool.Pool to provide high-performance PostgreSQL connectivity
 // with automatic connection management and health checking.
 type DB struct {
 	Pool *pgxpool.Pool // PostgreSQL connection pool
+
+	waiters atomic.Int64 // Goroutines currently waiting in Acquire
+
+	replicas    []*pgxpool.Pool // Read replica pools, used by Reader
+	nextReplica atomic.Uint64   // Index of the replica Reader returns next
 }
 
 // NewFromEnv creates a new database connection using environment configuration.
 // It automatically configures connection pooling, health checks, and connection validation.
 // The context is used for connection establishment and should have appropriate timeout.
-// Returns an error if the configuration is invalid or connection fails.
+// If DB_CONNECT_RETRIES is set, the database is pinged until it answers, waiting
+// DB_CONNECT_BACKOFF (doubling, with jitter) between attempts.
+// Read replicas listed in DB_REPLICA_DSNS get their own pools, used through Reader.
+// DB_STATEMENT_TIMEOUT and DB_IDLE_IN_TX_TIMEOUT are applied to every connection as it is acquired.
+// Returns an error if the configuration is invalid (see Config.Validate) or connection fails.
 func NewFromEnv(ctx context.Context) (*DB, error) {
-	cfg := NewConfigFromEnv()
+	return NewFromConfig(ctx, NewConfigFromEnv())
+}
 
-	pgxConfig, err := pgxpool.ParseConfig(dbDSN(cfg))
+// NewFromConfig creates a new database connection pool using cfg, configured
+// like NewFromEnv. Returns an error if cfg is nil or fails Config.Validate, or
+// the pool cannot be created.
+func NewFromConfig(ctx context.Context, cfg *Config) (*DB, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("database config is missing or invalid")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid database config: %w", err)
+	}
+
+	pool, err := newPool(ctx, cfg, dbDSN(cfg))
+	if err != nil {
+		return nil, err
+	}
+	db := &DB{Pool: pool}
+
+	for i, dsn := range cfg.ReplicaDSNs {
+		replica, err := newPool(ctx, cfg, dsn)
+		if err != nil {
+			db.closePools()
+			return nil, fmt.Errorf("replica %d: %w", i+1, err)
+		}
+		db.replicas = append(db.replicas, replica)
+	}
+
+	return db, nil
+}
+
+// newPool creates a connection pool for dsn with the settings of cfg that
+// apply to every pool, waiting for the database if cfg asks for retries.
+func newPool(ctx context.Context, cfg *Config, dsn string) (*pgxpool.Pool, error) {
+	logging.FromContext(ctx).Debug("🔌 Creating connection pool.", "dsn", RedactDSN(dsn))
+
+	pgxConfig, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse connection string: %w", err)
 	}
 
+	timeouts := sessionTimeouts(cfg)
+
 	// BeforeAcquire is called before before a connection is acquired from the
 	// pool. It must return true to allow the acquisition or false to indicate that
 	// the connection should be destroyed and a different connection should be
 	// acquired.
 	pgxConfig.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+		// Apply the session timeouts, which also checks that the connection is
+		// still valid, since the server has to answer.
+		if timeouts != "" {
+			_, err := conn.Exec(ctx, timeouts)
+			return err == nil
+		}
+
 		// Ping the connection to see if it is still valid. Ping returns an error if
 		// it fails.
 		return conn.Ping(ctx) == nil
 	}
 
+	if cfg.LogQueries {
+		pgxConfig.ConnConfig.Tracer = &QueryTracer{}
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, pgxConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
 
-	return &DB{Pool: pool}, nil
+	if cfg.ConnectRetries > 0 {
+		if err := waitForDatabase(ctx, pool, cfg.ConnectRetries, cfg.ConnectBackoff); err != nil {
+			pool.Close()
+			return nil, err
+		}
+	}
+
+	return pool, nil
+}
+
+// sessionTimeouts returns the SET statements applying the statement and
+// idle-in-transaction timeouts of cfg, or "" if neither is set.
+func sessionTimeouts(cfg *Config) string {
+	var statements []string
+	if cfg.StatementTimeout > 0 {
+		statements = append(statements, fmt.Sprintf("SET statement_timeout = %d", cfg.StatementTimeout.Milliseconds()))
+	}
+	if cfg.IdleInTxTimeout > 0 {
+		statements = append(statements, fmt.Sprintf("SET idle_in_transaction_session_timeout = %d", cfg.IdleInTxTimeout.Milliseconds()))
+	}
+	return strings.Join(statements, "; ")
+}
+
+// waitForDatabase pings the database until it answers, retrying up to retries
+// times with jittered exponential backoff starting at base, so that services
+// starting alongside the database do not fail while it is still coming up.
+func waitForDatabase(ctx context.Context, pool *pgxpool.Pool, retries int, base time.Duration) error {
+	if base <= 0 {
+		base = DefaultConnectBackoff
+	}
+	logger := logging.FromContext(ctx)
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = pool.Ping(ctx); err == nil {
+			return nil
+		}
+		if attempt == retries || ctx.Err() != nil {
+			break
+		}
+
+		delay := backoff(attempt, base, maxConnectBackoff)
+		logger.Warn("⏳ Database not reachable, retrying", "attempt", attempt+1, "retries", retries, "delay", delay, "error", err)
+		if err := sleep(ctx, delay); err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+	}
+	return fmt.Errorf("failed to connect to database after %d attempts: %w", retries+1, err)
 }
 
 // Close gracefully closes the database connection pool.
@@ -55,7 +167,63 @@ func NewFromEnv(ctx context.Context) (*DB, error) {
 func (db *DB) Close(ctx context.Context) {
 	logger := logging.FromContext(ctx)
 	logger.Info("🔌 Closing connection pool.")
+	db.closePools()
+}
+
+// Shutdown closes the database gracefully: new acquires fail at once, while
+// connections already acquired may finish their queries and be released.
+// Returns nil once every connection is closed, or an error if ctx is done
+// first; the pools then finish closing in the background as connections are released.
+func (db *DB) Shutdown(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+	logger.Info("🔌 Shutting down connection pool.", "in_use", db.inUse())
+
+	done := make(chan struct{})
+	go func() {
+		db.closePools()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("connection pool shutdown with %d connections in use: %w", db.inUse(), ctx.Err())
+	}
+}
+
+// inUse returns the number of connections acquired from all pools.
+func (db *DB) inUse() int32 {
+	n := db.Pool.Stat().AcquiredConns()
+	for _, replica := range db.replicas {
+		n += replica.Stat().AcquiredConns()
+	}
+	return n
+}
+
+// closePools closes the primary and replica pools.
+func (db *DB) closePools() {
 	db.Pool.Close()
+	for _, replica := range db.replicas {
+		replica.Close()
+	}
+}
+
+// Writer returns the pool of the primary database, which all writes must use.
+func (db *DB) Writer() *pgxpool.Pool {
+	return db.Pool
+}
+
+// Reader returns a pool for read-only queries. With replicas configured it
+// cycles through them in turn; otherwise it returns the primary pool.
+// Replicas may lag behind the primary, so reads that must see a write just
+// made should use Writer instead.
+func (db *DB) Reader() *pgxpool.Pool {
+	if len(db.replicas) == 0 {
+		return db.Pool
+	}
+	n := db.nextReplica.Add(1) - 1
+	return db.replicas[n%uint64(len(db.replicas))]
 }
 
 // dbDSN converts a Config to a PostgreSQL DSN string.
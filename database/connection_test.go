@@ -2,6 +2,7 @@ package database_test
 
 import (
 	"context"
+	"net"
 	"os"
 	"strings"
 	"testing"
@@ -443,3 +444,59 @@ func TestIntegration_WithRealDB(t *testing.T) {
 	assert.NoError(t, err)
 }
 */
+
+func TestNewFromConfig_ConnectRetries(t *testing.T) {
+	// Reserve a port, then close it so that connections are refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+
+	cfg := &database.Config{
+		Name:           "testdb",
+		User:           "testuser",
+		Host:           host,
+		Port:           port,
+		SSLMode:        "disable",
+		ConnectRetries: 2,
+		ConnectBackoff: 10 * time.Millisecond,
+	}
+
+	t.Run("gives up after retries", func(t *testing.T) {
+		db, err := database.NewFromConfig(context.Background(), cfg)
+		require.Error(t, err)
+		assert.Nil(t, db)
+		assert.Contains(t, err.Error(), "after 3 attempts")
+	})
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		slow := *cfg
+		slow.ConnectRetries = 100
+		slow.ConnectBackoff = time.Second
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := database.NewFromConfig(ctx, &slow)
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestNewFromEnv_InvalidConfig(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+
+	os.Setenv("DB_NAME", "testdb")
+	os.Setenv("DB_PORT", "99999")
+	os.Setenv("DB_SSLCERT", "/certs/client.pem")
+
+	db, err := database.NewFromEnv(context.Background())
+	require.Error(t, err)
+	assert.Nil(t, db)
+	assert.Contains(t, err.Error(), "invalid database config")
+	assert.Contains(t, err.Error(), "DB_PORT")
+	assert.Contains(t, err.Error(), "sslcert is set without sslkey")
+}
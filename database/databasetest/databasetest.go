@@ -0,0 +1,110 @@
+// Package databasetest provides throwaway PostgreSQL databases for tests.
+// Each test gets its own freshly created database on the server named by
+// TEST_DB_DSN, with migrations applied, which is dropped when the test ends.
+// Tests are skipped when TEST_DB_DSN is not set, so packages using it still
+// pass `go test` on machines without PostgreSQL.
+//
+// Example usage:
+//
+//	//go:embed migrations/*.sql
+//	var migrations embed.FS
+//
+//	func TestUsers(t *testing.T) {
+//		sub, _ := fs.Sub(migrations, "migrations")
+//		db := databasetest.StartTestDB(t, databasetest.WithMigrations(sub))
+//
+//		_, err := db.Pool.Exec(context.Background(), "INSERT INTO users (name) VALUES ('alice')")
+//		require.NoError(t, err)
+//	}
+package databasetest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/bilte-co/toolshed/database"
+)
+
+// DSNEnv is the environment variable naming the PostgreSQL server to create
+// test databases on. Its user must be allowed to create databases.
+const DSNEnv = "TEST_DB_DSN"
+
+// Option configures StartTestDB.
+type Option func(*options)
+
+// options holds the configuration applied by Option functions.
+type options struct {
+	migrations fs.FS
+}
+
+// WithMigrations applies the migrations in fsys, as read by
+// database.LoadMigrations, to the new database.
+func WithMigrations(fsys fs.FS) Option {
+	return func(o *options) {
+		o.migrations = fsys
+	}
+}
+
+// StartTestDB creates an empty database with a random name on the server named
+// by TEST_DB_DSN, applies any migrations and returns a connection to it.
+// When the test finishes the connection is closed and the database dropped.
+// The test is skipped if TEST_DB_DSN is not set and fails if any step fails.
+func StartTestDB(t testing.TB, opts ...Option) *database.DB {
+	t.Helper()
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dsn := os.Getenv(DSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set, skipping test that needs PostgreSQL", DSNEnv)
+	}
+
+	cfg, err := database.NewFromDSN(dsn)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", DSNEnv, err)
+	}
+	cfg.Name = randomName(t)
+
+	ctx := context.Background()
+	if err := database.CreateDatabase(ctx, cfg); err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := database.DropDatabase(ctx, cfg); err != nil {
+			t.Errorf("failed to drop test database %s: %v", cfg.Name, err)
+		}
+	})
+
+	db, err := database.NewFromConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	// Registered after the drop, so it runs first and no connection blocks the drop
+	t.Cleanup(func() {
+		db.Pool.Close()
+	})
+
+	if o.migrations != nil {
+		if err := database.Migrate(ctx, db, o.migrations); err != nil {
+			t.Fatalf("failed to migrate test database: %v", err)
+		}
+	}
+
+	return db
+}
+
+// randomName returns a unique database name for a test.
+func randomName(t testing.TB) string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("failed to generate database name: %v", err)
+	}
+	return "toolshed_test_" + hex.EncodeToString(b)
+}
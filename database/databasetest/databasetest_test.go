@@ -0,0 +1,40 @@
+package databasetest_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/database"
+	"github.com/bilte-co/toolshed/database/databasetest"
+)
+
+func TestStartTestDB_SkipsWithoutDSN(t *testing.T) {
+	t.Setenv(databasetest.DSNEnv, "")
+
+	ran := false
+	t.Run("needs database", func(t *testing.T) {
+		defer func() { ran = true }()
+		databasetest.StartTestDB(t)
+		t.Error("StartTestDB should have skipped the test")
+	})
+	require.True(t, ran)
+}
+
+func TestStartTestDB_WithMigrations(t *testing.T) {
+	migrations := fstest.MapFS{
+		"1_create_notes.up.sql": {Data: []byte("CREATE TABLE notes (id serial PRIMARY KEY, body text NOT NULL);")},
+	}
+	db := databasetest.StartTestDB(t, databasetest.WithMigrations(migrations))
+
+	ctx := context.Background()
+	_, err := db.Pool.Exec(ctx, "INSERT INTO notes (body) VALUES ('hello')")
+	require.NoError(t, err)
+
+	version, dirty, err := database.MigrationVersion(ctx, db)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), version)
+	require.False(t, dirty)
+}
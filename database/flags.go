@@ -0,0 +1,114 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// Flags holds command line flags mirroring the DB_* environment variables,
+// tagged for github.com/alecthomas/kong. Embed it in a kong command to accept
+// --db-dsn, --db-host and friends, then call Merge with the environment
+// configuration:
+//
+//	type ServeCmd struct {
+//		DB database.Flags `embed:""`
+//	}
+//
+//	cfg, err := cmd.DB.Merge(database.NewConfigFromEnv())
+type Flags struct {
+	DSN              string        `name:"db-dsn" help:"Database connection string, replacing the DB_* environment (DB_DSN)" placeholder:"DSN"`
+	Name             string        `name:"db-name" help:"Database name (DB_NAME)"`
+	User             string        `name:"db-user" help:"Database user (DB_USER)"`
+	Password         string        `name:"db-password" help:"Database password (DB_PASSWORD)"`
+	Host             string        `name:"db-host" help:"Database host (DB_HOST)"`
+	Port             string        `name:"db-port" help:"Database port (DB_PORT)"`
+	SSLMode          string        `name:"db-sslmode" help:"SSL mode: disable, allow, prefer, require, verify-ca or verify-full (DB_SSLMODE)"`
+	SSLCert          string        `name:"db-sslcert" help:"Path to the client SSL certificate (DB_SSLCERT)" type:"path"`
+	SSLKey           string        `name:"db-sslkey" help:"Path to the client SSL key (DB_SSLKEY)" type:"path"`
+	SSLRootCert      string        `name:"db-sslrootcert" help:"Path to the SSL root certificate (DB_SSLROOTCERT)" type:"path"`
+	ConnectTimeout   int           `name:"db-connect-timeout" help:"Connection timeout in seconds (DB_CONNECT_TIMEOUT)"`
+	ConnectRetries   int           `name:"db-connect-retries" help:"Times to retry the initial connection (DB_CONNECT_RETRIES)"`
+	ConnectBackoff   time.Duration `name:"db-connect-backoff" help:"Initial delay between connection attempts (DB_CONNECT_BACKOFF)"`
+	PoolMinConns     string        `name:"db-pool-min-conns" help:"Minimum connections in the pool (DB_POOL_MIN_CONNS)"`
+	PoolMaxConns     string        `name:"db-pool-max-conns" help:"Maximum connections in the pool (DB_POOL_MAX_CONNS)"`
+	StatementTimeout time.Duration `name:"db-statement-timeout" help:"Server-side limit on each statement (DB_STATEMENT_TIMEOUT)"`
+	IdleInTxTimeout  time.Duration `name:"db-idle-in-tx-timeout" help:"Server-side limit on idling inside a transaction (DB_IDLE_IN_TX_TIMEOUT)"`
+	LogQueries       bool          `name:"db-log-queries" help:"Log every query at debug level (DB_LOG_QUERIES)"`
+	ReplicaDSNs      []string      `name:"db-replica-dsn" sep:"none" help:"Read replica connection string, repeatable (DB_REPLICA_DSNS)" placeholder:"DSN"`
+}
+
+// Merge returns the configuration from the environment, usually from
+// NewConfigFromEnv, overridden by the flags that were set. Flags take
+// precedence over the environment: --db-dsn replaces the connection settings of
+// the environment configuration, like DB_DSN replaces the other variables, and
+// keeps the settings that also apply with DB_DSN (read replicas, query logging,
+// connection retries and server timeouts). Each other flag then overrides its
+// own setting. The env configuration is not modified.
+// Returns an error if --db-dsn cannot be parsed, or env is nil (an invalid
+// DB_DSN) and --db-dsn is not set.
+func (f *Flags) Merge(env *Config) (*Config, error) {
+	var cfg Config
+	switch {
+	case f.DSN != "":
+		parsed, err := NewFromDSN(f.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --db-dsn: %w", err)
+		}
+		cfg = *parsed
+		if env != nil {
+			cfg.ReplicaDSNs = env.ReplicaDSNs
+			cfg.LogQueries = env.LogQueries
+			cfg.ConnectRetries = env.ConnectRetries
+			cfg.ConnectBackoff = env.ConnectBackoff
+			cfg.StatementTimeout = env.StatementTimeout
+			cfg.IdleInTxTimeout = env.IdleInTxTimeout
+		}
+	case env != nil:
+		cfg = *env
+	default:
+		return nil, fmt.Errorf("database configuration from the environment is invalid, check DB_DSN")
+	}
+
+	overrideString(&cfg.Name, f.Name)
+	overrideString(&cfg.User, f.User)
+	overrideString(&cfg.Password, f.Password)
+	overrideString(&cfg.Host, f.Host)
+	overrideString(&cfg.Port, f.Port)
+	overrideString(&cfg.SSLMode, f.SSLMode)
+	overrideString(&cfg.SSLCertPath, f.SSLCert)
+	overrideString(&cfg.SSLKeyPath, f.SSLKey)
+	overrideString(&cfg.SSLRootCertPath, f.SSLRootCert)
+	overrideString(&cfg.PoolMinConnections, f.PoolMinConns)
+	overrideString(&cfg.PoolMaxConnections, f.PoolMaxConns)
+
+	if f.ConnectTimeout != 0 {
+		cfg.ConnectionTimeout = f.ConnectTimeout
+	}
+	if f.ConnectRetries != 0 {
+		cfg.ConnectRetries = f.ConnectRetries
+	}
+	if f.ConnectBackoff != 0 {
+		cfg.ConnectBackoff = f.ConnectBackoff
+	}
+	if f.StatementTimeout != 0 {
+		cfg.StatementTimeout = f.StatementTimeout
+	}
+	if f.IdleInTxTimeout != 0 {
+		cfg.IdleInTxTimeout = f.IdleInTxTimeout
+	}
+	if f.LogQueries {
+		cfg.LogQueries = true
+	}
+	if len(f.ReplicaDSNs) > 0 {
+		cfg.ReplicaDSNs = f.ReplicaDSNs
+	}
+
+	return &cfg, nil
+}
+
+// overrideString sets *dst to value if value is not empty.
+func overrideString(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
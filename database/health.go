@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/bilte-co/toolshed/logging"
+)
+
+// DefaultHealthCheckTimeout bounds how long HealthCheck waits for the database.
+const DefaultHealthCheckTimeout = 2 * time.Second
+
+// Health is the result of a health check.
+type Health struct {
+	Healthy bool          `json:"healthy"`
+	Latency time.Duration `json:"latency_ns"`      // Time taken to acquire a connection and ping
+	Error   string        `json:"error,omitempty"` // Why the check failed, if it did
+	Pool    PoolStats     `json:"pool"`            // Pool usage after the check
+}
+
+// HealthCheck acquires a connection and pings the database, waiting at most
+// DefaultHealthCheckTimeout or until ctx is done. The returned Health includes
+// the pool stats whether or not the check succeeded, and the error is an
+// *AcquireError if no connection could be obtained.
+func (db *DB) HealthCheck(ctx context.Context) (Health, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := db.ping(ctx)
+	health := Health{
+		Healthy: err == nil,
+		Latency: time.Since(start),
+		Pool:    db.poolStats(),
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+	return health, err
+}
+
+// ping acquires a connection through Acquire and pings the database with it.
+func (db *DB) ping(ctx context.Context) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	return conn.Ping(ctx)
+}
+
+// HealthHandler returns an http.Handler for readiness probes, usually mounted at
+// /healthz. It runs HealthCheck and responds with the Health as JSON, with status
+// 200 if the database is reachable and 503 otherwise.
+func (db *DB) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health, err := db.HealthCheck(r.Context())
+		if err != nil {
+			logging.FromContext(r.Context()).Warn("🩺 Database health check failed", "error", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if health.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(health)
+	})
+}
@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/database"
+	"github.com/bilte-co/toolshed/database/databasetest"
+)
+
+// newUnreachableDB returns a DB whose server never answers
+func newUnreachableDB(t *testing.T) *database.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("postgres://user:pass@%s/db?sslmode=disable&pool_max_conns=3", newSilentServer(t))
+	pool, err := pgxpool.New(context.Background(), dsn)
+	require.NoError(t, err)
+	db := &database.DB{Pool: pool}
+	t.Cleanup(func() { db.Close(context.Background()) })
+	return db
+}
+
+func TestDB_HealthCheck_Unreachable(t *testing.T) {
+	db := newUnreachableDB(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	health, err := db.HealthCheck(ctx)
+	require.Error(t, err)
+
+	var acquireErr *database.AcquireError
+	require.True(t, errors.As(err, &acquireErr))
+	assert.False(t, health.Healthy)
+	assert.Equal(t, err.Error(), health.Error)
+	assert.Equal(t, int32(3), health.Pool.MaxConns)
+	assert.GreaterOrEqual(t, health.Latency, 50*time.Millisecond)
+}
+
+func TestDB_HealthHandler_Unreachable(t *testing.T) {
+	db := newUnreachableDB(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	db.HealthHandler().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var health database.Health
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &health))
+	assert.False(t, health.Healthy)
+	assert.Contains(t, health.Error, "failed to acquire connection")
+	assert.Equal(t, int32(3), health.Pool.MaxConns)
+}
+
+func TestDB_HealthHandler_WithRealDB(t *testing.T) {
+	db := databasetest.StartTestDB(t)
+
+	rec := httptest.NewRecorder()
+	db.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"healthy":true`)
+}
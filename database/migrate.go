@@ -0,0 +1,284 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/bilte-co/toolshed/logging"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// MigrationsTable is the table recording the current migration version.
+// It holds at most one row.
+const MigrationsTable = "schema_migrations"
+
+// migrationLockID is the advisory lock key held while migrating, so that
+// concurrently starting instances do not apply the same migrations twice.
+const migrationLockID = 7_263_451_908
+
+// ErrDirtyMigration is returned when a previous migration failed part way.
+// The schema must be repaired by hand and the version set with ForceMigrationVersion.
+var ErrDirtyMigration = errors.New("database is in a dirty migration state")
+
+// migrationFilePattern matches migration file names such as 0001_create_users.up.sql.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change loaded from a pair of SQL files
+// named <version>_<name>.up.sql and, optionally, <version>_<name>.down.sql.
+type Migration struct {
+	Version int64  // Version parsed from the file name, applied in ascending order
+	Name    string // Descriptive name from the file name
+	Up      string // SQL applying the change
+	Down    string // SQL reverting the change, empty if it cannot be reverted
+}
+
+// migrationConn is the subset of a pooled connection used by migrations.
+type migrationConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// LoadMigrations reads the migrations in the root of fsys, ordered by version.
+// Files not matching the naming scheme are ignored. Returns an error if a version
+// is used by more than one migration or a down migration has no up migration.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %s: must be a positive integer", entry.Name())
+		}
+
+		sql, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(sql)
+		} else {
+			m.Down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up migration", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// Migrate applies the migrations in fsys newer than the database's current version,
+// in order. Each migration runs as its own statement batch; the version is marked
+// dirty while it runs, so a failed migration leaves the database dirty and later
+// calls return ErrDirtyMigration until the version is forced.
+// An advisory lock serialises concurrent Migrate calls against the same database.
+func Migrate(ctx context.Context, db *DB, fsys fs.FS) error {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return err
+	}
+
+	return db.withMigrationLock(ctx, func(conn migrationConn) error {
+		version, err := migrationState(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if version != 0 && !slices.ContainsFunc(migrations, func(m Migration) bool { return m.Version == version }) {
+			return fmt.Errorf("database is at migration version %d, which is not in the migrations", version)
+		}
+
+		logger := logging.FromContext(ctx)
+		for _, m := range migrations {
+			if m.Version <= version {
+				continue
+			}
+			logger.Info("⬆️ Applying migration", "version", m.Version, "name", m.Name)
+			if err := runMigration(ctx, conn, m.Version, m.Up, m.Version); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", m.Version, m.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// MigrateDown reverts the last steps applied migrations using their down SQL.
+// Returns an error if a migration to revert has no down migration.
+func MigrateDown(ctx context.Context, db *DB, fsys fs.FS, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1, got %d", steps)
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return err
+	}
+
+	return db.withMigrationLock(ctx, func(conn migrationConn) error {
+		version, err := migrationState(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		logger := logging.FromContext(ctx)
+		for ; steps > 0 && version != 0; steps-- {
+			i := slices.IndexFunc(migrations, func(m Migration) bool { return m.Version == version })
+			if i < 0 {
+				return fmt.Errorf("database is at migration version %d, which is not in the migrations", version)
+			}
+			m := migrations[i]
+			if m.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down migration", m.Version, m.Name)
+			}
+
+			var previous int64
+			if i > 0 {
+				previous = migrations[i-1].Version
+			}
+
+			logger.Info("⬇️ Reverting migration", "version", m.Version, "name", m.Name)
+			if err := runMigration(ctx, conn, m.Version, m.Down, previous); err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			version = previous
+		}
+		return nil
+	})
+}
+
+// MigrationVersion returns the current migration version, zero if no migration
+// has been applied, and whether the last migration failed part way.
+func MigrationVersion(ctx context.Context, db *DB) (version int64, dirty bool, err error) {
+	err = db.withMigrationLock(ctx, func(conn migrationConn) error {
+		version, dirty, err = readMigrationVersion(ctx, conn)
+		return err
+	})
+	return version, dirty, err
+}
+
+// ForceMigrationVersion sets the migration version and clears the dirty flag
+// without running any SQL, after a failed migration has been repaired by hand.
+// A version of zero records that no migration has been applied.
+func ForceMigrationVersion(ctx context.Context, db *DB, version int64) error {
+	if version < 0 {
+		return fmt.Errorf("migration version cannot be negative, got %d", version)
+	}
+
+	return db.withMigrationLock(ctx, func(conn migrationConn) error {
+		return setMigrationVersion(ctx, conn, version, false)
+	})
+}
+
+// withMigrationLock runs fn on a single connection holding the migration
+// advisory lock, after creating the migrations table if needed.
+func (db *DB) withMigrationLock(ctx context.Context, fn func(conn migrationConn) error) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so the lock is released even if ctx was cancelled
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+	}()
+
+	createTable := "CREATE TABLE IF NOT EXISTS " + MigrationsTable + " (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)"
+	if _, err := conn.Exec(ctx, createTable); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", MigrationsTable, err)
+	}
+
+	return fn(conn)
+}
+
+// migrationState returns the current version, or ErrDirtyMigration if it is dirty.
+func migrationState(ctx context.Context, conn migrationConn) (int64, error) {
+	version, dirty, err := readMigrationVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d", ErrDirtyMigration, version)
+	}
+	return version, nil
+}
+
+// readMigrationVersion reads the row of the migrations table.
+func readMigrationVersion(ctx context.Context, conn migrationConn) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRow(ctx, "SELECT version, dirty FROM "+MigrationsTable+" LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// setMigrationVersion replaces the row of the migrations table.
+func setMigrationVersion(ctx context.Context, conn migrationConn, version int64, dirty bool) error {
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM "+MigrationsTable); err != nil {
+			return err
+		}
+		if version == 0 && !dirty {
+			return nil
+		}
+		_, err := tx.Exec(ctx, "INSERT INTO "+MigrationsTable+" (version, dirty) VALUES ($1, $2)", version, dirty)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set migration version: %w", err)
+	}
+	return nil
+}
+
+// runMigration marks version dirty, runs sql and then records the resulting version.
+func runMigration(ctx context.Context, conn migrationConn, version int64, sql string, result int64) error {
+	if err := setMigrationVersion(ctx, conn, version, true); err != nil {
+		return err
+	}
+	if _, err := conn.Exec(ctx, sql); err != nil {
+		return err
+	}
+	return setMigrationVersion(ctx, conn, result, false)
+}
@@ -0,0 +1,94 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/database"
+	"github.com/bilte-co/toolshed/database/databasetest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD email text;")},
+		"0002_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id int);")},
+		"0002_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"README.md":                  {Data: []byte("not a migration")},
+		"seed/0001_seed.up.sql":      {Data: []byte("ignored")},
+	}
+
+	migrations, err := database.LoadMigrations(fsys)
+	require.NoError(t, err)
+	require.Equal(t, []database.Migration{
+		{Version: 2, Name: "create_users", Up: "CREATE TABLE users (id int);", Down: "DROP TABLE users;"},
+		{Version: 10, Name: "add_email", Up: "ALTER TABLE users ADD email text;"},
+	}, migrations)
+}
+
+func TestLoadMigrations_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		fsys  fstest.MapFS
+		error string
+	}{
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"1_one.up.sql": {Data: []byte("SELECT 1;")},
+				"1_two.up.sql": {Data: []byte("SELECT 2;")},
+			},
+			error: "duplicate migration version 1",
+		},
+		{
+			name:  "down without up",
+			fsys:  fstest.MapFS{"1_one.down.sql": {Data: []byte("SELECT 1;")}},
+			error: "has no up migration",
+		},
+		{
+			name:  "zero version",
+			fsys:  fstest.MapFS{"0_zero.up.sql": {Data: []byte("SELECT 1;")}},
+			error: "invalid migration version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := database.LoadMigrations(tt.fsys)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.error)
+		})
+	}
+}
+
+func TestMigrate_WithRealDB(t *testing.T) {
+	db := databasetest.StartTestDB(t)
+	ctx := context.Background()
+
+	fsys := fstest.MapFS{
+		"1_create_widgets.up.sql":   {Data: []byte("CREATE TABLE migrate_test_widgets (id int);")},
+		"1_create_widgets.down.sql": {Data: []byte("DROP TABLE migrate_test_widgets;")},
+		"2_broken.up.sql":           {Data: []byte("SELECT * FROM missing_table;")},
+	}
+
+	err := database.Migrate(ctx, db, fsys)
+	require.Error(t, err)
+
+	version, dirty, err := database.MigrationVersion(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+	assert.True(t, dirty)
+
+	require.ErrorIs(t, database.Migrate(ctx, db, fsys), database.ErrDirtyMigration)
+
+	require.NoError(t, database.ForceMigrationVersion(ctx, db, 1))
+	require.NoError(t, database.MigrateDown(ctx, db, fsys, 1))
+
+	version, dirty, err = database.MigrationVersion(ctx, db)
+	require.NoError(t, err)
+	assert.Zero(t, version)
+	assert.False(t, dirty)
+}
@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// NamedExec runs a statement written with :name placeholders, taking the
+// values from arg as described by BindNamed.
+func (db *DB) NamedExec(ctx context.Context, query string, arg any) (pgconn.CommandTag, error) {
+	sql, args, err := BindNamed(query, arg)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return db.Pool.Exec(ctx, sql, args...)
+}
+
+// NamedQuery runs a query written with :name placeholders, taking the values
+// from arg as described by BindNamed. The rows must be closed by the caller.
+func (db *DB) NamedQuery(ctx context.Context, query string, arg any) (pgx.Rows, error) {
+	sql, args, err := BindNamed(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return db.Pool.Query(ctx, sql, args...)
+}
+
+// BindNamed rewrites the :name placeholders in query to positional $1, $2, ...
+// placeholders and returns the matching arguments. A name used more than once
+// is bound to a single argument. Placeholders inside string literals, quoted
+// identifiers, dollar-quoted strings and comments are left alone, as are
+// ::type casts.
+//
+// arg is a map with string keys or a struct, or a pointer to one. Struct
+// fields match a name by their `db` tag, or otherwise by field name ignoring
+// case and underscores, so :user_id matches a UserID field; fields of
+// embedded structs are included and fields tagged `db:"-"` are skipped.
+// Returns an error if a name has no value in arg.
+func BindNamed(query string, arg any) (string, []any, error) {
+	lookup, err := namedLookup(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b strings.Builder
+	var args []any
+	positions := map[string]int{}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := quotedEnd(query, i, c)
+			b.WriteString(query[i:end])
+			i = end
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+		case c == '$':
+			end := dollarQuotedEnd(query, i)
+			b.WriteString(query[i:end])
+			i = end
+		case c == ':' && strings.HasPrefix(query[i:], "::"):
+			b.WriteString("::")
+			i += 2
+		case c == ':' && i+1 < len(query) && isNameStart(query[i+1]):
+			end := i + 2
+			for end < len(query) && isNamePart(query[end]) {
+				end++
+			}
+			name := query[i+1 : end]
+
+			position, ok := positions[name]
+			if !ok {
+				value, found := lookup(name)
+				if !found {
+					return "", nil, fmt.Errorf("missing value for named parameter :%s", name)
+				}
+				args = append(args, value)
+				position = len(args)
+				positions[name] = position
+			}
+			b.WriteString("$" + strconv.Itoa(position))
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return b.String(), args, nil
+}
+
+// namedLookup returns a function finding the value of a name in arg.
+func namedLookup(arg any) (func(name string) (any, bool), error) {
+	if m, ok := arg.(map[string]any); ok {
+		return func(name string) (any, bool) {
+			value, ok := m[name]
+			return value, ok
+		}, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		return func(name string) (any, bool) {
+			value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !value.IsValid() {
+				return nil, false
+			}
+			return value.Interface(), true
+		}, nil
+	case v.Kind() == reflect.Struct:
+		fields := map[string]any{}
+		collectFields(v, fields)
+		return func(name string) (any, bool) {
+			if value, ok := fields[name]; ok {
+				return value, true
+			}
+			value, ok := fields[normalizeName(name)]
+			return value, ok
+		}, nil
+	case arg == nil:
+		return func(string) (any, bool) { return nil, false }, nil
+	default:
+		return nil, fmt.Errorf("named parameters must be a map or struct, got %T", arg)
+	}
+}
+
+// collectFields adds the exported fields of struct v to fields, keyed by their
+// db tag and by normalized field name. Fields of outer structs take precedence
+// over fields of embedded ones.
+func collectFields(v reflect.Value, fields map[string]any) {
+	t := v.Type()
+	var embedded []reflect.Value
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" {
+			fv := v.Field(i)
+			for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				embedded = append(embedded, fv)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		value := v.Field(i).Interface()
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			fields[name] = value
+		} else {
+			fields[normalizeName(field.Name)] = value
+		}
+	}
+
+	for _, fv := range embedded {
+		inner := map[string]any{}
+		collectFields(fv, inner)
+		for name, value := range inner {
+			if _, ok := fields[name]; !ok {
+				fields[name] = value
+			}
+		}
+	}
+}
+
+// normalizeName lowercases name and removes underscores, so that user_id,
+// UserID and userId compare equal.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// quotedEnd returns the index just past the string literal or quoted identifier
+// starting at i. A doubled quote inside it is an escaped quote.
+func quotedEnd(query string, i int, quote byte) int {
+	for j := i + 1; j < len(query); j++ {
+		if query[j] != quote {
+			continue
+		}
+		if j+1 < len(query) && query[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(query)
+}
+
+// dollarQuotedEnd returns the index just past the dollar-quoted string starting
+// at i, such as $$...$$ or $fn$...$fn$, or i+1 if i does not start one (as in
+// a $1 placeholder).
+func dollarQuotedEnd(query string, i int) int {
+	j := i + 1
+	for j < len(query) && isNamePart(query[j]) && !(j == i+1 && query[j] >= '0' && query[j] <= '9') {
+		j++
+	}
+	if j >= len(query) || query[j] != '$' {
+		return i + 1
+	}
+
+	delimiter := query[i : j+1]
+	end := strings.Index(query[j+1:], delimiter)
+	if end < 0 {
+		return len(query)
+	}
+	return j + 1 + end + len(delimiter)
+}
+
+// isNameStart reports whether c can begin a parameter name.
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isNamePart reports whether c can continue a parameter name.
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,96 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/database"
+)
+
+type namedAudit struct {
+	UpdatedAt time.Time
+	UpdatedBy string `db:"editor"`
+}
+
+type namedUser struct {
+	namedAudit
+	UserID   int64
+	Email    string `db:"email_address"`
+	Password string `db:"-"`
+	internal string
+}
+
+func TestBindNamed_Map(t *testing.T) {
+	sql, args, err := database.BindNamed(
+		"UPDATE users SET name = :name WHERE id = :id AND (:name <> '' OR deleted)",
+		map[string]any{"id": 7, "name": "alice", "unused": true},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = $1 WHERE id = $2 AND ($1 <> '' OR deleted)", sql)
+	assert.Equal(t, []any{"alice", 7}, args)
+}
+
+func TestBindNamed_Struct(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := &namedUser{
+		namedAudit: namedAudit{UpdatedAt: now, UpdatedBy: "bob"},
+		UserID:     42,
+		Email:      "a@example.com",
+		Password:   "secret",
+	}
+
+	sql, args, err := database.BindNamed(
+		"UPDATE users SET email = :email_address, updated_at = :updated_at, updated_by = :editor WHERE id = :user_id",
+		user,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET email = $1, updated_at = $2, updated_by = $3 WHERE id = $4", sql)
+	assert.Equal(t, []any{"a@example.com", now, "bob", int64(42)}, args)
+
+	_, _, err = database.BindNamed("SELECT :password", user)
+	require.ErrorContains(t, err, "missing value for named parameter :password")
+
+	_, _, err = database.BindNamed("SELECT :internal", user)
+	require.Error(t, err)
+}
+
+func TestBindNamed_SkipsLiteralsAndCasts(t *testing.T) {
+	query := `SELECT :id::text, ':not_a_param', "col:name", $$ :body $$, $fn$ :x $fn$ -- :comment
+/* :block */ FROM t WHERE a = :a AND b = $9`
+
+	sql, args, err := database.BindNamed(query, map[string]any{"id": 1, "a": 2})
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT $1::text, ':not_a_param', "col:name", $$ :body $$, $fn$ :x $fn$ -- :comment
+/* :block */ FROM t WHERE a = $2 AND b = $9`, sql)
+	assert.Equal(t, []any{1, 2}, args)
+}
+
+func TestBindNamed_EscapedQuotes(t *testing.T) {
+	sql, args, err := database.BindNamed("SELECT 'it''s :not' || :yes", map[string]any{"yes": "y"})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT 'it''s :not' || $1", sql)
+	assert.Equal(t, []any{"y"}, args)
+}
+
+func TestBindNamed_Errors(t *testing.T) {
+	_, _, err := database.BindNamed("SELECT :a", 5)
+	require.ErrorContains(t, err, "must be a map or struct")
+
+	_, _, err = database.BindNamed("SELECT :a", map[string]any{})
+	require.ErrorContains(t, err, ":a")
+
+	sql, args, err := database.BindNamed("SELECT 1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT 1", sql)
+	assert.Empty(t, args)
+}
+
+func TestBindNamed_TypedMap(t *testing.T) {
+	sql, args, err := database.BindNamed("SELECT :a, :b", map[string]string{"a": "x", "b": "y"})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT $1, $2", sql)
+	assert.Equal(t, []any{"x", "y"}, args)
+}
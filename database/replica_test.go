@@ -0,0 +1,62 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/database"
+)
+
+func TestDB_ReaderWriter(t *testing.T) {
+	addr := newSilentServer(t)
+	cfg := &database.Config{
+		Name:    "primary",
+		Host:    "127.0.0.1",
+		Port:    "1",
+		SSLMode: "disable",
+		ReplicaDSNs: []string{
+			fmt.Sprintf("postgres://user@%s/replica1?sslmode=disable", addr),
+			fmt.Sprintf("postgres://user@%s/replica2?sslmode=disable", addr),
+		},
+	}
+
+	ctx := context.Background()
+	db, err := database.NewFromConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer db.Close(ctx)
+
+	assert.Same(t, db.Pool, db.Writer())
+
+	first, second, third := db.Reader(), db.Reader(), db.Reader()
+	assert.Equal(t, "replica1", first.Config().ConnConfig.Database)
+	assert.Equal(t, "replica2", second.Config().ConnConfig.Database)
+	assert.Same(t, first, third)
+	assert.NotSame(t, db.Pool, first)
+}
+
+func TestDB_Reader_WithoutReplicas(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewFromConfig(ctx, &database.Config{Name: "primary", Host: "127.0.0.1", Port: "1"})
+	require.NoError(t, err)
+	defer db.Close(ctx)
+
+	assert.Same(t, db.Pool, db.Reader())
+}
+
+func TestNewFromConfig_InvalidReplica(t *testing.T) {
+	cfg := &database.Config{
+		Name:        "primary",
+		Host:        "127.0.0.1",
+		Port:        "1",
+		ReplicaDSNs: []string{"postgres://user@localhost/replica?sslmode=bogus"},
+	}
+
+	db, err := database.NewFromConfig(context.Background(), cfg)
+	require.Error(t, err)
+	assert.Nil(t, db)
+	assert.Contains(t, err.Error(), "replica 1")
+}
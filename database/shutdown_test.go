@@ -0,0 +1,79 @@
+package database_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/database"
+	"github.com/bilte-co/toolshed/database/databasetest"
+)
+
+func TestDB_Shutdown_Idle(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewFromConfig(ctx, &database.Config{
+		Name:        "primary",
+		Host:        "127.0.0.1",
+		Port:        "1",
+		ReplicaDSNs: []string{"postgres://user@127.0.0.1:1/replica"},
+	})
+	require.NoError(t, err)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, db.Shutdown(shutdownCtx))
+
+	_, err = db.Acquire(ctx)
+	require.Error(t, err)
+	_, err = db.Reader().Acquire(ctx)
+	require.Error(t, err)
+}
+
+func TestDB_Shutdown_WaitsForInFlight(t *testing.T) {
+	db := databasetest.StartTestDB(t)
+	ctx := context.Background()
+
+	conn, err := db.Acquire(ctx)
+	require.NoError(t, err)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err = db.Shutdown(shutdownCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "1 connections in use")
+
+	// The in-flight connection still works until released
+	require.NoError(t, conn.Ping(ctx))
+	conn.Release()
+
+	_, err = db.Acquire(ctx)
+	require.Error(t, err)
+}
+
+func TestNewFromConfig_SessionTimeouts(t *testing.T) {
+	db := databasetest.StartTestDB(t)
+	ctx := context.Background()
+
+	cfg := db.Pool.Config().ConnConfig
+	timed, err := database.NewFromConfig(ctx, &database.Config{
+		Name:             cfg.Database,
+		User:             cfg.User,
+		Password:         cfg.Password,
+		Host:             cfg.Host,
+		Port:             strconv.Itoa(int(cfg.Port)),
+		StatementTimeout: 1500 * time.Millisecond,
+		IdleInTxTimeout:  time.Minute,
+	})
+	require.NoError(t, err)
+	defer timed.Close(ctx)
+
+	var statementTimeout, idleTimeout string
+	require.NoError(t, timed.Pool.QueryRow(ctx, "SHOW statement_timeout").Scan(&statementTimeout))
+	require.NoError(t, timed.Pool.QueryRow(ctx, "SHOW idle_in_transaction_session_timeout").Scan(&idleTimeout))
+	assert.Equal(t, "1500ms", statementTimeout)
+	assert.Equal(t, "1min", idleTimeout)
+}
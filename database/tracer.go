@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bilte-co/toolshed/logging"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// LogComponent is the logging component of query logs, so their level can be
+// set separately, e.g. LOG_LEVEL=info,database=debug.
+const LogComponent = "database"
+
+// QueryTracer is a pgx.QueryTracer that logs every query at debug level with its
+// statement, duration, affected or returned row count and any error. Argument
+// values are never logged, only their types, since they often hold personal data
+// or credentials. NewFromEnv installs it when DB_LOG_QUERIES is true.
+type QueryTracer struct {
+	// Logger receives the query logs. If nil, the logger from the query's
+	// context is used, named with LogComponent.
+	Logger *slog.Logger
+}
+
+// queryTraceKey is the context key under which TraceQueryStart stores its data.
+type queryTraceKey struct{}
+
+// queryTrace is the data carried from TraceQueryStart to TraceQueryEnd.
+type queryTrace struct {
+	start time.Time
+	sql   string
+	args  []string
+}
+
+// TraceQueryStart records the start of a query.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryTraceKey{}, queryTrace{
+		start: time.Now(),
+		sql:   data.SQL,
+		args:  redactArgs(data.Args),
+	})
+}
+
+// TraceQueryEnd logs the query started by TraceQueryStart.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, ok := ctx.Value(queryTraceKey{}).(queryTrace)
+	if !ok {
+		return
+	}
+
+	logger := t.Logger
+	if logger == nil {
+		logger = logging.NamedFrom(logging.FromContext(ctx), LogComponent)
+	}
+
+	attrs := []any{
+		"sql", trace.sql,
+		"args", trace.args,
+		"duration", time.Since(trace.start),
+		"rows", data.CommandTag.RowsAffected(),
+	}
+	if data.Err != nil {
+		logger.DebugContext(ctx, "Query failed", append(attrs, "error", data.Err)...)
+		return
+	}
+	logger.DebugContext(ctx, "Query", attrs...)
+}
+
+// redactArgs replaces query arguments with their types.
+func redactArgs(args []any) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = fmt.Sprintf("$%d=<%T>", i+1, arg)
+	}
+	return redacted
+}
@@ -0,0 +1,60 @@
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bilte-co/toolshed/database"
+)
+
+func TestQueryTracer(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := &database.QueryTracer{
+		Logger: slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL:  "SELECT id FROM users WHERE email = $1 AND active = $2",
+		Args: []any{"alice@example.com", true},
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 2")})
+
+	out := buf.String()
+	assert.Contains(t, out, "level=DEBUG")
+	assert.Contains(t, out, `sql="SELECT id FROM users WHERE email = $1 AND active = $2"`)
+	assert.Contains(t, out, "rows=2")
+	assert.Contains(t, out, "duration=")
+	assert.Contains(t, out, "$1=<string>")
+	assert.Contains(t, out, "$2=<bool>")
+	assert.NotContains(t, out, "alice@example.com")
+}
+
+func TestQueryTracer_Error(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := &database.QueryTracer{
+		Logger: slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT broken"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("syntax error")})
+
+	assert.Contains(t, buf.String(), `msg="Query failed"`)
+	assert.Contains(t, buf.String(), `error="syntax error"`)
+}
+
+func TestQueryTracer_SilentAboveDebug(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := &database.QueryTracer{Logger: slog.New(slog.NewTextHandler(&buf, nil))}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/bilte-co/toolshed/logging"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// MaxTxAttempts is the number of times WithTx runs a transaction that keeps
+// failing with serialization failures or deadlocks before giving up.
+const MaxTxAttempts = 5
+
+const (
+	txRetryBaseDelay = 10 * time.Millisecond // Delay before the first retry
+	txRetryMaxDelay  = time.Second           // Upper bound on the delay between retries
+)
+
+// PostgreSQL error codes for transaction conflicts that succeed when retried.
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// IsRetryable reports whether err is a serialization failure or deadlock,
+// meaning the whole transaction can be retried and may then succeed.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
+
+// WithTx runs fn in a transaction started with opts, committing if fn returns nil
+// and rolling back otherwise. If fn or the commit fails with a serialization
+// failure (40001) or deadlock (40P01), the transaction is retried up to
+// MaxTxAttempts times with jittered exponential backoff, so fn must be safe to
+// run more than once. Returns fn's error, or the last error once retries are exhausted.
+func (db *DB) WithTx(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	return withTx(ctx, func(ctx context.Context) (pgx.Tx, error) {
+		return db.Pool.BeginTx(ctx, opts)
+	}, fn)
+}
+
+// withTx implements WithTx using begin to start each attempt.
+func withTx(ctx context.Context, begin func(ctx context.Context) (pgx.Tx, error), fn func(tx pgx.Tx) error) error {
+	logger := logging.FromContext(ctx)
+
+	var err error
+	for attempt := range MaxTxAttempts {
+		if attempt > 0 {
+			delay := backoff(attempt-1, txRetryBaseDelay, txRetryMaxDelay)
+			logger.Debug("🔁 Retrying transaction", "attempt", attempt+1, "delay", delay, "error", err)
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+		}
+
+		err = runTx(ctx, begin, fn)
+		if !IsRetryable(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("transaction failed after %d attempts: %w", MaxTxAttempts, err)
+}
+
+// runTx makes a single attempt at the transaction.
+func runTx(ctx context.Context, begin func(ctx context.Context) (pgx.Tx, error), fn func(tx pgx.Tx) error) error {
+	tx, err := begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// backoff returns the delay before retry number attempt (starting at 0): base
+// doubled attempt times and capped at maxDelay, with full jitter so that
+// competing clients do not retry in lockstep.
+func backoff(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := maxDelay
+	if attempt < 32 && base<<attempt < maxDelay {
+		delay = base << attempt
+	}
+	return rand.N(delay) + 1
+}
+
+// sleep waits for d or until ctx is done, returning ctx's error in the latter case.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
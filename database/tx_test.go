@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx records how a transaction ended. Methods other than Commit and
+// Rollback panic through the nil embedded interface.
+type fakeTx struct {
+	pgx.Tx
+	commitErr  error
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	if tx.commitErr != nil {
+		return tx.commitErr
+	}
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if !tx.committed {
+		tx.rolledBack = true
+	}
+	return nil
+}
+
+// fakeBegin returns a begin function handing out the given transactions in order.
+func fakeBegin(txs ...*fakeTx) func(ctx context.Context) (pgx.Tx, error) {
+	return func(ctx context.Context) (pgx.Tx, error) {
+		tx := txs[0]
+		txs = txs[1:]
+		return tx, nil
+	}
+}
+
+func TestWithTx_Commits(t *testing.T) {
+	tx := &fakeTx{}
+	err := withTx(context.Background(), fakeBegin(tx), func(pgx.Tx) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, tx.committed)
+	assert.False(t, tx.rolledBack)
+}
+
+func TestWithTx_RollsBackOnError(t *testing.T) {
+	tx := &fakeTx{}
+	fnErr := errors.New("boom")
+
+	err := withTx(context.Background(), fakeBegin(tx), func(pgx.Tx) error { return fnErr })
+	require.ErrorIs(t, err, fnErr)
+	assert.False(t, tx.committed)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestWithTx_RetriesConflicts(t *testing.T) {
+	first, second, third := &fakeTx{}, &fakeTx{commitErr: &pgconn.PgError{Code: "40P01"}}, &fakeTx{}
+	attempts := 0
+
+	err := withTx(context.Background(), fakeBegin(first, second, third), func(pgx.Tx) error {
+		attempts++
+		if attempts == 1 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.True(t, first.rolledBack)
+	assert.True(t, second.rolledBack)
+	assert.True(t, third.committed)
+}
+
+func TestWithTx_GivesUp(t *testing.T) {
+	txs := make([]*fakeTx, MaxTxAttempts)
+	for i := range txs {
+		txs[i] = &fakeTx{}
+	}
+	attempts := 0
+
+	err := withTx(context.Background(), fakeBegin(txs...), func(pgx.Tx) error {
+		attempts++
+		return &pgconn.PgError{Code: "40001"}
+	})
+	require.Error(t, err)
+	assert.True(t, IsRetryable(err))
+	assert.Contains(t, err.Error(), "transaction failed after 5 attempts")
+	assert.Equal(t, MaxTxAttempts, attempts)
+}
+
+func TestWithTx_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+
+	err := withTx(ctx, fakeBegin(&fakeTx{}, &fakeTx{}), func(pgx.Tx) error {
+		attempts++
+		cancel()
+		return &pgconn.PgError{Code: "40001"}
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&pgconn.PgError{Code: "40001"}))
+	assert.True(t, IsRetryable(errors.Join(errors.New("wrapped"), &pgconn.PgError{Code: "40P01"})))
+	assert.False(t, IsRetryable(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, IsRetryable(errors.New("connection reset")))
+	assert.False(t, IsRetryable(nil))
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := range 40 {
+		delay := backoff(attempt, 10*time.Millisecond, time.Second)
+		assert.Positive(t, delay)
+		assert.LessOrEqual(t, delay, time.Second)
+		if attempt == 0 {
+			assert.LessOrEqual(t, delay, 10*time.Millisecond)
+		}
+	}
+}
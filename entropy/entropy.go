@@ -0,0 +1,236 @@
+// Package entropy provides byte-level entropy estimation and simple statistical
+// randomness tests. It is intended for sanity-checking key material and for telling
+// apart high-entropy blobs such as encrypted data (uniform byte distribution) and
+// compressed data (high entropy, but measurably non-uniform).
+//
+// Example usage:
+//
+//	report, err := entropy.Analyze(os.Stdin)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	fmt.Printf("%.4f bits/byte (%s)\n", report.Shannon, report.Classification())
+package entropy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+// MaxBitsPerByte is the maximum Shannon entropy of a byte stream.
+const MaxBitsPerByte = 8.0
+
+// DefaultSignificance is the p-value below which a randomness test is considered failed.
+const DefaultSignificance = 0.01
+
+// Histogram holds the number of occurrences of each byte value.
+type Histogram [256]uint64
+
+// Report contains the results of analysing a byte stream.
+type Report struct {
+	// Size is the number of bytes analysed.
+	Size int64 `json:"size"`
+	// Shannon is the Shannon entropy in bits per byte (0-8).
+	Shannon float64 `json:"shannon"`
+	// Mean is the arithmetic mean of all byte values (127.5 for uniform data).
+	Mean float64 `json:"mean"`
+	// ChiSquare is the chi-square statistic of the byte distribution (255 degrees of freedom).
+	ChiSquare float64 `json:"chi_square"`
+	// ChiSquareP is the approximate p-value of the chi-square statistic.
+	ChiSquareP float64 `json:"chi_square_p"`
+	// Runs is the number of runs of identical consecutive bits.
+	Runs uint64 `json:"runs"`
+	// RunsZ is the z-score of the Wald-Wolfowitz runs test over the bit stream.
+	RunsZ float64 `json:"runs_z"`
+	// RunsP is the two-sided p-value of the runs test.
+	RunsP float64 `json:"runs_p"`
+	// Histogram holds per-byte-value counts.
+	Histogram Histogram `json:"histogram"`
+}
+
+// Analyze reads r until EOF and returns an entropy report. Data is processed
+// in a streaming fashion, so memory use is constant regardless of input size.
+func Analyze(r io.Reader) (*Report, error) {
+	var (
+		hist     Histogram
+		size     int64
+		ones     uint64
+		runs     uint64
+		lastBit  byte
+		haveLast bool
+	)
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := r.Read(buf)
+		for _, b := range buf[:n] {
+			hist[b]++
+			for i := 7; i >= 0; i-- {
+				bit := (b >> uint(i)) & 1
+				if bit == 1 {
+					ones++
+				}
+				if !haveLast || bit != lastBit {
+					runs++
+					lastBit = bit
+					haveLast = true
+				}
+			}
+		}
+		size += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read data: %w", err)
+		}
+	}
+
+	report := &Report{
+		Size:      size,
+		Histogram: hist,
+		Runs:      runs,
+	}
+	if size == 0 {
+		return report, nil
+	}
+
+	report.Shannon = hist.Shannon()
+	report.Mean = hist.Mean()
+	report.ChiSquare = hist.ChiSquare()
+	report.ChiSquareP = chiSquarePValue(report.ChiSquare, 255)
+	report.RunsZ, report.RunsP = runsTest(uint64(size)*8, ones, runs)
+
+	return report, nil
+}
+
+// AnalyzeBytes returns an entropy report for an in-memory byte slice.
+func AnalyzeBytes(data []byte) *Report {
+	report, _ := Analyze(bytes.NewReader(data))
+	return report
+}
+
+// Shannon returns the Shannon entropy of data in bits per byte.
+func Shannon(data []byte) float64 {
+	var hist Histogram
+	for _, b := range data {
+		hist[b]++
+	}
+	return hist.Shannon()
+}
+
+// Total returns the number of bytes counted in the histogram.
+func (h *Histogram) Total() uint64 {
+	var total uint64
+	for _, c := range h {
+		total += c
+	}
+	return total
+}
+
+// Shannon returns the Shannon entropy of the histogram in bits per byte.
+func (h *Histogram) Shannon() float64 {
+	total := float64(h.Total())
+	if total == 0 {
+		return 0
+	}
+
+	var e float64
+	for _, c := range h {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / total
+		e -= p * math.Log2(p)
+	}
+	return e
+}
+
+// Mean returns the arithmetic mean of the byte values counted in the histogram.
+func (h *Histogram) Mean() float64 {
+	total := h.Total()
+	if total == 0 {
+		return 0
+	}
+
+	var sum float64
+	for v, c := range h {
+		sum += float64(v) * float64(c)
+	}
+	return sum / float64(total)
+}
+
+// ChiSquare returns the chi-square statistic of the histogram against a uniform distribution.
+func (h *Histogram) ChiSquare() float64 {
+	total := float64(h.Total())
+	if total == 0 {
+		return 0
+	}
+
+	expected := total / 256
+	var chi float64
+	for _, c := range h {
+		d := float64(c) - expected
+		chi += d * d / expected
+	}
+	return chi
+}
+
+// Uniform reports whether the byte distribution is consistent with uniformly
+// random data at the given significance level.
+func (r *Report) Uniform(significance float64) bool {
+	return r.Size > 0 && r.ChiSquareP >= significance
+}
+
+// Classification returns a short human-readable guess at what kind of data was analysed.
+func (r *Report) Classification() string {
+	switch {
+	case r.Size == 0:
+		return "empty"
+	case r.Size < 256:
+		return "too small to classify"
+	case r.Shannon < 5.0:
+		return "low entropy (text or structured data)"
+	case r.Shannon < 7.5:
+		return "medium entropy (binary or encoded data)"
+	case r.Uniform(DefaultSignificance) && r.RunsP >= DefaultSignificance:
+		return "high entropy, uniform (likely encrypted or random)"
+	default:
+		return "high entropy, non-uniform (likely compressed)"
+	}
+}
+
+// chiSquarePValue approximates the upper-tail p-value of a chi-square statistic
+// using the Wilson-Hilferty transformation to the standard normal distribution.
+func chiSquarePValue(chi float64, dof float64) float64 {
+	if chi <= 0 {
+		return 1
+	}
+	k := 2 / (9 * dof)
+	z := (math.Cbrt(chi/dof) - (1 - k)) / math.Sqrt(k)
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// runsTest performs the Wald-Wolfowitz runs test for a bit stream of n bits
+// containing ones set bits, returning the z-score and two-sided p-value.
+func runsTest(n, ones, runs uint64) (float64, float64) {
+	n1 := float64(ones)
+	n0 := float64(n - ones)
+	total := float64(n)
+	if n1 == 0 || n0 == 0 {
+		// A constant bit stream is as far from random as it gets
+		return 0, 0
+	}
+
+	mean := 2*n1*n0/total + 1
+	variance := (mean - 1) * (mean - 2) / (total - 1)
+	if variance <= 0 {
+		return 0, 1
+	}
+
+	z := (float64(runs) - mean) / math.Sqrt(variance)
+	return z, math.Erfc(math.Abs(z) / math.Sqrt2)
+}
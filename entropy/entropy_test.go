@@ -0,0 +1,135 @@
+package entropy_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/entropy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShannon_KnownValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected float64
+	}{
+		{"empty", []byte{}, 0},
+		{"single value", bytes.Repeat([]byte{'a'}, 100), 0},
+		{"two values", []byte("abababab"), 1},
+		{"four values", []byte("abcdabcd"), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.expected, entropy.Shannon(tt.data), 1e-9)
+		})
+	}
+}
+
+func TestShannon_AllByteValues(t *testing.T) {
+	data := make([]byte, 256*4)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	require.InDelta(t, entropy.MaxBitsPerByte, entropy.Shannon(data), 1e-9)
+}
+
+func TestAnalyze_Empty(t *testing.T) {
+	report, err := entropy.Analyze(bytes.NewReader(nil))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), report.Size)
+	require.Equal(t, "empty", report.Classification())
+}
+
+func TestAnalyze_RandomData(t *testing.T) {
+	data := make([]byte, 1<<20)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	report, err := entropy.Analyze(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), report.Size)
+	require.Greater(t, report.Shannon, 7.99)
+	require.InDelta(t, 127.5, report.Mean, 1.0)
+	require.True(t, report.Uniform(0.0001))
+	require.Equal(t, uint64(len(data)), report.Histogram.Total())
+}
+
+func TestAnalyze_CompressedData(t *testing.T) {
+	var plain strings.Builder
+	for i := 0; i < 20000; i++ {
+		plain.WriteString("the quick brown fox jumps over the lazy dog ")
+		plain.WriteByte(byte('a' + i%26))
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(plain.String()))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	report := entropy.AnalyzeBytes(buf.Bytes())
+	require.False(t, report.Uniform(entropy.DefaultSignificance))
+	require.NotContains(t, report.Classification(), "uniform (likely encrypted")
+}
+
+func TestAnalyze_TextData(t *testing.T) {
+	text := strings.Repeat("hello world, this is plain text. ", 100)
+
+	report := entropy.AnalyzeBytes([]byte(text))
+	require.Less(t, report.Shannon, 5.0)
+	require.Equal(t, "low entropy (text or structured data)", report.Classification())
+	require.False(t, report.Uniform(entropy.DefaultSignificance))
+}
+
+func TestAnalyze_ConstantBits(t *testing.T) {
+	report := entropy.AnalyzeBytes(bytes.Repeat([]byte{0x00}, 1024))
+	require.Equal(t, uint64(1), report.Runs)
+	require.Equal(t, 0.0, report.RunsP)
+	require.Equal(t, 0.0, report.Shannon)
+}
+
+func TestAnalyze_AlternatingBitsFailRunsTest(t *testing.T) {
+	// 0x55 = 01010101: far too many runs to be random
+	report := entropy.AnalyzeBytes(bytes.Repeat([]byte{0x55}, 1024))
+	require.Greater(t, report.RunsZ, 0.0)
+	require.Less(t, report.RunsP, entropy.DefaultSignificance)
+}
+
+func TestAnalyze_JSONEncodable(t *testing.T) {
+	report := entropy.AnalyzeBytes(bytes.Repeat([]byte{0xff}, 512))
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"shannon":0`)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
+
+func TestAnalyze_ReadError(t *testing.T) {
+	_, err := entropy.Analyze(failingReader{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read data")
+}
+
+func TestHistogram_ChiSquareUniform(t *testing.T) {
+	var hist entropy.Histogram
+	for i := range hist {
+		hist[i] = 10
+	}
+
+	require.Equal(t, 0.0, hist.ChiSquare())
+	require.InDelta(t, 127.5, hist.Mean(), 1e-9)
+	require.Equal(t, uint64(2560), hist.Total())
+}
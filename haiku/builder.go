@@ -0,0 +1,330 @@
+package haiku
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bilte-co/toolshed/base62"
+)
+
+// A Component is one kind of part of a haiku name.
+type Component int
+
+// Components of a haiku name.
+const (
+	Adjective Component = iota
+	Action
+	Noun
+	Token
+	Suffix
+)
+
+// String returns the name of the component.
+func (c Component) String() string {
+	switch c {
+	case Adjective:
+		return "adjective"
+	case Action:
+		return "action"
+	case Noun:
+		return "noun"
+	case Token:
+		return "token"
+	case Suffix:
+		return "suffix"
+	default:
+		return "Component(" + strconv.Itoa(int(c)) + ")"
+	}
+}
+
+// A TokenFormat is the way a Builder writes the random token.
+type TokenFormat int
+
+// Token formats.
+const (
+	Decimal TokenFormat = iota
+	Hex
+	Base62
+)
+
+// String returns the name of the token format.
+func (f TokenFormat) String() string {
+	switch f {
+	case Decimal:
+		return "decimal"
+	case Hex:
+		return "hex"
+	case Base62:
+		return "base62"
+	default:
+		return "TokenFormat(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+// ParseTokenFormat returns the token format with the given name, as returned
+// by TokenFormat.String.
+func ParseTokenFormat(name string) (TokenFormat, error) {
+	for _, f := range []TokenFormat{Decimal, Hex, Base62} {
+		if strings.EqualFold(name, f.String()) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown token format %q: must be decimal, hex or base62", name)
+}
+
+var (
+	// ErrNoComponents is returned by Builder.Haikunate when no component was added.
+	ErrNoComponents = errors.New("haiku has no components")
+
+	// ErrExhausted is returned by Builder.HaikunateUnique when the builder
+	// cannot generate enough distinct names.
+	ErrExhausted = errors.New("not enough distinct haiku names")
+)
+
+// maxDuplicates is the number of duplicates in a row after which
+// HaikunateUnique gives up, as the remaining names are too rare to find
+const maxDuplicates = 1000
+
+// A Builder generates haiku names of a custom shape, such as adjective+noun
+// only or several nouns, instead of the adjective, action, noun and token of
+// Haikunate. Components appear in the order they are added.
+//
+// Example usage:
+//
+//	h := haiku.NewHaikunator()
+//	name, err := h.Builder().Adjectives(1).Nouns(2).Token(0xffff, haiku.Hex).Haikunate()
+//	fmt.Println(name) // e.g. quiet-otter-plum-3fa2
+type Builder struct {
+	h           *Haikunator
+	delim       string
+	components  []Component
+	tokenMax    int64
+	tokenFormat TokenFormat
+	suffixChars int
+	maxLen      int
+	truncate    bool
+	lowercase   bool
+	dnsLabel    bool
+	err         error
+}
+
+// Builder returns a Builder using the word lists and delimiter of h.
+func (h Haikunator) Builder() *Builder {
+	return &Builder{h: &h, delim: h.delim}
+}
+
+// Delimiter sets the delimiter placed between components.
+func (b *Builder) Delimiter(delim string) *Builder {
+	b.delim = delim
+	return b
+}
+
+// Adjectives adds n adjectives.
+func (b *Builder) Adjectives(n int) *Builder {
+	return b.add(Adjective, n)
+}
+
+// Actions adds n actions.
+func (b *Builder) Actions(n int) *Builder {
+	return b.add(Action, n)
+}
+
+// Nouns adds n nouns.
+func (b *Builder) Nouns(n int) *Builder {
+	return b.add(Noun, n)
+}
+
+// Token adds a random token in [0, max) written in the given format. A
+// builder has at most one token; adding another replaces its range and
+// format but keeps its position.
+func (b *Builder) Token(max int64, format TokenFormat) *Builder {
+	if max <= 0 {
+		b.setErr(fmt.Errorf("token max must be positive, got %d", max))
+		return b
+	}
+	if format < Decimal || format > Base62 {
+		b.setErr(fmt.Errorf("unknown token format: %s", format))
+		return b
+	}
+	b.tokenMax, b.tokenFormat = max, format
+	return b.addOnce(Token)
+}
+
+// Components returns the components added so far, in order.
+func (b *Builder) Components() []Component {
+	return append([]Component(nil), b.components...)
+}
+
+// Haikunate generates a name with the builder's components. It can be called
+// repeatedly for more names of the same shape.
+func (b *Builder) Haikunate() (string, error) {
+	return b.haikunate(nil)
+}
+
+// haikunate generates a name, deriving its suffix from key unless key is nil
+func (b *Builder) haikunate(key []byte) (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if !b.h.isSafeDelimiter(b.delim) {
+		return "", fmt.Errorf("unsafe delimiter: %s", b.delim)
+	}
+	if b.h.err != nil {
+		return "", b.h.err
+	}
+	if len(b.components) == 0 {
+		return "", ErrNoComponents
+	}
+	if b.dnsLabel && b.delim != "-" {
+		return "", fmt.Errorf("DNS labels only allow the '-' delimiter, got %q", b.delim)
+	}
+
+	// Re-roll names that break the length limit
+	parts := make([]string, len(b.components))
+	for range maxRerolls {
+		for i, c := range b.components {
+			part, err := b.generate(c, key)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = part
+		}
+		if name, ok := b.constrain(parts); ok {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no name within the length and character limits in %d attempts", ErrNoValidName, maxRerolls)
+}
+
+// HaikunateUnique generates n distinct names with the builder's components,
+// re-rolling duplicates. It returns an error wrapping ErrExhausted if n is
+// more than Combinations, or if it keeps generating names it already has.
+func (b *Builder) HaikunateUnique(n int) ([]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("negative haiku count: %d", n)
+	}
+	if combinations := b.Combinations(); combinations.Sign() > 0 && combinations.Cmp(big.NewInt(int64(n))) < 0 {
+		return nil, fmt.Errorf("%w: requested %d, at most %s possible", ErrExhausted, n, combinations)
+	}
+
+	names := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+	for duplicates := 0; len(names) < n; {
+		name, err := b.Haikunate()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[name]; ok {
+			if duplicates++; duplicates >= maxDuplicates {
+				return nil, fmt.Errorf("%w: found %d of %d", ErrExhausted, len(names), n)
+			}
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+		duplicates = 0
+	}
+	return names, nil
+}
+
+// Combinations returns the number of names the builder can generate: the
+// product of the sizes of its word lists, token range and suffix space.
+// Repeated words, length limits and lowercasing make the real number smaller.
+// It returns 0 for a builder without components.
+func (b *Builder) Combinations() *big.Int {
+	if len(b.components) == 0 {
+		return new(big.Int)
+	}
+
+	combinations := big.NewInt(1)
+	for _, c := range b.components {
+		switch c {
+		case Token:
+			combinations.Mul(combinations, big.NewInt(b.tokenMax))
+		case Suffix:
+			combinations.Lsh(combinations, uint(suffixBits*b.suffixChars))
+		default:
+			combinations.Mul(combinations, big.NewInt(int64(len(b.words(c)))))
+		}
+	}
+	return combinations
+}
+
+// generate returns a random value for component c, or the suffix derived
+// from key if key is not nil
+func (b *Builder) generate(c Component, key []byte) (string, error) {
+	switch c {
+	case Token:
+		token, err := randomInt64(b.tokenMax)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random token: %w", err)
+		}
+		return formatToken(token, b.tokenFormat), nil
+	case Suffix:
+		return b.suffix(key)
+	}
+
+	words := b.words(c)
+	idx, err := randomInt(len(words))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random %s: %w", c, err)
+	}
+	return words[idx], nil
+}
+
+// words returns the word list of component c, or nil if it is not a word
+func (b *Builder) words(c Component) []string {
+	switch c {
+	case Adjective:
+		return wordsOrDefault(b.h.adjectives, ADJECTIVES)
+	case Action:
+		return wordsOrDefault(b.h.actions, ACTIONS)
+	case Noun:
+		return wordsOrDefault(b.h.nouns, NOUNS)
+	default:
+		return nil
+	}
+}
+
+// add appends n copies of component c
+func (b *Builder) add(c Component, n int) *Builder {
+	if n < 0 {
+		b.setErr(fmt.Errorf("negative %s count: %d", c, n))
+		return b
+	}
+	for range n {
+		b.components = append(b.components, c)
+	}
+	return b
+}
+
+// addOnce appends component c unless the builder already has it
+func (b *Builder) addOnce(c Component) *Builder {
+	if slices.Contains(b.components, c) {
+		return b
+	}
+	return b.add(c, 1)
+}
+
+// setErr records the first error from building
+func (b *Builder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// formatToken writes a non-negative token in the given format
+func formatToken(token int64, format TokenFormat) string {
+	switch format {
+	case Hex:
+		return strconv.FormatInt(token, 16)
+	case Base62:
+		return base62.StdEncoding.EncodeUint64(uint64(token))
+	default:
+		return strconv.FormatInt(token, 10)
+	}
+}
@@ -0,0 +1,196 @@
+package haiku
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/base62"
+)
+
+func TestBuilderAdjectiveNounOnly(t *testing.T) {
+	h := NewHaikunator()
+
+	haiku, err := h.Builder().Adjectives(1).Nouns(1).Haikunate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	parts := strings.SplitN(haiku, "-", 2)
+	if len(parts) != 2 {
+		t.Fatalf("Generated haiku [%s] should have 2 parts", haiku)
+	}
+	if !slices.Contains(ADJECTIVES, parts[0]) {
+		t.Errorf("First part should be an adjective: %s", parts[0])
+	}
+	if !slices.Contains(NOUNS, parts[1]) {
+		t.Errorf("Second part should be a noun: %s", parts[1])
+	}
+}
+
+func TestBuilderComponentOrder(t *testing.T) {
+	h := NewHaikunator(
+		WithAdjectives([]string{"brave"}),
+		WithActions([]string{"coding"}),
+		WithNouns([]string{"gopher"}),
+	)
+
+	b := h.Builder().Delimiter(".").Nouns(2).Adjectives(1).Token(1, Decimal).Actions(1)
+	haiku, err := b.Haikunate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if haiku != "gopher.gopher.brave.0.coding" {
+		t.Errorf("Expected gopher.gopher.brave.0.coding, got %s", haiku)
+	}
+
+	want := []Component{Noun, Noun, Adjective, Token, Action}
+	if !slices.Equal(b.Components(), want) {
+		t.Errorf("Expected components %v, got %v", want, b.Components())
+	}
+}
+
+func TestBuilderTokenFormats(t *testing.T) {
+	tests := []struct {
+		format TokenFormat
+		parse  func(string) (int64, error)
+	}{
+		{Decimal, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }},
+		{Hex, func(s string) (int64, error) { return strconv.ParseInt(s, 16, 64) }},
+		{Base62, func(s string) (int64, error) {
+			n, err := base62.StdEncoding.DecodeUint64(s)
+			return int64(n), err
+		}},
+	}
+
+	h := NewHaikunator()
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			for range 20 {
+				haiku, err := h.Builder().Nouns(1).Token(1<<40, tt.format).Haikunate()
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				token := haiku[strings.LastIndex(haiku, "-")+1:]
+				n, err := tt.parse(token)
+				if err != nil {
+					t.Fatalf("Token %s is not %s: %v", token, tt.format, err)
+				}
+				if n < 0 || n >= 1<<40 {
+					t.Errorf("Token %d is outside of bounds", n)
+				}
+			}
+		})
+	}
+}
+
+func TestBuilderSingleToken(t *testing.T) {
+	b := NewHaikunator().Builder().Token(10, Decimal).Nouns(1).Token(16, Hex)
+
+	want := []Component{Token, Noun}
+	if !slices.Equal(b.Components(), want) {
+		t.Errorf("Expected components %v, got %v", want, b.Components())
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	h := NewHaikunator()
+
+	tests := []struct {
+		name    string
+		builder *Builder
+		want    string
+	}{
+		{"no components", h.Builder(), "no components"},
+		{"negative count", h.Builder().Nouns(-1), "negative noun count"},
+		{"zero token max", h.Builder().Nouns(1).Token(0, Decimal), "token max must be positive"},
+		{"unknown format", h.Builder().Nouns(1).Token(10, TokenFormat(9)), "unknown token format"},
+		{"unsafe delimiter", h.Builder().Nouns(1).Delimiter("<>"), "unsafe delimiter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Haikunate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := h.Builder().Haikunate(); !errors.Is(err, ErrNoComponents) {
+		t.Errorf("Expected ErrNoComponents, got %v", err)
+	}
+}
+
+func TestParseTokenFormat(t *testing.T) {
+	for _, f := range []TokenFormat{Decimal, Hex, Base62} {
+		got, err := ParseTokenFormat(strings.ToUpper(f.String()))
+		if err != nil || got != f {
+			t.Errorf("ParseTokenFormat(%s) = %v, %v", f, got, err)
+		}
+	}
+
+	if _, err := ParseTokenFormat("octal"); err == nil {
+		t.Error("Expected error for unknown token format")
+	}
+}
+
+func TestBuilderHaikunateUnique(t *testing.T) {
+	h := NewHaikunator(WithAdjectives([]string{"brave", "calm"}), WithNouns([]string{"gopher", "otter"}))
+	b := h.Builder().Adjectives(1).Nouns(1).Token(3, Decimal)
+
+	names, err := b.HaikunateUnique(12)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(names) != 12 {
+		t.Fatalf("Expected 12 names, got %d", len(names))
+	}
+	slices.Sort(names)
+	if len(slices.Compact(names)) != 12 {
+		t.Errorf("Expected distinct names, got %v", names)
+	}
+}
+
+func TestBuilderHaikunateUniqueExhausted(t *testing.T) {
+	h := NewHaikunator(WithNouns([]string{"gopher", "otter"}))
+
+	_, err := h.Builder().Nouns(1).HaikunateUnique(3)
+	if !errors.Is(err, ErrExhausted) {
+		t.Errorf("Expected ErrExhausted, got %v", err)
+	}
+
+	// Repeated words make fewer names than Combinations reports
+	h = NewHaikunator(WithNouns([]string{"gopher", "gopher"}))
+	_, err = h.Builder().Nouns(1).HaikunateUnique(2)
+	if !errors.Is(err, ErrExhausted) {
+		t.Errorf("Expected ErrExhausted for repeated words, got %v", err)
+	}
+}
+
+func TestBuilderCombinations(t *testing.T) {
+	h := NewHaikunator(WithAdjectives([]string{"brave", "calm", "quiet"}), WithNouns([]string{"gopher", "otter"}))
+
+	tests := []struct {
+		name    string
+		builder *Builder
+		want    int64
+	}{
+		{"empty", h.Builder(), 0},
+		{"adjective noun", h.Builder().Adjectives(1).Nouns(1), 6},
+		{"two nouns", h.Builder().Nouns(2), 4},
+		{"with token", h.Builder().Adjectives(1).Token(100, Hex), 300},
+		{"default actions", h.Builder().Actions(1), int64(len(ACTIONS))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.builder.Combinations().Int64(); got != tt.want {
+				t.Errorf("Expected %d combinations, got %d", tt.want, got)
+			}
+		})
+	}
+}
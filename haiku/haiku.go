@@ -24,6 +24,13 @@ var (
 type Haikunator struct {
 	delim string
 	token int64
+
+	// Word lists, or nil for the built-in ones
+	adjectives []string
+	actions    []string
+	nouns      []string
+
+	err error // Error applying options
 }
 
 // randomInt generates a cryptographically secure random integer in range [0, max)
@@ -50,8 +57,13 @@ func randomInt64(max int64) (int64, error) {
 	return n.Int64(), nil
 }
 
-func NewHaikunator() Haikunator {
+// NewHaikunator returns a Haikunator using the built-in word lists unless
+// replaced with options such as WithWordLists.
+func NewHaikunator(opts ...Option) Haikunator {
 	h := Haikunator{delim: "-", token: 9999}
+	for _, opt := range opts {
+		opt(&h)
+	}
 	return h
 }
 
@@ -59,23 +71,30 @@ func (h *Haikunator) haikunate(token, delim string) (string, error) {
 	if !h.isSafeDelimiter(delim) {
 		return "", fmt.Errorf("unsafe delimiter: %s", delim)
 	}
+	if h.err != nil {
+		return "", h.err
+	}
 
-	adjIdx, err := randomInt(len(ADJECTIVES))
+	adjectives := wordsOrDefault(h.adjectives, ADJECTIVES)
+	actions := wordsOrDefault(h.actions, ACTIONS)
+	nouns := wordsOrDefault(h.nouns, NOUNS)
+
+	adjIdx, err := randomInt(len(adjectives))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate random adjective: %w", err)
 	}
 
-	actionIdx, err := randomInt(len(ACTIONS))
+	actionIdx, err := randomInt(len(actions))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate random action: %w", err)
 	}
 
-	nounIdx, err := randomInt(len(NOUNS))
+	nounIdx, err := randomInt(len(nouns))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate random noun: %w", err)
 	}
 
-	haiku := fmt.Sprintf("%s%s%s%s%s", ADJECTIVES[adjIdx], delim, ACTIONS[actionIdx], delim, NOUNS[nounIdx])
+	haiku := fmt.Sprintf("%s%s%s%s%s", adjectives[adjIdx], delim, actions[actionIdx], delim, nouns[nounIdx])
 
 	if len(token) > 0 {
 		haiku = fmt.Sprintf("%s%s%s", haiku, delim, token)
@@ -127,6 +146,14 @@ func (h *Haikunator) TokenDelimHaikunate(token int64, delim string) (string, err
 	return h.haikunate(tokenString, delim)
 }
 
+// wordsOrDefault returns words, or fallback if no words were configured
+func wordsOrDefault(words, fallback []string) []string {
+	if len(words) == 0 {
+		return fallback
+	}
+	return words
+}
+
 func (h *Haikunator) isSafeDelimiter(delim string) bool {
 	if len(delim) == 0 || len(delim) > 5 {
 		return false
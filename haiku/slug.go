@@ -0,0 +1,130 @@
+package haiku
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxDNSLabelLength is the longest DNS label (RFC 1123), and so the longest
+// Kubernetes resource name or S3 bucket name.
+const MaxDNSLabelLength = 63
+
+// maxRerolls is the number of names generated before giving up on finding
+// one short enough
+const maxRerolls = 1000
+
+// ErrNoValidName is returned by Builder.Haikunate when it cannot generate a
+// name within the length set by MaxLength, or with valid DNSLabel characters.
+var ErrNoValidName = errors.New("no valid haiku name")
+
+// MaxLength limits names to n characters. Longer names are re-rolled, or
+// shortened if Truncate is set.
+func (b *Builder) MaxLength(n int) *Builder {
+	if n < 1 {
+		b.setErr(fmt.Errorf("max length must be positive, got %d", n))
+		return b
+	}
+	b.maxLen = n
+	return b
+}
+
+// Truncate shortens names longer than MaxLength instead of re-rolling them.
+// The longest words are shortened first, one character at a time; the token
+// and suffix are kept whole so that names stay unique. Names that are still
+// too long with every word cut to one character are re-rolled.
+func (b *Builder) Truncate() *Builder {
+	b.truncate = true
+	return b
+}
+
+// Lowercase makes names lowercase, for custom word lists with capitals and
+// base62 tokens. Lowercase base62 tokens are less random, as 'a' and 'A'
+// become the same.
+func (b *Builder) Lowercase() *Builder {
+	b.lowercase = true
+	return b
+}
+
+// DNSLabel makes names valid DNS labels (RFC 1123), as required for
+// Kubernetes resource names and S3 buckets: lowercase letters, digits and
+// '-' only, no leading or trailing '-', and at most MaxDNSLabelLength
+// characters or MaxLength if lower. Other characters in words, such as the
+// space of "bell pepper", become '-'. The delimiter must be "-".
+//
+// Example usage:
+//
+//	name, err := h.Builder().Adjectives(1).Nouns(1).Suffix(6).DNSLabel().Haikunate()
+//	fmt.Println(name) // e.g. quiet-bell-pepper-3fa2kq
+func (b *Builder) DNSLabel() *Builder {
+	b.dnsLabel = true
+	b.lowercase = true
+	return b
+}
+
+// maxLength returns the length limit, or 0 for none
+func (b *Builder) maxLength() int {
+	if b.dnsLabel && (b.maxLen == 0 || b.maxLen > MaxDNSLabelLength) {
+		return MaxDNSLabelLength
+	}
+	return b.maxLen
+}
+
+// constrain applies the case, character and length constraints to the parts
+// of a name and joins them, reporting false if the name must be re-rolled
+func (b *Builder) constrain(parts []string) (string, bool) {
+	for i, part := range parts {
+		if b.lowercase {
+			part = strings.ToLower(part)
+		}
+		if b.dnsLabel {
+			part = strings.Trim(strings.Map(dnsLabelRune, part), "-")
+		}
+		if part == "" {
+			// Nothing left of a word without a valid character
+			return "", false
+		}
+		parts[i] = part
+	}
+
+	maxLen := b.maxLength()
+	name := strings.Join(parts, b.delim)
+	if maxLen == 0 || utf8.RuneCountInString(name) <= maxLen {
+		return name, true
+	}
+	if !b.truncate {
+		return "", false
+	}
+
+	for utf8.RuneCountInString(name) > maxLen {
+		longest := -1
+		for i, part := range parts {
+			if b.components[i] == Token || b.components[i] == Suffix {
+				continue
+			}
+			if longest < 0 || utf8.RuneCountInString(part) > utf8.RuneCountInString(parts[longest]) {
+				longest = i
+			}
+		}
+		if longest < 0 || utf8.RuneCountInString(parts[longest]) <= 1 {
+			return "", false
+		}
+
+		runes := []rune(parts[longest])
+		parts[longest] = strings.TrimRight(string(runes[:len(runes)-1]), "-")
+		if parts[longest] == "" {
+			return "", false
+		}
+		name = strings.Join(parts, b.delim)
+	}
+	return name, true
+}
+
+// dnsLabelRune maps characters not allowed in DNS labels to '-'
+func dnsLabelRune(r rune) rune {
+	if 'a' <= r && r <= 'z' || '0' <= r && r <= '9' {
+		return r
+	}
+	return '-'
+}
@@ -0,0 +1,142 @@
+package haiku
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var dnsLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+func TestBuilderMaxLengthRerolls(t *testing.T) {
+	h := NewHaikunator(WithNouns([]string{"ox", "hippopotamus"}))
+	b := h.Builder().Nouns(2).MaxLength(5)
+
+	for range 20 {
+		haiku, err := b.Haikunate()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if haiku != "ox-ox" {
+			t.Errorf("Expected the only short name ox-ox, got %s", haiku)
+		}
+	}
+}
+
+func TestBuilderMaxLengthTooLong(t *testing.T) {
+	h := NewHaikunator(WithNouns([]string{"hippopotamus"}))
+
+	_, err := h.Builder().Nouns(1).MaxLength(5).Haikunate()
+	if !errors.Is(err, ErrNoValidName) {
+		t.Errorf("Expected ErrNoValidName, got %v", err)
+	}
+
+	_, err = h.Builder().Nouns(1).MaxLength(0).Haikunate()
+	if err == nil || !strings.Contains(err.Error(), "max length must be positive") {
+		t.Errorf("Expected max length error, got %v", err)
+	}
+}
+
+func TestBuilderTruncate(t *testing.T) {
+	h := NewHaikunator(WithAdjectives([]string{"quiet"}), WithNouns([]string{"hippopotamus"}))
+
+	haiku, err := h.Builder().Adjectives(1).Nouns(1).Token(1, Decimal).MaxLength(14).Truncate().Haikunate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The longest word is shortened first and the token is kept
+	if haiku != "quiet-hippop-0" {
+		t.Errorf("Expected quiet-hippop-0, got %s", haiku)
+	}
+
+	haiku, err = h.Builder().Adjectives(1).Nouns(1).Suffix(8).MaxLength(12).Truncate().Haikunate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(haiku, "q-h-") || len(haiku) != 12 {
+		t.Errorf("Expected q-h- and an 8 character suffix, got %s", haiku)
+	}
+
+	// The suffix alone is longer than the limit
+	_, err = h.Builder().Nouns(1).Suffix(8).MaxLength(8).Truncate().Haikunate()
+	if !errors.Is(err, ErrNoValidName) {
+		t.Errorf("Expected ErrNoValidName, got %v", err)
+	}
+}
+
+func TestBuilderTruncateTrimsDelimiters(t *testing.T) {
+	h := NewHaikunator(WithNouns([]string{"sea-lion"}))
+
+	haiku, err := h.Builder().Nouns(1).MaxLength(4).Truncate().Haikunate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if haiku != "sea" {
+		t.Errorf("Expected sea, got %s", haiku)
+	}
+}
+
+func TestBuilderLowercase(t *testing.T) {
+	h := NewHaikunator(WithAdjectives([]string{"Brave"}), WithNouns([]string{"GOPHER"}))
+
+	haiku, err := h.Builder().Adjectives(1).Nouns(1).Lowercase().Haikunate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if haiku != "brave-gopher" {
+		t.Errorf("Expected brave-gopher, got %s", haiku)
+	}
+}
+
+func TestBuilderDNSLabel(t *testing.T) {
+	h := NewHaikunator()
+	b := h.Builder().Adjectives(2).Actions(1).Nouns(3).Token(1<<40, Base62).Suffix(8).DNSLabel()
+
+	for range 100 {
+		haiku, err := b.Haikunate()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(haiku) > MaxDNSLabelLength {
+			t.Errorf("DNS label %s is longer than %d characters", haiku, MaxDNSLabelLength)
+		}
+		if !dnsLabelPattern.MatchString(haiku) {
+			t.Errorf("Invalid DNS label: %s", haiku)
+		}
+	}
+}
+
+func TestBuilderDNSLabelSanitizesWords(t *testing.T) {
+	h := NewHaikunator(WithAdjectives([]string{" Hot "}), WithNouns([]string{"bell pepper"}))
+
+	haiku, err := h.Builder().Adjectives(1).Nouns(1).DNSLabel().Haikunate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if haiku != "hot-bell-pepper" {
+		t.Errorf("Expected hot-bell-pepper, got %s", haiku)
+	}
+
+	h = NewHaikunator(WithNouns([]string{"日本"}))
+	if _, err := h.Builder().Nouns(1).DNSLabel().Haikunate(); !errors.Is(err, ErrNoValidName) {
+		t.Errorf("Expected an error for words without valid characters, got %v", err)
+	}
+}
+
+func TestBuilderDNSLabelMaxLength(t *testing.T) {
+	h := NewHaikunator()
+
+	haiku, err := h.Builder().Nouns(8).DNSLabel().MaxLength(100).Truncate().Haikunate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if utf8.RuneCountInString(haiku) > MaxDNSLabelLength {
+		t.Errorf("DNS label %s is longer than %d characters", haiku, MaxDNSLabelLength)
+	}
+
+	if _, err := h.Builder().Nouns(1).Delimiter("_").DNSLabel().Haikunate(); err == nil {
+		t.Error("Expected error for a DNS label with '_' delimiter")
+	}
+}
@@ -0,0 +1,102 @@
+package haiku
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/bilte-co/toolshed/hash"
+)
+
+// suffixAlphabet is the lowercase Crockford base32 alphabet, which avoids
+// letters easily mistaken for digits and is safe in DNS labels
+const suffixAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+const (
+	suffixBits = 5 // Bits per suffix character
+
+	// MaxSuffixChars is the longest suffix, 160 bits.
+	MaxSuffixChars = 32
+)
+
+// Suffix adds a suffix of chars lowercase base32 characters, 5 random bits
+// each from crypto/rand, so that names can be used as unique identifiers at
+// scale. Use SuffixChars to choose chars for a collision probability. A
+// builder has at most one suffix; adding another replaces its length but
+// keeps its position.
+//
+// Example usage:
+//
+//	chars := haiku.SuffixChars(1_000_000, 1e-9)
+//	name, err := h.Builder().Adjectives(1).Nouns(1).Suffix(chars).Haikunate()
+//	fmt.Println(name) // e.g. quiet-otter-7k2m9qx4hc1pa3
+func (b *Builder) Suffix(chars int) *Builder {
+	if chars < 1 || chars > MaxSuffixChars {
+		b.setErr(fmt.Errorf("suffix length must be between 1 and %d, got %d", MaxSuffixChars, chars))
+		return b
+	}
+	b.suffixChars = chars
+	return b.addOnce(Suffix)
+}
+
+// HaikunateKey generates a name like Haikunate, except that the suffix is
+// derived from the SHA-256 hash of key, such as the ID of the resource being
+// named, instead of from crypto/rand. Names for the same key share a suffix,
+// and names for distinct keys collide with the probability given by
+// SuffixChars. It returns an error if the builder has no suffix.
+func (b *Builder) HaikunateKey(key []byte) (string, error) {
+	if !slices.Contains(b.components, Suffix) {
+		return "", fmt.Errorf("haiku has no suffix to derive from the key")
+	}
+	if key == nil {
+		key = []byte{}
+	}
+	return b.haikunate(key)
+}
+
+// SuffixChars returns the suffix length that keeps the probability of any
+// two of n names sharing a suffix at or below probability, by the birthday
+// bound n²/2 / 32^chars. Probabilities outside (0, 1) and lengths over
+// MaxSuffixChars are clamped.
+func SuffixChars(n int, probability float64) int {
+	if n < 2 {
+		return 1
+	}
+	if probability <= 0 || math.IsNaN(probability) {
+		return MaxSuffixChars
+	}
+	probability = min(probability, 1)
+
+	// Bits needed so that n²/2 / 2^bits <= probability
+	bits := 2*math.Log2(float64(n)) - 1 - math.Log2(probability)
+	chars := int(math.Ceil(bits / suffixBits))
+	return min(max(chars, 1), MaxSuffixChars)
+}
+
+// suffix returns a random suffix, or the one derived from key if key is not
+// nil
+func (b *Builder) suffix(key []byte) (string, error) {
+	suffix := make([]byte, b.suffixChars)
+	if key == nil {
+		for i := range suffix {
+			idx, err := randomInt(len(suffixAlphabet))
+			if err != nil {
+				return "", fmt.Errorf("failed to generate random suffix: %w", err)
+			}
+			suffix[i] = suffixAlphabet[idx]
+		}
+		return string(suffix), nil
+	}
+
+	digest, err := hash.HashBytes(key, "sha256")
+	if err != nil {
+		return "", fmt.Errorf("failed to hash suffix key: %w", err)
+	}
+	for i := range suffix {
+		// Take the digest 5 bits at a time, most significant first
+		bit := i * suffixBits
+		v := uint(digest[bit/8])<<8 | uint(digest[bit/8+1])
+		suffix[i] = suffixAlphabet[v>>(16-suffixBits-bit%8)&(1<<suffixBits-1)]
+	}
+	return string(suffix), nil
+}
@@ -0,0 +1,111 @@
+package haiku
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuilderSuffix(t *testing.T) {
+	h := NewHaikunator()
+	b := h.Builder().Adjectives(1).Nouns(1).Suffix(8)
+
+	seen := make(map[string]bool)
+	for range 50 {
+		haiku, err := b.Haikunate()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		suffix := haiku[strings.LastIndex(haiku, "-")+1:]
+		if len(suffix) != 8 {
+			t.Errorf("Expected an 8 character suffix, got %s", suffix)
+		}
+		for _, c := range suffix {
+			if !strings.ContainsRune(suffixAlphabet, c) {
+				t.Errorf("Suffix %s contains invalid character %c", suffix, c)
+			}
+		}
+		seen[suffix] = true
+	}
+	if len(seen) < 49 {
+		t.Errorf("Expected random suffixes, got %d distinct of 50", len(seen))
+	}
+}
+
+func TestBuilderSuffixErrors(t *testing.T) {
+	for _, chars := range []int{0, -1, MaxSuffixChars + 1} {
+		_, err := NewHaikunator().Builder().Nouns(1).Suffix(chars).Haikunate()
+		if err == nil || !strings.Contains(err.Error(), "suffix length") {
+			t.Errorf("Expected suffix length error for %d, got %v", chars, err)
+		}
+	}
+}
+
+func TestBuilderHaikunateKey(t *testing.T) {
+	b := NewHaikunator().Builder().Nouns(1).Suffix(MaxSuffixChars)
+
+	first, err := b.HaikunateKey([]byte("user-42"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, err := b.HaikunateKey([]byte("user-42"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	other, err := b.HaikunateKey([]byte("user-43"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	suffix := func(s string) string { return s[strings.LastIndex(s, "-")+1:] }
+	if suffix(first) != suffix(second) {
+		t.Errorf("Expected the same suffix for the same key, got %s and %s", first, second)
+	}
+	if suffix(first) == suffix(other) {
+		t.Errorf("Expected different suffixes for different keys, got %s and %s", first, other)
+	}
+
+	if _, err := NewHaikunator().Builder().Nouns(1).HaikunateKey([]byte("user-42")); err == nil {
+		t.Error("Expected error for a builder without suffix")
+	}
+}
+
+func TestBuilderHaikunateKeyDigest(t *testing.T) {
+	b := NewHaikunator(WithNouns([]string{"gopher"})).Builder().Nouns(1).Suffix(MaxSuffixChars)
+
+	// Base32 of the SHA-256 hash of the empty string, e3b0c442 98fc1c14...
+	haiku, err := b.HaikunateKey(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "gopher-werc8gmrzge196qvyk49jvxs4gktwgf4"; haiku != want {
+		t.Errorf("Expected %s, got %s", want, haiku)
+	}
+}
+
+func TestSuffixChars(t *testing.T) {
+	tests := []struct {
+		n           int
+		probability float64
+		want        int
+	}{
+		{1, 0.5, 1},
+		{1_000_000, 1e-9, 14},
+		{1_000, 0.01, 6},
+		{1 << 20, 0, MaxSuffixChars},
+		{1 << 40, 1e-300, MaxSuffixChars},
+	}
+
+	for _, tt := range tests {
+		if got := SuffixChars(tt.n, tt.probability); got != tt.want {
+			t.Errorf("SuffixChars(%d, %g) = %d, want %d", tt.n, tt.probability, got, tt.want)
+		}
+	}
+}
+
+func TestCombinationsWithSuffix(t *testing.T) {
+	h := NewHaikunator(WithNouns([]string{"gopher", "otter"}))
+	if got := h.Builder().Nouns(1).Suffix(2).Combinations().Int64(); got != 2*32*32 {
+		t.Errorf("Expected %d combinations, got %d", 2*32*32, got)
+	}
+}
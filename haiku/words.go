@@ -0,0 +1,110 @@
+package haiku
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Word list file names read by WithWordLists, one word per line.
+const (
+	AdjectivesFile = "adjectives.txt"
+	ActionsFile    = "actions.txt"
+	NounsFile      = "nouns.txt"
+)
+
+// Option configures a Haikunator created by NewHaikunator.
+type Option func(*Haikunator)
+
+// WithAdjectives replaces the built-in ADJECTIVES list. An empty list keeps
+// the current one.
+func WithAdjectives(words []string) Option {
+	return func(h *Haikunator) {
+		if len(words) > 0 {
+			h.adjectives = words
+		}
+	}
+}
+
+// WithActions replaces the built-in ACTIONS list. An empty list keeps the
+// current one.
+func WithActions(words []string) Option {
+	return func(h *Haikunator) {
+		if len(words) > 0 {
+			h.actions = words
+		}
+	}
+}
+
+// WithNouns replaces the built-in NOUNS list. An empty list keeps the current
+// one.
+func WithNouns(words []string) Option {
+	return func(h *Haikunator) {
+		if len(words) > 0 {
+			h.nouns = words
+		}
+	}
+}
+
+// WithWordLists replaces the built-in lists with those read from the
+// AdjectivesFile, ActionsFile and NounsFile files of fsys, so that teams can
+// supply their own vocabulary such as brand-safe words or other languages.
+// Each file lists one word per line; blank lines and lines starting with '#'
+// are ignored. A missing file keeps the current list for that component.
+//
+// Errors reading the files are returned by every call that generates a name.
+//
+// Example usage:
+//
+//	h := haiku.NewHaikunator(haiku.WithWordLists(os.DirFS("words")))
+//	name, err := h.Haikunate()
+func WithWordLists(fsys fs.FS) Option {
+	return func(h *Haikunator) {
+		for _, list := range []struct {
+			file  string
+			words *[]string
+		}{
+			{AdjectivesFile, &h.adjectives},
+			{ActionsFile, &h.actions},
+			{NounsFile, &h.nouns},
+		} {
+			words, err := readWordList(fsys, list.file)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				h.err = err
+				return
+			}
+			*list.words = words
+		}
+	}
+}
+
+// readWordList reads the words listed in a file of fsys, one per line
+func readWordList(fsys fs.FS, name string) ([]string, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+
+	var words []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list %s: %w", name, err)
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("word list %s is empty", name)
+	}
+	return words, nil
+}
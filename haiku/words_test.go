@@ -0,0 +1,77 @@
+package haiku
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWithWordListOptions(t *testing.T) {
+	h := NewHaikunator(
+		WithAdjectives([]string{"brave"}),
+		WithActions([]string{"coding"}),
+		WithNouns([]string{"gopher"}),
+	)
+
+	haiku, err := h.DelimHaikunate("-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if haiku != "brave-coding-gopher" {
+		t.Errorf("Expected brave-coding-gopher, got %s", haiku)
+	}
+}
+
+func TestWithEmptyWordListKeepsDefault(t *testing.T) {
+	h := NewHaikunator(WithAdjectives(nil), WithActions([]string{}))
+
+	haiku, err := h.DelimHaikunate("-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	parts := strings.Split(haiku, "-")
+	if len(parts) < 3 {
+		t.Fatalf("Generated haiku [%s] should have at least 3 parts", haiku)
+	}
+	if parts[0] == "" || parts[1] == "" {
+		t.Errorf("Expected default words, got %s", haiku)
+	}
+}
+
+func TestWithWordLists(t *testing.T) {
+	fsys := fstest.MapFS{
+		AdjectivesFile: {Data: []byte("# Brand-safe adjectives\n\n  sonnig  \n")},
+		NounsFile:      {Data: []byte("katze\r\n")},
+	}
+	h := NewHaikunator(WithWordLists(fsys), WithActions([]string{"schlafend"}))
+
+	haiku, err := h.TokenDelimHaikunate(0, "-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if haiku != "sonnig-schlafend-katze" {
+		t.Errorf("Expected sonnig-schlafend-katze, got %s", haiku)
+	}
+}
+
+func TestWithWordListsMissingFileKeepsDefault(t *testing.T) {
+	fsys := fstest.MapFS{NounsFile: {Data: []byte("gopher\n")}}
+	h := NewHaikunator(WithWordLists(fsys))
+
+	haiku, err := h.DelimHaikunate("-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(haiku, "-gopher") {
+		t.Errorf("Expected the custom noun, got %s", haiku)
+	}
+}
+
+func TestWithWordListsEmptyFileReturnsError(t *testing.T) {
+	fsys := fstest.MapFS{ActionsFile: {Data: []byte("# nothing here\n\n")}}
+	h := NewHaikunator(WithWordLists(fsys))
+
+	if _, err := h.Haikunate(); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("Expected empty word list error, got %v", err)
+	}
+}
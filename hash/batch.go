@@ -13,6 +13,8 @@ type FileHashResult struct {
 	Hash      []byte
 	Error     error
 	Algorithm string
+	// Index is the position of Path in the input slice.
+	Index int
 }
 
 // BatchHashResult represents the results of batch hashing operations.
@@ -21,20 +23,70 @@ type BatchHashResult struct {
 	Errors  []error
 }
 
+// BatchOptions configures batch hashing with HashFilesBatch.
+type BatchOptions struct {
+	// Workers is the number of concurrent workers. If 0 or negative, it defaults
+	// to the number of CPU cores.
+	Workers int
+
+	// Ordered delivers results in input order instead of completion order.
+	// Only a bounded window of files is in flight at once, so a slow file
+	// delays later results rather than letting them pile up in memory.
+	Ordered bool
+
+	// OnResult, if set, is invoked for each file as soon as its result is
+	// available (respecting Ordered). Results are then not retained in
+	// BatchHashResult.Results, which keeps memory flat for huge batches.
+	// The callback is never invoked concurrently.
+	OnResult func(FileHashResult)
+
+	// Output, if set, formats each hash according to the given options
+	// before it is delivered. The formatted value is stored in Hash.
+	Output *Options
+}
+
+// orderedWindowPerWorker bounds how many files may be in flight per worker
+// when results are delivered in input order.
+const orderedWindowPerWorker = 4
+
 // HashFilesInParallel hashes multiple files in parallel using the specified number of workers.
 // If workers is 0 or negative, it defaults to the number of CPU cores.
 func HashFilesInParallel(paths []string, algorithm string, workers int) *BatchHashResult {
+	return HashFilesBatch(paths, algorithm, BatchOptions{Workers: workers})
+}
+
+// HashFilesInParallelWithOptions hashes multiple files in parallel with custom options.
+func HashFilesInParallelWithOptions(paths []string, algorithm string, workers int, opts Options) *BatchHashResult {
+	return HashFilesBatch(paths, algorithm, BatchOptions{Workers: workers, Output: &opts})
+}
+
+// HashFilesBatch hashes multiple files in parallel according to opts.
+func HashFilesBatch(paths []string, algorithm string, opts BatchOptions) *BatchHashResult {
+	workers := opts.Workers
 	if workers <= 0 {
 		workers = runtime.NumCPU()
 	}
 
+	batchResult := &BatchHashResult{Results: []FileHashResult{}, Errors: []error{}}
 	if len(paths) == 0 {
-		return &BatchHashResult{Results: []FileHashResult{}, Errors: []error{}}
+		return batchResult
+	}
+
+	type job struct {
+		index int
+		path  string
 	}
 
 	// Create channels for work distribution and result collection
-	jobs := make(chan string, len(paths))
-	results := make(chan FileHashResult, len(paths))
+	jobs := make(chan job)
+	results := make(chan FileHashResult, workers)
+
+	// In ordered mode, a slot is taken per dispatched file and only given back
+	// once that file's result has been delivered
+	var window chan struct{}
+	if opts.Ordered {
+		window = make(chan struct{}, workers*orderedWindowPerWorker)
+	}
 
 	// Start workers
 	var wg sync.WaitGroup
@@ -42,13 +94,14 @@ func HashFilesInParallel(paths []string, algorithm string, workers int) *BatchHa
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			for path := range jobs {
-				hash, err := HashFile(path, algorithm)
+			for j := range jobs {
+				hash, err := HashFile(j.path, algorithm)
 				results <- FileHashResult{
-					Path:      path,
+					Path:      j.path,
 					Hash:      hash,
 					Error:     err,
 					Algorithm: algorithm,
+					Index:     j.index,
 				}
 			}
 		}()
@@ -57,8 +110,11 @@ func HashFilesInParallel(paths []string, algorithm string, workers int) *BatchHa
 	// Send jobs to workers
 	go func() {
 		defer close(jobs)
-		for _, path := range paths {
-			jobs <- path
+		for i, path := range paths {
+			if window != nil {
+				window <- struct{}{}
+			}
+			jobs <- job{index: i, path: path}
 		}
 	}()
 
@@ -68,45 +124,54 @@ func HashFilesInParallel(paths []string, algorithm string, workers int) *BatchHa
 		close(results)
 	}()
 
-	// Collect results
-	var fileResults []FileHashResult
-	var errors []error
-
-	for result := range results {
-		fileResults = append(fileResults, result)
+	deliver := func(result FileHashResult) {
 		if result.Error != nil {
-			errors = append(errors, fmt.Errorf("failed to hash %s: %w", result.Path, result.Error))
-		}
-	}
-
-	return &BatchHashResult{
-		Results: fileResults,
-		Errors:  errors,
-	}
-}
-
-// HashFilesInParallelWithOptions hashes multiple files in parallel with custom options.
-func HashFilesInParallelWithOptions(paths []string, algorithm string, workers int, opts Options) *BatchHashResult {
-	batchResult := HashFilesInParallel(paths, algorithm, workers)
-
-	// Format the results according to options
-	for i := range batchResult.Results {
-		if batchResult.Results[i].Error == nil {
-			formatted, err := formatOutput(batchResult.Results[i].Hash, algorithm, opts)
+			batchResult.Errors = append(batchResult.Errors, fmt.Errorf("failed to hash %s: %w", result.Path, result.Error))
+		} else if opts.Output != nil {
+			formatted, err := formatOutput(result.Hash, algorithm, *opts.Output)
 			if err != nil {
-				batchResult.Results[i].Error = err
+				result.Error = err
 				batchResult.Errors = append(batchResult.Errors,
-					fmt.Errorf("failed to format output for %s: %w", batchResult.Results[i].Path, err))
+					fmt.Errorf("failed to format output for %s: %w", result.Path, err))
 			} else {
-				// Store formatted result in Hash field as interface{}
 				switch f := formatted.(type) {
 				case []byte:
-					batchResult.Results[i].Hash = f
+					result.Hash = f
 				case string:
-					batchResult.Results[i].Hash = []byte(f)
+					result.Hash = []byte(f)
 				}
 			}
 		}
+
+		if opts.OnResult != nil {
+			opts.OnResult(result)
+			return
+		}
+		batchResult.Results = append(batchResult.Results, result)
+	}
+
+	if !opts.Ordered {
+		for result := range results {
+			deliver(result)
+		}
+		return batchResult
+	}
+
+	// Buffer out-of-order results until the next expected index arrives
+	pending := make(map[int]FileHashResult)
+	next := 0
+	for result := range results {
+		pending[result.Index] = result
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			deliver(r)
+			<-window
+			next++
+		}
 	}
 
 	return batchResult
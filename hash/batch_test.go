@@ -90,6 +90,83 @@ func TestHashFilesInParallelWithOptions(t *testing.T) {
 	assert.NotEmpty(t, result.Results[0].Hash, "Hash should not be empty")
 }
 
+func createBatchFiles(t *testing.T, count int) []string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	paths := make([]string, count)
+	for i := range paths {
+		paths[i] = filepath.Join(tmpDir, fmt.Sprintf("file%03d.txt", i))
+		err := os.WriteFile(paths[i], []byte(fmt.Sprintf("content %d", i)), 0644)
+		require.NoError(t, err, "Failed to create test file")
+	}
+	return paths
+}
+
+func TestHashFilesBatch_Ordered(t *testing.T) {
+	paths := createBatchFiles(t, 50)
+	paths = append(paths, "/nonexistent/file.txt")
+
+	result := HashFilesBatch(paths, "sha256", BatchOptions{Workers: 8, Ordered: true})
+
+	require.Len(t, result.Results, len(paths))
+	require.Len(t, result.Errors, 1)
+	for i, fileResult := range result.Results {
+		assert.Equal(t, paths[i], fileResult.Path, "Results should be in input order")
+		assert.Equal(t, i, fileResult.Index)
+	}
+	assert.Error(t, result.Results[len(paths)-1].Error)
+}
+
+func TestHashFilesBatch_OnResultStreams(t *testing.T) {
+	paths := createBatchFiles(t, 30)
+
+	var seen []FileHashResult
+	result := HashFilesBatch(paths, "sha256", BatchOptions{
+		Workers:  4,
+		Ordered:  true,
+		OnResult: func(r FileHashResult) { seen = append(seen, r) },
+	})
+
+	// Results are delivered to the callback instead of being buffered
+	assert.Empty(t, result.Results)
+	assert.Empty(t, result.Errors)
+	require.Len(t, seen, len(paths))
+	for i, fileResult := range seen {
+		assert.Equal(t, paths[i], fileResult.Path)
+
+		expected, err := HashFile(paths[i], "sha256")
+		require.NoError(t, err)
+		assert.Equal(t, expected, fileResult.Hash)
+	}
+}
+
+func TestHashFilesBatch_OnResultUnordered(t *testing.T) {
+	paths := createBatchFiles(t, 20)
+
+	seen := make(map[string]bool)
+	HashFilesBatch(paths, "sha256", BatchOptions{
+		Workers:  3,
+		OnResult: func(r FileHashResult) { seen[r.Path] = true },
+	})
+
+	assert.Len(t, seen, len(paths))
+}
+
+func TestHashFilesBatch_OutputFormat(t *testing.T) {
+	paths := createBatchFiles(t, 5)
+
+	result := HashFilesBatch(paths, "sha256", BatchOptions{
+		Ordered: true,
+		Output:  &Options{Format: FormatHex, Prefix: true},
+	})
+
+	require.Len(t, result.Results, len(paths))
+	for _, fileResult := range result.Results {
+		assert.Contains(t, string(fileResult.Hash), "sha256:")
+	}
+}
+
 func TestValidateFileChecksum(t *testing.T) {
 	// Create temporary test file
 	tmpDir := t.TempDir()
@@ -0,0 +1,143 @@
+package hash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/bilte-co/toolshed/ignore"
+)
+
+// FileDifference describes a file present in both directories with different content.
+type FileDifference struct {
+	Path  string `json:"path"`
+	HashA string `json:"hash_a"`
+	HashB string `json:"hash_b"`
+}
+
+// DirComparison is the result of comparing two directory trees by content hash.
+// All paths are relative to the compared directories and use forward slashes.
+type DirComparison struct {
+	DirA      string           `json:"dir_a"`
+	DirB      string           `json:"dir_b"`
+	Algorithm string           `json:"algorithm"`
+	OnlyInA   []string         `json:"only_in_a"`
+	OnlyInB   []string         `json:"only_in_b"`
+	Different []FileDifference `json:"different"`
+	Identical int              `json:"identical"`
+}
+
+// Equal reports whether both directories contain the same files with the same content.
+func (c *DirComparison) Equal() bool {
+	return len(c.OnlyInA) == 0 && len(c.OnlyInB) == 0 && len(c.Different) == 0
+}
+
+// CompareDirs recursively compares two directories, reporting files that exist
+// in only one of them and files whose content hashes differ. opts.Exclude is
+// applied to both trees and opts.Workers controls hashing concurrency.
+func CompareDirs(dirA, dirB string, algorithm string, opts Options) (*DirComparison, error) {
+	if _, err := getHasher(algorithm); err != nil {
+		return nil, err
+	}
+
+	filesA, err := listFiles(dirA, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	filesB, err := listFiles(dirB, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DirComparison{
+		DirA:      dirA,
+		DirB:      dirB,
+		Algorithm: algorithm,
+		OnlyInA:   []string{},
+		OnlyInB:   []string{},
+		Different: []FileDifference{},
+	}
+
+	var common []string
+	for rel := range filesA {
+		if _, ok := filesB[rel]; ok {
+			common = append(common, rel)
+		} else {
+			result.OnlyInA = append(result.OnlyInA, rel)
+		}
+	}
+	for rel := range filesB {
+		if _, ok := filesA[rel]; !ok {
+			result.OnlyInB = append(result.OnlyInB, rel)
+		}
+	}
+
+	sort.Strings(common)
+	sort.Strings(result.OnlyInA)
+	sort.Strings(result.OnlyInB)
+
+	// Hash both sides in one ordered batch: even indices are from A, odd from B
+	paths := make([]string, 0, len(common)*2)
+	for _, rel := range common {
+		paths = append(paths, filesA[rel], filesB[rel])
+	}
+
+	batch := HashFilesBatch(paths, algorithm, BatchOptions{Workers: opts.Workers, Ordered: true})
+	if len(batch.Errors) > 0 {
+		return nil, batch.Errors[0]
+	}
+
+	for i, rel := range common {
+		hashA := batch.Results[2*i].Hash
+		hashB := batch.Results[2*i+1].Hash
+		if EqualConstantTime(hashA, hashB) {
+			result.Identical++
+			continue
+		}
+		result.Different = append(result.Different, FileDifference{
+			Path:  rel,
+			HashA: hex.EncodeToString(hashA),
+			HashB: hex.EncodeToString(hashB),
+		})
+	}
+
+	return result, nil
+}
+
+// listFiles returns all regular files below root, keyed by slash-separated relative path.
+func listFiles(root string, exclude *ignore.Matcher) (map[string]string, error) {
+	files := make(map[string]string)
+
+	err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filePath == root {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path for %s: %w", filePath, err)
+		}
+
+		if exclude.Match(relPath, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.Type().IsRegular() {
+			files[filepath.ToSlash(relPath)] = filePath
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory %s: %w", root, err)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,93 @@
+package hash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/ignore"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestCompareDirs_Identical(t *testing.T) {
+	files := map[string]string{
+		"a.txt":        "alpha",
+		"sub/b.txt":    "bravo",
+		"sub/deep/c":   "charlie",
+		"empty/.keep":  "",
+		"unicode/ü.md": "umlaut",
+	}
+	dirA, dirB := t.TempDir(), t.TempDir()
+	writeTree(t, dirA, files)
+	writeTree(t, dirB, files)
+
+	report, err := CompareDirs(dirA, dirB, "sha256", Options{})
+	require.NoError(t, err)
+	assert.True(t, report.Equal())
+	assert.Equal(t, len(files), report.Identical)
+	assert.Empty(t, report.OnlyInA)
+	assert.Empty(t, report.OnlyInB)
+	assert.Empty(t, report.Different)
+}
+
+func TestCompareDirs_Differences(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	writeTree(t, dirA, map[string]string{
+		"same.txt":       "same",
+		"changed.txt":    "old",
+		"removed.txt":    "gone",
+		"nested/old.bin": "x",
+	})
+	writeTree(t, dirB, map[string]string{
+		"same.txt":       "same",
+		"changed.txt":    "new",
+		"added.txt":      "fresh",
+		"nested/new.bin": "y",
+	})
+
+	report, err := CompareDirs(dirA, dirB, "sha256", Options{Workers: 2})
+	require.NoError(t, err)
+	assert.False(t, report.Equal())
+	assert.Equal(t, []string{"nested/old.bin", "removed.txt"}, report.OnlyInA)
+	assert.Equal(t, []string{"added.txt", "nested/new.bin"}, report.OnlyInB)
+	require.Len(t, report.Different, 1)
+	assert.Equal(t, "changed.txt", report.Different[0].Path)
+	assert.NotEqual(t, report.Different[0].HashA, report.Different[0].HashB)
+	assert.Equal(t, 1, report.Identical)
+}
+
+func TestCompareDirs_Exclude(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	writeTree(t, dirA, map[string]string{"app.go": "package main", "debug.log": "a"})
+	writeTree(t, dirB, map[string]string{"app.go": "package main", "debug.log": "b", "cache/x": "z"})
+
+	report, err := CompareDirs(dirA, dirB, "sha256", Options{Exclude: ignore.New("*.log", "cache/")})
+	require.NoError(t, err)
+	assert.True(t, report.Equal())
+	assert.Equal(t, 1, report.Identical)
+}
+
+func TestCompareDirs_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := CompareDirs(dir, dir, "unsupported", Options{})
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+
+	_, err = CompareDirs("/nonexistent/a", dir, "sha256", Options{})
+	assert.Error(t, err)
+
+	_, err = CompareDirs(dir, "/nonexistent/b", "sha256", Options{})
+	assert.Error(t, err)
+}
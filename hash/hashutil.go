@@ -23,6 +23,8 @@ import (
 	"sync"
 
 	"golang.org/x/crypto/blake2b"
+
+	"github.com/bilte-co/toolshed/ignore"
 )
 
 var (
@@ -55,6 +57,9 @@ type Options struct {
 	Prefix     bool
 	Workers    int
 	BufferSize int
+	// Exclude skips matching files and directories when hashing a directory.
+	// Patterns are matched against paths relative to the hashed directory.
+	Exclude *ignore.Matcher
 }
 
 // DefaultOptions provides sensible defaults for hash operations.
@@ -144,6 +149,31 @@ func RegisterHasher(name string, factory func() hash.Hash) {
 	customHashers[strings.ToLower(name)] = factory
 }
 
+// builtinAlgorithms are the hash algorithms supported without registration.
+var builtinAlgorithms = []string{"md5", "sha1", "sha256", "sha512", "blake2b"}
+
+// Algorithms returns the names of all supported hash algorithms, including
+// those added with RegisterHasher, in sorted order.
+func Algorithms() []string {
+	hasherMutex.RLock()
+	defer hasherMutex.RUnlock()
+
+	seen := make(map[string]bool, len(builtinAlgorithms)+len(customHashers))
+	algorithms := make([]string, 0, len(builtinAlgorithms)+len(customHashers))
+	for _, name := range builtinAlgorithms {
+		seen[name] = true
+		algorithms = append(algorithms, name)
+	}
+	for name := range customHashers {
+		if !seen[name] {
+			algorithms = append(algorithms, name)
+		}
+	}
+
+	sort.Strings(algorithms)
+	return algorithms
+}
+
 // formatOutput formats the hash bytes according to the specified format and options.
 func formatOutput(data []byte, algorithm string, opts Options) (any, error) {
 	var result string
@@ -245,6 +275,11 @@ func HashFileWithOptions(path string, algorithm string, opts Options) (any, erro
 
 // HashDir hashes a directory's contents deterministically.
 func HashDir(path string, algorithm string, recursive bool) ([]byte, error) {
+	return hashDir(path, algorithm, recursive, nil)
+}
+
+// hashDir hashes a directory's contents, skipping paths matched by exclude.
+func hashDir(path string, algorithm string, recursive bool, exclude *ignore.Matcher) ([]byte, error) {
 	h, err := getHasher(algorithm)
 	if err != nil {
 		return nil, err
@@ -257,6 +292,19 @@ func HashDir(path string, algorithm string, recursive bool) ([]byte, error) {
 			return err
 		}
 
+		if exclude != nil && filePath != path {
+			relPath, err := filepath.Rel(path, filePath)
+			if err != nil {
+				return fmt.Errorf("failed to get relative path for %s: %w", filePath, err)
+			}
+			if exclude.Match(relPath, d.IsDir()) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
 		if d.IsDir() {
 			if !recursive && filePath != path {
 				return filepath.SkipDir
@@ -297,7 +345,7 @@ func HashDir(path string, algorithm string, recursive bool) ([]byte, error) {
 
 // HashDirWithOptions hashes a directory with custom options.
 func HashDirWithOptions(path string, algorithm string, recursive bool, opts Options) (any, error) {
-	data, err := HashDir(path, algorithm, recursive)
+	data, err := hashDir(path, algorithm, recursive, opts.Exclude)
 	if err != nil {
 		return nil, err
 	}
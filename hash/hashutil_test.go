@@ -13,6 +13,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/ignore"
 )
 
 // Test vectors from known sources
@@ -203,6 +205,23 @@ func TestRegisterHasher(t *testing.T) {
 	assert.Equal(t, expected, result, "Custom hasher should produce same result as sha256")
 }
 
+func TestAlgorithms(t *testing.T) {
+	algorithms := Algorithms()
+	assert.Subset(t, algorithms, []string{"md5", "sha1", "sha256", "sha512", "blake2b"})
+	assert.IsNonDecreasing(t, algorithms, "Algorithms should be sorted")
+
+	RegisterHasher("Listed-Custom", func() hash.Hash {
+		return sha256.New()
+	})
+	assert.Contains(t, Algorithms(), "listed-custom", "Registered hashers should be listed by lowercase name")
+
+	// Every listed algorithm must be usable
+	for _, name := range Algorithms() {
+		_, err := NewHasher(name)
+		require.NoError(t, err, name)
+	}
+}
+
 func TestBLAKE2b(t *testing.T) {
 	// Test BLAKE2b algorithm
 	result, err := HashString("test", "blake2b")
@@ -264,6 +283,34 @@ func TestHashDir_NonExistentDir(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to walk directory")
 }
 
+func TestHashDirWithOptions_Exclude(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644))
+
+	baseline, err := HashDirWithOptions(tmpDir, "sha256", true, Options{Format: FormatHex})
+	require.NoError(t, err)
+
+	// Add files that should be excluded
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "debug.log"), []byte("log"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "build", "out"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "build", "out", "app"), []byte("bin"), 0644))
+
+	opts := Options{Format: FormatHex, Exclude: ignore.New("*.log", "build/")}
+	excluded, err := HashDirWithOptions(tmpDir, "sha256", true, opts)
+	require.NoError(t, err)
+	assert.Equal(t, baseline, excluded, "Excluded files should not affect the hash")
+
+	withAll, err := HashDirWithOptions(tmpDir, "sha256", true, Options{Format: FormatHex})
+	require.NoError(t, err)
+	assert.NotEqual(t, baseline, withAll)
+
+	// Negated patterns re-include files
+	opts.Exclude = ignore.New("*.log", "build/", "!debug.log")
+	reincluded, err := HashDirWithOptions(tmpDir, "sha256", true, opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, baseline, reincluded)
+}
+
 func TestHashDir_EmptyDir(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,92 @@
+// Package hexutil provides hexadecimal encoding and decoding with the
+// formatting options commonly needed when reading or pasting hex by hand:
+// uppercase digits, separators between bytes (as in MAC addresses and
+// certificate fingerprints) and hexdump -C style dumps.
+//
+// Decoding is lenient: it ignores case, whitespace, an optional 0x prefix and
+// the ':' and '-' separators, so the output of Format can always be decoded.
+//
+// Example usage:
+//
+//	// Format bytes as a fingerprint
+//	fingerprint := hexutil.Format(sum, hexutil.FormatOptions{Upper: true, Separator: ":"})
+//	fmt.Println(fingerprint) // DE:AD:BE:EF
+//
+//	// Decode pasted hex
+//	data, err := hexutil.Decode("0xdead beef")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+package hexutil
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// FormatOptions controls how Format writes hex.
+type FormatOptions struct {
+	// Upper writes the digits A-F in uppercase.
+	Upper bool
+	// Separator is written between bytes, e.g. ":" or " ".
+	Separator string
+	// Prefix is written before the first byte, e.g. "0x".
+	Prefix string
+}
+
+// Encode returns the lowercase hex encoding of data
+func Encode(data []byte) string {
+	return hex.EncodeToString(data)
+}
+
+// Format returns the hex encoding of data formatted according to opts
+func Format(data []byte, opts FormatOptions) string {
+	encoded := hex.EncodeToString(data)
+	if opts.Upper {
+		encoded = strings.ToUpper(encoded)
+	}
+
+	if opts.Separator != "" && len(data) > 1 {
+		var b strings.Builder
+		b.Grow(len(encoded) + (len(data)-1)*len(opts.Separator))
+		for i := 0; i < len(encoded); i += 2 {
+			if i > 0 {
+				b.WriteString(opts.Separator)
+			}
+			b.WriteString(encoded[i : i+2])
+		}
+		encoded = b.String()
+	}
+
+	return opts.Prefix + encoded
+}
+
+// Dump returns a hex dump of data in the format of hexdump -C, with offsets
+// and the printable ASCII characters of every 16 bytes
+func Dump(data []byte) string {
+	return hex.Dump(data)
+}
+
+// Decode decodes hex to bytes. Case, whitespace, a leading 0x and the ':' and
+// '-' separators are ignored.
+func Decode(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if len(encoded) >= 2 && encoded[0] == '0' && (encoded[1] == 'x' || encoded[1] == 'X') {
+		encoded = encoded[2:]
+	}
+
+	encoded = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == ':' || r == '-' {
+			return -1
+		}
+		return r
+	}, encoded)
+
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex input: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,99 @@
+package hexutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	require.Equal(t, "", Encode(nil))
+	require.Equal(t, "deadbeef", Encode([]byte{0xde, 0xad, 0xbe, 0xef}))
+}
+
+func TestFormat(t *testing.T) {
+	data := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		opts     FormatOptions
+		expected string
+	}{
+		{name: "default", data: data, expected: "deadbeef"},
+		{name: "upper", data: data, opts: FormatOptions{Upper: true}, expected: "DEADBEEF"},
+		{name: "colon separated", data: data, opts: FormatOptions{Upper: true, Separator: ":"}, expected: "DE:AD:BE:EF"},
+		{name: "space separated", data: data, opts: FormatOptions{Separator: " "}, expected: "de ad be ef"},
+		{name: "prefix", data: data, opts: FormatOptions{Prefix: "0x"}, expected: "0xdeadbeef"},
+		{name: "single byte with separator", data: []byte{0x0a}, opts: FormatOptions{Separator: ":"}, expected: "0a"},
+		{name: "empty", data: nil, opts: FormatOptions{Separator: ":", Prefix: "0x"}, expected: "0x"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Format(tc.data, tc.opts))
+		})
+	}
+}
+
+func TestDump(t *testing.T) {
+	dump := Dump([]byte("hello, world"))
+	require.Equal(t, "00000000  68 65 6c 6c 6f 2c 20 77  6f 72 6c 64              |hello, world|\n", dump)
+}
+
+func TestDecode(t *testing.T) {
+	expected := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "lowercase", input: "deadbeef"},
+		{name: "uppercase", input: "DEADBEEF"},
+		{name: "prefix", input: "0xdeadbeef"},
+		{name: "uppercase prefix", input: "0XDEADBEEF"},
+		{name: "colons", input: "DE:AD:BE:EF"},
+		{name: "hyphens", input: "de-ad-be-ef"},
+		{name: "whitespace", input: " de ad\nbe\tef \n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := Decode(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, expected, decoded)
+		})
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "odd length", input: "abc"},
+		{name: "invalid character", input: "zz"},
+		{name: "prefix only in the middle", input: "de0xad"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid hex input")
+		})
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	data := []byte{0x00, 0x01, 0x7f, 0x80, 0xff}
+	for _, opts := range []FormatOptions{
+		{},
+		{Upper: true, Separator: ":"},
+		{Separator: " ", Prefix: "0x"},
+	} {
+		decoded, err := Decode(Format(data, opts))
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+}
@@ -0,0 +1,88 @@
+package hexutil
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io"
+)
+
+// NewEncoder returns a stream encoder that writes lowercase hex of the data
+// written to it to w
+func NewEncoder(w io.Writer) io.Writer {
+	return hex.NewEncoder(w)
+}
+
+// NewDumper returns a stream writer that writes a hex dump of the data
+// written to it to w, in the format of Dump. The caller must Close the dumper
+// to write the final partial line.
+func NewDumper(w io.Writer) io.WriteCloser {
+	return hex.Dumper(w)
+}
+
+// NewDecoder returns a stream decoder that reads hex from r. Like Decode, it
+// ignores case, whitespace, a leading 0x and the ':' and '-' separators.
+func NewDecoder(r io.Reader) io.Reader {
+	return hex.NewDecoder(&separatorSkipper{r: bufio.NewReader(r), start: true})
+}
+
+// separatorSkipper drops whitespace, separators and a leading 0x from hex input
+type separatorSkipper struct {
+	r     *bufio.Reader
+	start bool
+}
+
+func (s *separatorSkipper) Read(p []byte) (int, error) {
+	if s.start {
+		s.start = false
+		if err := s.skipPrefix(); err != nil {
+			return 0, err
+		}
+	}
+
+	for {
+		n, err := s.r.Read(p)
+		kept := 0
+		for _, b := range p[:n] {
+			if !isSeparator(b) {
+				p[kept] = b
+				kept++
+			}
+		}
+		// Avoid returning 0, nil when a read was all separators
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+// skipPrefix discards leading whitespace and a 0x or 0X prefix
+func (s *separatorSkipper) skipPrefix() error {
+	for {
+		b, err := s.r.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if isSeparator(b) {
+			continue
+		}
+		if b == '0' {
+			if next, err := s.r.Peek(1); err == nil && (next[0] == 'x' || next[0] == 'X') {
+				s.r.Discard(1)
+				return nil
+			}
+		}
+		return s.r.UnreadByte()
+	}
+}
+
+func isSeparator(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\r', '\f', '\v', ':', '-':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,52 @@
+package hexutil
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewEncoder(&buf).Write([]byte{0xde, 0xad, 0xbe, 0xef})
+	require.NoError(t, err)
+	require.Equal(t, "deadbeef", buf.String())
+}
+
+func TestNewDumper(t *testing.T) {
+	var buf bytes.Buffer
+	dumper := NewDumper(&buf)
+	_, err := dumper.Write([]byte("hello, "))
+	require.NoError(t, err)
+	_, err = dumper.Write([]byte("world"))
+	require.NoError(t, err)
+	require.NoError(t, dumper.Close())
+
+	require.Equal(t, Dump([]byte("hello, world")), buf.String())
+}
+
+func TestNewDecoder(t *testing.T) {
+	for _, input := range []string{"deadbeef", "0xDEADBEEF", "  0xde:ad:be:ef\n", "de-ad be\nef"} {
+		t.Run(input, func(t *testing.T) {
+			decoded, err := io.ReadAll(NewDecoder(strings.NewReader(input)))
+			require.NoError(t, err)
+			require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, decoded)
+		})
+	}
+
+	// A lone zero is not a prefix
+	_, err := io.ReadAll(NewDecoder(strings.NewReader("0")))
+	require.Error(t, err)
+
+	decoded, err := io.ReadAll(NewDecoder(strings.NewReader("")))
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+}
+
+func TestNewDecoder_Invalid(t *testing.T) {
+	_, err := io.ReadAll(NewDecoder(strings.NewReader("zz")))
+	require.Error(t, err)
+}
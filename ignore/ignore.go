@@ -0,0 +1,240 @@
+// Package ignore implements gitignore-style path matching so that every toolshed
+// feature that walks a directory tree excludes files the same way git does.
+//
+// Supported syntax follows gitignore(5):
+//   - Blank lines and lines starting with '#' are ignored ('\#' matches a literal '#')
+//   - A leading '!' negates the pattern, re-including a previously excluded path
+//   - A trailing '/' only matches directories
+//   - A pattern containing a '/' (other than a trailing one) is anchored to the root;
+//     otherwise it matches a name at any depth
+//   - '*', '?' and '[...]' match within a single path segment
+//   - '**' matches any number of segments ("**/foo", "foo/**", "a/**/b")
+//
+// As in git, a path inside an excluded directory cannot be re-included by a
+// negated pattern.
+//
+// Example usage:
+//
+//	m := ignore.New("*.log", "build/", "!important.log")
+//
+//	m.Match("debug.log", false)      // true
+//	m.Match("important.log", false)  // false
+//	m.Match("build/out.bin", false)  // true
+package ignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Matcher matches paths against an ordered list of gitignore-style patterns.
+// The zero value matches nothing. A Matcher is safe for concurrent use once
+// all patterns have been added.
+type Matcher struct {
+	rules []rule
+}
+
+// rule is a single parsed pattern.
+type rule struct {
+	pattern  string
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// New creates a Matcher from the given pattern lines.
+func New(patterns ...string) *Matcher {
+	m := &Matcher{}
+	for _, p := range patterns {
+		m.Add(p)
+	}
+	return m
+}
+
+// Parse reads gitignore-style patterns, one per line, from r.
+func Parse(r io.Reader) (*Matcher, error) {
+	m := &Matcher{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m.Add(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read patterns: %w", err)
+	}
+	return m, nil
+}
+
+// ParseFile reads gitignore-style patterns from the file at path.
+func ParseFile(path string) (*Matcher, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ignore file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Add parses a single pattern line and appends it to the matcher. Comments,
+// blank lines and malformed patterns are silently skipped, as git does.
+func (m *Matcher) Add(line string) {
+	line = strings.TrimSuffix(line, "\r")
+	line = trimTrailingSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+
+	r := rule{pattern: line}
+
+	switch {
+	case strings.HasPrefix(line, "!"):
+		r.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return
+	}
+
+	// A slash anywhere but the end anchors the pattern to the root
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	segments := strings.Split(line, "/")
+	if !anchored && segments[0] != "**" {
+		segments = append([]string{"**"}, segments...)
+	}
+
+	for _, seg := range segments {
+		if seg == "**" {
+			continue
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return
+		}
+	}
+
+	r.segments = segments
+	m.rules = append(m.rules, r)
+}
+
+// Len returns the number of patterns in the matcher.
+func (m *Matcher) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.rules)
+}
+
+// Patterns returns the original pattern lines in the order they were added.
+func (m *Matcher) Patterns() []string {
+	if m == nil {
+		return nil
+	}
+	patterns := make([]string, len(m.rules))
+	for i, r := range m.rules {
+		patterns[i] = r.pattern
+	}
+	return patterns
+}
+
+// Match reports whether the path, relative to the root the patterns apply to,
+// is ignored. isDir indicates whether the path refers to a directory. Both
+// '/' and the OS path separator are accepted.
+func (m *Matcher) Match(p string, isDir bool) bool {
+	if m == nil || len(m.rules) == 0 {
+		return false
+	}
+
+	segments := splitPath(p)
+	if len(segments) == 0 {
+		return false
+	}
+
+	// A path inside an ignored directory is always ignored
+	for i := 1; i < len(segments); i++ {
+		if m.matchSegments(segments[:i], true) {
+			return true
+		}
+	}
+
+	return m.matchSegments(segments, isDir)
+}
+
+// matchSegments applies all rules in order; the last matching rule wins.
+func (m *Matcher) matchSegments(segments []string, isDir bool) bool {
+	ignored := false
+	for _, r := range m.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if matchGlob(r.segments, segments) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// matchGlob matches path segments against pattern segments, where "**" matches
+// zero or more whole segments.
+func matchGlob(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				// A trailing "/**" matches everything inside, but not the directory itself
+				return len(segments) > 0
+			}
+			for i := 0; i <= len(segments); i++ {
+				if matchGlob(rest, segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// splitPath normalises p to slash-separated, root-relative segments.
+func splitPath(p string) []string {
+	p = filepath.ToSlash(p)
+	var segments []string
+	for _, seg := range strings.Split(p, "/") {
+		if seg == "" || seg == "." {
+			continue
+		}
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+// trimTrailingSpace removes unescaped trailing spaces from a pattern line.
+func trimTrailingSpace(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+	if strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-2] + " "
+	}
+	return line
+}
@@ -0,0 +1,123 @@
+package ignore_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/ignore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch_Patterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{"basename any depth", []string{"*.log"}, "a/b/debug.log", false, true},
+		{"basename root", []string{"*.log"}, "debug.log", false, true},
+		{"no match", []string{"*.log"}, "debug.txt", false, false},
+		{"dir only matches dir", []string{"build/"}, "build", true, true},
+		{"dir only skips file", []string{"build/"}, "build", false, false},
+		{"dir only nested", []string{"build/"}, "src/build", true, true},
+		{"file inside ignored dir", []string{"build/"}, "build/out/app.bin", false, true},
+		{"anchored leading slash", []string{"/todo.txt"}, "todo.txt", false, true},
+		{"anchored leading slash nested", []string{"/todo.txt"}, "docs/todo.txt", false, false},
+		{"anchored middle slash", []string{"doc/frotz"}, "doc/frotz", false, true},
+		{"anchored middle slash nested", []string{"doc/frotz"}, "a/doc/frotz", false, false},
+		{"leading double star", []string{"**/foo"}, "x/y/foo", false, true},
+		{"leading double star root", []string{"**/foo"}, "foo", false, true},
+		{"trailing double star", []string{"abc/**"}, "abc/x/y", false, true},
+		{"trailing double star not dir itself", []string{"abc/**"}, "abc", true, false},
+		{"middle double star", []string{"a/**/b"}, "a/x/y/b", false, true},
+		{"middle double star zero", []string{"a/**/b"}, "a/b", false, true},
+		{"question mark", []string{"file?.txt"}, "file1.txt", false, true},
+		{"character class", []string{"file[0-9].txt"}, "filea.txt", false, false},
+		{"star does not cross slash", []string{"a/*.txt"}, "a/b/c.txt", false, false},
+		{"escaped hash", []string{`\#notes`}, "#notes", false, true},
+		{"escaped bang", []string{`\!important`}, "!important", false, true},
+		{"windows separators", []string{"build/"}, `build\out.bin`, false, filepath.Separator == '\\'},
+		{"dot prefix", []string{"*.tmp"}, "./a.tmp", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ignore.New(tt.patterns...)
+			require.Equal(t, tt.expected, m.Match(tt.path, tt.isDir))
+		})
+	}
+}
+
+func TestMatch_Negation(t *testing.T) {
+	m := ignore.New("*.log", "!important.log")
+
+	require.True(t, m.Match("debug.log", false))
+	require.False(t, m.Match("important.log", false))
+	require.False(t, m.Match("logs/important.log", false))
+}
+
+func TestMatch_LastRuleWins(t *testing.T) {
+	m := ignore.New("!keep.txt", "*.txt")
+	require.True(t, m.Match("keep.txt", false))
+}
+
+func TestMatch_NegationCannotReincludeInsideIgnoredDir(t *testing.T) {
+	m := ignore.New("vendor/", "!vendor/keep.go")
+	require.True(t, m.Match("vendor/keep.go", false))
+}
+
+func TestMatch_ReincludeWithDirectoryContentsPattern(t *testing.T) {
+	// Ignoring the contents rather than the directory allows re-inclusion
+	m := ignore.New("vendor/*", "!vendor/keep.go")
+	require.False(t, m.Match("vendor/keep.go", false))
+	require.True(t, m.Match("vendor/other.go", false))
+}
+
+func TestMatch_NilAndEmpty(t *testing.T) {
+	var m *ignore.Matcher
+	require.False(t, m.Match("anything", false))
+	require.Zero(t, m.Len())
+
+	require.False(t, ignore.New().Match("anything", false))
+	require.False(t, ignore.New("*").Match("", true))
+}
+
+func TestParse_CommentsAndBlankLines(t *testing.T) {
+	input := strings.Join([]string{
+		"# comment",
+		"",
+		"*.o   ",
+		"   ",
+		"!keep.o",
+		"trailing\\ ",
+		"bad[",
+	}, "\n")
+
+	m, err := ignore.Parse(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, []string{"*.o", "!keep.o", "trailing "}, m.Patterns())
+	require.True(t, m.Match("main.o", false))
+	require.False(t, m.Match("keep.o", false))
+	require.True(t, m.Match("trailing ", false))
+}
+
+func TestParseFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitignore")
+	require.NoError(t, os.WriteFile(path, []byte("node_modules/\r\n*.swp\r\n"), 0644))
+
+	m, err := ignore.ParseFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, m.Len())
+	require.True(t, m.Match("web/node_modules/react/index.js", false))
+	require.True(t, m.Match(".main.go.swp", false))
+}
+
+func TestParseFile_Missing(t *testing.T) {
+	_, err := ignore.ParseFile("/nonexistent/.gitignore")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to open ignore file")
+}
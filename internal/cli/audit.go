@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/bilte-co/toolshed/audit"
+)
+
+// AuditCmd represents the audit command group
+type AuditCmd struct {
+	Verify AuditVerifyCmd `cmd:"" help:"Verify that an audit log has not been modified or truncated"`
+}
+
+// AuditVerifyCmd checks the hash chain of an audit log
+type AuditVerifyCmd struct {
+	File          string `arg:"" help:"Audit log file (use '-' to read from stdin)"`
+	Key           string `env:"TOOLSHED_AUDIT_KEY" help:"HMAC key the log was written with"`
+	Algorithm     string `short:"a" default:"sha256" help:"Hash algorithm the log was written with"`
+	Head          string `help:"Fail unless the log contains this head hash from an earlier verification, to detect truncation"`
+	RequireSealed bool   `name:"require-sealed" help:"Fail if the log does not end with a seal, as when it was truncated"`
+	JSON          bool   `long:"json" help:"Output as JSON"`
+}
+
+// auditVerification is the JSON output of the audit verify command
+type auditVerification struct {
+	Valid   bool   `json:"valid"`
+	Entries int    `json:"entries"`
+	Head    string `json:"head,omitempty"`
+	Sealed  bool   `json:"sealed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Run executes the audit verify command
+func (cmd *AuditVerifyCmd) Run(ctx *CLIContext) error {
+	opts := &audit.Options{Key: []byte(cmd.Key), Algorithm: cmd.Algorithm, Head: cmd.Head}
+	ctx.Logger.Debug("Verifying audit log", "file", cmd.File, "algorithm", cmd.Algorithm, "hmac", cmd.Key != "")
+
+	var result *audit.Result
+	var err error
+	if cmd.File == "-" {
+		data, readErr := readStdin()
+		if readErr != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", readErr)
+			return fmt.Errorf("failed to read from stdin: %w", readErr)
+		}
+		result, err = audit.Verify(bytes.NewReader(data), opts)
+	} else {
+		result, err = audit.VerifyFile(cmd.File, opts)
+	}
+	if err != nil && !errors.Is(err, audit.ErrTampered) {
+		ctx.Logger.Error("Failed to verify audit log", "error", err)
+		return err
+	}
+
+	report := auditVerification{Valid: err == nil}
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		report.Entries, report.Head, report.Sealed = result.Entries, result.Head, result.Sealed
+	}
+
+	if cmd.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			ctx.Logger.Error("Failed to encode verification", "error", err)
+			return fmt.Errorf("failed to encode verification: %w", err)
+		}
+	} else {
+		cmd.printReport(report)
+	}
+
+	// Exit with non-zero code when the log fails verification
+	if !report.Valid || (cmd.RequireSealed && !report.Sealed) {
+		ExitFunc(1)
+	}
+	return nil
+}
+
+// printReport prints the verification in human-readable form
+func (cmd *AuditVerifyCmd) printReport(report auditVerification) {
+	if !report.Valid {
+		fmt.Printf("✗ %s\n", report.Error)
+		return
+	}
+
+	fmt.Printf("✓ %d entries verified\n", report.Entries)
+	if report.Head != "" {
+		fmt.Printf("Head: %s\n", report.Head)
+	}
+	if !report.Sealed {
+		fmt.Println("⚠ Log is not sealed: it is still being written or was truncated")
+	}
+}
@@ -0,0 +1,199 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/audit"
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAuditLog writes an audit log of two entries, followed by a seal if
+// sealed is set
+func writeAuditLog(t *testing.T, key string, sealed bool) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	logger, err := audit.NewLogger(file, &audit.Options{Key: []byte(key)})
+	require.NoError(t, err)
+	_, err = logger.Log("alice", "user.create", map[string]any{"user_id": 1})
+	require.NoError(t, err)
+	_, err = logger.Log("alice", "user.delete", map[string]any{"user_id": 1})
+	require.NoError(t, err)
+	if sealed {
+		require.NoError(t, logger.Close())
+	}
+	return path
+}
+
+// captureExit replaces cli.ExitFunc for the test and returns the exit code
+func captureExit(t *testing.T) *int {
+	t.Helper()
+
+	oldExit := cli.ExitFunc
+	exitCode := 0
+	cli.ExitFunc = func(code int) { exitCode = code }
+	t.Cleanup(func() { cli.ExitFunc = oldExit })
+	return &exitCode
+}
+
+func TestAuditVerifyCmd_Valid(t *testing.T) {
+	exitCode := captureExit(t)
+	cmd := &cli.AuditVerifyCmd{File: writeAuditLog(t, "secret", true), Key: "secret", Algorithm: "sha256"}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(createTestContext(t)))
+	})
+
+	require.Equal(t, 0, *exitCode)
+	require.Contains(t, output, "✓ 3 entries verified")
+	require.Regexp(t, `Head: [0-9a-f]{64}`, output)
+	require.NotContains(t, output, "not sealed")
+}
+
+func TestAuditVerifyCmd_Tampered(t *testing.T) {
+	exitCode := captureExit(t)
+	path := writeAuditLog(t, "secret", true)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), "user.delete", "user.view", 1)), 0o600))
+
+	cmd := &cli.AuditVerifyCmd{File: path, Key: "secret", Algorithm: "sha256"}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(createTestContext(t)))
+	})
+
+	require.Equal(t, 1, *exitCode)
+	require.Contains(t, output, "✗ audit log tampered: line 2: hash mismatch")
+}
+
+func TestAuditVerifyCmd_WrongKey(t *testing.T) {
+	exitCode := captureExit(t)
+	cmd := &cli.AuditVerifyCmd{File: writeAuditLog(t, "secret", true), Key: "guess", Algorithm: "sha256"}
+
+	captureStdout(t, func() {
+		require.NoError(t, cmd.Run(createTestContext(t)))
+	})
+	require.Equal(t, 1, *exitCode)
+}
+
+func TestAuditVerifyCmd_Unsealed(t *testing.T) {
+	path := writeAuditLog(t, "", false)
+
+	t.Run("warns", func(t *testing.T) {
+		exitCode := captureExit(t)
+		cmd := &cli.AuditVerifyCmd{File: path, Algorithm: "sha256"}
+		output := captureStdout(t, func() {
+			require.NoError(t, cmd.Run(createTestContext(t)))
+		})
+		require.Equal(t, 0, *exitCode)
+		require.Contains(t, output, "✓ 2 entries verified")
+		require.Contains(t, output, "⚠ Log is not sealed")
+	})
+
+	t.Run("require sealed", func(t *testing.T) {
+		exitCode := captureExit(t)
+		cmd := &cli.AuditVerifyCmd{File: path, Algorithm: "sha256", RequireSealed: true}
+		captureStdout(t, func() {
+			require.NoError(t, cmd.Run(createTestContext(t)))
+		})
+		require.Equal(t, 1, *exitCode)
+	})
+}
+
+func TestAuditVerifyCmd_JSON(t *testing.T) {
+	captureExit(t)
+	cmd := &cli.AuditVerifyCmd{File: writeAuditLog(t, "secret", true), Key: "secret", Algorithm: "sha256", JSON: true}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(createTestContext(t)))
+	})
+
+	var report map[string]any
+	require.NoError(t, json.Unmarshal([]byte(output), &report))
+	require.Equal(t, true, report["valid"])
+	require.EqualValues(t, 3, report["entries"])
+	require.Equal(t, true, report["sealed"])
+	require.Len(t, report["head"], 64)
+}
+
+func TestAuditVerifyCmd_Stdin(t *testing.T) {
+	exitCode := captureExit(t)
+	data, err := os.ReadFile(writeAuditLog(t, "", true))
+	require.NoError(t, err)
+	defer replaceStdin(t, string(data))()
+
+	cmd := &cli.AuditVerifyCmd{File: "-", Algorithm: "sha256"}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(createTestContext(t)))
+	})
+
+	require.Equal(t, 0, *exitCode)
+	require.Contains(t, output, "✓ 3 entries verified")
+}
+
+func TestAuditVerifyCmd_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  cli.AuditVerifyCmd
+		want string
+	}{
+		{"missing file", cli.AuditVerifyCmd{File: filepath.Join(t.TempDir(), "missing.log"), Algorithm: "sha256"}, "failed to open audit log"},
+		{"unsupported algorithm", cli.AuditVerifyCmd{File: writeAuditLog(t, "", true), Algorithm: "crc32"}, "unsupported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Run(createTestContext(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestAuditVerifyCmd_Head(t *testing.T) {
+	path := writeAuditLog(t, "secret", true)
+	result, err := audit.VerifyFile(path, &audit.Options{Key: []byte("secret")})
+	require.NoError(t, err)
+
+	// Append a second session, then cut the log back to the first seal
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	logger, err := audit.Open(path, &audit.Options{Key: []byte("secret")})
+	require.NoError(t, err)
+	_, err = logger.Log("bob", "user.create", nil)
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+	latest, err := audit.VerifyFile(path, &audit.Options{Key: []byte("secret")})
+	require.NoError(t, err)
+
+	t.Run("matches", func(t *testing.T) {
+		exitCode := captureExit(t)
+		cmd := &cli.AuditVerifyCmd{File: path, Key: "secret", Algorithm: "sha256", Head: result.Head, RequireSealed: true}
+		output := captureStdout(t, func() {
+			require.NoError(t, cmd.Run(createTestContext(t)))
+		})
+		require.Equal(t, 0, *exitCode)
+		require.Contains(t, output, "✓ 5 entries verified")
+	})
+
+	t.Run("truncated to an earlier seal", func(t *testing.T) {
+		require.NoError(t, os.Truncate(path, info.Size()))
+
+		exitCode := captureExit(t)
+		cmd := &cli.AuditVerifyCmd{File: path, Key: "secret", Algorithm: "sha256", Head: latest.Head, RequireSealed: true}
+		output := captureStdout(t, func() {
+			require.NoError(t, cmd.Run(createTestContext(t)))
+		})
+		require.Equal(t, 1, *exitCode)
+		require.Contains(t, output, "✗ audit log tampered: expected head")
+	})
+}
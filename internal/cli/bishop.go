@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"crypto/md5"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
@@ -12,21 +14,19 @@ import (
 
 // BishopCmd represents the bishop command group
 type BishopCmd struct {
-	String BishopStringCmd `cmd:"" help:"Generate ASCII art from a string"`
-	File   BishopFileCmd   `cmd:"" help:"Generate ASCII art from a file"`
-	Stdin  BishopStdinCmd  `cmd:"" help:"Generate ASCII art from stdin"`
+	String      BishopStringCmd      `cmd:"" help:"Generate ASCII art from a string"`
+	File        BishopFileCmd        `cmd:"" help:"Generate ASCII art from a file"`
+	Stdin       BishopStdinCmd       `cmd:"" help:"Generate ASCII art from stdin"`
+	Fingerprint BishopFingerprintCmd `cmd:"" help:"Generate ssh-keygen style randomart from a key fingerprint"`
+	Compare     BishopCompareCmd     `cmd:"" help:"Compare two fingerprints side by side"`
 }
 
 // BishopStringCmd generates ASCII art from a string
 type BishopStringCmd struct {
-	Text      string `arg:"" help:"Text to generate ASCII art from"`
-	Width     int    `short:"w" default:"17" help:"Grid width (minimum 3)"`
-	Height    int    `short:"h" default:"9" help:"Grid height (minimum 3)"`
-	Symbols   string `short:"s" help:"Custom symbols for visit counts (e.g., ' .o+=')" `
-	StartChar string `long:"start" default:"S" help:"Start position marker"`
-	EndChar   string `long:"end" default:"E" help:"End position marker"`
-	NoBorder  bool   `short:"b" help:"Hide decorative border"`
-	Algorithm string `short:"a" default:"md5" help:"Hash algorithm (md5, sha256)"`
+	Text              string `arg:"" help:"Text to generate ASCII art from"`
+	BishopBoardFlags  `embed:""`
+	BishopOutputFlags `embed:""`
+	Algorithm         string `short:"a" default:"md5" help:"Hash algorithm (md5, sha256)"`
 }
 
 func (cmd *BishopStringCmd) Run(ctx *CLIContext) error {
@@ -36,66 +36,35 @@ func (cmd *BishopStringCmd) Run(ctx *CLIContext) error {
 		"algorithm", cmd.Algorithm,
 		"noborder", cmd.NoBorder)
 
-	opts, err := cmd.buildOptions()
+	opts, err := cmd.buildOptions(cmd.BishopOutputFlags)
 	if err != nil {
 		ctx.Logger.Error("Invalid options", "error", err)
 		return err
 	}
 
-	var result string
-	switch strings.ToLower(cmd.Algorithm) {
-	case "md5":
-		result = bishop.GenerateFromString(cmd.Text, opts)
-	case "sha256":
-		result = bishop.GenerateFromStringSHA256(cmd.Text, opts)
-	default:
+	digest, err := bishopDigest([]byte(cmd.Text), cmd.Algorithm, false)
+	if err != nil {
 		ctx.Logger.Error("Invalid algorithm", "algorithm", cmd.Algorithm)
-		return fmt.Errorf("invalid algorithm '%s' (supported: md5, sha256)", cmd.Algorithm)
+		return err
 	}
 
-	fmt.Print(result)
+	board := bishop.NewBoard(opts)
+	board.Walk(digest)
+	if err := writeBishopArt(board, cmd.Format, cmd.Output); err != nil {
+		ctx.Logger.Error("Failed to write bishop art", "error", err)
+		return err
+	}
 	ctx.Logger.Info("Bishop art generated successfully")
 	return nil
 }
 
-func (cmd *BishopStringCmd) buildOptions() (*bishop.Options, error) {
-	opts := bishop.DefaultOptions()
-
-	if cmd.Width > 0 {
-		opts.Width = cmd.Width
-	}
-	if cmd.Height > 0 {
-		opts.Height = cmd.Height
-	}
-
-	if cmd.Symbols != "" {
-		opts.Symbols = []rune(cmd.Symbols)
-	}
-
-	if len(cmd.StartChar) > 0 {
-		opts.StartChar = []rune(cmd.StartChar)[0]
-	}
-
-	if len(cmd.EndChar) > 0 {
-		opts.EndChar = []rune(cmd.EndChar)[0]
-	}
-
-	opts.ShowBorder = !cmd.NoBorder
-
-	return opts, nil
-}
-
 // BishopFileCmd generates ASCII art from a file
 type BishopFileCmd struct {
-	Path      string `arg:"" help:"File path to read from" type:"existingfile"`
-	Width     int    `short:"w" default:"17" help:"Grid width (minimum 3)"`
-	Height    int    `short:"h" default:"9" help:"Grid height (minimum 3)"`
-	Symbols   string `short:"s" help:"Custom symbols for visit counts (e.g., ' .o+=')" `
-	StartChar string `long:"start" default:"S" help:"Start position marker"`
-	EndChar   string `long:"end" default:"E" help:"End position marker"`
-	NoBorder  bool   `short:"b" help:"Hide decorative border"`
-	Algorithm string `short:"a" default:"md5" help:"Hash algorithm (md5, sha256)"`
-	Raw       bool   `short:"r" help:"Use raw file bytes instead of hashing"`
+	Path              string `arg:"" help:"File path to read from" type:"existingfile"`
+	BishopBoardFlags  `embed:""`
+	BishopOutputFlags `embed:""`
+	Algorithm         string `short:"a" default:"md5" help:"Hash algorithm (md5, sha256)"`
+	Raw               bool   `short:"r" help:"Use raw file bytes instead of hashing"`
 }
 
 func (cmd *BishopFileCmd) Run(ctx *CLIContext) error {
@@ -113,71 +82,34 @@ func (cmd *BishopFileCmd) Run(ctx *CLIContext) error {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
-	opts, err := cmd.buildOptions()
+	opts, err := cmd.buildOptions(cmd.BishopOutputFlags)
 	if err != nil {
 		ctx.Logger.Error("Invalid options", "error", err)
 		return err
 	}
 
-	var result string
-	if cmd.Raw {
-		// Use raw file bytes directly
-		result = bishop.GenerateFromBytes(data, opts)
-	} else {
-		// Hash the file content first
-		switch strings.ToLower(cmd.Algorithm) {
-		case "md5":
-			result = bishop.GenerateFromString(string(data), opts)
-		case "sha256":
-			result = bishop.GenerateFromStringSHA256(string(data), opts)
-		default:
-			ctx.Logger.Error("Invalid algorithm", "algorithm", cmd.Algorithm)
-			return fmt.Errorf("invalid algorithm '%s' (supported: md5, sha256)", cmd.Algorithm)
-		}
+	digest, err := bishopDigest(data, cmd.Algorithm, cmd.Raw)
+	if err != nil {
+		ctx.Logger.Error("Invalid algorithm", "algorithm", cmd.Algorithm)
+		return err
 	}
 
-	fmt.Print(result)
+	board := bishop.NewBoard(opts)
+	board.Walk(digest)
+	if err := writeBishopArt(board, cmd.Format, cmd.Output); err != nil {
+		ctx.Logger.Error("Failed to write bishop art", "error", err)
+		return err
+	}
 	ctx.Logger.Info("Bishop art generated successfully from file", "file", cmd.Path)
 	return nil
 }
 
-func (cmd *BishopFileCmd) buildOptions() (*bishop.Options, error) {
-	opts := bishop.DefaultOptions()
-
-	if cmd.Width > 0 {
-		opts.Width = cmd.Width
-	}
-	if cmd.Height > 0 {
-		opts.Height = cmd.Height
-	}
-
-	if cmd.Symbols != "" {
-		opts.Symbols = []rune(cmd.Symbols)
-	}
-
-	if len(cmd.StartChar) > 0 {
-		opts.StartChar = []rune(cmd.StartChar)[0]
-	}
-
-	if len(cmd.EndChar) > 0 {
-		opts.EndChar = []rune(cmd.EndChar)[0]
-	}
-
-	opts.ShowBorder = !cmd.NoBorder
-
-	return opts, nil
-}
-
 // BishopStdinCmd generates ASCII art from stdin
 type BishopStdinCmd struct {
-	Width     int    `short:"w" default:"17" help:"Grid width (minimum 3)"`
-	Height    int    `short:"h" default:"9" help:"Grid height (minimum 3)"`
-	Symbols   string `short:"s" help:"Custom symbols for visit counts (e.g., ' .o+=')" `
-	StartChar string `long:"start" default:"S" help:"Start position marker"`
-	EndChar   string `long:"end" default:"E" help:"End position marker"`
-	NoBorder  bool   `short:"b" help:"Hide decorative border"`
-	Algorithm string `short:"a" default:"md5" help:"Hash algorithm (md5, sha256)"`
-	Raw       bool   `short:"r" help:"Use raw bytes instead of hashing"`
+	BishopBoardFlags  `embed:""`
+	BishopOutputFlags `embed:""`
+	Algorithm         string `short:"a" default:"md5" help:"Hash algorithm (md5, sha256)"`
+	Raw               bool   `short:"r" help:"Use raw bytes instead of hashing"`
 }
 
 func (cmd *BishopStdinCmd) Run(ctx *CLIContext) error {
@@ -199,57 +131,313 @@ func (cmd *BishopStdinCmd) Run(ctx *CLIContext) error {
 		return fmt.Errorf("no data received from stdin")
 	}
 
-	opts, err := cmd.buildOptions()
+	opts, err := cmd.buildOptions(cmd.BishopOutputFlags)
 	if err != nil {
 		ctx.Logger.Error("Invalid options", "error", err)
 		return err
 	}
 
-	var result string
-	if cmd.Raw {
-		// Use raw bytes directly
-		result = bishop.GenerateFromBytes(data, opts)
-	} else {
-		// Hash the input first
-		switch strings.ToLower(cmd.Algorithm) {
-		case "md5":
-			result = bishop.GenerateFromString(string(data), opts)
-		case "sha256":
-			result = bishop.GenerateFromStringSHA256(string(data), opts)
-		default:
-			ctx.Logger.Error("Invalid algorithm", "algorithm", cmd.Algorithm)
-			return fmt.Errorf("invalid algorithm '%s' (supported: md5, sha256)", cmd.Algorithm)
-		}
+	digest, err := bishopDigest(data, cmd.Algorithm, cmd.Raw)
+	if err != nil {
+		ctx.Logger.Error("Invalid algorithm", "algorithm", cmd.Algorithm)
+		return err
 	}
 
-	fmt.Print(result)
+	board := bishop.NewBoard(opts)
+	board.Walk(digest)
+	if err := writeBishopArt(board, cmd.Format, cmd.Output); err != nil {
+		ctx.Logger.Error("Failed to write bishop art", "error", err)
+		return err
+	}
 	ctx.Logger.Info("Bishop art generated successfully from stdin")
 	return nil
 }
 
-func (cmd *BishopStdinCmd) buildOptions() (*bishop.Options, error) {
+// BishopFingerprintCmd generates randomart from the fingerprint of an SSH
+// public key, an SSHFP record or an X.509 certificate, like ssh-keygen -lv
+type BishopFingerprintCmd struct {
+	SSHKey            string `name:"ssh-key" help:"SSH public key or certificate file, as in ~/.ssh/id_ed25519.pub (use '-' for stdin)"`
+	SSHFP             string `name:"sshfp" help:"SSHFP DNS record, as printed by ssh-keygen -r (use '-' for stdin)"`
+	Cert              string `name:"cert" help:"X.509 certificate file in PEM or DER form (use '-' for stdin)"`
+	BishopBoardFlags  `embed:""`
+	BishopOutputFlags `embed:""`
+}
+
+// Validate checks that exactly one input is given
+func (cmd *BishopFingerprintCmd) Validate() error {
+	inputs := 0
+	for _, input := range []string{cmd.SSHKey, cmd.SSHFP, cmd.Cert} {
+		if input != "" {
+			inputs++
+		}
+	}
+	if inputs != 1 {
+		return fmt.Errorf("exactly one of --ssh-key, --sshfp or --cert is required")
+	}
+	return nil
+}
+
+func (cmd *BishopFingerprintCmd) Run(ctx *CLIContext) error {
+	ctx.Logger.Debug("Generating bishop art from fingerprint",
+		"width", cmd.Width,
+		"height", cmd.Height)
+
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid flags", "error", err)
+		return err
+	}
+
+	opts, err := cmd.buildOptions(cmd.BishopOutputFlags)
+	if err != nil {
+		ctx.Logger.Error("Invalid options", "error", err)
+		return err
+	}
+
+	fp, err := cmd.fingerprint()
+	if err != nil {
+		ctx.Logger.Error("Failed to read fingerprint", "error", err)
+		return err
+	}
+
+	if err := writeBishopArt(fp.Board(opts), cmd.Format, cmd.Output); err != nil {
+		ctx.Logger.Error("Failed to write bishop art", "error", err)
+		return err
+	}
+	ctx.Logger.Info("Bishop art generated successfully from fingerprint", "type", fp.Title(), "hash", fp.Hash)
+	return nil
+}
+
+// fingerprint reads and parses the given input
+func (cmd *BishopFingerprintCmd) fingerprint() (*bishop.Fingerprint, error) {
+	switch {
+	case cmd.SSHKey != "":
+		data, err := readInputFile(cmd.SSHKey)
+		if err != nil {
+			return nil, err
+		}
+		return bishop.FromSSHPublicKey(data)
+	case cmd.SSHFP != "":
+		record := cmd.SSHFP
+		if record == "-" {
+			data, err := readInputFile(record)
+			if err != nil {
+				return nil, err
+			}
+			record = string(data)
+		}
+		return bishop.FromSSHFP(record)
+	default:
+		data, err := readInputFile(cmd.Cert)
+		if err != nil {
+			return nil, err
+		}
+		return bishop.FromCertificate(data)
+	}
+}
+
+// BishopCompareCmd draws two fingerprints side by side and highlights the
+// cells where their boards differ
+type BishopCompareCmd struct {
+	Fingerprint1     string `arg:"" help:"First fingerprint (e.g. SHA256:..., MD5:aa:bb:..., hex) or SSH public key or certificate file"`
+	Fingerprint2     string `arg:"" help:"Second fingerprint (e.g. SHA256:..., MD5:aa:bb:..., hex) or SSH public key or certificate file"`
+	BishopBoardFlags `embed:""`
+}
+
+func (cmd *BishopCompareCmd) Run(ctx *CLIContext) error {
+	ctx.Logger.Debug("Comparing fingerprints",
+		"width", cmd.Width,
+		"height", cmd.Height)
+
+	opts, err := cmd.buildOptions(BishopOutputFlags{})
+	if err != nil {
+		ctx.Logger.Error("Invalid options", "error", err)
+		return err
+	}
+
+	fp1, err := resolveFingerprint(cmd.Fingerprint1)
+	if err != nil {
+		ctx.Logger.Error("Failed to read first fingerprint", "error", err)
+		return err
+	}
+	fp2, err := resolveFingerprint(cmd.Fingerprint2)
+	if err != nil {
+		ctx.Logger.Error("Failed to read second fingerprint", "error", err)
+		return err
+	}
+	if fp1.Hash != "" && fp2.Hash != "" && fp1.Hash != fp2.Hash {
+		err := fmt.Errorf("cannot compare %s and %s fingerprints", fp1.Hash, fp2.Hash)
+		ctx.Logger.Error("Incompatible fingerprints", "error", err)
+		return err
+	}
+
+	art, differences := bishop.Compare(fp1, fp2, opts)
+	fmt.Println(art)
+
+	if fp1.Equal(fp2) {
+		ctx.Logger.Info("Fingerprints match")
+		fmt.Println("✓ Fingerprints match")
+		return nil
+	}
+
+	ctx.Logger.Info("Fingerprints differ", "cells", differences)
+	fmt.Printf("✗ Fingerprints differ in %d of %d×%d cells\n", differences, opts.Width, opts.Height)
+
+	// Exit with non-zero code when the fingerprints differ
+	ExitFunc(1)
+	return nil
+}
+
+// resolveFingerprint parses a fingerprint, or reads it from an SSH public key
+// or X.509 certificate if arg names a file
+func resolveFingerprint(arg string) (*bishop.Fingerprint, error) {
+	info, err := os.Stat(arg)
+	if err != nil || info.IsDir() {
+		return bishop.ParseFingerprint(arg)
+	}
+
+	data, err := readInputFile(arg)
+	if err != nil {
+		return nil, err
+	}
+	if fp, err := bishop.FromSSHPublicKey(data); err == nil {
+		return fp, nil
+	}
+	fp, err := bishop.FromCertificate(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither an SSH public key nor a certificate: %w", arg, err)
+	}
+	return fp, nil
+}
+
+// readInputFile reads a file, or stdin if path is "-"
+func readInputFile(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := readStdin()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// bishopDigest returns the bytes for the bishop to walk: data itself if raw,
+// or its hash
+func bishopDigest(data []byte, algorithm string, raw bool) ([]byte, error) {
+	if raw {
+		return data, nil
+	}
+
+	switch strings.ToLower(algorithm) {
+	case "md5":
+		sum := md5.Sum(data)
+		return sum[:], nil
+	case "sha256":
+		sum := sha256.Sum256(data)
+		return sum[:], nil
+	default:
+		return nil, fmt.Errorf("invalid algorithm '%s' (supported: md5, sha256)", algorithm)
+	}
+}
+
+// writeBishopArt writes a walked board in the given format to the output
+// file, or to stdout if there is none
+func writeBishopArt(board *bishop.Board, format, output string) (err error) {
+	w, err := openEncodeOutput(output)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := w.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to write output file: %w", closeErr)
+		}
+	}()
+
+	switch strings.ToLower(format) {
+	case "svg":
+		return board.WriteSVG(w)
+	case "png":
+		return board.WritePNG(w)
+	}
+
+	art := board.Render()
+	if output != "" {
+		art += "\n"
+	}
+	if _, err := io.WriteString(w, art); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// BishopBoardFlags are the board flags shared by the bishop commands
+type BishopBoardFlags struct {
+	Width     int    `short:"w" default:"17" help:"Grid width (minimum 3)"`
+	Height    int    `short:"h" default:"9" help:"Grid height (minimum 3)"`
+	Symbols   string `short:"s" help:"Custom symbols for visit counts (e.g., ' .o+=')" `
+	Charset   string `short:"c" default:"openssh" help:"Symbol set for visit counts (openssh, ascii, blocks, digits, hex); --symbols overrides it"`
+	StartChar string `long:"start" default:"S" help:"Start position marker"`
+	EndChar   string `long:"end" default:"E" help:"End position marker"`
+	NoBorder  bool   `short:"b" help:"Hide decorative border"`
+	Color     bool   `help:"Color cells by visit count on terminals and in images, and differences when comparing (NO_COLOR disables, FORCE_COLOR forces)"`
+}
+
+// BishopOutputFlags are the flags of the bishop commands that write art
+type BishopOutputFlags struct {
+	Format string `short:"f" default:"text" enum:"text,svg,png" help:"Output format (text, svg, png)"`
+	Output string `short:"o" help:"Write the art to this file instead of stdout"`
+}
+
+// buildOptions converts the board flags to bishop options for art written
+// as out, using the defaults for unset flags
+func (flags *BishopBoardFlags) buildOptions(out BishopOutputFlags) (*bishop.Options, error) {
 	opts := bishop.DefaultOptions()
 
-	if cmd.Width > 0 {
-		opts.Width = cmd.Width
+	if flags.Width > 0 {
+		opts.Width = flags.Width
+	}
+	if flags.Height > 0 {
+		opts.Height = flags.Height
 	}
-	if cmd.Height > 0 {
-		opts.Height = cmd.Height
+	if err := ValidateDimensions(opts.Width, opts.Height); err != nil {
+		return nil, err
 	}
 
-	if cmd.Symbols != "" {
-		opts.Symbols = []rune(cmd.Symbols)
+	if flags.Charset != "" {
+		symbols, ok := bishop.Charset(flags.Charset)
+		if !ok {
+			return nil, fmt.Errorf("unknown charset '%s' (supported: %s)", flags.Charset, strings.Join(bishop.CharsetNames(), ", "))
+		}
+		opts.Symbols = symbols
+	}
+	if flags.Symbols != "" {
+		opts.Symbols = []rune(flags.Symbols)
 	}
 
-	if len(cmd.StartChar) > 0 {
-		opts.StartChar = []rune(cmd.StartChar)[0]
+	if len(flags.StartChar) > 0 {
+		opts.StartChar = []rune(flags.StartChar)[0]
 	}
 
-	if len(cmd.EndChar) > 0 {
-		opts.EndChar = []rune(cmd.EndChar)[0]
+	if len(flags.EndChar) > 0 {
+		opts.EndChar = []rune(flags.EndChar)[0]
 	}
 
-	opts.ShowBorder = !cmd.NoBorder
+	opts.ShowBorder = !flags.NoBorder
+
+	// Images are always colored on request; text only on terminals
+	switch strings.ToLower(out.Format) {
+	case "svg", "png":
+		opts.Color = flags.Color
+	case "", "text":
+		opts.Color = flags.Color && out.Output == "" && colorEnabled(os.Stdout)
+	default:
+		return nil, fmt.Errorf("unknown format '%s' (supported: text, svg, png)", out.Format)
+	}
 
 	return opts, nil
 }
@@ -2,6 +2,8 @@ package cli_test
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bilte-co/toolshed/internal/cli"
@@ -11,9 +13,11 @@ import (
 
 func TestBishopStringCmd_Basic(t *testing.T) {
 	cmd := &cli.BishopStringCmd{
-		Text:      "test",
-		Width:     7,
-		Height:    5,
+		Text: "test",
+		BishopBoardFlags: cli.BishopBoardFlags{
+			Width:  7,
+			Height: 5,
+		},
 		Algorithm: "md5",
 	}
 
@@ -24,13 +28,15 @@ func TestBishopStringCmd_Basic(t *testing.T) {
 
 func TestBishopStringCmd_CustomOptions(t *testing.T) {
 	cmd := &cli.BishopStringCmd{
-		Text:      "custom",
-		Width:     5,
-		Height:    3,
-		Symbols:   " .o+",
-		StartChar: "A",
-		EndChar:   "Z",
-		NoBorder:  true,
+		Text: "custom",
+		BishopBoardFlags: cli.BishopBoardFlags{
+			Width:     5,
+			Height:    3,
+			Symbols:   " .o+",
+			StartChar: "A",
+			EndChar:   "Z",
+			NoBorder:  true,
+		},
 		Algorithm: "sha256",
 	}
 
@@ -62,10 +68,12 @@ func TestBishopFileCmd_Temp(t *testing.T) {
 	tmpfile.Close()
 
 	cmd := &cli.BishopFileCmd{
-		Path:      tmpfile.Name(),
-		Width:     5,
-		Height:    3,
-		NoBorder:  true,
+		Path: tmpfile.Name(),
+		BishopBoardFlags: cli.BishopBoardFlags{
+			Width:    5,
+			Height:   3,
+			NoBorder: true,
+		},
 		Algorithm: "md5",
 	}
 
@@ -85,11 +93,13 @@ func TestBishopFileCmd_Raw(t *testing.T) {
 	tmpfile.Close()
 
 	cmd := &cli.BishopFileCmd{
-		Path:     tmpfile.Name(),
-		Width:    5,
-		Height:   3,
-		NoBorder: true,
-		Raw:      true,
+		Path: tmpfile.Name(),
+		BishopBoardFlags: cli.BishopBoardFlags{
+			Width:    5,
+			Height:   3,
+			NoBorder: true,
+		},
+		Raw: true,
 	}
 
 	ctx := createTestContext(t)
@@ -126,9 +136,11 @@ func TestBishopStdinCmd_Raw(t *testing.T) {
 	}()
 
 	cmd := &cli.BishopStdinCmd{
-		Width:     5,
-		Height:    3,
-		NoBorder:  true,
+		BishopBoardFlags: cli.BishopBoardFlags{
+			Width:    5,
+			Height:   3,
+			NoBorder: true,
+		},
 		Algorithm: "md5",
 	}
 
@@ -187,6 +199,310 @@ func TestParseCharacter(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestBishopStringCmd_Charset(t *testing.T) {
+	cmd := &cli.BishopStringCmd{
+		Text: "hello world",
+		BishopBoardFlags: cli.BishopBoardFlags{
+			Width:   11,
+			Height:  5,
+			Charset: "digits",
+		},
+		Algorithm: "md5",
+	}
+
+	ctx := createTestContext(t)
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+
+	lines := strings.Split(output, "\n")
+	require.Len(t, lines, 7)
+	for _, line := range lines[1:6] {
+		require.Regexp(t, `^\|[ 1-9SE]{11}\|$`, line)
+	}
+}
+
+func TestBishopStringCmd_SymbolsOverrideCharset(t *testing.T) {
+	cmd := &cli.BishopStringCmd{
+		Text: "hello world",
+		BishopBoardFlags: cli.BishopBoardFlags{
+			Charset:  "blocks",
+			Symbols:  "_x",
+			NoBorder: true,
+		},
+		Algorithm: "md5",
+	}
+
+	ctx := createTestContext(t)
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Regexp(t, `^[_xSE\n]+$`, output)
+}
+
+func TestBishopCmd_InvalidBoard(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  cli.BishopStringCmd
+		want string
+	}{
+		{"unknown charset", cli.BishopStringCmd{Text: "test", BishopBoardFlags: cli.BishopBoardFlags{Charset: "emoji"}, Algorithm: "md5"}, "unknown charset 'emoji' (supported: ascii, blocks, digits, hex, openssh)"},
+		{"narrow board", cli.BishopStringCmd{Text: "test", BishopBoardFlags: cli.BishopBoardFlags{Width: 2}, Algorithm: "md5"}, "width must be at least 3"},
+		{"tall board", cli.BishopStringCmd{Text: "test", BishopBoardFlags: cli.BishopBoardFlags{Height: 201}, Algorithm: "md5"}, "height too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Run(createTestContext(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+const bishopTestKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBxWa53YPLyL7M/gN91olxM67hxIZ0JeqZ3eRq4KPyxW test"
+
+func TestBishopFingerprintCmd_SSHKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "id_ed25519.pub")
+	require.NoError(t, os.WriteFile(path, []byte(bishopTestKey+"\n"), 0o644))
+
+	cmd := &cli.BishopFingerprintCmd{SSHKey: path}
+	ctx := createTestContext(t)
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+
+	// Same as ssh-keygen -lvf
+	lines := strings.Split(output, "\n")
+	require.Len(t, lines, 11)
+	require.Equal(t, "+--[ED25519 256]--+", lines[0])
+	require.Equal(t, "|     o =SB.. =   |", lines[5])
+	require.Equal(t, "+----[SHA256]-----+", lines[10])
+}
+
+func TestBishopFingerprintCmd_SSHFP(t *testing.T) {
+	cmd := &cli.BishopFingerprintCmd{
+		SSHFP: "host IN SSHFP 4 2 3179851d1a9b04bf3aac7341e7c69a907eaa17f4f97836450f9bceba371818a2",
+		BishopBoardFlags: cli.BishopBoardFlags{
+			Charset: "ascii",
+		},
+	}
+	ctx := createTestContext(t)
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+
+	lines := strings.Split(output, "\n")
+	require.Equal(t, "+----[ED25519]----+", lines[0])
+	require.Equal(t, "|     : =S*.. =   |", lines[5])
+}
+
+func TestBishopFingerprintCmd_SSHFPStdin(t *testing.T) {
+	restore := replaceStdin(t, "4 2 3179851d1a9b04bf3aac7341e7c69a907eaa17f4f97836450f9bceba371818a2\n")
+	defer restore()
+
+	cmd := &cli.BishopFingerprintCmd{SSHFP: "-"}
+	ctx := createTestContext(t)
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.True(t, strings.HasPrefix(output, "+----[ED25519]----+\n"))
+}
+
+func TestBishopFingerprintCmd_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0o644))
+
+	tests := []struct {
+		name string
+		cmd  cli.BishopFingerprintCmd
+		want string
+	}{
+		{"no input", cli.BishopFingerprintCmd{}, "exactly one of --ssh-key, --sshfp or --cert is required"},
+		{"two inputs", cli.BishopFingerprintCmd{SSHKey: path, Cert: path}, "exactly one of"},
+		{"missing file", cli.BishopFingerprintCmd{SSHKey: path + ".missing"}, "failed to read file"},
+		{"invalid certificate", cli.BishopFingerprintCmd{Cert: path}, "invalid fingerprint input"},
+		{"invalid record", cli.BishopFingerprintCmd{SSHFP: "4 2"}, "invalid fingerprint input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Run(createTestContext(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestBishopStringCmd_Color(t *testing.T) {
+	tests := []struct {
+		name       string
+		color      bool
+		noColor    string
+		forceColor string
+		colored    bool
+	}{
+		{"not a terminal", true, "", "", false},
+		{"forced", true, "", "1", true},
+		{"NO_COLOR wins", true, "1", "1", false},
+		{"flag not given", false, "", "1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("FORCE_COLOR", tt.forceColor)
+
+			cmd := &cli.BishopStringCmd{Text: "hello world", Algorithm: "md5", BishopBoardFlags: cli.BishopBoardFlags{Color: tt.color}}
+			ctx := createTestContext(t)
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+
+			require.Equal(t, tt.colored, strings.Contains(output, "\x1b[38;5;"), output)
+		})
+	}
+}
+
 func createTestContext(t *testing.T) *cli.CLIContext {
 	return testutil.NewTestContext()
 }
+
+func TestBishopCompareCmd_Match(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "id_ed25519.pub")
+	require.NoError(t, os.WriteFile(path, []byte(bishopTestKey+"\n"), 0o644))
+
+	oldExit := cli.ExitFunc
+	exitCode := 0
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	cmd := &cli.BishopCompareCmd{
+		Fingerprint1: path,
+		Fingerprint2: "SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKI",
+	}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(createTestContext(t)))
+	})
+
+	require.Equal(t, 0, exitCode)
+	require.Contains(t, output, "+--[ED25519 256]--+  +-----------------+  +-----[diff]------+")
+	require.Contains(t, output, "✓ Fingerprints match")
+}
+
+func TestBishopCompareCmd_Differ(t *testing.T) {
+	oldExit := cli.ExitFunc
+	exitCode := 0
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	cmd := &cli.BishopCompareCmd{
+		Fingerprint1: "MD5:6d:fe:76:ca:0b:8a:44:bb:a4:73:49:52:fc:de:6e:52",
+		Fingerprint2: "6d:fe:76:ca:0b:8a:44:bb:a4:73:49:52:fc:de:6e:53",
+	}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(createTestContext(t)))
+	})
+
+	require.Equal(t, 1, exitCode)
+	require.Contains(t, output, "*")
+	require.Regexp(t, `✗ Fingerprints differ in \d+ of 17×9 cells`, output)
+}
+
+func TestBishopCompareCmd_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  cli.BishopCompareCmd
+		want string
+	}{
+		{"invalid fingerprint", cli.BishopCompareCmd{Fingerprint1: "nope", Fingerprint2: "0102"}, "invalid fingerprint input"},
+		{
+			"different hashes",
+			cli.BishopCompareCmd{
+				Fingerprint1: "MD5:6d:fe:76:ca:0b:8a:44:bb:a4:73:49:52:fc:de:6e:52",
+				Fingerprint2: "SHA256:MXmFHRqbBL86rHNB58aakH6qF/T5eDZFD5vOujcYGKI",
+			},
+			"cannot compare MD5 and SHA256 fingerprints",
+		},
+		{"invalid board", cli.BishopCompareCmd{Fingerprint1: "0102", Fingerprint2: "0102", BishopBoardFlags: cli.BishopBoardFlags{Width: 500}}, "width too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Run(createTestContext(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestBishopCmd_Formats(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "id_ed25519.pub")
+	require.NoError(t, os.WriteFile(keyPath, []byte(bishopTestKey+"\n"), 0o644))
+
+	tests := []struct {
+		name   string
+		run    func(output string) error
+		prefix string
+	}{
+		{
+			"text",
+			func(output string) error {
+				cmd := &cli.BishopStringCmd{Text: "hello world", Algorithm: "md5", BishopOutputFlags: cli.BishopOutputFlags{Format: "text", Output: output}}
+				return cmd.Run(createTestContext(t))
+			},
+			"+-----------------+\n",
+		},
+		{
+			"svg",
+			func(output string) error {
+				cmd := &cli.BishopFingerprintCmd{SSHKey: keyPath, BishopOutputFlags: cli.BishopOutputFlags{Format: "svg", Output: output}}
+				return cmd.Run(createTestContext(t))
+			},
+			"<svg ",
+		},
+		{
+			"png",
+			func(output string) error {
+				cmd := &cli.BishopFileCmd{Path: keyPath, Algorithm: "sha256", BishopOutputFlags: cli.BishopOutputFlags{Format: "png", Output: output}}
+				return cmd.Run(createTestContext(t))
+			},
+			"\x89PNG",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(dir, "art."+tt.name)
+			stdout := captureStdout(t, func() {
+				require.NoError(t, tt.run(output))
+			})
+			require.Empty(t, stdout)
+
+			data, err := os.ReadFile(output)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(string(data), tt.prefix))
+		})
+	}
+}
+
+func TestBishopStringCmd_SVGToStdout(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	cmd := &cli.BishopStringCmd{Text: "hello world", Algorithm: "md5", BishopBoardFlags: cli.BishopBoardFlags{Color: true}, BishopOutputFlags: cli.BishopOutputFlags{Format: "svg"}}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(createTestContext(t)))
+	})
+
+	// Images are colored on request whatever the terminal settings
+	require.True(t, strings.HasPrefix(output, "<svg "))
+	require.Contains(t, output, `font-weight="bold"`)
+}
+
+func TestBishopStringCmd_InvalidFormat(t *testing.T) {
+	cmd := &cli.BishopStringCmd{Text: "hello world", Algorithm: "md5", BishopOutputFlags: cli.BishopOutputFlags{Format: "gif"}}
+	err := cmd.Run(createTestContext(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown format 'gif'")
+}
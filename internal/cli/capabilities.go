@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/bilte-co/toolshed/buildinfo"
+	"github.com/bilte-co/toolshed/hash"
+)
+
+// supportedCiphers lists the ciphers available through the aes commands
+var supportedCiphers = []string{"aes-128-gcm", "aes-192-gcm", "aes-256-gcm"}
+
+// CapabilitiesCmd lists the features compiled into the binary so scripts can
+// feature-detect instead of parsing version strings
+type CapabilitiesCmd struct {
+	JSON bool `long:"json" help:"Output capabilities as JSON"`
+}
+
+// capabilities is the machine-readable inventory printed by the capabilities command
+type capabilities struct {
+	Version        string   `json:"version"`
+	Commit         string   `json:"commit"`
+	Date           string   `json:"date"`
+	GoVersion      string   `json:"go_version"`
+	OS             string   `json:"os"`
+	Arch           string   `json:"arch"`
+	CGO            bool     `json:"cgo"`
+	BuildTags      []string `json:"build_tags"`
+	Commands       []string `json:"commands"`
+	HashAlgorithms []string `json:"hash_algorithms"`
+	Encodings      []string `json:"encodings"`
+	Ciphers        []string `json:"ciphers"`
+}
+
+func (cmd *CapabilitiesCmd) Run(ctx *CLIContext, kctx *kong.Context, vars kong.Vars) error {
+	ctx.Logger.Debug("Collecting capabilities")
+
+	caps := capabilities{
+		Version:        vars["version"],
+		Commit:         vars["commit"],
+		Date:           vars["date"],
+		GoVersion:      runtime.Version(),
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+		BuildTags:      []string{},
+		Commands:       []string{},
+		HashAlgorithms: hash.Algorithms(),
+		Encodings:      supportedEncodings,
+		Ciphers:        supportedCiphers,
+	}
+
+	if info, err := buildinfo.Read(); err == nil {
+		caps.BuildTags = info.Tags()
+		caps.CGO = info.CGO()
+	}
+
+	if kctx != nil {
+		for _, node := range kctx.Model.Leaves(true) {
+			caps.Commands = append(caps.Commands, node.Path())
+		}
+		sort.Strings(caps.Commands)
+	}
+
+	if cmd.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(caps); err != nil {
+			ctx.Logger.Error("Failed to encode capabilities", "error", err)
+			return fmt.Errorf("failed to encode capabilities: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Printf("Version:         %s (%s, %s)\n", caps.Version, caps.Commit, caps.Date)
+	fmt.Printf("Go:              %s %s/%s\n", caps.GoVersion, caps.OS, caps.Arch)
+	fmt.Printf("CGO:             %t\n", caps.CGO)
+	fmt.Printf("Build tags:      %s\n", joinOrNone(caps.BuildTags))
+	fmt.Printf("Hash algorithms: %s\n", joinOrNone(caps.HashAlgorithms))
+	fmt.Printf("Encodings:       %s\n", joinOrNone(caps.Encodings))
+	fmt.Printf("Ciphers:         %s\n", joinOrNone(caps.Ciphers))
+	fmt.Println("Commands:")
+	for _, command := range caps.Commands {
+		fmt.Printf("  %s\n", command)
+	}
+
+	return nil
+}
+
+// joinOrNone joins values with commas, or returns "none" for an empty list
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
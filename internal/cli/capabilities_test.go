@@ -0,0 +1,60 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+)
+
+func TestCapabilitiesCmd_JSON(t *testing.T) {
+	var app struct {
+		Capabilities cli.CapabilitiesCmd `cmd:""`
+		Hash         cli.HashCmd         `cmd:""`
+	}
+	parser, err := kong.New(&app, kong.Vars{"version": "v1.2.3", "commit": "abc1234", "date": "2025-01-01"})
+	require.NoError(t, err)
+
+	kctx, err := parser.Parse([]string{"capabilities", "--json"})
+	require.NoError(t, err)
+
+	output := captureStdout(t, func() {
+		require.NoError(t, kctx.Run(testutil.NewTestContext()))
+	})
+
+	var caps struct {
+		Version        string   `json:"version"`
+		Commit         string   `json:"commit"`
+		GoVersion      string   `json:"go_version"`
+		BuildTags      []string `json:"build_tags"`
+		Commands       []string `json:"commands"`
+		HashAlgorithms []string `json:"hash_algorithms"`
+		Encodings      []string `json:"encodings"`
+		Ciphers        []string `json:"ciphers"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &caps))
+	require.Equal(t, "v1.2.3", caps.Version)
+	require.Equal(t, "abc1234", caps.Commit)
+	require.NotEmpty(t, caps.GoVersion)
+	require.NotNil(t, caps.BuildTags)
+	require.Contains(t, caps.Commands, "capabilities")
+	require.Contains(t, caps.Commands, "hash compare-dirs")
+	require.Subset(t, caps.HashAlgorithms, []string{"sha256", "blake2b"})
+	require.Subset(t, caps.Encodings, []string{"base64", "base62"})
+	require.Contains(t, caps.Ciphers, "aes-256-gcm")
+}
+
+func TestCapabilitiesCmd_Text(t *testing.T) {
+	cmd := &cli.CapabilitiesCmd{}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext(), nil, kong.Vars{"version": "dev"}))
+	})
+	require.Contains(t, output, "Version:         dev")
+	require.Contains(t, output, "Hash algorithms:")
+	require.Contains(t, output, "sha256")
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"os"
+)
+
+// colorEnabled reports whether ANSI colors should be written to f: only to
+// terminals, unless FORCE_COLOR is set, and never when NO_COLOR is set
+// (https://no-color.org) or TERM is "dumb"
+func colorEnabled(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if os.Getenv("FORCE_COLOR") != "" {
+		return true
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,80 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDataURICmd(t *testing.T) {
+	dir := t.TempDir()
+	png := filepath.Join(dir, "logo.bin")
+	require.NoError(t, os.WriteFile(png, []byte("\x89PNG\r\n\x1a\nxx"), 0o644))
+	css := filepath.Join(dir, "style.css")
+	require.NoError(t, os.WriteFile(css, []byte("a{color:red}"), 0o644))
+
+	tests := []struct {
+		name     string
+		cmd      cli.EncodeDataURICmd
+		expected string
+	}{
+		{name: "sniffed from content", cmd: cli.EncodeDataURICmd{File: png}, expected: "data:image/png;base64,iVBORw0KGgp4eA=="},
+		{name: "from extension", cmd: cli.EncodeDataURICmd{File: css}, expected: "data:text/css;charset=utf-8;base64,YXtjb2xvcjpyZWR9"},
+		{name: "text", cmd: cli.EncodeDataURICmd{Text: "hi"}, expected: "data:text/plain;charset=utf-8;base64,aGk="},
+		{name: "explicit type", cmd: cli.EncodeDataURICmd{Text: "<svg/>", Type: "image/svg+xml"}, expected: "data:image/svg+xml;base64,PHN2Zy8+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, tt.cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected+"\n", output)
+		})
+	}
+}
+
+func TestDecodeDataURICmd(t *testing.T) {
+	data := []byte("\x89PNG\r\n\x1a\nxx")
+	out := filepath.Join(t.TempDir(), "logo.png")
+
+	cmd := &cli.DecodeDataURICmd{URI: "data:image/png;base64,iVBORw0KGgp4eA==", Output: out}
+	require.NoError(t, cmd.Run(testutil.NewTestContext()))
+
+	written, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, data, written)
+}
+
+func TestDataURI_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.png")
+	uri := filepath.Join(dir, "logo.txt")
+	out := filepath.Join(dir, "out.png")
+	data := []byte("\x89PNG\r\n\x1a\n\x00\x01\x02\xff")
+	require.NoError(t, os.WriteFile(in, data, 0o644))
+	ctx := testutil.NewTestContext()
+
+	require.NoError(t, (&cli.EncodeDataURICmd{File: in, Output: uri}).Run(ctx))
+	require.NoError(t, (&cli.DecodeDataURICmd{File: uri, Output: out}).Run(ctx))
+
+	decoded, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+}
+
+func TestDecodeDataURICmd_Invalid(t *testing.T) {
+	ctx := testutil.NewTestContext()
+
+	err := (&cli.DecodeDataURICmd{URI: "aGk="}).Run(ctx)
+	require.ErrorContains(t, err, "failed to decode data URI")
+
+	err = (&cli.DecodeDataURICmd{}).Run(ctx)
+	require.ErrorContains(t, err, "a data URI or --file is required")
+}
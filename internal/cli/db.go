@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/bilte-co/toolshed/database"
+	"github.com/bilte-co/toolshed/logging"
+)
+
+// DBCmd represents the db command group
+type DBCmd struct {
+	database.Flags `embed:""`
+
+	Create   DBCreateCmd   `cmd:"" help:"Create the configured database"`
+	Drop     DBDropCmd     `cmd:"" help:"Drop the configured database"`
+	Migrate  DBMigrateCmd  `cmd:"" help:"Apply or revert schema migrations"`
+	Ping     DBPingCmd     `cmd:"" help:"Check that the database is reachable"`
+	Truncate DBTruncateCmd `cmd:"" help:"Empty all tables except the migrations table"`
+	URL      DBURLCmd      `cmd:"" name:"url" help:"Print the connection URL with the password redacted"`
+}
+
+// AfterApply merges the database flags over the DB_* environment variables and
+// binds the resulting *database.Config for the subcommands
+func (cmd *DBCmd) AfterApply(kctx *kong.Context) error {
+	cfg, err := cmd.Merge(database.NewConfigFromEnv())
+	if err != nil {
+		return err
+	}
+	kctx.Bind(cfg)
+	return nil
+}
+
+// DBPingCmd checks database connectivity
+type DBPingCmd struct {
+	Timeout time.Duration `short:"t" default:"5s" help:"How long to wait for the database"`
+}
+
+// Run executes the db ping command
+func (cmd *DBPingCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	dbCtx, db, err := openDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close(dbCtx)
+
+	pingCtx, cancel := context.WithTimeout(dbCtx, cmd.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := db.Pool.Ping(pingCtx); err != nil {
+		ctx.Logger.Debug("Ping failed", "error", err)
+		fmt.Printf("✗ Database unreachable: %v\n", err)
+		ExitFunc(1)
+		return nil
+	}
+
+	fmt.Printf("✓ Database reachable (%s)\n", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// DBCreateCmd creates the configured database
+type DBCreateCmd struct{}
+
+// Run executes the db create command
+func (cmd *DBCreateCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	dbCtx := logging.WithLogger(context.Background(), ctx.Logger)
+
+	if err := database.CreateDatabase(dbCtx, cfg); err != nil {
+		ctx.Logger.Error("Failed to create database", "error", err)
+		return err
+	}
+
+	fmt.Printf("✓ Created database %s\n", cfg.Name)
+	return nil
+}
+
+// DBDropCmd drops the configured database
+type DBDropCmd struct {
+	Yes bool `short:"y" help:"Confirm dropping the database and all its data"`
+}
+
+// Run executes the db drop command
+func (cmd *DBDropCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	if !cmd.Yes {
+		ctx.Logger.Error("Drop not confirmed")
+		return fmt.Errorf("refusing to drop database without --yes")
+	}
+
+	dbCtx := logging.WithLogger(context.Background(), ctx.Logger)
+	if err := database.DropDatabase(dbCtx, cfg); err != nil {
+		ctx.Logger.Error("Failed to drop database", "error", err)
+		return err
+	}
+
+	fmt.Printf("✓ Dropped database %s\n", cfg.Name)
+	return nil
+}
+
+// DBTruncateCmd empties the tables of a schema
+type DBTruncateCmd struct {
+	Schema string `short:"s" default:"public" help:"Schema whose tables are truncated"`
+	Yes    bool   `short:"y" help:"Confirm deleting all rows"`
+}
+
+// Run executes the db truncate command
+func (cmd *DBTruncateCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	if !cmd.Yes {
+		ctx.Logger.Error("Truncate not confirmed")
+		return fmt.Errorf("refusing to truncate tables without --yes")
+	}
+
+	dbCtx, db, err := openDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close(dbCtx)
+
+	tables, err := database.TruncateTables(dbCtx, db, cmd.Schema)
+	if err != nil {
+		ctx.Logger.Error("Failed to truncate tables", "error", err)
+		return err
+	}
+
+	for _, table := range tables {
+		fmt.Printf("✓ Truncated %s.%s\n", cmd.Schema, table)
+	}
+	ctx.Logger.Info("Tables truncated", "schema", cmd.Schema, "count", len(tables))
+	return nil
+}
+
+// DBURLCmd prints the configured connection URL
+type DBURLCmd struct {
+	ShowPassword bool `help:"Print the password instead of redacting it"`
+}
+
+// Run executes the db url command
+func (cmd *DBURLCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	if cmd.ShowPassword {
+		fmt.Println(cfg.ConnectionURL())
+	} else {
+		fmt.Println(cfg.Redacted())
+	}
+	return nil
+}
+
+// DBMigrateCmd represents the db migrate command group
+type DBMigrateCmd struct {
+	Up     DBMigrateUpCmd     `cmd:"" default:"withargs" help:"Apply all pending migrations"`
+	Down   DBMigrateDownCmd   `cmd:"" help:"Revert the most recently applied migrations"`
+	Status DBMigrateStatusCmd `cmd:"" help:"Show the current migration version"`
+	Force  DBMigrateForceCmd  `cmd:"" help:"Set the migration version and clear the dirty state without running SQL"`
+}
+
+// migrationsDir holds the flag locating the migration files
+type migrationsDir struct {
+	Dir string `short:"d" default:"migrations" help:"Directory containing <version>_<name>.up.sql and .down.sql files"`
+}
+
+// openDB connects to the database configured by the flags and DB_* environment variables
+func openDB(ctx *CLIContext, cfg *database.Config) (context.Context, *database.DB, error) {
+	dbCtx := logging.WithLogger(context.Background(), ctx.Logger)
+	db, err := database.NewFromConfig(dbCtx, cfg)
+	if err != nil {
+		ctx.Logger.Error("Failed to connect to database", "error", err)
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return dbCtx, db, nil
+}
+
+// DBMigrateUpCmd applies pending migrations
+type DBMigrateUpCmd struct {
+	migrationsDir `embed:""`
+}
+
+// Run executes the db migrate up command
+func (cmd *DBMigrateUpCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	ctx.Logger.Debug("Applying migrations", "dir", cmd.Dir)
+
+	dbCtx, db, err := openDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close(dbCtx)
+
+	if err := database.Migrate(dbCtx, db, os.DirFS(cmd.Dir)); err != nil {
+		ctx.Logger.Error("Migration failed", "error", err)
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	return printMigrationVersion(ctx, dbCtx, db)
+}
+
+// DBMigrateDownCmd reverts applied migrations
+type DBMigrateDownCmd struct {
+	migrationsDir `embed:""`
+	Steps         int `short:"n" default:"1" help:"Number of migrations to revert"`
+}
+
+// Validate checks the db migrate down flags
+func (cmd *DBMigrateDownCmd) Validate() error {
+	if cmd.Steps < 1 {
+		return fmt.Errorf("steps must be at least 1")
+	}
+	return nil
+}
+
+// Run executes the db migrate down command
+func (cmd *DBMigrateDownCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	ctx.Logger.Debug("Reverting migrations", "dir", cmd.Dir, "steps", cmd.Steps)
+
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid steps", "steps", cmd.Steps)
+		return err
+	}
+
+	dbCtx, db, err := openDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close(dbCtx)
+
+	if err := database.MigrateDown(dbCtx, db, os.DirFS(cmd.Dir), cmd.Steps); err != nil {
+		ctx.Logger.Error("Migration failed", "error", err)
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	return printMigrationVersion(ctx, dbCtx, db)
+}
+
+// DBMigrateStatusCmd shows the migration version
+type DBMigrateStatusCmd struct{}
+
+// Run executes the db migrate status command
+func (cmd *DBMigrateStatusCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	dbCtx, db, err := openDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close(dbCtx)
+
+	return printMigrationVersion(ctx, dbCtx, db)
+}
+
+// DBMigrateForceCmd overrides the migration version
+type DBMigrateForceCmd struct {
+	Version int64 `arg:"" help:"Migration version the schema is actually at (0 for none)"`
+}
+
+// Validate checks the db migrate force arguments
+func (cmd *DBMigrateForceCmd) Validate() error {
+	if cmd.Version < 0 {
+		return fmt.Errorf("version cannot be negative")
+	}
+	return nil
+}
+
+// Run executes the db migrate force command
+func (cmd *DBMigrateForceCmd) Run(ctx *CLIContext, cfg *database.Config) error {
+	ctx.Logger.Debug("Forcing migration version", "version", cmd.Version)
+
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid version", "version", cmd.Version)
+		return err
+	}
+
+	dbCtx, db, err := openDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close(dbCtx)
+
+	if err := database.ForceMigrationVersion(dbCtx, db, cmd.Version); err != nil {
+		ctx.Logger.Error("Failed to force migration version", "error", err)
+		return fmt.Errorf("failed to force migration version: %w", err)
+	}
+
+	return printMigrationVersion(ctx, dbCtx, db)
+}
+
+// printMigrationVersion prints the migration version, exiting with status 1 if it is dirty
+func printMigrationVersion(ctx *CLIContext, dbCtx context.Context, db *database.DB) error {
+	version, dirty, err := database.MigrationVersion(dbCtx, db)
+	if err != nil {
+		ctx.Logger.Error("Failed to read migration version", "error", err)
+		return fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	if dirty {
+		fmt.Printf("✗ Migration version %d is dirty; repair the schema, then run 'toolshed db migrate force'\n", version)
+		ExitFunc(1)
+		return nil
+	}
+	fmt.Printf("✓ Migration version %d\n", version)
+	return nil
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bilte-co/toolshed/base32"
+	"github.com/bilte-co/toolshed/base62"
+	"github.com/bilte-co/toolshed/base64"
+	"github.com/bilte-co/toolshed/hexutil"
+)
+
+// DecodeAutoCmd detects the encoding of an opaque token and decodes it
+type DecodeAutoCmd struct {
+	Input string `arg:"" optional:"" help:"Encoded text to decode (use '-' or omit to read from stdin)"`
+	Auto  bool   `help:"Detect the encoding (the default when no subcommand is given)"`
+}
+
+// autoDecoders are the encodings tried by decode --auto, most specific first.
+// Hex digits are also valid base32, base64 and base62, for instance, so hex
+// is tried before them.
+var autoDecoders = []struct {
+	name    string
+	charset func(string) bool
+	decode  func(string) ([]byte, error)
+}{
+	{"hex", isHexToken, hexutil.Decode},
+	{"base32", isBase32Token, base32.StdEncoding.DecodeString},
+	{"base64", isTokenOf("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/", true), base64.StdEncoding.DecodeString},
+	{"base64url", isTokenOf("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_", true), base64.URLEncoding.DecodeString},
+	{"base62", isTokenOf("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789", false), base62.StdEncoding.DecodeString},
+}
+
+// Run executes the decode --auto command
+func (cmd *DecodeAutoCmd) Run(ctx *CLIContext) error {
+	input := cmd.Input
+	if input == "" || input == "-" {
+		data, err := readStdin()
+		if err != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", err)
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		input = string(data)
+	}
+	input = strings.Join(strings.Fields(input), "")
+	if input == "" {
+		ctx.Logger.Error("Empty input")
+		return fmt.Errorf("input cannot be empty")
+	}
+
+	encoding, decoded, ok := detectEncoding(input)
+	if !ok {
+		ctx.Logger.Error("No encoding matched", "tried", autoDecoderNames())
+		return fmt.Errorf("input does not match any of: %s", autoDecoderNames())
+	}
+
+	// Report on stderr so that stdout can be piped
+	fmt.Fprintf(os.Stderr, "Detected encoding: %s\n", encoding)
+	if _, err := os.Stdout.Write(decoded); err != nil {
+		ctx.Logger.Error("Failed to write output", "error", err)
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	fmt.Println()
+
+	ctx.Logger.Info("Text decoded successfully", "encoding", encoding)
+	return nil
+}
+
+// detectEncoding decodes input with the first encoding that yields
+// printable text, or with the first one that decodes it at all
+func detectEncoding(input string) (string, []byte, bool) {
+	var (
+		fallback        string
+		fallbackDecoded []byte
+	)
+	for _, decoder := range autoDecoders {
+		if !decoder.charset(input) {
+			continue
+		}
+		decoded, err := decoder.decode(input)
+		if err != nil || len(decoded) == 0 {
+			continue
+		}
+		if isPrintableText(decoded) {
+			return decoder.name, decoded, true
+		}
+		if fallback == "" {
+			fallback, fallbackDecoded = decoder.name, decoded
+		}
+	}
+	return fallback, fallbackDecoded, fallback != ""
+}
+
+// isHexToken reports whether s is an even number of hex digits, with an
+// optional 0x prefix
+func isHexToken(s string) bool {
+	if len(s) > 2 && (s[:2] == "0x" || s[:2] == "0X") {
+		s = s[2:]
+	}
+	return len(s)%2 == 0 && isTokenOf("0123456789abcdefABCDEF", false)(s)
+}
+
+// isBase32Token reports whether s uses the standard base32 alphabet in a
+// single case, as base32 is written in either case but never mixed
+func isBase32Token(s string) bool {
+	return isTokenOf("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567", true)(s) ||
+		isTokenOf("abcdefghijklmnopqrstuvwxyz234567", true)(s)
+}
+
+// isTokenOf returns a function reporting whether a string consists of the
+// characters of alphabet, optionally followed by '=' padding
+func isTokenOf(alphabet string, padding bool) func(string) bool {
+	return func(s string) bool {
+		if padding {
+			s = strings.TrimRight(s, "=")
+		}
+		if s == "" {
+			return false
+		}
+		for i := 0; i < len(s); i++ {
+			if strings.IndexByte(alphabet, s[i]) < 0 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// isPrintableText reports whether data is UTF-8 text without control
+// characters other than whitespace
+func isPrintableText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// autoDecoderNames lists the encodings tried by decode --auto
+func autoDecoderNames() string {
+	names := make([]string, len(autoDecoders))
+	for i, decoder := range autoDecoders {
+		names[i] = decoder.name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,91 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAutoCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "hex", input: "48656c6c6f", expected: "Hello"},
+		{name: "hex with prefix", input: "0x48656C6C6F", expected: "Hello"},
+		{name: "base32", input: "JBSWY3DPFQQFO33SNRSCC===", expected: "Hello, World!"},
+		{name: "lowercase base32", input: "jbswy3dpfqqfo33snrscc", expected: "Hello, World!"},
+		{name: "base64", input: "SGVsbG8sIFdvcmxkIQ==", expected: "Hello, World!"},
+		{name: "wrapped base64", input: "SGVsbG8s\nIFdvcmxkIQ==\n", expected: "Hello, World!"},
+		{name: "base64url", input: "PDw_Pz8-Pg", expected: "<<???>>"},
+		{name: "base62", input: "T8dgcjRGkZ3aysdN", expected: "Hello World!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.DecodeAutoCmd{Input: tt.input, Auto: true}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected+"\n", output)
+		})
+	}
+}
+
+func TestDecodeAutoCmd_Binary(t *testing.T) {
+	// Hex digits are valid in every candidate; hex is the most specific
+	cmd := &cli.DecodeAutoCmd{Input: "deadbeef"}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, "\xde\xad\xbe\xef\n", output)
+}
+
+func TestDecodeAutoCmd_Stdin(t *testing.T) {
+	for _, input := range []string{"-", ""} {
+		restore := replaceStdin(t, "SGVsbG8=\n")
+
+		cmd := &cli.DecodeAutoCmd{Input: input}
+		ctx := testutil.NewTestContext()
+
+		output := captureStdout(t, func() {
+			require.NoError(t, cmd.Run(ctx))
+		})
+		restore()
+		require.Equal(t, "Hello\n", output)
+	}
+}
+
+func TestDecodeCmd_AutoFlag(t *testing.T) {
+	var app struct {
+		Decode cli.DecodeCmd `cmd:""`
+	}
+	parser, err := kong.New(&app)
+	require.NoError(t, err)
+
+	kctx, err := parser.Parse([]string{"decode", "--auto", "SGVsbG8="})
+	require.NoError(t, err)
+	require.Equal(t, "decode auto <input>", kctx.Command())
+	require.Equal(t, "SGVsbG8=", app.Decode.Auto.Input)
+
+	_, err = parser.Parse([]string{"decode", "--auto", "jwt", "a.b.c"})
+	require.ErrorContains(t, err, "--auto cannot be used with decode jwt")
+}
+
+func TestDecodeAutoCmd_NoMatch(t *testing.T) {
+	ctx := testutil.NewTestContext()
+
+	err := (&cli.DecodeAutoCmd{Input: "not*encoded!"}).Run(ctx)
+	require.ErrorContains(t, err, "input does not match any of")
+
+	err = (&cli.DecodeAutoCmd{Input: "  "}).Run(ctx)
+	require.ErrorContains(t, err, "input cannot be empty")
+}
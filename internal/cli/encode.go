@@ -2,133 +2,377 @@ package cli
 
 import (
 	"fmt"
+	"html"
 	"io"
-	"os"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/bilte-co/toolshed/base32"
 	"github.com/bilte-co/toolshed/base62"
 	"github.com/bilte-co/toolshed/base64"
+	"github.com/bilte-co/toolshed/base85"
+	"github.com/bilte-co/toolshed/hexutil"
+	"github.com/bilte-co/toolshed/mimeenc"
 )
 
+// supportedEncodings lists the encoding schemes accepted by the encode and decode commands
+var supportedEncodings = []string{"base64", "base62", "base32", "base32-crockford", "hex", "url", "ascii85", "z85", "html", "quoted-printable", "rfc2047", "rot13", "rotN"}
+
 // EncodeCmd represents the encode command group
 type EncodeCmd struct {
-	Encode EncodeTextCmd `cmd:"" help:"Encode text using various encoding schemes"`
-	Decode DecodeTextCmd `cmd:"" help:"Decode text using various encoding schemes"`
+	Encode  EncodeTextCmd    `cmd:"" help:"Encode text using various encoding schemes"`
+	Decode  DecodeTextCmd    `cmd:"" help:"Decode text using various encoding schemes"`
+	IDNA    EncodeIDNACmd    `cmd:"" name:"idna" help:"Convert Unicode domain names to punycode (xn--)"`
+	DataURI EncodeDataURICmd `cmd:"" name:"data-uri" help:"Wrap a file or text in a base64 data URI"`
 }
 
 // EncodeTextCmd encodes text using specified encoding
 type EncodeTextCmd struct {
-	Text     string `arg:"" help:"Text to encode (use '-' to read from stdin)"`
-	Encoding string `short:"e" default:"base64" help:"Encoding scheme (base64, base62)"`
+	Text      string `arg:"" optional:"" help:"Text to encode (use '-' to read from stdin, or --file)"`
+	File      string `short:"f" help:"Read the input from this file instead"`
+	Output    string `short:"o" help:"Write the result to this file instead of stdout"`
+	Encoding  string `short:"e" default:"base64" help:"Encoding scheme (base64, base62, base32, base32-crockford, hex, url, ascii85, z85, html, quoted-printable, rfc2047, rot13, rot1-rot25)"`
+	Variant   string `enum:"std,url" default:"std" help:"Alphabet: std uses '+' and '/', url uses '-' and '_' (base64)"`
+	NoPadding bool   `help:"Omit the trailing '=' padding (base64, base32)"`
+	Wrap      int    `help:"Break the output into CRLF-separated lines of this many characters, e.g. 76 for MIME (base64)"`
+	Upper     bool   `help:"Use uppercase hex digits (hex)"`
+	Separator string `help:"Separator between hex bytes, e.g. ':' or ' ' (hex)"`
+	Dump      bool   `help:"Print a hexdump -C style dump with offsets and ASCII (hex)"`
+	Component string `enum:"query,path" default:"query" help:"URL component to escape for: query (spaces become '+') or path (url)"`
+	Numeric   bool   `help:"Also escape non-ASCII characters as numeric references such as &#xE9; (html)"`
+	Word      string `enum:"q,b" default:"q" help:"Encoded-word encoding: q keeps ASCII readable, b is base64 (rfc2047)"`
 }
 
+// Validate validates the command arguments
+func (cmd *EncodeTextCmd) Validate() error {
+	if cmd.File != "" && cmd.Text != "" {
+		return fmt.Errorf("text and --file cannot be used together")
+	}
+	if cmd.Dump && normalizeEncoding(cmd.Encoding) != "hex" {
+		return fmt.Errorf("--dump is only supported with the hex encoding")
+	}
+	if cmd.Wrap < 0 {
+		return fmt.Errorf("--wrap cannot be negative")
+	}
+	if cmd.Wrap > 0 && normalizeEncoding(cmd.Encoding) != "base64" {
+		return fmt.Errorf("--wrap is only supported with the base64 encoding")
+	}
+	return nil
+}
+
+// Run encodes the input, streaming it with constant memory when the
+// encoding allows
 func (cmd *EncodeTextCmd) Run(ctx *CLIContext) error {
 	ctx.Logger.Debug("Encoding text", "encoding", cmd.Encoding)
 
-	var input string
-	var err error
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid flags", "error", err)
+		return err
+	}
 
-	// Check if we should read from stdin
-	if cmd.Text == "-" {
-		input, err = cmd.readStdin()
-		if err != nil {
-			ctx.Logger.Error("Failed to read from stdin", "error", err)
-			return err
-		}
-	} else {
-		input = cmd.Text
+	encoding := normalizeEncoding(cmd.Encoding)
+	if !isSupportedEncoding(encoding) {
+		ctx.Logger.Error("Unsupported encoding", "encoding", encoding)
+		return unsupportedEncodingError(encoding)
 	}
 
-	// Set default encoding if empty
-	encoding := cmd.Encoding
-	if encoding == "" {
-		encoding = "base64"
+	input, err := openEncodeInput(cmd.Text, cmd.File)
+	if err != nil {
+		ctx.Logger.Error("Failed to open input", "error", err)
+		return err
 	}
+	defer input.Close()
 
-	var result string
-	switch strings.ToLower(encoding) {
-	case "base64":
-		result = base64.EncodeString(input)
-	case "base62":
-		result = base62.StdEncoding.EncodeToString([]byte(input))
-	default:
-		err := fmt.Errorf("unsupported encoding: %s (supported: base64, base62)", encoding)
-		ctx.Logger.Error("Unsupported encoding", "encoding", encoding)
+	output, err := openEncodeOutput(cmd.Output)
+	if err != nil {
+		ctx.Logger.Error("Failed to open output", "error", err)
 		return err
 	}
+	defer output.Close()
+
+	if encoder := cmd.newStreamEncoder(encoding, output); encoder != nil {
+		if _, err := io.Copy(encoder, input); err != nil {
+			ctx.Logger.Error("Failed to encode "+encoding, "error", err)
+			return fmt.Errorf("failed to encode %s: %w", encoding, err)
+		}
+		if err := encoder.Close(); err != nil {
+			ctx.Logger.Error("Failed to encode "+encoding, "error", err)
+			return fmt.Errorf("failed to encode %s: %w", encoding, err)
+		}
+	} else {
+		data, err := io.ReadAll(input)
+		if err != nil {
+			ctx.Logger.Error("Failed to read input", "error", err)
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		result, err := cmd.encode(encoding, data)
+		if err != nil {
+			ctx.Logger.Error("Failed to encode "+encoding, "error", err)
+			return fmt.Errorf("failed to encode %s: %w", encoding, err)
+		}
+		if _, err := io.WriteString(output, result); err != nil {
+			ctx.Logger.Error("Failed to write output", "error", err)
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	// End the line on a terminal; hex dumps end with a newline already
+	if cmd.Output == "" && !cmd.Dump {
+		fmt.Fprintln(output)
+	}
+	if err := output.Close(); err != nil {
+		ctx.Logger.Error("Failed to write output", "error", err)
+		return fmt.Errorf("failed to write output: %w", err)
+	}
 
-	fmt.Println(result)
 	ctx.Logger.Info("Text encoded successfully", "encoding", encoding)
 	return nil
 }
 
-func (cmd *EncodeTextCmd) readStdin() (string, error) {
-	data, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		return "", fmt.Errorf("failed to read from stdin: %w", err)
+// encode encodes data with the named encoding, which must be supported
+func (cmd *EncodeTextCmd) encode(encoding string, data []byte) (string, error) {
+	switch encoding {
+	case "base64":
+		return cmd.base64Encoding().EncodeToString(data), nil
+	case "base62":
+		return base62.StdEncoding.EncodeToString(data), nil
+	case "base32":
+		return base32.StdEncoding.WithPadding(!cmd.NoPadding).EncodeToString(data), nil
+	case "base32-crockford":
+		return base32.CrockfordEncoding.EncodeToString(data), nil
+	case "hex":
+		if cmd.Dump {
+			return hexutil.Dump(data), nil
+		}
+		return hexutil.Format(data, hexutil.FormatOptions{Upper: cmd.Upper, Separator: cmd.Separator}), nil
+	case "url":
+		if cmd.Component == "path" {
+			return url.PathEscape(string(data)), nil
+		}
+		return url.QueryEscape(string(data)), nil
+	case "ascii85":
+		return base85.Encode(data), nil
+	case "z85":
+		return base85.EncodeZ85(data)
+	case "html":
+		escaped := html.EscapeString(string(data))
+		if cmd.Numeric {
+			escaped = escapeNonASCII(escaped)
+		}
+		return escaped, nil
+	case "quoted-printable":
+		return mimeenc.EncodeQuotedPrintable(data), nil
+	case "rfc2047":
+		if cmd.Word == "b" {
+			return mimeenc.EncodeHeader(string(data), mimeenc.BEncoding), nil
+		}
+		return mimeenc.EncodeHeader(string(data), mimeenc.QEncoding), nil
+	default:
+		if shift, ok := rotShift(encoding); ok {
+			return rotate(string(data), shift), nil
+		}
+		return "", unsupportedEncodingError(encoding)
 	}
-	return strings.TrimRight(string(data), "\n\r"), nil
+}
+
+// base64Encoding returns the base64 encoding selected by the flags
+func (cmd *EncodeTextCmd) base64Encoding() *base64.Encoding {
+	return base64Variant(cmd.Variant).WithPadding(!cmd.NoPadding).WithWrap(cmd.Wrap)
 }
 
 // DecodeTextCmd decodes text using specified encoding
 type DecodeTextCmd struct {
-	Text     string `arg:"" help:"Text to decode (use '-' to read from stdin)"`
-	Encoding string `short:"e" default:"base64" help:"Encoding scheme (base64, base62)"`
+	Text      string `arg:"" optional:"" help:"Text to decode (use '-' to read from stdin, or --file)"`
+	File      string `short:"f" help:"Read the input from this file instead"`
+	Output    string `short:"o" help:"Write the decoded bytes to this file instead of stdout"`
+	Encoding  string `short:"e" default:"base64" help:"Encoding scheme (base64, base62, base32, base32-crockford, hex, url, ascii85, z85, html, quoted-printable, rfc2047, rot13, rot1-rot25)"`
+	Variant   string `enum:"std,url" default:"std" help:"Alphabet: std uses '+' and '/', url uses '-' and '_'; padding is optional (base64)"`
+	Component string `enum:"query,path" default:"query" help:"URL component to unescape: query ('+' becomes a space) or path (url)"`
+}
+
+// Validate validates the command arguments
+func (cmd *DecodeTextCmd) Validate() error {
+	if cmd.File != "" && cmd.Text != "" {
+		return fmt.Errorf("text and --file cannot be used together")
+	}
+	return nil
 }
 
+// Run decodes the input, streaming it with constant memory when the
+// encoding allows
 func (cmd *DecodeTextCmd) Run(ctx *CLIContext) error {
 	ctx.Logger.Debug("Decoding text", "encoding", cmd.Encoding)
 
-	var input string
-	var err error
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid flags", "error", err)
+		return err
+	}
+
+	encoding := normalizeEncoding(cmd.Encoding)
+	if !isSupportedEncoding(encoding) {
+		ctx.Logger.Error("Unsupported encoding", "encoding", encoding)
+		return unsupportedEncodingError(encoding)
+	}
 
-	// Check if we should read from stdin
-	if cmd.Text == "-" {
-		input, err = cmd.readStdin()
-		if err != nil {
-			ctx.Logger.Error("Failed to read from stdin", "error", err)
-			return err
-		}
-	} else {
-		input = cmd.Text
+	input, err := openEncodeInput(cmd.Text, cmd.File)
+	if err != nil {
+		ctx.Logger.Error("Failed to open input", "error", err)
+		return err
 	}
+	defer input.Close()
 
-	// Set default encoding if empty
-	encoding := cmd.Encoding
-	if encoding == "" {
-		encoding = "base64"
+	output, err := openEncodeOutput(cmd.Output)
+	if err != nil {
+		ctx.Logger.Error("Failed to open output", "error", err)
+		return err
 	}
+	defer output.Close()
 
-	var result string
-	switch strings.ToLower(encoding) {
-	case "base64":
-		result, err = base64.DecodeToString(input)
+	if decoder := cmd.newStreamDecoder(encoding, input); decoder != nil {
+		if _, err := io.Copy(output, decoder); err != nil {
+			ctx.Logger.Error("Failed to decode "+encoding, "error", err)
+			return fmt.Errorf("failed to decode %s: %w", encoding, err)
+		}
+	} else {
+		data, err := io.ReadAll(input)
 		if err != nil {
-			ctx.Logger.Error("Failed to decode base64", "error", err)
-			return fmt.Errorf("failed to decode base64: %w", err)
+			ctx.Logger.Error("Failed to read input", "error", err)
+			return fmt.Errorf("failed to read input: %w", err)
 		}
-	case "base62":
-		decoded, err := base62.StdEncoding.DecodeString(input)
+		decoded, err := cmd.decode(encoding, string(data))
 		if err != nil {
-			ctx.Logger.Error("Failed to decode base62", "error", err)
-			return fmt.Errorf("failed to decode base62: %w", err)
+			ctx.Logger.Error("Failed to decode "+encoding, "error", err)
+			return fmt.Errorf("failed to decode %s: %w", encoding, err)
+		}
+		if _, err := output.Write(decoded); err != nil {
+			ctx.Logger.Error("Failed to write output", "error", err)
+			return fmt.Errorf("failed to write output: %w", err)
 		}
-		result = string(decoded)
-	default:
-		err := fmt.Errorf("unsupported encoding: %s (supported: base64, base62)", encoding)
-		ctx.Logger.Error("Unsupported encoding", "encoding", encoding)
-		return err
 	}
 
-	fmt.Println(result)
+	// Files get the decoded bytes exactly; a terminal gets a final newline
+	if cmd.Output == "" {
+		fmt.Fprintln(output)
+	}
+	if err := output.Close(); err != nil {
+		ctx.Logger.Error("Failed to write output", "error", err)
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
 	ctx.Logger.Info("Text decoded successfully", "encoding", encoding)
 	return nil
 }
 
-func (cmd *DecodeTextCmd) readStdin() (string, error) {
-	data, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		return "", fmt.Errorf("failed to read from stdin: %w", err)
+// decode decodes input with the named encoding, which must be supported
+func (cmd *DecodeTextCmd) decode(encoding, input string) ([]byte, error) {
+	switch encoding {
+	case "base64":
+		return base64Variant(cmd.Variant).DecodeString(input)
+	case "base62":
+		return base62.StdEncoding.DecodeString(input)
+	case "base32":
+		return base32.StdEncoding.DecodeString(input)
+	case "base32-crockford":
+		return base32.CrockfordEncoding.DecodeString(input)
+	case "hex":
+		return hexutil.Decode(input)
+	case "url":
+		var decoded string
+		var err error
+		if cmd.Component == "path" {
+			decoded, err = url.PathUnescape(input)
+		} else {
+			decoded, err = url.QueryUnescape(input)
+		}
+		return []byte(decoded), err
+	case "ascii85":
+		return base85.Decode(input)
+	case "z85":
+		return base85.DecodeZ85(input)
+	case "html":
+		// Handles named entities and decimal and hex numeric references
+		return []byte(html.UnescapeString(input)), nil
+	case "quoted-printable":
+		return mimeenc.DecodeQuotedPrintable(input)
+	case "rfc2047":
+		decoded, err := mimeenc.DecodeHeader(input)
+		return []byte(decoded), err
+	default:
+		if shift, ok := rotShift(encoding); ok {
+			return []byte(rotate(input, 26-shift)), nil
+		}
+		return nil, unsupportedEncodingError(encoding)
+	}
+}
+
+// escapeNonASCII replaces non-ASCII characters with hex numeric character
+// references, so the text survives channels that only carry ASCII
+func escapeNonASCII(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+		} else {
+			fmt.Fprintf(&b, "&#x%X;", r)
+		}
 	}
-	return strings.TrimRight(string(data), "\n\r"), nil
+	return b.String()
+}
+
+// base64Variant returns the base64 encoding for the --variant flag
+func base64Variant(variant string) *base64.Encoding {
+	if variant == "url" {
+		return base64.URLEncoding
+	}
+	return base64.StdEncoding
+}
+
+// normalizeEncoding lowercases an encoding name, defaulting to base64
+func normalizeEncoding(encoding string) string {
+	if encoding == "" {
+		return "base64"
+	}
+	return strings.ToLower(encoding)
+}
+
+// isSupportedEncoding reports whether encoding is one of supportedEncodings,
+// counting rot1 to rot25 as rotN
+func isSupportedEncoding(encoding string) bool {
+	_, rot := rotShift(encoding)
+	return rot || slices.Contains(supportedEncodings, encoding)
+}
+
+// rotShift returns the shift of a rotN encoding name such as rot13
+func rotShift(encoding string) (int, bool) {
+	digits, ok := strings.CutPrefix(encoding, "rot")
+	if !ok {
+		return 0, false
+	}
+	shift, err := strconv.Atoi(digits)
+	if err != nil || shift < 1 || shift > 25 || digits[0] == '+' {
+		return 0, false
+	}
+	return shift, true
+}
+
+// rotate shifts the ASCII letters of s by shift places in the alphabet, as
+// in a Caesar cipher, leaving everything else as is
+func rotate(s string, shift int) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 'a' + (r-'a'+rune(shift))%26
+		case r >= 'A' && r <= 'Z':
+			return 'A' + (r-'A'+rune(shift))%26
+		default:
+			return r
+		}
+	}, s)
+}
+
+func unsupportedEncodingError(encoding string) error {
+	return fmt.Errorf("unsupported encoding: %s (supported: %s)", encoding, strings.Join(supportedEncodings, ", "))
 }
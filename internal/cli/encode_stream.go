@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bilte-co/toolshed/hexutil"
+)
+
+// newStreamEncoder returns an encoder writing to w when the encoding and
+// options can be encoded with constant memory, or nil otherwise
+func (cmd *EncodeTextCmd) newStreamEncoder(encoding string, w io.Writer) io.WriteCloser {
+	switch {
+	case encoding == "base64":
+		return cmd.base64Encoding().NewEncoder(w)
+	case encoding == "hex" && cmd.Dump:
+		return hexutil.NewDumper(w)
+	case encoding == "hex" && !cmd.Upper && cmd.Separator == "":
+		return nopWriteCloser{hexutil.NewEncoder(w)}
+	default:
+		return nil
+	}
+}
+
+// newStreamDecoder returns a decoder reading from r when the encoding can be
+// decoded with constant memory, or nil otherwise
+func (cmd *DecodeTextCmd) newStreamDecoder(encoding string, r io.Reader) io.Reader {
+	switch encoding {
+	case "base64":
+		return base64Variant(cmd.Variant).NewDecoder(r)
+	case "hex":
+		return hexutil.NewDecoder(r)
+	default:
+		return nil
+	}
+}
+
+// openEncodeInput opens the input of the encode and decode commands: the
+// file if one is given, stdin without trailing line breaks if text is "-",
+// and the text itself otherwise
+func openEncodeInput(text, file string) (io.ReadCloser, error) {
+	switch {
+	case file != "":
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open input file: %w", err)
+		}
+		return f, nil
+	case text == "-":
+		return io.NopCloser(&newlineTrimmer{r: os.Stdin}), nil
+	default:
+		return io.NopCloser(strings.NewReader(text)), nil
+	}
+}
+
+// openEncodeOutput opens the output of the encode and decode commands: the
+// file if one is given, and stdout otherwise
+func openEncodeOutput(file string) (io.WriteCloser, error) {
+	if file == "" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return f, nil
+}
+
+// nopWriteCloser adds a no-op Close to an io.Writer
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// newlineTrimmer drops the line breaks at the end of a stream, like the
+// shell does with command substitution, so that `echo text | toolshed encode
+// encode -` encodes just the text. Line breaks are held back until more data
+// follows them.
+type newlineTrimmer struct {
+	r       io.Reader
+	buf     []byte
+	out     []byte // Data ready to be returned
+	pending []byte // Line breaks that may end the stream
+	err     error
+}
+
+func (t *newlineTrimmer) Read(p []byte) (int, error) {
+	for len(t.out) == 0 {
+		if t.err != nil {
+			return 0, t.err
+		}
+		if t.buf == nil {
+			t.buf = make([]byte, 32*1024)
+		}
+
+		n, err := t.r.Read(t.buf)
+		chunk := t.buf[:n]
+		trimmed := bytes.TrimRight(chunk, "\r\n")
+		if len(trimmed) > 0 {
+			t.out = append(append(t.out[:0], t.pending...), trimmed...)
+			t.pending = append(t.pending[:0], chunk[len(trimmed):]...)
+		} else {
+			t.pending = append(t.pending, chunk...)
+		}
+		t.err = err
+	}
+
+	n := copy(p, t.out)
+	t.out = t.out[n:]
+	return n, nil
+}
@@ -1,7 +1,10 @@
 package cli_test
 
 import (
+	"crypto/rand"
+	stdbase64 "encoding/base64"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -468,3 +471,536 @@ func TestDecodeTextCmd_DefaultEncoding(t *testing.T) {
 	err := cmd.Run(ctx)
 	require.NoError(t, err)
 }
+
+func TestEncodeTextCmd_Base32(t *testing.T) {
+	tests := []struct {
+		name      string
+		encoding  string
+		noPadding bool
+		expected  string
+	}{
+		{name: "padded", encoding: "base32", expected: "JBSWY3DPFQQFO33SNRSCC==="},
+		{name: "no padding", encoding: "base32", noPadding: true, expected: "JBSWY3DPFQQFO33SNRSCC"},
+		{name: "crockford", encoding: "base32-crockford", expected: "91JPRV3F5GG5EVVJDHJ22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.EncodeTextCmd{
+				Text:      "Hello, World!",
+				Encoding:  tt.encoding,
+				NoPadding: tt.noPadding,
+			}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected+"\n", output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_Base32(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		text     string
+	}{
+		{name: "padded", encoding: "base32", text: "JBSWY3DPFQQFO33SNRSCC==="},
+		{name: "unpadded lowercase", encoding: "base32", text: "jbswy3dpfqqfo33snrscc"},
+		{name: "crockford with hyphens", encoding: "base32-crockford", text: "91jprv3f-5gg5evvj-dhj22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.DecodeTextCmd{
+				Text:     tt.text,
+				Encoding: tt.encoding,
+			}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, "Hello, World!\n", output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_InvalidBase32(t *testing.T) {
+	cmd := &cli.DecodeTextCmd{
+		Text:     "JBSW!3DP",
+		Encoding: "base32",
+	}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decode base32")
+}
+
+func TestEncodeTextCmd_Hex(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      cli.EncodeTextCmd
+		expected string
+	}{
+		{name: "plain", cmd: cli.EncodeTextCmd{Text: "hi!"}, expected: "686921\n"},
+		{name: "upper with separator", cmd: cli.EncodeTextCmd{Text: "hi!", Upper: true, Separator: ":"}, expected: "68:69:21\n"},
+		{name: "dump", cmd: cli.EncodeTextCmd{Text: "hi!", Dump: true}, expected: "00000000  68 69 21                                          |hi!|\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.cmd
+			cmd.Encoding = "hex"
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestEncodeTextCmd_DumpRequiresHex(t *testing.T) {
+	cmd := &cli.EncodeTextCmd{Text: "test", Encoding: "base64", Dump: true}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--dump")
+}
+
+func TestDecodeTextCmd_Hex(t *testing.T) {
+	for _, text := range []string{"686921", "0x686921", "68:69:21", "68 69 21"} {
+		t.Run(text, func(t *testing.T) {
+			cmd := &cli.DecodeTextCmd{Text: text, Encoding: "hex"}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, "hi!\n", output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_InvalidHex(t *testing.T) {
+	cmd := &cli.DecodeTextCmd{Text: "abc", Encoding: "hex"}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decode hex")
+}
+
+func TestEncodeTextCmd_URL(t *testing.T) {
+	tests := []struct {
+		name      string
+		component string
+		expected  string
+	}{
+		{name: "query", component: "query", expected: "a+b%2Fc%3Fd%3De%26f\n"},
+		{name: "path", component: "path", expected: "a%20b%2Fc%3Fd=e&f\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.EncodeTextCmd{Text: "a b/c?d=e&f", Encoding: "url", Component: tt.component}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_URL(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		component string
+		expected  string
+	}{
+		{name: "query", text: "a+b%2Fc%3Fd%3De%26f", component: "query", expected: "a b/c?d=e&f\n"},
+		{name: "path keeps plus", text: "a+b%20c", component: "path", expected: "a+b c\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.DecodeTextCmd{Text: tt.text, Encoding: "url", Component: tt.component}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_InvalidURL(t *testing.T) {
+	cmd := &cli.DecodeTextCmd{Text: "100%", Encoding: "url"}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decode url")
+}
+
+func TestEncodeTextCmd_Base85(t *testing.T) {
+	tests := []struct {
+		encoding string
+		text     string
+		expected string
+	}{
+		{encoding: "ascii85", text: "Hello", expected: "87cURDZ\n"},
+		{encoding: "z85", text: "Hi!!", expected: "nnfj<\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			cmd := &cli.EncodeTextCmd{Text: tt.text, Encoding: tt.encoding}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestEncodeTextCmd_Z85InvalidLength(t *testing.T) {
+	cmd := &cli.EncodeTextCmd{Text: "abc", Encoding: "z85"}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to encode z85")
+}
+
+func TestDecodeTextCmd_Base85(t *testing.T) {
+	tests := []struct {
+		encoding string
+		text     string
+		expected string
+	}{
+		{encoding: "ascii85", text: "<~87cURDZ~>", expected: "Hello\n"},
+		{encoding: "z85", text: "nnfj<", expected: "Hi!!\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			cmd := &cli.DecodeTextCmd{Text: tt.text, Encoding: tt.encoding}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestEncodeTextCmd_HTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		numeric  bool
+		expected string
+	}{
+		{name: "escape", expected: "&lt;a href=&#34;x&#34;&gt;Café &amp; Co&lt;/a&gt;\n"},
+		{name: "numeric", numeric: true, expected: "&lt;a href=&#34;x&#34;&gt;Caf&#xE9; &amp; Co&lt;/a&gt;\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.EncodeTextCmd{Text: `<a href="x">Café & Co</a>`, Encoding: "html", Numeric: tt.numeric}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_HTML(t *testing.T) {
+	cmd := &cli.DecodeTextCmd{Text: "&lt;b&gt;Caf&eacute; &#233; &#xE9; &amp;amp;&lt;/b&gt;", Encoding: "html"}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, "<b>Café é é &amp;</b>\n", output)
+}
+
+func TestEncodeTextCmd_Mail(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      cli.EncodeTextCmd
+		expected string
+	}{
+		{name: "quoted-printable", cmd: cli.EncodeTextCmd{Text: "Café = 3€", Encoding: "quoted-printable"}, expected: "Caf=C3=A9 =3D 3=E2=82=AC\n"},
+		{name: "rfc2047 q", cmd: cli.EncodeTextCmd{Text: "Café menu", Encoding: "rfc2047"}, expected: "=?utf-8?q?Caf=C3=A9_menu?=\n"},
+		{name: "rfc2047 b", cmd: cli.EncodeTextCmd{Text: "Café menu", Encoding: "rfc2047", Word: "b"}, expected: "=?utf-8?b?Q2Fmw6kgbWVudQ==?=\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewTestContext()
+			output := captureStdout(t, func() {
+				require.NoError(t, tt.cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_Mail(t *testing.T) {
+	tests := []struct {
+		encoding string
+		text     string
+		expected string
+	}{
+		{encoding: "quoted-printable", text: "Caf=C3=A9 =3D soft=\r\nbreak", expected: "Café = softbreak\n"},
+		{encoding: "rfc2047", text: "=?ISO-8859-1?Q?Andr=E9?= <andre@example.com>", expected: "André <andre@example.com>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			cmd := &cli.DecodeTextCmd{Text: tt.text, Encoding: tt.encoding}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestEncodeDecode_FileStreaming(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, 1<<20+3)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	for _, encoding := range []string{"base64", "hex", "base32"} {
+		t.Run(encoding, func(t *testing.T) {
+			inputPath := filepath.Join(dir, encoding+".bin")
+			encodedPath := filepath.Join(dir, encoding+".txt")
+			decodedPath := filepath.Join(dir, encoding+".out")
+			require.NoError(t, os.WriteFile(inputPath, data, 0o600))
+			ctx := testutil.NewTestContext()
+
+			encodeCmd := &cli.EncodeTextCmd{File: inputPath, Output: encodedPath, Encoding: encoding}
+			require.NoError(t, encodeCmd.Run(ctx))
+
+			decodeCmd := &cli.DecodeTextCmd{File: encodedPath, Output: decodedPath, Encoding: encoding}
+			require.NoError(t, decodeCmd.Run(ctx))
+
+			decoded, err := os.ReadFile(decodedPath)
+			require.NoError(t, err)
+			require.Equal(t, data, decoded)
+		})
+	}
+
+	encoded, err := os.ReadFile(filepath.Join(dir, "base64.txt"))
+	require.NoError(t, err)
+	require.Equal(t, stdbase64.StdEncoding.EncodeToString(data), string(encoded))
+}
+
+func TestEncodeTextCmd_StdinTrailingNewlines(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdin    string
+		expected string
+	}{
+		{name: "trailing newlines dropped", stdin: "hello\r\n\n", expected: "aGVsbG8=\n"},
+		{name: "inner newlines kept", stdin: "a\nb\n", expected: "YQpi\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restore := replaceStdin(t, tt.stdin)
+			defer restore()
+
+			cmd := &cli.EncodeTextCmd{Text: "-", Encoding: "base64"}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestEncodeTextCmd_TextAndFile(t *testing.T) {
+	ctx := testutil.NewTestContext()
+
+	err := (&cli.EncodeTextCmd{Text: "x", File: "in.txt"}).Run(ctx)
+	require.ErrorContains(t, err, "cannot be used together")
+
+	err = (&cli.DecodeTextCmd{Text: "x", File: "in.txt"}).Run(ctx)
+	require.ErrorContains(t, err, "cannot be used together")
+
+	err = (&cli.EncodeTextCmd{File: filepath.Join(t.TempDir(), "missing")}).Run(ctx)
+	require.ErrorContains(t, err, "failed to open input file")
+}
+
+func TestEncodeTextCmd_Rot(t *testing.T) {
+	tests := []struct {
+		encoding string
+		text     string
+		expected string
+	}{
+		{encoding: "rot13", text: "Hello, World!", expected: "Uryyb, Jbeyq!\n"},
+		{encoding: "ROT13", text: "Uryyb, Jbeyq!", expected: "Hello, World!\n"},
+		{encoding: "rot3", text: "xyz ABC 123", expected: "abc DEF 123\n"},
+		{encoding: "rot25", text: "Café", expected: "Bzeé\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			cmd := &cli.EncodeTextCmd{Text: tt.text, Encoding: tt.encoding}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_Rot(t *testing.T) {
+	cmd := &cli.DecodeTextCmd{Text: "abc DEF 123", Encoding: "rot3"}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, "xyz ABC 123\n", output)
+}
+
+func TestEncodeTextCmd_InvalidRot(t *testing.T) {
+	for _, encoding := range []string{"rot0", "rot26", "rot-1", "rot+3", "rotN", "rot"} {
+		t.Run(encoding, func(t *testing.T) {
+			cmd := &cli.EncodeTextCmd{Text: "test", Encoding: encoding}
+			ctx := testutil.NewTestContext()
+
+			err := cmd.Run(ctx)
+			require.ErrorContains(t, err, "unsupported encoding")
+		})
+	}
+}
+
+func TestEncodeTextCmd_Base64Variant(t *testing.T) {
+	tests := []struct {
+		name      string
+		variant   string
+		noPadding bool
+		expected  string
+	}{
+		{name: "std", variant: "std", expected: "aGk/Pg=="},
+		{name: "url", variant: "url", expected: "aGk_Pg=="},
+		{name: "std without padding", variant: "std", noPadding: true, expected: "aGk/Pg"},
+		{name: "url without padding", variant: "url", noPadding: true, expected: "aGk_Pg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.EncodeTextCmd{
+				Text:      "hi?>",
+				Encoding:  "base64",
+				Variant:   tt.variant,
+				NoPadding: tt.noPadding,
+			}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, tt.expected+"\n", output)
+		})
+	}
+}
+
+func TestDecodeTextCmd_Base64Variant(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant string
+		text    string
+	}{
+		{name: "std", variant: "std", text: "aGk/Pg=="},
+		{name: "std without padding", variant: "std", text: "aGk/Pg"},
+		{name: "url", variant: "url", text: "aGk_Pg=="},
+		{name: "url without padding", variant: "url", text: "aGk_Pg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.DecodeTextCmd{
+				Text:     tt.text,
+				Encoding: "base64",
+				Variant:  tt.variant,
+			}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			require.Equal(t, "hi?>\n", output)
+		})
+	}
+
+	// The url alphabet does not contain '/'
+	cmd := &cli.DecodeTextCmd{Text: "aGk/Pg", Encoding: "base64", Variant: "url"}
+	err := cmd.Run(testutil.NewTestContext())
+	require.ErrorContains(t, err, "failed to decode base64")
+}
+
+func TestEncodeTextCmd_Wrap(t *testing.T) {
+	text := strings.Repeat("wrap me ", 20)
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, (&cli.EncodeTextCmd{Text: text, Encoding: "base64", Wrap: 76}).Run(ctx))
+	})
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\r\n")
+	require.Len(t, lines, 3)
+	require.Len(t, lines[0], 76)
+	require.Equal(t, stdbase64.StdEncoding.EncodeToString([]byte(text)), strings.Join(lines, ""))
+
+	// Decoding ignores the line breaks
+	decoded := captureStdout(t, func() {
+		require.NoError(t, (&cli.DecodeTextCmd{Text: output, Encoding: "base64"}).Run(ctx))
+	})
+	require.Equal(t, text+"\n", decoded)
+
+	// Streaming from a file wraps the same way
+	file := filepath.Join(t.TempDir(), "input")
+	require.NoError(t, os.WriteFile(file, []byte(text), 0o644))
+	streamed := captureStdout(t, func() {
+		require.NoError(t, (&cli.EncodeTextCmd{File: file, Encoding: "base64", Wrap: 76}).Run(ctx))
+	})
+	require.Equal(t, output, streamed)
+}
+
+func TestEncodeTextCmd_InvalidWrap(t *testing.T) {
+	ctx := testutil.NewTestContext()
+
+	err := (&cli.EncodeTextCmd{Text: "test", Encoding: "hex", Wrap: 76}).Run(ctx)
+	require.ErrorContains(t, err, "--wrap is only supported with the base64 encoding")
+
+	err = (&cli.EncodeTextCmd{Text: "test", Encoding: "base64", Wrap: -1}).Run(ctx)
+	require.ErrorContains(t, err, "--wrap cannot be negative")
+}
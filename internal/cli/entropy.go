@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bilte-co/toolshed/entropy"
+)
+
+// EntropyCmd estimates the entropy of data and runs simple randomness tests
+type EntropyCmd struct {
+	Path      string `arg:"" optional:"" help:"File to analyse (use '-' or omit for stdin)"`
+	JSON      bool   `long:"json" help:"Output the report as JSON"`
+	Histogram bool   `long:"histogram" help:"Include the byte histogram in text output"`
+}
+
+func (cmd *EntropyCmd) Run(ctx *CLIContext) error {
+	var reader io.Reader
+	source := "stdin"
+
+	if cmd.Path == "" || cmd.Path == "-" {
+		ctx.Logger.Debug("Reading data from stdin")
+		reader = os.Stdin
+	} else {
+		cleanPath := filepath.Clean(cmd.Path)
+		file, err := os.Open(cleanPath)
+		if err != nil {
+			ctx.Logger.Error("Failed to open file", "path", cleanPath, "error", err)
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
+		reader = file
+		source = cleanPath
+	}
+
+	report, err := entropy.Analyze(reader)
+	if err != nil {
+		ctx.Logger.Error("Failed to analyse data", "source", source, "error", err)
+		return fmt.Errorf("failed to analyse data: %w", err)
+	}
+
+	if report.Size == 0 {
+		ctx.Logger.Error("No data to analyse", "source", source)
+		return fmt.Errorf("no data to analyse")
+	}
+
+	if cmd.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		cmd.printReport(report)
+	}
+
+	ctx.Logger.Info("Entropy analysis complete", "source", source, "bytes", report.Size, "shannon", report.Shannon)
+	return nil
+}
+
+func (cmd *EntropyCmd) printReport(report *entropy.Report) {
+	fmt.Printf("Size:           %d bytes\n", report.Size)
+	fmt.Printf("Shannon:        %.6f bits/byte (%.2f%% of maximum)\n", report.Shannon, report.Shannon/entropy.MaxBitsPerByte*100)
+	fmt.Printf("Mean:           %.4f (127.5 = random)\n", report.Mean)
+	fmt.Printf("Chi-square:     %.2f (p = %.4f) %s\n", report.ChiSquare, report.ChiSquareP, passFail(report.ChiSquareP))
+	fmt.Printf("Runs test:      z = %.4f (p = %.4f) %s\n", report.RunsZ, report.RunsP, passFail(report.RunsP))
+	fmt.Printf("Classification: %s\n", report.Classification())
+
+	if cmd.Histogram {
+		fmt.Println()
+		fmt.Println("Histogram (non-zero byte values):")
+		for value, count := range report.Histogram {
+			if count == 0 {
+				continue
+			}
+			fmt.Printf("  0x%02x  %10d  %6.3f%%\n", value, count, float64(count)/float64(report.Size)*100)
+		}
+	}
+}
+
+// passFail renders a randomness test p-value as a pass/fail marker
+func passFail(p float64) string {
+	if p >= entropy.DefaultSignificance {
+		return "✓"
+	}
+	return "✗"
+}
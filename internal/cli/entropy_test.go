@@ -0,0 +1,103 @@
+package cli_test
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/entropy"
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntropyCmd_File(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "random.bin")
+
+	data := make([]byte, 4096)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	cmd := &cli.EntropyCmd{Path: path, Histogram: true}
+	ctx := testutil.NewTestContext()
+
+	err = cmd.Run(ctx)
+	require.NoError(t, err)
+}
+
+func TestEntropyCmd_NonExistentFile(t *testing.T) {
+	cmd := &cli.EntropyCmd{Path: "/nonexistent/file"}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to open file")
+}
+
+func TestEntropyCmd_EmptyStdin(t *testing.T) {
+	oldStdin := os.Stdin
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdin = r
+	defer func() {
+		os.Stdin = oldStdin
+		r.Close()
+	}()
+	w.Close()
+
+	cmd := &cli.EntropyCmd{}
+	ctx := testutil.NewTestContext()
+
+	err = cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no data to analyse")
+}
+
+func TestEntropyCmd_StdinJSON(t *testing.T) {
+	input := strings.Repeat("aaaabbbb", 64)
+
+	oldStdin := os.Stdin
+	stdinR, stdinW, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdin = stdinR
+	defer func() {
+		os.Stdin = oldStdin
+		stdinR.Close()
+	}()
+
+	go func() {
+		defer stdinW.Close()
+		stdinW.WriteString(input)
+	}()
+
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+	stdoutR, stdoutW, err := os.Pipe()
+	require.NoError(t, err)
+	defer stdoutR.Close()
+	os.Stdout = stdoutW
+
+	cmd := &cli.EntropyCmd{Path: "-", JSON: true}
+	ctx := testutil.NewTestContext()
+
+	go func() {
+		defer stdoutW.Close()
+		err := cmd.Run(ctx)
+		require.NoError(t, err)
+	}()
+
+	output, err := io.ReadAll(stdoutR)
+	require.NoError(t, err)
+
+	var report entropy.Report
+	require.NoError(t, json.Unmarshal(output, &report))
+	require.Equal(t, int64(len(input)), report.Size)
+	require.InDelta(t, 1.0, report.Shannon, 1e-9)
+	require.Equal(t, uint64(len(input)/2), report.Histogram['a'])
+}
@@ -1,42 +1,142 @@
 package cli
 
 import (
+	"cmp"
 	"fmt"
+	"os"
 
 	"github.com/bilte-co/toolshed/haiku"
 )
 
 type HaikuCmd struct {
-	Generate HaikuGenerateCmd `cmd:"generate" help:"Generate a random haiku name"`
+	Generate HaikuGenerateCmd `cmd:"generate" default:"withargs" help:"Generate random haiku names (the default)"`
 }
 
 type HaikuGenerateCmd struct {
-	Token   int64  `short:"t" default:"9999" help:"Maximum token value (default: 9999, use 0 for no token)"`
-	Delim   string `short:"d" default:"-" help:"Delimiter between words (default: '-')"`
-	NoToken bool   `short:"n" help:"Generate haiku without numeric token"`
+	Token       int64  `short:"t" default:"9999" aliases:"token-max" help:"Maximum token value (default: 9999, use 0 for no token)"`
+	Delim       string `short:"d" default:"-" aliases:"delimiter" help:"Delimiter between words (default: '-')"`
+	NoToken     bool   `short:"n" help:"Generate haiku without numeric token"`
+	Words       string `short:"w" type:"existingdir" help:"Directory with custom adjectives.txt, actions.txt and/or nouns.txt word lists"`
+	Adjectives  int    `default:"1" help:"Number of adjectives"`
+	Actions     int    `default:"1" help:"Number of actions"`
+	Nouns       int    `default:"1" help:"Number of nouns"`
+	TokenFormat string `enum:"decimal,hex,base62" default:"decimal" help:"Token format (decimal, hex, base62)"`
+	Count       int    `short:"c" default:"1" help:"Number of names to generate"`
+	Unique      bool   `short:"u" help:"Guarantee no duplicate names within the run"`
+	Suffix      int    `short:"s" help:"Append a random base32 suffix of N characters, for names used as unique identifiers"`
+	SuffixKey   string `help:"Derive the suffix from the SHA-256 hash of this key (such as a resource ID) instead of crypto/rand"`
+	MaxLength   int    `help:"Maximum name length; longer names are re-rolled unless --truncate is given"`
+	Truncate    bool   `help:"Shorten the longest words of names over --max-length instead of re-rolling"`
+	Lowercase   bool   `help:"Lowercase the name"`
+	DNS         bool   `name:"dns" help:"Generate valid DNS labels for Kubernetes and S3 names: lowercase, '-' only, at most 63 characters"`
 }
 
-func (cmd *HaikuGenerateCmd) Run(ctx *CLIContext) error {
-	ctx.Logger.Debug("Generating haiku name")
+// Validate checks the flags
+func (cmd *HaikuGenerateCmd) Validate() error {
+	if cmd.Count < 0 {
+		return fmt.Errorf("--count cannot be negative")
+	}
+	if cmd.SuffixKey != "" && cmd.Suffix == 0 {
+		return fmt.Errorf("--suffix-key requires --suffix")
+	}
+	if cmd.SuffixKey != "" && (cmd.Count > 1 || cmd.Unique) {
+		return fmt.Errorf("--suffix-key names a single resource and cannot be used with --count or --unique")
+	}
+	if cmd.MaxLength < 0 {
+		return fmt.Errorf("--max-length cannot be negative")
+	}
+	if cmd.Truncate && cmd.MaxLength == 0 && !cmd.DNS {
+		return fmt.Errorf("--truncate requires --max-length or --dns")
+	}
+	return nil
+}
 
-	haikuinator := haiku.NewHaikunator()
+func (cmd *HaikuGenerateCmd) Run(ctx *CLIContext) error {
+	ctx.Logger.Debug("Generating haiku name", "count", cmd.Count)
 
-	var haikuName string
-	var err error
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid flags", "error", err)
+		return err
+	}
 
-	// Choose the appropriate method based on whether token is needed
-	if cmd.NoToken || cmd.Token == 0 {
-		haikuName, err = haikuinator.DelimHaikunate(cmd.Delim)
-	} else {
-		haikuName, err = haikuinator.TokenDelimHaikunate(cmd.Token, cmd.Delim)
+	builder, err := cmd.builder()
+	if err != nil {
+		ctx.Logger.Error("Invalid haiku options", "error", err)
+		return err
 	}
 
+	count := max(cmd.Count, 1)
+	var haikuNames []string
+	switch {
+	case cmd.SuffixKey != "":
+		var haikuName string
+		haikuName, err = builder.HaikunateKey([]byte(cmd.SuffixKey))
+		haikuNames = append(haikuNames, haikuName)
+	case cmd.Unique:
+		haikuNames, err = builder.HaikunateUnique(count)
+	default:
+		for range count {
+			var haikuName string
+			if haikuName, err = builder.Haikunate(); err != nil {
+				break
+			}
+			haikuNames = append(haikuNames, haikuName)
+		}
+	}
 	if err != nil {
 		ctx.Logger.Error("Failed to generate haiku name", "error", err)
 		return fmt.Errorf("failed to generate haiku: %w", err)
 	}
 
-	fmt.Println(haikuName)
-	ctx.Logger.Info("Haiku name generated successfully", "haiku", haikuName)
+	for _, haikuName := range haikuNames {
+		fmt.Println(haikuName)
+	}
+	ctx.Logger.Info("Haiku names generated successfully", "count", len(haikuNames))
 	return nil
 }
+
+// builder returns a haiku builder for the shape chosen by the flags
+func (cmd *HaikuGenerateCmd) builder() (*haiku.Builder, error) {
+	var opts []haiku.Option
+	if cmd.Words != "" {
+		opts = append(opts, haiku.WithWordLists(os.DirFS(cmd.Words)))
+	}
+	haikuinator := haiku.NewHaikunator(opts...)
+
+	adjectives, actions, nouns := cmd.Adjectives, cmd.Actions, cmd.Nouns
+	if adjectives == 0 && actions == 0 && nouns == 0 {
+		// A haiku needs words; fall back to the usual adjective-action-noun
+		adjectives, actions, nouns = 1, 1, 1
+	}
+
+	builder := haikuinator.Builder().
+		Delimiter(cmd.Delim).
+		Adjectives(adjectives).
+		Actions(actions).
+		Nouns(nouns)
+
+	// Non-positive token values generate no token
+	if !cmd.NoToken && cmd.Token > 0 {
+		format, err := haiku.ParseTokenFormat(cmp.Or(cmd.TokenFormat, "decimal"))
+		if err != nil {
+			return nil, err
+		}
+		builder.Token(cmd.Token, format)
+	}
+	if cmd.Suffix != 0 {
+		builder.Suffix(cmd.Suffix)
+	}
+	if cmd.MaxLength > 0 {
+		builder.MaxLength(cmd.MaxLength)
+	}
+	if cmd.Truncate {
+		builder.Truncate()
+	}
+	if cmd.Lowercase {
+		builder.Lowercase()
+	}
+	if cmd.DNS {
+		builder.DNSLabel()
+	}
+	return builder, nil
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alecthomas/kong"
 	"github.com/bilte-co/toolshed/internal/cli"
 	"github.com/bilte-co/toolshed/internal/testutil"
 	"github.com/stretchr/testify/require"
@@ -370,3 +371,246 @@ func TestHaikuGenerateCmd_EdgeCaseCombinations(t *testing.T) {
 		})
 	}
 }
+
+func TestHaikuGenerateCmd_CustomWordLists(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(dir+"/adjectives.txt", []byte("brave\n"), 0o644))
+	require.NoError(t, os.WriteFile(dir+"/actions.txt", []byte("coding\n"), 0o644))
+	require.NoError(t, os.WriteFile(dir+"/nouns.txt", []byte("gopher\n"), 0o644))
+
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", NoToken: true, Words: dir}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, "brave-coding-gopher\n", output)
+}
+
+func TestHaikuGenerateCmd_EmptyWordList(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(dir+"/nouns.txt", []byte("\n"), 0o644))
+
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", Words: dir}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "word list nouns.txt is empty")
+}
+
+func TestHaikuGenerateCmd_CustomShape(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(dir+"/adjectives.txt", []byte("brave\n"), 0o644))
+	require.NoError(t, os.WriteFile(dir+"/nouns.txt", []byte("gopher\n"), 0o644))
+
+	cmd := &cli.HaikuGenerateCmd{
+		Delim:       "_",
+		Words:       dir,
+		Adjectives:  1,
+		Nouns:       2,
+		Token:       1,
+		TokenFormat: "hex",
+	}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, "brave_gopher_gopher_0\n", output)
+}
+
+func TestHaikuGenerateCmd_TokenFormats(t *testing.T) {
+	tests := []struct {
+		format  string
+		charset string
+	}{
+		{"decimal", "0123456789"},
+		{"hex", "0123456789abcdef"},
+		{"base62", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			cmd := &cli.HaikuGenerateCmd{Delim: "-", Token: 1 << 40, TokenFormat: tt.format}
+			ctx := testutil.NewTestContext()
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(ctx))
+			})
+			parts := strings.Split(strings.TrimSpace(output), "-")
+			token := parts[len(parts)-1]
+			require.NotEmpty(t, token)
+			for _, c := range token {
+				require.Contains(t, tt.charset, string(c), "token %s is not %s", token, tt.format)
+			}
+		})
+	}
+}
+
+func TestHaikuGenerateCmd_NegativeCount(t *testing.T) {
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", Nouns: -1}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "negative noun count")
+}
+
+func TestHaikuCmd_ParseDefaultGenerate(t *testing.T) {
+	var app struct {
+		Haiku cli.HaikuCmd `cmd:"" name:"haiku"`
+	}
+	parser, err := kong.New(&app)
+	require.NoError(t, err)
+
+	kctx, err := parser.Parse([]string{"haiku", "--count", "3", "--unique", "--delimiter", ".", "--token-max", "10"})
+	require.NoError(t, err)
+	require.Equal(t, "haiku generate", kctx.Command())
+	require.Equal(t, 3, app.Haiku.Generate.Count)
+	require.True(t, app.Haiku.Generate.Unique)
+	require.Equal(t, ".", app.Haiku.Generate.Delim)
+	require.Equal(t, int64(10), app.Haiku.Generate.Token)
+}
+
+func TestHaikuGenerateCmd_Count(t *testing.T) {
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", Token: 9999, Count: 5}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Len(t, strings.Split(strings.TrimSpace(output), "\n"), 5)
+}
+
+func TestHaikuGenerateCmd_Unique(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(dir+"/adjectives.txt", []byte("brave\ncalm\n"), 0o644))
+	require.NoError(t, os.WriteFile(dir+"/actions.txt", []byte("coding\n"), 0o644))
+	require.NoError(t, os.WriteFile(dir+"/nouns.txt", []byte("gopher\n"), 0o644))
+
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", Token: 3, Count: 6, Unique: true, Words: dir}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	names := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, names, 6)
+	require.ElementsMatch(t, []string{
+		"brave-coding-gopher-0", "brave-coding-gopher-1", "brave-coding-gopher-2",
+		"calm-coding-gopher-0", "calm-coding-gopher-1", "calm-coding-gopher-2",
+	}, names)
+
+	// Only 6 distinct names exist
+	cmd.Count = 7
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not enough distinct haiku names")
+}
+
+func TestHaikuGenerateCmd_NegativeCountFlag(t *testing.T) {
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", Count: -1}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--count cannot be negative")
+}
+
+func TestHaikuGenerateCmd_Suffix(t *testing.T) {
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", NoToken: true, Suffix: 10}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	parts := strings.Split(strings.TrimSpace(output), "-")
+	require.Len(t, parts[len(parts)-1], 10)
+}
+
+func TestHaikuGenerateCmd_SuffixKey(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(dir+"/adjectives.txt", []byte("brave\n"), 0o644))
+	require.NoError(t, os.WriteFile(dir+"/actions.txt", []byte("coding\n"), 0o644))
+	require.NoError(t, os.WriteFile(dir+"/nouns.txt", []byte("gopher\n"), 0o644))
+
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", NoToken: true, Words: dir, Suffix: 8}
+	ctx := testutil.NewTestContext()
+
+	// Base32 of the SHA-256 hash of "user-42" is stable
+	cmd.SuffixKey = "user-42"
+	first := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	second := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, first, second)
+	require.Regexp(t, `^brave-coding-gopher-[0-9a-z]{8}\n$`, first)
+}
+
+func TestHaikuGenerateCmd_SuffixValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  cli.HaikuGenerateCmd
+		want string
+	}{
+		{"key without suffix", cli.HaikuGenerateCmd{Delim: "-", SuffixKey: "id"}, "--suffix-key requires --suffix"},
+		{"key with count", cli.HaikuGenerateCmd{Delim: "-", Suffix: 8, SuffixKey: "id", Count: 2}, "cannot be used with --count"},
+		{"suffix too long", cli.HaikuGenerateCmd{Delim: "-", Suffix: 33}, "suffix length must be between 1 and 32"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Run(testutil.NewTestContext())
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestHaikuGenerateCmd_DNSLabel(t *testing.T) {
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", Nouns: 6, Token: 1 << 40, TokenFormat: "base62", DNS: true, Truncate: true}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	name := strings.TrimSpace(output)
+	require.LessOrEqual(t, len(name), 63)
+	require.Regexp(t, `^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`, name)
+}
+
+func TestHaikuGenerateCmd_MaxLength(t *testing.T) {
+	cmd := &cli.HaikuGenerateCmd{Delim: "-", NoToken: true, MaxLength: 18, Count: 20}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	for _, name := range strings.Split(strings.TrimSpace(output), "\n") {
+		require.LessOrEqual(t, len(name), 18, name)
+	}
+}
+
+func TestHaikuGenerateCmd_LengthValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  cli.HaikuGenerateCmd
+		want string
+	}{
+		{"negative max length", cli.HaikuGenerateCmd{Delim: "-", MaxLength: -1}, "--max-length cannot be negative"},
+		{"truncate without limit", cli.HaikuGenerateCmd{Delim: "-", Truncate: true}, "--truncate requires --max-length or --dns"},
+		{"dns with underscore", cli.HaikuGenerateCmd{Delim: "_", DNS: true}, "DNS labels only allow the '-' delimiter"},
+		{"unreachable length", cli.HaikuGenerateCmd{Delim: "-", MaxLength: 3}, "no valid haiku name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Run(testutil.NewTestContext())
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 	"github.com/briandowns/spinner"
 
 	"github.com/bilte-co/toolshed/hash"
+	"github.com/bilte-co/toolshed/ignore"
 )
 
 // HashCmd represents the hash command group
@@ -21,6 +23,8 @@ type HashCmd struct {
 	HMAC     HMACCmd       `cmd:"" help:"Compute HMAC of data"`
 	Validate ValidateCmd   `cmd:"" help:"Validate file against expected hash"`
 	Compare  CompareCmd    `cmd:"" help:"Compare two hashes using constant-time comparison"`
+
+	CompareDirs CompareDirsCmd `cmd:"" name:"compare-dirs" help:"Compare the contents of two directories by hash"`
 }
 
 // HashStringCmd hashes a string
@@ -116,11 +120,13 @@ func (cmd *HashFileCmd) hashStdin(ctx *CLIContext) error {
 
 // HashDirCmd hashes a directory
 type HashDirCmd struct {
-	Path      string `arg:"" help:"Directory path to hash" type:"existingdir"`
-	Algo      string `short:"a" default:"sha256" help:"Hash algorithm (md5, sha1, sha256, sha512, blake2b)"`
-	Format    string `short:"f" default:"hex" help:"Output format (hex, base64, raw)"`
-	Prefix    bool   `short:"p" help:"Prefix output with algorithm name"`
-	Recursive bool   `short:"r" default:"true" help:"Hash directories recursively"`
+	Path       string   `arg:"" help:"Directory path to hash" type:"existingdir"`
+	Algo       string   `short:"a" default:"sha256" help:"Hash algorithm (md5, sha1, sha256, sha512, blake2b)"`
+	Format     string   `short:"f" default:"hex" help:"Output format (hex, base64, raw)"`
+	Prefix     bool     `short:"p" help:"Prefix output with algorithm name"`
+	Recursive  bool     `short:"r" default:"true" help:"Hash directories recursively"`
+	Exclude    []string `short:"x" sep:"none" help:"Exclude paths matching a gitignore-style pattern (repeatable)"`
+	IgnoreFile string   `long:"ignore-file" help:"Read gitignore-style exclude patterns from a file" type:"existingfile"`
 }
 
 func (cmd *HashDirCmd) Run(ctx *CLIContext) error {
@@ -135,9 +141,19 @@ func (cmd *HashDirCmd) Run(ctx *CLIContext) error {
 	s.Start()
 	defer s.Stop()
 
+	exclude, err := buildExcludeMatcher(cmd.Exclude, cmd.IgnoreFile)
+	if err != nil {
+		ctx.Logger.Error("Failed to load exclude patterns", "error", err)
+		return err
+	}
+	if exclude.Len() > 0 {
+		ctx.Logger.Debug("Excluding paths", "patterns", exclude.Patterns())
+	}
+
 	opts := hash.Options{
-		Format: hash.Format(cmd.Format),
-		Prefix: cmd.Prefix,
+		Format:  hash.Format(cmd.Format),
+		Prefix:  cmd.Prefix,
+		Exclude: exclude,
 	}
 
 	result, err := hash.HashDirWithOptions(cleanPath, cmd.Algo, cmd.Recursive, opts)
@@ -152,6 +168,25 @@ func (cmd *HashDirCmd) Run(ctx *CLIContext) error {
 	return nil
 }
 
+// buildExcludeMatcher combines an ignore file and --exclude patterns.
+// Command-line patterns are applied last so they can override the file.
+func buildExcludeMatcher(patterns []string, ignoreFile string) (*ignore.Matcher, error) {
+	exclude := ignore.New()
+	if ignoreFile != "" {
+		var err error
+		exclude, err = ignore.ParseFile(filepath.Clean(ignoreFile))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pattern := range patterns {
+		exclude.Add(pattern)
+	}
+
+	return exclude, nil
+}
+
 // HMACCmd computes HMAC
 type HMACCmd struct {
 	Text   string `arg:"" help:"Text to compute HMAC for"`
@@ -246,3 +281,100 @@ func (cmd *CompareCmd) Run(ctx *CLIContext) error {
 
 	return nil
 }
+
+// CompareDirsCmd compares two directory trees by content hash
+type CompareDirsCmd struct {
+	DirA       string   `arg:"" help:"First directory" type:"existingdir"`
+	DirB       string   `arg:"" help:"Second directory" type:"existingdir"`
+	Algo       string   `short:"a" default:"sha256" help:"Hash algorithm (md5, sha1, sha256, sha512, blake2b)"`
+	JSON       bool     `long:"json" help:"Output the comparison report as JSON"`
+	Exclude    []string `short:"x" sep:"none" help:"Exclude paths matching a gitignore-style pattern (repeatable)"`
+	IgnoreFile string   `long:"ignore-file" help:"Read gitignore-style exclude patterns from a file" type:"existingfile"`
+	Workers    int      `short:"w" help:"Number of parallel hashing workers (default: number of CPUs)"`
+}
+
+func (cmd *CompareDirsCmd) Run(ctx *CLIContext) error {
+	dirA := filepath.Clean(cmd.DirA)
+	dirB := filepath.Clean(cmd.DirB)
+	ctx.Logger.Debug("Comparing directories", "a", dirA, "b", dirB, "algorithm", cmd.Algo)
+
+	exclude, err := buildExcludeMatcher(cmd.Exclude, cmd.IgnoreFile)
+	if err != nil {
+		ctx.Logger.Error("Failed to load exclude patterns", "error", err)
+		return err
+	}
+
+	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
+	s.Suffix = " Comparing directories..."
+	s.Writer = os.Stderr
+	s.Start()
+	defer s.Stop()
+
+	opts := hash.Options{
+		Workers: cmd.Workers,
+		Exclude: exclude,
+	}
+
+	report, err := hash.CompareDirs(dirA, dirB, cmd.Algo, opts)
+	s.Stop()
+	if err != nil {
+		ctx.Logger.Error("Failed to compare directories", "error", err)
+		return fmt.Errorf("failed to compare directories: %w", err)
+	}
+
+	if cmd.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		printDirComparison(report)
+	}
+
+	if report.Equal() {
+		ctx.Logger.Info("Directories match", "files", report.Identical)
+		return nil
+	}
+
+	ctx.Logger.Info("Directories differ",
+		"only_in_a", len(report.OnlyInA),
+		"only_in_b", len(report.OnlyInB),
+		"different", len(report.Different))
+
+	// Exit with non-zero code when the directories differ
+	ExitFunc(1)
+	return nil
+}
+
+// printDirComparison prints a human-readable directory comparison report
+func printDirComparison(report *hash.DirComparison) {
+	if len(report.OnlyInA) > 0 {
+		fmt.Printf("Only in %s:\n", report.DirA)
+		for _, path := range report.OnlyInA {
+			fmt.Printf("  - %s\n", path)
+		}
+	}
+
+	if len(report.OnlyInB) > 0 {
+		fmt.Printf("Only in %s:\n", report.DirB)
+		for _, path := range report.OnlyInB {
+			fmt.Printf("  + %s\n", path)
+		}
+	}
+
+	if len(report.Different) > 0 {
+		fmt.Println("Content differs:")
+		for _, diff := range report.Different {
+			fmt.Printf("  ~ %s\n", diff.Path)
+		}
+	}
+
+	if report.Equal() {
+		fmt.Printf("✓ Directories are identical (%d files)\n", report.Identical)
+		return
+	}
+
+	fmt.Printf("✗ Directories differ: %d only in first, %d only in second, %d different, %d identical\n",
+		len(report.OnlyInA), len(report.OnlyInB), len(report.Different), report.Identical)
+}
@@ -2,10 +2,13 @@ package cli_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/alecthomas/kong"
 	"github.com/bilte-co/toolshed/hash"
 	"github.com/bilte-co/toolshed/internal/cli"
 	"github.com/bilte-co/toolshed/internal/testutil"
@@ -274,6 +277,116 @@ func TestHashDirCmd_NonexistentDirectory(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestHashDirCmd_Exclude(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "keep.txt"), []byte("keep"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "skip.log"), []byte("skip"), 0644))
+
+	ignoreFile := filepath.Join(t.TempDir(), ".gitignore")
+	require.NoError(t, os.WriteFile(ignoreFile, []byte("# logs\n*.log\n"), 0644))
+
+	cmd := &cli.HashDirCmd{
+		Path:       tmpDir,
+		Algo:       "sha256",
+		Format:     "hex",
+		Recursive:  true,
+		Exclude:    []string{"tmp/"},
+		IgnoreFile: ignoreFile,
+	}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.NoError(t, err)
+}
+
+func TestHashDirCmd_ExcludeFlagKeepsCommas(t *testing.T) {
+	var app struct {
+		Hash cli.HashCmd `cmd:""`
+	}
+	parser, err := kong.New(&app)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	_, err = parser.Parse([]string{"hash", "dir", dir, "-x", "*.{log,tmp}", "-x", "build/"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"*.{log,tmp}", "build/"}, app.Hash.Dir.Exclude)
+
+	_, err = parser.Parse([]string{"hash", "compare-dirs", dir, dir, "-x", "*.{log,tmp}"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"*.{log,tmp}"}, app.Hash.CompareDirs.Exclude)
+}
+
+func TestHashDirCmd_MissingIgnoreFile(t *testing.T) {
+	cmd := &cli.HashDirCmd{
+		Path:       t.TempDir(),
+		Algo:       "sha256",
+		Format:     "hex",
+		IgnoreFile: "/nonexistent/.gitignore",
+	}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to open ignore file")
+}
+
+func TestCompareDirsCmd_Identical(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	for _, dir := range []string{dirA, dirB} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "file.txt"), []byte("same"), 0644))
+	}
+
+	oldExit := cli.ExitFunc
+	exitCode := -1
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	cmd := &cli.CompareDirsCmd{DirA: dirA, DirB: dirB, Algo: "sha256"}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, -1, exitCode, "Should not exit for identical directories")
+}
+
+func TestCompareDirsCmd_DifferentJSON(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dirA, "file.txt"), []byte("one"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dirB, "file.txt"), []byte("two"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dirB, "extra.txt"), []byte("extra"), 0644))
+
+	oldExit := cli.ExitFunc
+	var exitCode int
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	os.Stdout = w
+
+	cmd := &cli.CompareDirsCmd{DirA: dirA, DirB: dirB, Algo: "sha256", JSON: true}
+	ctx := testutil.NewTestContext()
+
+	go func() {
+		defer w.Close()
+		err := cmd.Run(ctx)
+		require.NoError(t, err)
+	}()
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var report hash.DirComparison
+	require.NoError(t, json.Unmarshal(output, &report))
+	require.Equal(t, []string{"extra.txt"}, report.OnlyInB)
+	require.Len(t, report.Different, 1)
+	require.Equal(t, "file.txt", report.Different[0].Path)
+	require.Equal(t, 1, exitCode, "Should exit with code 1 when directories differ")
+}
+
 func TestHMACCmd_BasicOperation(t *testing.T) {
 	cmd := &cli.HMACCmd{
 		Text:   "test message",
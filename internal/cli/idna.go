@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// EncodeIDNACmd converts internationalized domain names to their ASCII form
+type EncodeIDNACmd struct {
+	Domains []string `arg:"" optional:"" help:"Unicode domains to convert (use '-' or omit to read one per line from stdin)"`
+}
+
+// Run executes the encode idna command
+func (cmd *EncodeIDNACmd) Run(ctx *CLIContext) error {
+	return convertDomains(ctx, cmd.Domains, "encode", idna.Lookup.ToASCII)
+}
+
+// DecodeIDNACmd converts punycode (xn--) domain names to Unicode
+type DecodeIDNACmd struct {
+	Domains []string `arg:"" optional:"" help:"Punycode domains to convert (use '-' or omit to read one per line from stdin)"`
+}
+
+// Run executes the decode idna command
+func (cmd *DecodeIDNACmd) Run(ctx *CLIContext) error {
+	return convertDomains(ctx, cmd.Domains, "decode", func(domain string) (string, error) {
+		decoded, err := idna.Display.ToUnicode(domain)
+		if err != nil {
+			return "", err
+		}
+		// Lookalike domains often combine letters from several scripts
+		for _, label := range strings.Split(decoded, ".") {
+			if scripts := labelScripts(label); len(scripts) > 1 {
+				ctx.Logger.Warn("Domain label mixes scripts, possible homograph", "domain", domain, "label", label, "scripts", strings.Join(scripts, ", "))
+			}
+		}
+		return decoded, nil
+	})
+}
+
+// convertDomains converts each domain with convert and prints the results
+func convertDomains(ctx *CLIContext, domains []string, action string, convert func(string) (string, error)) error {
+	if len(domains) == 0 || (len(domains) == 1 && domains[0] == "-") {
+		data, err := readStdin()
+		if err != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", err)
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		domains = nil
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				domains = append(domains, line)
+			}
+		}
+	}
+
+	if len(domains) == 0 {
+		ctx.Logger.Error("Empty domain input")
+		return fmt.Errorf("domain cannot be empty")
+	}
+
+	for _, domain := range domains {
+		converted, err := convert(domain)
+		if err != nil {
+			ctx.Logger.Error("Failed to "+action+" domain", "domain", domain, "error", err)
+			return fmt.Errorf("failed to %s %s: %w", action, domain, err)
+		}
+		fmt.Println(converted)
+	}
+
+	ctx.Logger.Info("Domains converted successfully", "count", len(domains))
+	return nil
+}
+
+// homographScripts are the scripts checked for in domain labels. Common and
+// Inherited characters such as digits and hyphens belong to none of them.
+var homographScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Greek", unicode.Greek},
+	{"Armenian", unicode.Armenian},
+	{"Hebrew", unicode.Hebrew},
+	{"Arabic", unicode.Arabic},
+	{"Devanagari", unicode.Devanagari},
+	{"Thai", unicode.Thai},
+	{"Han", unicode.Han},
+	{"Hiragana", unicode.Hiragana},
+	{"Katakana", unicode.Katakana},
+	{"Hangul", unicode.Hangul},
+}
+
+// labelScripts returns the scripts used by the letters of label, in the
+// order of homographScripts
+func labelScripts(label string) []string {
+	var scripts []string
+	for _, script := range homographScripts {
+		for _, r := range label {
+			if unicode.Is(script.table, r) {
+				scripts = append(scripts, script.name)
+				break
+			}
+		}
+	}
+	return scripts
+}
@@ -0,0 +1,54 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+)
+
+func TestEncodeIDNACmd_Run(t *testing.T) {
+	cmd := &cli.EncodeIDNACmd{Domains: []string{"bücher.example", "Straße.de", "example.com"}}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	// IDNA2008 keeps ß rather than mapping it to ss
+	require.Equal(t, "xn--bcher-kva.example\nxn--strae-oqa.de\nexample.com\n", output)
+}
+
+func TestEncodeIDNACmd_Invalid(t *testing.T) {
+	cmd := &cli.EncodeIDNACmd{Domains: []string{"bad_label.com"}}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to encode bad_label.com")
+}
+
+func TestDecodeIDNACmd_Run(t *testing.T) {
+	// The second domain spells apple with a Cyrillic а
+	cmd := &cli.DecodeIDNACmd{Domains: []string{"xn--bcher-kva.example", "xn--pple-43d.com"}}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, "bücher.example\nаpple.com\n", output)
+}
+
+func TestDecodeIDNACmd_Stdin(t *testing.T) {
+	restore := replaceStdin(t, "xn--bcher-kva.example\n\nexample.com\n")
+	defer restore()
+
+	cmd := &cli.DecodeIDNACmd{}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, "bücher.example\nexample.com\n", output)
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/bilte-co/toolshed/jwt"
+)
+
+// DecodeCmd represents the decode command group for structured tokens
+type DecodeCmd struct {
+	Auto    DecodeAutoCmd    `cmd:"" default:"withargs" help:"Detect whether input is hex, base32, base64, base64url or base62 and decode it"`
+	JWT     DecodeJWTCmd     `cmd:"" name:"jwt" help:"Decode a JWT and show its header and claims, optionally verifying it"`
+	IDNA    DecodeIDNACmd    `cmd:"" name:"idna" help:"Convert punycode (xn--) domain names to Unicode"`
+	DataURI DecodeDataURICmd `cmd:"" name:"data-uri" help:"Write the data of a data URI back out as bytes"`
+}
+
+// Validate rejects --auto in front of another decode subcommand, which would
+// otherwise be parsed as a flag of the default auto subcommand and ignored
+func (cmd *DecodeCmd) Validate(kctx *kong.Context) error {
+	if selected := kctx.Selected(); cmd.Auto.Auto && selected != nil && selected.Name != "auto" {
+		return fmt.Errorf("--auto cannot be used with decode %s", selected.Name)
+	}
+	return nil
+}
+
+// DecodeJWTCmd decodes and optionally verifies a JSON Web Token
+type DecodeJWTCmd struct {
+	Token   string        `arg:"" help:"JWT to decode (use '-' to read from stdin)"`
+	Secret  string        `env:"TOOLSHED_JWT_SECRET" help:"Verify an HMAC (HS256/384/512) signature with this secret"`
+	JWKSURL string        `name:"jwks-url" help:"Verify the signature with the keys published at this JWKS URL"`
+	Timeout time.Duration `default:"10s" help:"Timeout for fetching the JWKS"`
+	JSON    bool          `long:"json" help:"Output as JSON"`
+}
+
+// jwtInspection is the JSON output of the decode jwt command
+type jwtInspection struct {
+	Header    json.RawMessage `json:"header"`
+	Payload   json.RawMessage `json:"payload"`
+	IssuedAt  *idTimestamp    `json:"issued_at,omitempty"`
+	NotBefore *idTimestamp    `json:"not_before,omitempty"`
+	ExpiresAt *idTimestamp    `json:"expires_at,omitempty"`
+	Expired   bool            `json:"expired"`
+	Verified  bool            `json:"verified"`
+}
+
+// Validate validates the command arguments
+func (cmd *DecodeJWTCmd) Validate() error {
+	if cmd.Secret != "" && cmd.JWKSURL != "" {
+		return fmt.Errorf("--secret and --jwks-url cannot be used together")
+	}
+	return nil
+}
+
+// Run executes the decode jwt command
+func (cmd *DecodeJWTCmd) Run(ctx *CLIContext) error {
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid JWT options", "error", err)
+		return err
+	}
+
+	input := cmd.Token
+	if input == "-" {
+		data, err := readStdin()
+		if err != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", err)
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		input = string(data)
+	}
+
+	token, err := jwt.Parse(input)
+	if err != nil {
+		ctx.Logger.Error("Failed to parse JWT", "error", err)
+		return err
+	}
+	ctx.Logger.Debug("Decoded JWT", "alg", token.Algorithm(), "kid", token.KeyID())
+
+	now := time.Now()
+	verify := cmd.Secret != "" || cmd.JWKSURL != ""
+	var signatureErr error
+	if verify {
+		signatureErr = cmd.verify(token)
+	}
+
+	if cmd.JSON {
+		if err := cmd.writeJSON(token, now, verify && signatureErr == nil); err != nil {
+			ctx.Logger.Error("Failed to encode JWT", "error", err)
+			return fmt.Errorf("failed to encode JWT: %w", err)
+		}
+	} else {
+		printJWT(token, now, verify, signatureErr)
+	}
+
+	if signatureErr != nil {
+		ctx.Logger.Error("JWT signature verification failed", "error", signatureErr)
+		return fmt.Errorf("JWT verification failed: %w", signatureErr)
+	}
+
+	// A verified token is only valid within its validity period
+	switch {
+	case verify && token.Expired(now):
+		ctx.Logger.Error("JWT is expired")
+		return fmt.Errorf("JWT verification failed: token is expired")
+	case verify && token.NotYetValid(now):
+		ctx.Logger.Error("JWT is not valid yet")
+		return fmt.Errorf("JWT verification failed: token is not valid yet")
+	case token.Expired(now):
+		ctx.Logger.Warn("JWT is expired")
+	}
+	return nil
+}
+
+// verify checks the token signature against the secret or JWKS
+func (cmd *DecodeJWTCmd) verify(token *jwt.Token) error {
+	if cmd.Secret != "" {
+		return token.Verify([]byte(cmd.Secret))
+	}
+
+	reqCtx, cancel := context.WithTimeout(context.Background(), cmd.Timeout)
+	defer cancel()
+
+	set, err := jwt.FetchJWKS(reqCtx, &http.Client{Timeout: cmd.Timeout}, cmd.JWKSURL)
+	if err != nil {
+		return err
+	}
+	return set.Verify(token)
+}
+
+func (cmd *DecodeJWTCmd) writeJSON(token *jwt.Token, now time.Time, verified bool) error {
+	inspection := jwtInspection{
+		Header:    token.RawHeader,
+		Payload:   token.RawClaims,
+		IssuedAt:  jwtTimestamp(token, "iat"),
+		NotBefore: jwtTimestamp(token, "nbf"),
+		ExpiresAt: jwtTimestamp(token, "exp"),
+		Expired:   token.Expired(now),
+		Verified:  verified,
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(inspection)
+}
+
+// jwtTimestamp returns the numeric date claim name, or nil if it is missing
+func jwtTimestamp(token *jwt.Token, name string) *idTimestamp {
+	t, ok := token.Time(name)
+	if !ok {
+		return nil
+	}
+	ts := newIDTimestamp(t)
+	return &ts
+}
+
+// printJWT prints the header, claims, validity period and signature status
+func printJWT(token *jwt.Token, now time.Time, verify bool, signatureErr error) {
+	fmt.Println("Header:")
+	fmt.Println(indentJSON(token.RawHeader))
+	fmt.Println()
+	fmt.Println("Payload:")
+	fmt.Println(indentJSON(token.RawClaims))
+	fmt.Println()
+
+	if iat, ok := token.Time("iat"); ok {
+		fmt.Printf("Issued at:  %s\n", iat.Format(time.RFC3339))
+	}
+	if nbf, ok := token.Time("nbf"); ok {
+		status := ""
+		if token.NotYetValid(now) {
+			status = fmt.Sprintf(" (not valid for another %s)", nbf.Sub(now).Round(time.Second))
+		}
+		fmt.Printf("Not before: %s%s\n", nbf.Format(time.RFC3339), status)
+	}
+	if exp, ok := token.Time("exp"); ok {
+		status := fmt.Sprintf(" (expires in %s)", exp.Sub(now).Round(time.Second))
+		if token.Expired(now) {
+			status = fmt.Sprintf(" (✗ expired %s ago)", now.Sub(exp).Round(time.Second))
+		}
+		fmt.Printf("Expires:    %s%s\n", exp.Format(time.RFC3339), status)
+	}
+
+	switch {
+	case !verify:
+		fmt.Printf("Signature:  not verified (%s; use --secret or --jwks-url)\n", token.Algorithm())
+	case signatureErr != nil:
+		fmt.Printf("Signature:  ✗ %v\n", signatureErr)
+	default:
+		fmt.Printf("Signature:  ✓ valid (%s)\n", token.Algorithm())
+	}
+}
+
+// indentJSON pretty-prints JSON, keeping the order of its keys
+func indentJSON(data []byte) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return strings.TrimSpace(string(data))
+	}
+	return buf.String()
+}
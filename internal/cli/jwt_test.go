@@ -0,0 +1,124 @@
+package cli_test
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+)
+
+// hs256Token signs claims with HS256
+func hs256Token(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	input := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestDecodeJWTCmd_Decode(t *testing.T) {
+	token := hs256Token(t, "secret", map[string]any{"sub": "alice", "exp": time.Now().Add(time.Hour).Unix()})
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, (&cli.DecodeJWTCmd{Token: token}).Run(ctx))
+	})
+	require.Contains(t, output, "Header:\n{\n  \"alg\": \"HS256\",\n  \"typ\": \"JWT\"\n}")
+	require.Contains(t, output, `"sub": "alice"`)
+	require.Contains(t, output, "expires in")
+	require.Contains(t, output, "Signature:  not verified")
+}
+
+func TestDecodeJWTCmd_Expired(t *testing.T) {
+	token := hs256Token(t, "secret", map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})
+	ctx := testutil.NewTestContext()
+
+	// Without verification an expired token is flagged but decodes successfully
+	output := captureStdout(t, func() {
+		require.NoError(t, (&cli.DecodeJWTCmd{Token: token}).Run(ctx))
+	})
+	require.Contains(t, output, "✗ expired")
+
+	// With verification it fails even though the signature is valid
+	var err error
+	output = captureStdout(t, func() {
+		err = (&cli.DecodeJWTCmd{Token: token, Secret: "secret"}).Run(ctx)
+	})
+	require.ErrorContains(t, err, "token is expired")
+	require.Contains(t, output, "Signature:  ✓ valid (HS256)")
+}
+
+func TestDecodeJWTCmd_Secret(t *testing.T) {
+	token := hs256Token(t, "secret", map[string]any{"sub": "alice"})
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, (&cli.DecodeJWTCmd{Token: token, Secret: "secret"}).Run(ctx))
+	})
+	require.Contains(t, output, "Signature:  ✓ valid (HS256)")
+
+	var err error
+	output = captureStdout(t, func() {
+		err = (&cli.DecodeJWTCmd{Token: token, Secret: "wrong"}).Run(ctx)
+	})
+	require.ErrorContains(t, err, "invalid JWT signature")
+	require.Contains(t, output, "Signature:  ✗")
+}
+
+func TestDecodeJWTCmd_JWKS(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"k1","x":%q}]}`, base64.RawURLEncoding.EncodeToString(pub))
+	}))
+	defer server.Close()
+
+	input := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA","kid":"k1"}`)) + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"alice"}`))
+	token := input + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, []byte(input)))
+
+	cmd := &cli.DecodeJWTCmd{Token: token, JWKSURL: server.URL, Timeout: 5 * time.Second, JSON: true}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+
+	var result struct {
+		Header   map[string]any `json:"header"`
+		Payload  map[string]any `json:"payload"`
+		Expired  bool           `json:"expired"`
+		Verified bool           `json:"verified"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	require.Equal(t, "EdDSA", result.Header["alg"])
+	require.Equal(t, "alice", result.Payload["sub"])
+	require.False(t, result.Expired)
+	require.True(t, result.Verified)
+}
+
+func TestDecodeJWTCmd_Invalid(t *testing.T) {
+	ctx := testutil.NewTestContext()
+
+	err := (&cli.DecodeJWTCmd{Token: "not-a-jwt"}).Run(ctx)
+	require.ErrorContains(t, err, "malformed JWT")
+
+	err = (&cli.DecodeJWTCmd{Token: "a.b.c", Secret: "s", JWKSURL: "http://example.com"}).Run(ctx)
+	require.ErrorContains(t, err, "cannot be used together")
+}
@@ -2,11 +2,16 @@ package cli
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bilte-co/toolshed/password"
 )
@@ -17,12 +22,30 @@ var ExitFunc = os.Exit
 // PasswordCmd represents the password command group
 type PasswordCmd struct {
 	Check PasswordCheckCmd `cmd:"" help:"Check password strength"`
+	Pwned PasswordPwnedCmd `cmd:"" help:"Check password against known data breaches (Have I Been Pwned)"`
+	Audit PasswordAuditCmd `cmd:"" help:"Audit a list of passwords from a file or stdin"`
+	PIN   PasswordPINCmd   `cmd:"" name:"pin" help:"Generate cryptographically secure numeric codes"`
 }
 
 // PasswordCheckCmd checks password strength
 type PasswordCheckCmd struct {
-	Text    string  `arg:"" optional:"" help:"Password to check (use '-' for stdin)"`
-	Entropy float64 `long:"entropy" help:"Custom minimum entropy requirement (default: 60.0)"`
+	Text    string        `arg:"" optional:"" help:"Password to check (use '-' for stdin)"`
+	Entropy float64       `long:"entropy" help:"Custom minimum entropy requirement (default: 60.0)"`
+	JSON    bool          `long:"json" help:"Output a detailed strength report as JSON"`
+	Preset  string        `long:"preset" help:"Check compliance with a policy preset instead of entropy (nist)"`
+	Offline bool          `long:"offline" help:"Do not access the network (presets that screen breached passwords fail explicitly)"`
+	Timeout time.Duration `long:"timeout" default:"10s" help:"Timeout for the breached password API request"`
+	APIURL  string        `long:"api-url" hidden:"" help:"Pwned Passwords range API endpoint (default: api.pwnedpasswords.com)"`
+}
+
+// passwordCheckReport is the JSON output of the password check command
+type passwordCheckReport struct {
+	Valid           bool                   `json:"valid"`
+	RequiredEntropy float64                `json:"required_entropy,omitempty"`
+	Message         string                 `json:"message,omitempty"`
+	Feedback        password.Feedback      `json:"feedback"`
+	Policy          *password.PolicyResult `json:"policy,omitempty"`
+	password.Analysis
 }
 
 func (cmd *PasswordCheckCmd) Run(ctx *CLIContext) error {
@@ -32,7 +55,7 @@ func (cmd *PasswordCheckCmd) Run(ctx *CLIContext) error {
 	// Handle input source
 	if cmd.Text == "" || cmd.Text == "-" {
 		ctx.Logger.Debug("Reading password from stdin")
-		passwordText, err = cmd.readPasswordFromStdin()
+		passwordText, err = readPasswordFromStdin()
 		if err != nil {
 			ctx.Logger.Error("Failed to read password from stdin", "error", err)
 			return fmt.Errorf("failed to read password from stdin: %w", err)
@@ -47,6 +70,10 @@ func (cmd *PasswordCheckCmd) Run(ctx *CLIContext) error {
 		return fmt.Errorf("password cannot be empty")
 	}
 
+	if cmd.Preset != "" {
+		return cmd.checkPreset(ctx, passwordText)
+	}
+
 	ctx.Logger.Debug("Checking password strength", "length", len(passwordText), "entropy", cmd.Entropy)
 
 	// Use custom entropy if provided, otherwise use the password package's default
@@ -61,6 +88,10 @@ func (cmd *PasswordCheckCmd) Run(ctx *CLIContext) error {
 		ctx.Logger.Debug("Using default entropy", "minimum", password.DefaultEntropy)
 	}
 
+	if cmd.JSON {
+		return cmd.writeJSON(ctx, passwordText, valid, message)
+	}
+
 	// Output results
 	if valid {
 		ctx.Logger.Info("Password validation successful")
@@ -72,6 +103,9 @@ func (cmd *PasswordCheckCmd) Run(ctx *CLIContext) error {
 		} else {
 			fmt.Printf("  Meets minimum entropy requirement: %.1f\n", password.DefaultEntropy)
 		}
+
+		// Predictable patterns are worth pointing out even when the entropy is sufficient
+		printFeedback(password.GetFeedback(passwordText))
 		return nil
 	}
 
@@ -87,14 +121,129 @@ func (cmd *PasswordCheckCmd) Run(ctx *CLIContext) error {
 		fmt.Printf("  Required entropy: %.1f\n", password.DefaultEntropy)
 	}
 
+	printFeedback(password.GetFeedback(passwordText))
+
 	// Exit with non-zero code on validation failure
 	ExitFunc(1)
 	return nil
 }
 
+// checkPreset checks the password for compliance with a policy preset
+func (cmd *PasswordCheckCmd) checkPreset(ctx *CLIContext, passwordText string) error {
+	policy, err := password.Preset(cmd.Preset)
+	if err != nil {
+		ctx.Logger.Error("Unknown policy preset", "preset", cmd.Preset)
+		return err
+	}
+
+	client := &password.PwnedClient{
+		BaseURL: cmd.APIURL,
+		Offline: cmd.Offline,
+	}
+
+	reqCtx := context.Background()
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(reqCtx, cmd.Timeout)
+		defer cancel()
+	}
+
+	ctx.Logger.Debug("Checking password policy compliance", "policy", policy.Name, "offline", cmd.Offline)
+	result, err := policy.Evaluate(reqCtx, passwordText, client)
+	if err != nil {
+		ctx.Logger.Error("Policy check failed", "policy", policy.Name, "error", err)
+		return fmt.Errorf("failed to check password against %s policy: %w", policy.Description, err)
+	}
+
+	if cmd.JSON {
+		messages := make([]string, len(result.Violations))
+		for i, v := range result.Violations {
+			messages[i] = "password " + v.Message
+		}
+
+		report := passwordCheckReport{
+			Valid:    result.Compliant,
+			Message:  strings.Join(messages, "; "),
+			Feedback: password.GetFeedback(passwordText),
+			Policy:   result,
+			Analysis: password.Analyze(passwordText),
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			ctx.Logger.Error("Failed to encode report", "error", err)
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else if result.Compliant {
+		fmt.Printf("✓ Password complies with %s\n", policy.Description)
+	} else {
+		fmt.Printf("✗ Password does not comply with %s\n", policy.Description)
+		for _, v := range result.Violations {
+			fmt.Printf("  - Password %s\n", v.Message)
+		}
+	}
+
+	if !result.Compliant {
+		ctx.Logger.Warn("Password policy check failed", "policy", policy.Name, "violations", len(result.Violations))
+		// Exit with non-zero code on validation failure
+		ExitFunc(1)
+		return nil
+	}
+
+	ctx.Logger.Info("Password policy check successful", "policy", policy.Name)
+	return nil
+}
+
+// printFeedback prints the warning and suggestions for improving a password
+func printFeedback(feedback password.Feedback) {
+	if feedback.Warning != "" {
+		fmt.Printf("  Warning: %s\n", feedback.Warning)
+	}
+	if len(feedback.Suggestions) > 0 {
+		fmt.Println("  Suggestions:")
+		for _, suggestion := range feedback.Suggestions {
+			fmt.Printf("    - %s\n", suggestion)
+		}
+	}
+}
+
+// writeJSON prints a machine-readable strength report for the password
+func (cmd *PasswordCheckCmd) writeJSON(ctx *CLIContext, passwordText string, valid bool, message string) error {
+	required := cmd.Entropy
+	if required <= 0 {
+		required = password.DefaultEntropy
+	}
+
+	report := passwordCheckReport{
+		Valid:           valid,
+		RequiredEntropy: required,
+		Message:         message,
+		Feedback:        password.GetFeedback(passwordText),
+		Analysis:        password.Analyze(passwordText),
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		ctx.Logger.Error("Failed to encode report", "error", err)
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	if !valid {
+		ctx.Logger.Warn("Password validation failed", "reason", message)
+		// Exit with non-zero code on validation failure
+		ExitFunc(1)
+		return nil
+	}
+
+	ctx.Logger.Info("Password validation successful")
+	return nil
+}
+
 // readPasswordFromStdin reads a password from stdin
 // It handles both piped input and terminal input
-func (cmd *PasswordCheckCmd) readPasswordFromStdin() (string, error) {
+func readPasswordFromStdin() (string, error) {
 	// Check if input is available from pipe
 	stat, err := os.Stdin.Stat()
 	if err != nil {
@@ -103,16 +252,16 @@ func (cmd *PasswordCheckCmd) readPasswordFromStdin() (string, error) {
 
 	// If stdin is a pipe or file, read from it
 	if (stat.Mode() & os.ModeCharDevice) == 0 {
-		return cmd.readFromPipe()
+		return readPasswordFromPipe()
 	}
 
 	// If stdin is a terminal, prompt for input
 	fmt.Print("Enter password to check: ")
-	return cmd.readFromTerminal()
+	return readPasswordFromTerminal()
 }
 
-// readFromPipe reads password from piped input
-func (cmd *PasswordCheckCmd) readFromPipe() (string, error) {
+// readPasswordFromPipe reads password from piped input
+func readPasswordFromPipe() (string, error) {
 	input, err := io.ReadAll(os.Stdin)
 	if err != nil {
 		return "", fmt.Errorf("failed to read from pipe: %w", err)
@@ -127,8 +276,8 @@ func (cmd *PasswordCheckCmd) readFromPipe() (string, error) {
 	return password, nil
 }
 
-// readFromTerminal reads password from terminal input
-func (cmd *PasswordCheckCmd) readFromTerminal() (string, error) {
+// readPasswordFromTerminal reads password from terminal input
+func readPasswordFromTerminal() (string, error) {
 	scanner := bufio.NewScanner(os.Stdin)
 	if !scanner.Scan() {
 		if err := scanner.Err(); err != nil {
@@ -157,5 +306,298 @@ func (cmd *PasswordCheckCmd) Validate() error {
 		return fmt.Errorf("entropy value is unrealistically high, got: %s", strconv.FormatFloat(cmd.Entropy, 'f', 1, 64))
 	}
 
+	if cmd.Preset != "" {
+		if _, err := password.Preset(cmd.Preset); err != nil {
+			return err
+		}
+		// Presets define their own requirements
+		if cmd.Entropy > 0 {
+			return fmt.Errorf("--entropy cannot be combined with --preset")
+		}
+	}
+
+	return nil
+}
+
+// PasswordPwnedCmd checks a password against the Have I Been Pwned database.
+// Only the first 5 characters of the password's SHA-1 hash leave the machine.
+type PasswordPwnedCmd struct {
+	Text    string        `arg:"" optional:"" help:"Password to check (use '-' for stdin)"`
+	Offline bool          `long:"offline" help:"Do not access the network (the check fails with an explicit error)"`
+	Timeout time.Duration `long:"timeout" default:"10s" help:"Timeout for the API request"`
+	APIURL  string        `long:"api-url" hidden:"" help:"Pwned Passwords range API endpoint (default: api.pwnedpasswords.com)"`
+}
+
+func (cmd *PasswordPwnedCmd) Run(ctx *CLIContext) error {
+	var passwordText string
+	var err error
+
+	// Handle input source
+	if cmd.Text == "" || cmd.Text == "-" {
+		ctx.Logger.Debug("Reading password from stdin")
+		passwordText, err = readPasswordFromStdin()
+		if err != nil {
+			ctx.Logger.Error("Failed to read password from stdin", "error", err)
+			return fmt.Errorf("failed to read password from stdin: %w", err)
+		}
+	} else {
+		passwordText = cmd.Text
+	}
+
+	if passwordText == "" {
+		ctx.Logger.Error("Password cannot be empty")
+		return fmt.Errorf("password cannot be empty")
+	}
+
+	client := &password.PwnedClient{
+		BaseURL: cmd.APIURL,
+		Offline: cmd.Offline,
+	}
+
+	reqCtx := context.Background()
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(reqCtx, cmd.Timeout)
+		defer cancel()
+	}
+
+	ctx.Logger.Debug("Querying pwned passwords API", "offline", cmd.Offline)
+	count, err := client.Check(reqCtx, passwordText)
+	if err != nil {
+		if errors.Is(err, password.ErrOffline) {
+			ctx.Logger.Error("Pwned check requires network access", "error", err)
+		} else {
+			ctx.Logger.Error("Pwned check failed", "error", err)
+		}
+		return fmt.Errorf("failed to check password: %w", err)
+	}
+
+	if count == 0 {
+		ctx.Logger.Info("Password not found in breach corpus")
+		fmt.Println("✓ Password not found in known data breaches")
+		return nil
+	}
+
+	ctx.Logger.Warn("Password found in breach corpus", "count", count)
+	fmt.Println("✗ Password has appeared in known data breaches")
+	fmt.Printf("  Times seen: %d\n", count)
+
+	// Exit with non-zero code when the password is compromised
+	ExitFunc(1)
+	return nil
+}
+
+// PasswordAuditCmd checks a list of passwords, one per line, for weak entropy,
+// predictable patterns, reuse and optionally known data breaches.
+type PasswordAuditCmd struct {
+	File    string        `short:"f" long:"file" type:"existingfile" help:"File containing one password per line (default: stdin)"`
+	Entropy float64       `long:"entropy" help:"Custom minimum entropy requirement (default: 60.0)"`
+	Workers int           `short:"w" long:"workers" help:"Number of concurrent workers (default: number of CPU cores)"`
+	Pwned   bool          `long:"pwned" help:"Also check each password against known data breaches (Have I Been Pwned)"`
+	Timeout time.Duration `long:"timeout" default:"10s" help:"Timeout for each breach API request"`
+	APIURL  string        `long:"api-url" hidden:"" help:"Pwned Passwords range API endpoint (default: api.pwnedpasswords.com)"`
+	JSON    bool          `long:"json" help:"Output the audit report as JSON"`
+}
+
+// passwordAuditEntry is an audit result annotated with its line in the input
+type passwordAuditEntry struct {
+	Line int `json:"line"`
+	password.AuditResult
+}
+
+// passwordAuditReport is the JSON output of the password audit command
+type passwordAuditReport struct {
+	Source string `json:"source"`
+	*password.AuditReport
+	Results []passwordAuditEntry `json:"results"`
+}
+
+func (cmd *PasswordAuditCmd) Run(ctx *CLIContext) error {
+	source := cmd.File
+	var input io.Reader = os.Stdin
+	if source == "" || source == "-" {
+		source = "stdin"
+		ctx.Logger.Debug("Reading passwords from stdin")
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			ctx.Logger.Error("Failed to open password file", "file", source, "error", err)
+			return fmt.Errorf("failed to open password file %s: %w", source, err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	passwords, lines, err := readPasswordList(input)
+	if err != nil {
+		ctx.Logger.Error("Failed to read passwords", "source", source, "error", err)
+		return fmt.Errorf("failed to read passwords from %s: %w", source, err)
+	}
+	if len(passwords) == 0 {
+		ctx.Logger.Error("No passwords to audit", "source", source)
+		return fmt.Errorf("no passwords found in %s", source)
+	}
+
+	opts := password.AuditOptions{
+		MinEntropy: cmd.Entropy,
+		Workers:    cmd.Workers,
+	}
+	if cmd.Pwned {
+		opts.Pwned = &password.PwnedClient{
+			HTTPClient: &http.Client{Timeout: cmd.Timeout},
+			BaseURL:    cmd.APIURL,
+		}
+	}
+
+	ctx.Logger.Debug("Auditing passwords", "count", len(passwords), "workers", cmd.Workers, "pwned", cmd.Pwned)
+	report := password.Audit(context.Background(), passwords, opts)
+
+	entries := make([]passwordAuditEntry, len(report.Results))
+	for i, result := range report.Results {
+		entries[i] = passwordAuditEntry{Line: lines[result.Index], AuditResult: result}
+	}
+
+	if cmd.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(passwordAuditReport{Source: source, AuditReport: report, Results: entries}); err != nil {
+			ctx.Logger.Error("Failed to encode report", "error", err)
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		printAuditReport(source, report, entries)
+	}
+
+	if !report.Passed() {
+		ctx.Logger.Warn("Password audit found problems",
+			"weak", report.Weak, "pwned", report.Pwned, "reused", report.Reused, "errors", report.Errors)
+		// Exit with non-zero code when any password fails the audit
+		ExitFunc(1)
+		return nil
+	}
+
+	ctx.Logger.Info("Password audit successful", "count", report.Total)
+	return nil
+}
+
+// Validate validates the command arguments
+func (cmd *PasswordAuditCmd) Validate() error {
+	if cmd.Entropy < 0 {
+		return fmt.Errorf("entropy value must be non-negative, got: %s", strconv.FormatFloat(cmd.Entropy, 'f', 1, 64))
+	}
+	if cmd.Entropy > 200 {
+		return fmt.Errorf("entropy value is unrealistically high, got: %s", strconv.FormatFloat(cmd.Entropy, 'f', 1, 64))
+	}
+	if cmd.Workers < 0 {
+		return fmt.Errorf("workers must be non-negative, got: %d", cmd.Workers)
+	}
+	return nil
+}
+
+// readPasswordList reads one password per line, skipping blank lines.
+// It returns the passwords along with their 1-based line numbers.
+func readPasswordList(r io.Reader) ([]string, []int, error) {
+	var passwords []string
+	var lines []int
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		// Only strip line endings: surrounding spaces are part of the password
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		passwords = append(passwords, line)
+		lines = append(lines, lineNo)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return passwords, lines, nil
+}
+
+// printAuditReport prints a summary of the audit followed by the failing entries.
+// Passwords are identified by line number and never printed.
+func printAuditReport(source string, report *password.AuditReport, entries []passwordAuditEntry) {
+	fmt.Printf("Audited %d passwords from %s\n", report.Total, source)
+	fmt.Printf("  Minimum entropy: %.1f\n", report.MinEntropy)
+	fmt.Printf("  Weak:            %d\n", report.Weak)
+	fmt.Printf("  Reused:          %d\n", report.Reused)
+	if report.BreachCheck {
+		fmt.Printf("  Breached:        %d\n", report.Pwned)
+		if report.Errors > 0 {
+			fmt.Printf("  Check errors:    %d\n", report.Errors)
+		}
+	}
+
+	if report.Passed() {
+		fmt.Println("✓ All passwords passed the audit")
+		return
+	}
+
+	fmt.Println("✗ Some passwords failed the audit")
+	for _, entry := range entries {
+		if !entry.Compromised() && entry.Error == "" {
+			continue
+		}
+
+		var problems []string
+		if !entry.Valid {
+			problems = append(problems, fmt.Sprintf("weak (entropy %.1f)", entry.Entropy))
+		}
+		if entry.Reused {
+			problems = append(problems, "reused")
+		}
+		if entry.PwnedCount > 0 {
+			problems = append(problems, fmt.Sprintf("seen in %d breaches", entry.PwnedCount))
+		}
+		if entry.Error != "" {
+			problems = append(problems, "breach check failed")
+		}
+		if len(entry.Patterns) > 0 {
+			patterns := make([]string, len(entry.Patterns))
+			for i, p := range entry.Patterns {
+				patterns[i] = string(p)
+			}
+			problems = append(problems, "patterns: "+strings.Join(patterns, ", "))
+		}
+
+		fmt.Printf("  line %d: %s\n", entry.Line, strings.Join(problems, "; "))
+	}
+}
+
+// PasswordPINCmd generates random numeric codes such as PINs and one-time codes
+type PasswordPINCmd struct {
+	Digits int `short:"d" default:"6" help:"Number of digits in each code"`
+	Count  int `short:"n" default:"1" help:"Number of codes to generate"`
+}
+
+func (cmd *PasswordPINCmd) Run(ctx *CLIContext) error {
+	ctx.Logger.Debug("Generating PINs", "digits", cmd.Digits, "count", cmd.Count)
+
+	for range cmd.Count {
+		pin, err := password.GeneratePIN(cmd.Digits)
+		if err != nil {
+			ctx.Logger.Error("Failed to generate PIN", "error", err)
+			return fmt.Errorf("failed to generate PIN: %w", err)
+		}
+		fmt.Println(pin)
+	}
+
+	ctx.Logger.Debug("PINs generated successfully", "count", cmd.Count)
+	return nil
+}
+
+// Validate validates the command arguments
+func (cmd *PasswordPINCmd) Validate() error {
+	if cmd.Digits < 1 || cmd.Digits > password.MaxPINDigits {
+		return fmt.Errorf("digits must be between 1 and %d, got: %d", password.MaxPINDigits, cmd.Digits)
+	}
+	if cmd.Count < 1 {
+		return fmt.Errorf("count must be at least 1, got: %d", cmd.Count)
+	}
 	return nil
 }
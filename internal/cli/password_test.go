@@ -1,7 +1,12 @@
 package cli_test
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -450,3 +455,373 @@ func TestPasswordCheckCmd_ReadFromTerminal(t *testing.T) {
 	err = cmd.Run(ctx)
 	require.NoError(t, err)
 }
+
+func TestPasswordPwnedCmd_Offline(t *testing.T) {
+	cmd := &cli.PasswordPwnedCmd{
+		Text:    "password",
+		Offline: true,
+	}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.Error(t, err)
+	require.ErrorIs(t, err, password.ErrOffline)
+}
+
+func TestPasswordPwnedCmd_Found(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+		require.Equal(t, "/5BAA6", r.URL.Path)
+		w.Write([]byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:42\r\n"))
+	}))
+	defer server.Close()
+
+	oldExit := cli.ExitFunc
+	var exitCode int
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	cmd := &cli.PasswordPwnedCmd{
+		Text:   "password",
+		APIURL: server.URL,
+	}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, exitCode, "Should exit with code 1 for breached password")
+}
+
+func TestPasswordPwnedCmd_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("0000000000000000000000000000000000A:0\r\n"))
+	}))
+	defer server.Close()
+
+	oldExit := cli.ExitFunc
+	exitCode := -1
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	cmd := &cli.PasswordPwnedCmd{
+		Text:   "MyStr0ng!P@ssw0rd2024",
+		APIURL: server.URL,
+	}
+	ctx := testutil.NewTestContext()
+
+	err := cmd.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, -1, exitCode, "Should not exit for unbreached password")
+}
+
+func TestPasswordCheckCmd_JSONOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		password   string
+		valid      bool
+		expectExit int
+	}{
+		{"strong", "MyStr0ng!P@ssw0rd2024", true, -1},
+		{"weak", "hunter2", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldExit := cli.ExitFunc
+			exitCode := -1
+			cli.ExitFunc = func(code int) { exitCode = code }
+			defer func() { cli.ExitFunc = oldExit }()
+
+			oldStdout := os.Stdout
+			defer func() { os.Stdout = oldStdout }()
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			defer r.Close()
+			os.Stdout = w
+
+			cmd := &cli.PasswordCheckCmd{
+				Text: tt.password,
+				JSON: true,
+			}
+			ctx := testutil.NewTestContext()
+
+			go func() {
+				defer w.Close()
+				err := cmd.Run(ctx)
+				require.NoError(t, err)
+			}()
+
+			output, err := io.ReadAll(r)
+			require.NoError(t, err)
+
+			var report map[string]any
+			require.NoError(t, json.Unmarshal(output, &report))
+			require.Equal(t, tt.valid, report["valid"])
+			require.Equal(t, password.DefaultEntropy, report["required_entropy"])
+			require.Contains(t, report, "entropy")
+			require.Contains(t, report, "crack_time")
+			require.Contains(t, report, "character_classes")
+			require.Contains(t, report, "feedback")
+			require.NotContains(t, string(output), tt.password, "Report must not leak the password")
+			require.Equal(t, tt.expectExit, exitCode)
+		})
+	}
+}
+
+func TestPasswordAuditCmd_File(t *testing.T) {
+	oldExit := cli.ExitFunc
+	var exitCode int
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	path := filepath.Join(t.TempDir(), "creds.txt")
+	content := "password\n\nMyStr0ng!P@ssw0rd2024xyz\r\nabc123\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	cmd := &cli.PasswordAuditCmd{File: path}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+
+	require.Equal(t, 1, exitCode)
+	require.Contains(t, output, "Audited 3 passwords")
+	require.Contains(t, output, "line 1: weak")
+	require.Contains(t, output, "line 4: weak")
+	require.NotContains(t, output, "line 3")
+	// Passwords themselves are never printed
+	require.NotContains(t, output, "abc123")
+	require.NotContains(t, output, "MyStr0ng")
+}
+
+func TestPasswordAuditCmd_StdinJSON(t *testing.T) {
+	oldExit := cli.ExitFunc
+	var exitCode int
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	restore := replaceStdin(t, "k8#Vq!zR2@wLp9$mXn4&\nhunter2\nk8#Vq!zR2@wLp9$mXn4&\n")
+	defer restore()
+
+	cmd := &cli.PasswordAuditCmd{JSON: true}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, 1, exitCode)
+	require.NotContains(t, output, "hunter2")
+
+	var report struct {
+		Source  string `json:"source"`
+		Total   int    `json:"total"`
+		Weak    int    `json:"weak"`
+		Reused  int    `json:"reused"`
+		Results []struct {
+			Line   int  `json:"line"`
+			Valid  bool `json:"valid"`
+			Reused bool `json:"reused"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &report))
+	require.Equal(t, "stdin", report.Source)
+	require.Equal(t, 3, report.Total)
+	require.Equal(t, 1, report.Weak)
+	require.Equal(t, 2, report.Reused)
+	require.Len(t, report.Results, 3)
+	require.Equal(t, 2, report.Results[1].Line)
+	require.False(t, report.Results[1].Valid)
+	require.True(t, report.Results[2].Reused)
+}
+
+func TestPasswordAuditCmd_AllPass(t *testing.T) {
+	oldExit := cli.ExitFunc
+	exitCode := -1
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	path := filepath.Join(t.TempDir(), "creds.txt")
+	require.NoError(t, os.WriteFile(path, []byte("k8#Vq!zR2@wLp9$mXn4&\nMyStr0ng!P@ssw0rd2024xyz\n"), 0600))
+
+	cmd := &cli.PasswordAuditCmd{File: path, Workers: 1}
+	ctx := testutil.NewTestContext()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(ctx))
+	})
+	require.Equal(t, -1, exitCode)
+	require.Contains(t, output, "✓ All passwords passed the audit")
+}
+
+func TestPasswordAuditCmd_Empty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.txt")
+	require.NoError(t, os.WriteFile(path, []byte("\n\n"), 0600))
+
+	cmd := &cli.PasswordAuditCmd{File: path}
+	err := cmd.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no passwords found")
+}
+
+func TestPasswordAuditCmd_Validate(t *testing.T) {
+	require.NoError(t, (&cli.PasswordAuditCmd{}).Validate())
+	require.Error(t, (&cli.PasswordAuditCmd{Entropy: -1}).Validate())
+	require.Error(t, (&cli.PasswordAuditCmd{Entropy: 500}).Validate())
+	require.Error(t, (&cli.PasswordAuditCmd{Workers: -2}).Validate())
+}
+
+// captureStdout runs fn with os.Stdout redirected and returns what it printed
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	os.Stdout = w
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer w.Close()
+		fn()
+	}()
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	<-done
+	return string(output)
+}
+
+// replaceStdin feeds input to os.Stdin and returns a function restoring it
+func replaceStdin(t *testing.T, input string) func() {
+	t.Helper()
+
+	oldStdin := os.Stdin
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.WriteString(input)
+	}()
+
+	return func() {
+		os.Stdin = oldStdin
+		r.Close()
+	}
+}
+
+func TestPasswordPINCmd_Run(t *testing.T) {
+	cmd := &cli.PasswordPINCmd{Digits: 8, Count: 5}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext()))
+	})
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, lines, 5)
+	for _, line := range lines {
+		require.Regexp(t, `^[0-9]{8}$`, line)
+	}
+}
+
+func TestPasswordPINCmd_InvalidDigits(t *testing.T) {
+	cmd := &cli.PasswordPINCmd{Digits: 0, Count: 1}
+	err := cmd.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to generate PIN")
+}
+
+func TestPasswordPINCmd_Validate(t *testing.T) {
+	require.NoError(t, (&cli.PasswordPINCmd{Digits: 6, Count: 1}).Validate())
+	require.Error(t, (&cli.PasswordPINCmd{Digits: 0, Count: 1}).Validate())
+	require.Error(t, (&cli.PasswordPINCmd{Digits: password.MaxPINDigits + 1, Count: 1}).Validate())
+	require.Error(t, (&cli.PasswordPINCmd{Digits: 6, Count: 0}).Validate())
+}
+
+func TestPasswordCheckCmd_PresetNIST(t *testing.T) {
+	// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:42\r\n"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		password   string
+		expectExit int
+		contains   []string
+	}{
+		{"compliant passphrase", "correct horse battery staple", -1, []string{"✓ Password complies with NIST SP 800-63B"}},
+		{"short", "k9#Vq2", 1, []string{"✗ Password does not comply", "at least 8 characters"}},
+		{"breached and common", "password", 1, []string{"commonly used", "known data breaches (42 times)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldExit := cli.ExitFunc
+			exitCode := -1
+			cli.ExitFunc = func(code int) { exitCode = code }
+			defer func() { cli.ExitFunc = oldExit }()
+
+			cmd := &cli.PasswordCheckCmd{Text: tt.password, Preset: "nist", APIURL: server.URL}
+
+			output := captureStdout(t, func() {
+				require.NoError(t, cmd.Run(testutil.NewTestContext()))
+			})
+			for _, s := range tt.contains {
+				require.Contains(t, output, s)
+			}
+			require.Equal(t, tt.expectExit, exitCode)
+		})
+	}
+}
+
+func TestPasswordCheckCmd_PresetJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("0000000000000000000000000000000000A:0\r\n"))
+	}))
+	defer server.Close()
+
+	oldExit := cli.ExitFunc
+	exitCode := -1
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	cmd := &cli.PasswordCheckCmd{Text: "qwertyuiop", Preset: "nist", JSON: true, APIURL: server.URL}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext()))
+	})
+	require.Equal(t, 1, exitCode)
+	require.NotContains(t, output, "qwertyuiop")
+
+	var report struct {
+		Valid  bool                  `json:"valid"`
+		Policy password.PolicyResult `json:"policy"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &report))
+	require.False(t, report.Valid)
+	require.Equal(t, "nist", report.Policy.Policy)
+	require.True(t, report.Policy.BreachChecked)
+	require.Len(t, report.Policy.Violations, 1)
+	require.Equal(t, password.RuleCommon, report.Policy.Violations[0].Rule)
+}
+
+func TestPasswordCheckCmd_PresetOffline(t *testing.T) {
+	cmd := &cli.PasswordCheckCmd{Text: "correct horse battery staple", Preset: "nist", Offline: true}
+
+	err := cmd.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.ErrorIs(t, err, password.ErrOffline)
+}
+
+func TestPasswordCheckCmd_ValidatePreset(t *testing.T) {
+	require.NoError(t, (&cli.PasswordCheckCmd{Preset: "nist"}).Validate())
+	require.Error(t, (&cli.PasswordCheckCmd{Preset: "unknown"}).Validate())
+	require.Error(t, (&cli.PasswordCheckCmd{Preset: "nist", Entropy: 50}).Validate())
+}
@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	stdbase64 "encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bilte-co/toolshed/aes"
+	"github.com/bilte-co/toolshed/cache"
+)
+
+// pastePath is the URL path under which a paste-enabled server stores pastes
+const pastePath = "/paste"
+
+// maxPasteSize is the largest encrypted paste a server accepts
+const maxPasteSize = 1 << 20
+
+// PasteCmd represents the paste command group
+type PasteCmd struct {
+	Create PasteCreateCmd `cmd:"" help:"Encrypt and upload a paste, printing a URL containing the key"`
+	Read   PasteReadCmd   `cmd:"" help:"Download and decrypt a paste"`
+}
+
+// PasteCreateCmd encrypts content client-side and uploads it to a toolshed
+// server running with --paste. The key only ever appears in the URL fragment,
+// which is not sent to the server.
+type PasteCreateCmd struct {
+	Text    string        `arg:"" optional:"" help:"Text to paste (use '-' or omit for stdin)"`
+	Server  string        `short:"s" required:"" env:"TOOLSHED_PASTE_SERVER" help:"Base URL of a 'toolshed serve --paste' instance"`
+	Timeout time.Duration `long:"timeout" default:"30s" help:"Timeout for the upload"`
+}
+
+// pasteCreated is the response of a paste server to an upload
+type pasteCreated struct {
+	ID        string    `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (cmd *PasteCreateCmd) Run(ctx *CLIContext) error {
+	content := cmd.Text
+	if content == "" || content == "-" {
+		ctx.Logger.Debug("Reading paste from stdin")
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", err)
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		content = string(data)
+	}
+	if content == "" {
+		ctx.Logger.Error("Paste cannot be empty")
+		return fmt.Errorf("paste cannot be empty")
+	}
+
+	base, err := url.Parse(strings.TrimRight(cmd.Server, "/"))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		ctx.Logger.Error("Invalid server URL", "server", cmd.Server)
+		return fmt.Errorf("invalid server URL: %s", cmd.Server)
+	}
+
+	key, err := aes.GenerateAESKey(256)
+	if err != nil {
+		ctx.Logger.Error("Failed to generate key", "error", err)
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	ciphertext, err := aes.Encrypt(key, content)
+	if err != nil {
+		ctx.Logger.Error("Failed to encrypt paste", "error", err)
+		return fmt.Errorf("failed to encrypt paste: %w", err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(context.Background(), cmd.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, base.String()+pastePath, strings.NewReader(ciphertext))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	ctx.Logger.Debug("Uploading encrypted paste", "server", base.String(), "size", len(ciphertext))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		ctx.Logger.Error("Failed to upload paste", "error", err)
+		return fmt.Errorf("failed to upload paste: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		ctx.Logger.Error("Paste server rejected upload", "status", resp.StatusCode)
+		return fmt.Errorf("paste server returned status %d", resp.StatusCode)
+	}
+
+	var created pasteCreated
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil || created.ID == "" {
+		ctx.Logger.Error("Invalid paste server response", "error", err)
+		return fmt.Errorf("invalid paste server response")
+	}
+
+	// The fragment carries the key in URL-safe form and is never sent to the server
+	rawKey, err := stdbase64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode key: %w", err)
+	}
+	pasteURL := fmt.Sprintf("%s%s/%s#%s", base.String(), pastePath, created.ID, stdbase64.RawURLEncoding.EncodeToString(rawKey))
+
+	ctx.Logger.Info("Paste created", "expires_at", created.ExpiresAt)
+	fmt.Println(pasteURL)
+	return nil
+}
+
+// PasteReadCmd downloads a paste and decrypts it with the key from the URL fragment
+type PasteReadCmd struct {
+	URL     string        `arg:"" help:"Paste URL as printed by 'paste create', including the #key"`
+	Key     string        `long:"key" help:"Decryption key, if not included in the URL"`
+	Timeout time.Duration `long:"timeout" default:"30s" help:"Timeout for the download"`
+}
+
+func (cmd *PasteReadCmd) Run(ctx *CLIContext) error {
+	u, err := url.Parse(cmd.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		ctx.Logger.Error("Invalid paste URL")
+		return fmt.Errorf("invalid paste URL")
+	}
+
+	encodedKey := cmd.Key
+	if encodedKey == "" {
+		encodedKey = u.Fragment
+	}
+	if encodedKey == "" {
+		ctx.Logger.Error("Paste key missing")
+		return fmt.Errorf("paste key missing: include it as the URL fragment or pass --key")
+	}
+	rawKey, err := stdbase64.RawURLEncoding.DecodeString(encodedKey)
+	if err != nil {
+		ctx.Logger.Error("Invalid paste key", "error", err)
+		return fmt.Errorf("invalid paste key: %w", err)
+	}
+
+	// Never send the key to the server
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	reqCtx, cancel := context.WithTimeout(context.Background(), cmd.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	ctx.Logger.Debug("Downloading paste", "url", u.String())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		ctx.Logger.Error("Failed to download paste", "error", err)
+		return fmt.Errorf("failed to download paste: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		ctx.Logger.Error("Paste not found or expired")
+		return fmt.Errorf("paste not found or expired")
+	}
+	if resp.StatusCode != http.StatusOK {
+		ctx.Logger.Error("Paste server returned an error", "status", resp.StatusCode)
+		return fmt.Errorf("paste server returned status %d", resp.StatusCode)
+	}
+
+	ciphertext, err := io.ReadAll(io.LimitReader(resp.Body, maxPasteSize+1))
+	if err != nil {
+		ctx.Logger.Error("Failed to read paste", "error", err)
+		return fmt.Errorf("failed to read paste: %w", err)
+	}
+
+	plaintext, err := aes.Decrypt(stdbase64.StdEncoding.EncodeToString(rawKey), string(ciphertext))
+	if err != nil {
+		ctx.Logger.Error("Failed to decrypt paste", "error", err)
+		return fmt.Errorf("failed to decrypt paste: %w", err)
+	}
+
+	fmt.Print(plaintext)
+	if !strings.HasSuffix(plaintext, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+// pasteHandler stores and serves encrypted pastes. It never sees the keys.
+type pasteHandler struct {
+	pastes cache.Cache
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+// newPasteHandler creates a paste handler whose pastes expire after ttl
+func newPasteHandler(ttl time.Duration, logger *slog.Logger) (*pasteHandler, error) {
+	pastes, err := cache.NewCacheWithTTL(context.Background(), ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &pasteHandler{pastes: pastes, ttl: ttl, logger: logger}, nil
+}
+
+func (ph *pasteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, pastePath), "/")
+
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		ph.create(w, r)
+	case r.Method == http.MethodGet && id != "" && !strings.Contains(id, "/"):
+		ph.read(w, id)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// create stores an uploaded ciphertext under a new random ID
+func (ph *pasteHandler) create(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPasteSize))
+	if err != nil {
+		http.Error(w, "paste too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// Pastes are base64 AES-GCM ciphertexts, anything else is rejected
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		http.Error(w, "paste cannot be empty", http.StatusBadRequest)
+		return
+	}
+	if _, err := stdbase64.StdEncoding.DecodeString(string(body)); err != nil {
+		http.Error(w, "paste must be base64 ciphertext", http.StatusBadRequest)
+		return
+	}
+
+	id, err := newToken()
+	if err != nil {
+		ph.logger.Error("Failed to generate paste ID", "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	ph.pastes.Set(id, body)
+	created := pasteCreated{ID: id, ExpiresAt: time.Now().Add(ph.ttl).UTC()}
+	ph.logger.Info("Paste stored", "size", len(body), "expires_at", created.ExpiresAt)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// read returns a stored ciphertext
+func (ph *pasteHandler) read(w http.ResponseWriter, id string) {
+	value, ok := ph.pastes.Get(id)
+	body, isBytes := value.([]byte)
+	if !ok || !isBytes {
+		http.Error(w, "paste not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(body)
+}
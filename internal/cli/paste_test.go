@@ -0,0 +1,133 @@
+package cli_test
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+)
+
+// startPasteServer runs a paste-enabled file server and returns its base URL
+func startPasteServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	cmd := &cli.ServeFilesCmd{Dir: t.TempDir(), Port: port, Paste: true, PasteTTL: time.Minute}
+	go cmd.Run(testutil.NewTestContext())
+
+	// The banner is printed before the server listens, so it never mixes with captured output
+	base := fmt.Sprintf("http://127.0.0.1:%d", port)
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	return base
+}
+
+func TestPaste_RoundTrip(t *testing.T) {
+	base := startPasteServer(t)
+	content := "deploy token: s3cr3t\nsecond line\n"
+
+	create := &cli.PasteCreateCmd{Text: content, Server: base, Timeout: 5 * time.Second}
+	var output string
+	require.NoError(t, func() (err error) {
+		output = captureStdout(t, func() { err = create.Run(testutil.NewTestContext()) })
+		return err
+	}())
+
+	pasteURL := strings.TrimSpace(output)
+	u, err := url.Parse(pasteURL)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(u.Path, "/paste/"))
+	require.NotEmpty(t, u.Fragment, "URL should carry the key in its fragment")
+
+	// The server only ever holds ciphertext
+	resp, err := http.Get(base + u.Path)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotContains(t, string(stored), "s3cr3t")
+
+	read := &cli.PasteReadCmd{URL: pasteURL, Timeout: 5 * time.Second}
+	require.NoError(t, func() (err error) {
+		output = captureStdout(t, func() { err = read.Run(testutil.NewTestContext()) })
+		return err
+	}())
+	require.Equal(t, content, output)
+}
+
+func TestPasteReadCmd_WrongKey(t *testing.T) {
+	base := startPasteServer(t)
+
+	create := &cli.PasteCreateCmd{Text: "hello", Server: base, Timeout: 5 * time.Second}
+	output := captureStdout(t, func() { require.NoError(t, create.Run(testutil.NewTestContext())) })
+
+	u, err := url.Parse(strings.TrimSpace(output))
+	require.NoError(t, err)
+
+	read := &cli.PasteReadCmd{
+		URL:     base + u.Path,
+		Key:     "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+		Timeout: 5 * time.Second,
+	}
+	err = read.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decrypt paste")
+}
+
+func TestPasteReadCmd_MissingKey(t *testing.T) {
+	read := &cli.PasteReadCmd{URL: "http://127.0.0.1:1/paste/abc"}
+	err := read.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "paste key missing")
+}
+
+func TestPasteReadCmd_NotFound(t *testing.T) {
+	base := startPasteServer(t)
+
+	read := &cli.PasteReadCmd{URL: base + "/paste/doesnotexist#AAAA", Timeout: 5 * time.Second}
+	err := read.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found or expired")
+}
+
+func TestPasteServer_RejectsPlaintext(t *testing.T) {
+	base := startPasteServer(t)
+
+	resp, err := http.Post(base+"/paste", "text/plain", strings.NewReader("not base64 !!"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Post(base+"/paste", "text/plain", strings.NewReader(strings.Repeat("A", 2<<20)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestPasteCreateCmd_InvalidServer(t *testing.T) {
+	create := &cli.PasteCreateCmd{Text: "hello", Server: "not a url"}
+	err := create.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid server URL")
+}
@@ -1,28 +1,156 @@
 package cli
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"log/slog"
 	"math/big"
+	"mime"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/bilte-co/toolshed/base62"
+	"github.com/bilte-co/toolshed/cache"
+	"github.com/bilte-co/toolshed/logging"
 )
 
 // ServeCmd represents the serve command
 type ServeCmd struct {
-	Port int    `short:"p" help:"Port to listen on (default: random available port)"`
-	Dir  string `short:"d" help:"Directory to serve (default: current directory)"`
+	Files ServeFilesCmd `cmd:"" default:"withargs" help:"Serve a directory over HTTP (default)"`
+	Share ServeShareCmd `cmd:"" help:"Share a single file behind a one-time URL"`
+}
+
+// ServeFilesCmd serves a directory as a static file server
+type ServeFilesCmd struct {
+	Port     int           `short:"p" help:"Port to listen on (default: random available port)"`
+	Dir      string        `short:"d" help:"Directory to serve (default: current directory)"`
+	Host     string        `long:"host" default:"127.0.0.1" help:"Address to bind to (use 0.0.0.0 to serve on the network)"`
+	Paste    bool          `long:"paste" help:"Also accept encrypted pastes from 'toolshed paste create'"`
+	PasteTTL time.Duration `long:"paste-ttl" default:"24h" help:"Time pastes are kept when --paste is enabled"`
+	Auth     []string      `long:"auth" sep:"none" placeholder:"USER:PASS" help:"Require HTTP basic auth with these credentials (repeatable)"`
+	AuthFile string        `long:"auth-file" type:"path" help:"Require HTTP basic auth with the users of an htpasswd file (bcrypt or SHA hashes)"`
+
+	NoIndex       bool   `long:"no-index" help:"Disable directory listings"`
+	IndexTemplate string `long:"index-template" type:"path" help:"html/template file to render directory listings with"`
+
+	RenderMarkdown bool `long:"render-markdown" help:"Render .md files as HTML pages (append ?raw for the source)"`
+
+	Checksums bool `long:"checksums" help:"Send Digest and X-Checksum-SHA256 headers and list file hashes at /__checksums"`
+
+	CacheControl string `long:"cache-control" placeholder:"VALUE" help:"Cache-Control header to send with every response, e.g. 'public, max-age=3600'"`
+	NoCache      bool   `long:"no-cache" help:"Make browsers revalidate every file (Cache-Control: no-cache)"`
+
+	Upload        bool  `long:"upload" help:"Accept file uploads with PUT to a file path or multipart POST to a directory (combine with --auth)"`
+	MaxUploadSize int64 `long:"max-upload-size" default:"100" help:"Largest upload accepted, in MiB"`
+
+	TLSCert string `long:"tls-cert" type:"path" help:"Serve HTTPS (and HTTP/2) with this PEM certificate"`
+	TLSKey  string `long:"tls-key" type:"path" help:"PEM private key for --tls-cert"`
+	H2C     bool   `name:"h2c" help:"Accept cleartext HTTP/2 (h2c) alongside HTTP/1.1"`
+
+	Headers  []string `name:"header" short:"H" sep:"none" placeholder:"KEY: VALUE" help:"Add a header to every response (repeatable)"`
+	NotFound string   `name:"404" type:"path" placeholder:"FILE" help:"Page to serve for files that don't exist"`
+
+	Allow []string `long:"allow" placeholder:"CIDR" help:"Only serve clients in this IP range (repeatable)"`
+	Deny  []string `long:"deny" placeholder:"CIDR" help:"Refuse clients in this IP range, even if allowed (repeatable)"`
+
+	Metrics bool `long:"metrics" help:"Expose Prometheus metrics at /metrics"`
+
+	LogFormat string `long:"log-format" enum:"text,combined,json" default:"text" help:"Access log format: text, combined (Apache) or json"`
+	AccessLog string `long:"access-log" type:"path" help:"Append access logs to this file instead of the console"`
+}
+
+// Validate validates the command arguments
+func (cmd *ServeFilesCmd) Validate() error {
+	for _, auth := range cmd.Auth {
+		if _, err := parseAuth(auth); err != nil {
+			return err
+		}
+	}
+	switch cmd.LogFormat {
+	case "", logFormatText, logFormatCombined, logFormatJSON:
+	default:
+		return fmt.Errorf("unknown log format: %s", cmd.LogFormat)
+	}
+	if _, err := parseHeaders(cmd.Headers); err != nil {
+		return err
+	}
+	if (cmd.TLSCert == "") != (cmd.TLSKey == "") {
+		return fmt.Errorf("--tls-cert and --tls-key must be used together")
+	}
+	if cmd.H2C && cmd.TLSCert != "" {
+		return fmt.Errorf("--h2c is for cleartext connections, HTTP/2 is already enabled with TLS")
+	}
+	if _, err := parsePrefixes(cmd.Allow); err != nil {
+		return err
+	}
+	if _, err := parsePrefixes(cmd.Deny); err != nil {
+		return err
+	}
+	if cmd.Upload && cmd.MaxUploadSize < 1 {
+		return fmt.Errorf("max upload size must be at least 1 MiB, got: %d", cmd.MaxUploadSize)
+	}
+	if cmd.NoCache && cmd.CacheControl != "" {
+		return fmt.Errorf("--no-cache and --cache-control cannot be used together")
+	}
+	if cmd.NoIndex && cmd.IndexTemplate != "" {
+		return fmt.Errorf("--no-index and --index-template cannot be used together")
+	}
+	return nil
+}
+
+// credentials returns the users allowed by --auth and --auth-file, or none if
+// neither is set
+func (cmd *ServeFilesCmd) credentials() ([]credential, error) {
+	var credentials []credential
+	for _, auth := range cmd.Auth {
+		c, err := parseAuth(auth)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, c)
+	}
+
+	if cmd.AuthFile != "" {
+		users, err := loadHtpasswd(cmd.AuthFile)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, users...)
+	}
+
+	return credentials, nil
 }
 
-func (cmd *ServeCmd) Run(ctx *CLIContext) error {
+func (cmd *ServeFilesCmd) Run(ctx *CLIContext) error {
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid serve options", "error", err)
+		return err
+	}
+
+	credentials, err := cmd.credentials()
+	if err != nil {
+		ctx.Logger.Error("Invalid auth options", "error", err)
+		return err
+	}
+
+	tmpl, err := loadIndexTemplate(cmd.IndexTemplate)
+	if err != nil {
+		ctx.Logger.Error("Invalid index template", "error", err)
+		return err
+	}
+
 	// Set defaults
 	if cmd.Dir == "" {
-		var err error
 		cmd.Dir, err = os.Getwd()
 		if err != nil {
 			ctx.Logger.Error("Failed to get current directory", "error", err)
@@ -45,21 +173,159 @@ func (cmd *ServeCmd) Run(ctx *CLIContext) error {
 	}
 
 	// Get an available port
-	port, err := cmd.getPort()
+	port, err := getPort(cmd.Port)
 	if err != nil {
 		ctx.Logger.Error("Failed to get available port", "error", err)
 		return fmt.Errorf("failed to get available port: %w", err)
 	}
 
+	absDir, _ := filepath.Abs(cmd.Dir)
+
 	// Create a custom file server with security
 	fs := &secureFileSystem{http.Dir(cmd.Dir)}
+	var files http.Handler = &indexHandler{
+		fs:       fs,
+		files:    http.FileServer(fs),
+		noIndex:  cmd.NoIndex,
+		upload:   cmd.Upload,
+		tmpl:     tmpl,
+		rootName: filepath.Base(absDir),
+		logger:   ctx.Logger,
+	}
+
+	if cmd.RenderMarkdown {
+		files = newMarkdownHandler(fs, files, ctx.Logger)
+	}
+
+	cacheControl := cmd.CacheControl
+	if cmd.NoCache {
+		cacheControl = "no-cache"
+	}
+	digests := newFileDigests()
+	files = &cacheHandler{
+		fs:           fs,
+		handler:      files,
+		cacheControl: cacheControl,
+		checksums:    cmd.Checksums,
+		digests:      digests,
+		logger:       ctx.Logger,
+	}
+
+	if cmd.Upload {
+		root, err := os.OpenRoot(cmd.Dir)
+		if err != nil {
+			ctx.Logger.Error("Failed to open directory for uploads", "dir", cmd.Dir, "error", err)
+			return fmt.Errorf("failed to open directory for uploads: %w", err)
+		}
+		defer root.Close()
+
+		files = &uploadHandler{
+			root:    root,
+			handler: files,
+			maxSize: cmd.MaxUploadSize << 20,
+			logger:  ctx.Logger,
+		}
+	}
+
+	if cmd.NotFound != "" {
+		files, err = newNotFoundHandler(files, cmd.NotFound)
+		if err != nil {
+			ctx.Logger.Error("Invalid 404 page", "file", cmd.NotFound, "error", err)
+			return err
+		}
+	}
+
+	var metrics *serveMetrics
+	if cmd.Metrics {
+		metrics = newServeMetrics()
+	}
+
+	if cmd.Paste || cmd.Checksums || cmd.Metrics {
+		mux := http.NewServeMux()
+
+		if cmd.Paste {
+			pastes, err := newPasteHandler(cmd.PasteTTL, ctx.Logger)
+			if err != nil {
+				ctx.Logger.Error("Failed to create paste store", "error", err)
+				return fmt.Errorf("failed to create paste store: %w", err)
+			}
+			mux.Handle(pastePath, pastes)
+			mux.Handle(pastePath+"/", pastes)
+		}
+
+		if cmd.Checksums {
+			checksums := &checksumsHandler{fs: fs, digests: digests, logger: ctx.Logger}
+			mux.Handle(checksumsPath, checksums)
+			mux.Handle(checksumsPath+"/", checksums)
+		}
+
+		if cmd.Metrics {
+			mux.Handle(metricsPath, metrics)
+		}
+
+		mux.Handle("/", files)
+		files = mux
+	}
+
+	if len(credentials) > 0 {
+		files = &basicAuthHandler{
+			handler:     files,
+			credentials: credentials,
+			logger:      ctx.Logger,
+		}
+	}
+
+	if len(cmd.Allow) > 0 || len(cmd.Deny) > 0 {
+		// Validate has checked the ranges already
+		allow, _ := parsePrefixes(cmd.Allow)
+		deny, _ := parsePrefixes(cmd.Deny)
+		files = &ipFilterHandler{
+			handler: files,
+			allow:   allow,
+			deny:    deny,
+			logger:  ctx.Logger,
+		}
+	}
+
+	if len(cmd.Headers) > 0 {
+		// Validate has checked the headers already
+		headers, _ := parseHeaders(cmd.Headers)
+		files = &headerHandler{handler: files, headers: headers}
+	}
+
+	if cmd.Metrics {
+		files = &metricsHandler{handler: files, metrics: metrics}
+	}
+
 	handler := &loggingHandler{
-		handler: http.FileServer(fs),
+		handler: files,
 		logger:  ctx.Logger,
+		format:  cmd.LogFormat,
+		out:     os.Stdout,
+	}
+	if cmd.AccessLog != "" {
+		accessLog, err := os.OpenFile(cmd.AccessLog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			ctx.Logger.Error("Failed to open access log", "file", cmd.AccessLog, "error", err)
+			return fmt.Errorf("failed to open access log: %w", err)
+		}
+		defer accessLog.Close()
+
+		handler.out = accessLog
+		if cmd.LogFormat == logFormatText || cmd.LogFormat == "" {
+			handler.logger = slog.New(slog.NewTextHandler(accessLog, nil))
+		}
+	}
+	if cmd.LogFormat == logFormatJSON {
+		handler.logger = logging.NewJSONLogger(handler.out, "info")
 	}
 
 	// Setup server
-	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	host := cmd.Host
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
 	server := &http.Server{
 		Addr:         addr,
 		Handler:      handler,
@@ -67,10 +333,35 @@ func (cmd *ServeCmd) Run(ctx *CLIContext) error {
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	if cmd.Upload {
+		// Large uploads may take a while, so only the headers are timed
+		server.ReadTimeout = 0
+		server.ReadHeaderTimeout = 30 * time.Second
+	}
+
+	scheme := "http"
+	if cmd.TLSCert != "" {
+		// Load the key pair now so that a bad certificate fails before the
+		// server is announced. HTTP/2 is negotiated over TLS automatically.
+		cert, err := tls.LoadX509KeyPair(cmd.TLSCert, cmd.TLSKey)
+		if err != nil {
+			ctx.Logger.Error("Failed to load TLS certificate", "cert", cmd.TLSCert, "key", cmd.TLSKey, "error", err)
+			return fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		server.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+		scheme = "https"
+	}
+	if cmd.H2C {
+		server.Protocols = new(http.Protocols)
+		server.Protocols.SetHTTP1(true)
+		server.Protocols.SetUnencryptedHTTP2(true)
+	}
 
 	// Log startup information
-	absDir, _ := filepath.Abs(cmd.Dir)
-	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	url := scheme + "://" + addr
 
 	ctx.Logger.Info("Starting HTTP server",
 		"port", port,
@@ -80,20 +371,44 @@ func (cmd *ServeCmd) Run(ctx *CLIContext) error {
 
 	fmt.Printf("Serving %s on port %d\n", absDir, port)
 	fmt.Printf("Server running at %s\n", url)
+	if cmd.Paste {
+		fmt.Printf("Accepting encrypted pastes at %s%s (kept for %s)\n", url, pastePath, cmd.PasteTTL)
+	}
+	if cmd.Metrics {
+		fmt.Printf("Prometheus metrics at %s%s\n", url, metricsPath)
+	}
+	if cmd.Checksums {
+		fmt.Printf("File checksums at %s%s\n", url, checksumsPath)
+	}
+	if cmd.TLSCert != "" {
+		fmt.Println("TLS enabled, HTTP/2 available")
+	}
+	if cmd.H2C {
+		fmt.Println("Cleartext HTTP/2 (h2c) enabled")
+	}
+	if cmd.Upload {
+		fmt.Printf("Accepting uploads of up to %d MiB\n", cmd.MaxUploadSize)
+	}
+	if len(credentials) > 0 {
+		fmt.Printf("Basic auth required (%d user(s))\n", len(credentials))
+	}
 	fmt.Println("Press Ctrl+C to stop")
 
 	// Start server
+	if server.TLSConfig != nil {
+		return server.ListenAndServeTLS("", "")
+	}
 	return server.ListenAndServe()
 }
 
 // getPort returns the specified port or finds an available random port between 4000-8999
-func (cmd *ServeCmd) getPort() (int, error) {
-	if cmd.Port != 0 {
+func getPort(port int) (int, error) {
+	if port != 0 {
 		// Check if specified port is available
-		if err := cmd.checkPortAvailable(cmd.Port); err != nil {
-			return 0, fmt.Errorf("specified port %d is not available: %w", cmd.Port, err)
+		if err := checkPortAvailable(port); err != nil {
+			return 0, fmt.Errorf("specified port %d is not available: %w", port, err)
 		}
-		return cmd.Port, nil
+		return port, nil
 	}
 
 	// Find a random available port in range 4000-8999
@@ -113,10 +428,10 @@ func (cmd *ServeCmd) getPort() (int, error) {
 			return 0, fmt.Errorf("failed to generate random port: %w", err)
 		}
 
-		port := minPort + randPort.Int64()
+		candidate := int(minPort + randPort.Int64())
 
-		if err := cmd.checkPortAvailable(int(port)); err == nil {
-			return int(port), nil
+		if err := checkPortAvailable(candidate); err == nil {
+			return candidate, nil
 		}
 	}
 
@@ -124,7 +439,7 @@ func (cmd *ServeCmd) getPort() (int, error) {
 }
 
 // checkPortAvailable checks if a port is available for binding
-func (cmd *ServeCmd) checkPortAvailable(port int) error {
+func checkPortAvailable(port int) error {
 	addr := fmt.Sprintf("127.0.0.1:%d", port)
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -151,10 +466,24 @@ func (sfs *secureFileSystem) Open(name string) (http.File, error) {
 	return sfs.fs.Open(name)
 }
 
-// loggingHandler wraps an http.Handler to log requests
+// Access log formats accepted by --log-format
+const (
+	logFormatText     = "text"
+	logFormatCombined = "combined"
+	logFormatJSON     = "json"
+)
+
+// combinedTimeFormat is the timestamp layout of the Apache combined log format
+const combinedTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// loggingHandler wraps an http.Handler to log requests. Text and JSON entries
+// go to logger; combined entries are written to out.
 type loggingHandler struct {
 	handler http.Handler
 	logger  *slog.Logger
+	format  string // One of the logFormat constants, text if empty
+	out     io.Writer
+	mu      sync.Mutex // Serializes writes to out
 }
 
 func (lh *loggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -172,19 +501,74 @@ func (lh *loggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	duration := time.Since(start)
 
 	// Log the request
-	lh.logger.Info("HTTP request",
-		"method", r.Method,
-		"path", r.URL.Path,
-		"status", recorder.statusCode,
-		"duration", duration.String(),
-		"remote_addr", r.RemoteAddr,
+	switch lh.format {
+	case logFormatCombined:
+		lh.mu.Lock()
+		defer lh.mu.Unlock()
+		fmt.Fprint(lh.out, combinedLogLine(r, recorder, start))
+	case logFormatJSON:
+		lh.logger.Info("HTTP request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"query", r.URL.RawQuery,
+			"proto", r.Proto,
+			"status", recorder.statusCode,
+			"bytes", recorder.bytes,
+			"duration_ms", float64(duration.Microseconds())/1000,
+			"remote_ip", remoteIP(r),
+			"user_agent", r.UserAgent(),
+			"referer", r.Referer(),
+		)
+	default:
+		lh.logger.Info("HTTP request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", recorder.statusCode,
+			"bytes", recorder.bytes,
+			"duration", duration.String(),
+			"remote_addr", r.RemoteAddr,
+		)
+	}
+}
+
+// combinedLogLine formats a request in the Apache combined log format
+func combinedLogLine(r *http.Request, recorder *responseRecorder, start time.Time) string {
+	user := "-"
+	if name, _, ok := r.BasicAuth(); ok && name != "" {
+		user = name
+	}
+
+	size := "-"
+	if recorder.bytes > 0 {
+		size = strconv.FormatInt(recorder.bytes, 10)
+	}
+
+	return fmt.Sprintf("%s - %s [%s] %s %d %s %s %s\n",
+		remoteIP(r),
+		user,
+		start.Format(combinedTimeFormat),
+		strconv.Quote(fmt.Sprintf("%s %s %s", r.Method, r.URL.RequestURI(), r.Proto)),
+		recorder.statusCode,
+		size,
+		strconv.Quote(r.Referer()),
+		strconv.Quote(r.UserAgent()),
 	)
 }
 
-// responseRecorder captures the status code from the response
+// remoteIP returns the IP address of the client, without the port
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// responseRecorder captures the status code and body size of the response
 type responseRecorder struct {
 	http.ResponseWriter
 	statusCode int
+	bytes      int64
 }
 
 func (rr *responseRecorder) WriteHeader(code int) {
@@ -193,5 +577,268 @@ func (rr *responseRecorder) WriteHeader(code int) {
 }
 
 func (rr *responseRecorder) Write(b []byte) (int, error) {
-	return rr.ResponseWriter.Write(b)
+	n, err := rr.ResponseWriter.Write(b)
+	rr.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap returns the underlying ResponseWriter, so that http.ResponseController
+// can reach optional interfaces such as http.Flusher.
+func (rr *responseRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
+
+// tokenBytes is the number of random bytes in share and paste URL tokens
+const tokenBytes = 24
+
+// ServeShareCmd shares a single file behind a random one-time URL that expires
+// after a number of downloads or a period of time, whichever comes first
+type ServeShareCmd struct {
+	File         string        `arg:"" type:"existingfile" help:"File to share"`
+	Port         int           `short:"p" help:"Port to listen on (default: random available port)"`
+	Host         string        `long:"host" default:"127.0.0.1" help:"Address to bind to (use 0.0.0.0 to share on the network)"`
+	TTL          time.Duration `long:"ttl" default:"10m" help:"Time until the link expires"`
+	MaxDownloads int           `long:"max-downloads" default:"1" help:"Number of downloads before the link expires"`
+}
+
+// shareEntry tracks the state of a shared file. It is stored in the cache
+// under the share token and removed once the link is used up.
+type shareEntry struct {
+	mu        sync.Mutex
+	path      string
+	name      string
+	expiresAt time.Time
+	remaining int
+}
+
+// Validate validates the command arguments
+func (cmd *ServeShareCmd) Validate() error {
+	if cmd.TTL <= 0 {
+		return fmt.Errorf("ttl must be positive, got: %s", cmd.TTL)
+	}
+	if cmd.MaxDownloads < 1 {
+		return fmt.Errorf("max downloads must be at least 1, got: %d", cmd.MaxDownloads)
+	}
+	return nil
+}
+
+func (cmd *ServeShareCmd) Run(ctx *CLIContext) error {
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid share options", "error", err)
+		return err
+	}
+
+	info, err := os.Stat(cmd.File)
+	if err != nil {
+		ctx.Logger.Error("File not accessible", "file", cmd.File, "error", err)
+		return fmt.Errorf("file not accessible: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		ctx.Logger.Error("Path is not a regular file", "path", cmd.File)
+		return fmt.Errorf("path is not a regular file: %s", cmd.File)
+	}
+
+	port, err := getPort(cmd.Port)
+	if err != nil {
+		ctx.Logger.Error("Failed to get available port", "error", err)
+		return fmt.Errorf("failed to get available port: %w", err)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		ctx.Logger.Error("Failed to generate share token", "error", err)
+		return fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	shares, err := cache.NewCacheWithTTL(context.Background(), cmd.TTL)
+	if err != nil {
+		ctx.Logger.Error("Failed to create share cache", "error", err)
+		return fmt.Errorf("failed to create share cache: %w", err)
+	}
+
+	entry := &shareEntry{
+		path:      cmd.File,
+		name:      filepath.Base(cmd.File),
+		expiresAt: time.Now().Add(cmd.TTL),
+		remaining: cmd.MaxDownloads,
+	}
+	shares.Set(token, entry)
+
+	// done is closed when the last download has been served
+	done := make(chan struct{})
+	handler := &shareHandler{
+		shares: shares,
+		logger: ctx.Logger,
+		done:   done,
+	}
+
+	host := cmd.Host
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		ctx.Logger.Error("Failed to listen", "addr", addr, "error", err)
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:      handler,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 0, // Downloads of large files may take a while
+		IdleTimeout:  60 * time.Second,
+	}
+
+	shareURL := fmt.Sprintf("http://%s/%s/%s", addr, token, url.PathEscape(entry.name))
+
+	ctx.Logger.Info("Sharing file",
+		"file", cmd.File,
+		"size", info.Size(),
+		"ttl", cmd.TTL.String(),
+		"max_downloads", cmd.MaxDownloads,
+	)
+
+	fmt.Printf("Sharing %s (%d bytes)\n", entry.name, info.Size())
+	fmt.Printf("Share URL: %s\n", shareURL)
+	fmt.Printf("Expires in %s or after %d download(s)\n", cmd.TTL, cmd.MaxDownloads)
+	fmt.Println("Press Ctrl+C to stop")
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	timer := time.NewTimer(cmd.TTL)
+	defer timer.Stop()
+
+	select {
+	case err := <-serveErr:
+		ctx.Logger.Error("Share server failed", "error", err)
+		return fmt.Errorf("share server failed: %w", err)
+	case <-done:
+		ctx.Logger.Info("Share link used up", "downloads", cmd.MaxDownloads)
+		fmt.Println("✓ Download limit reached, link expired")
+	case <-timer.C:
+		shares.Delete(token)
+		ctx.Logger.Info("Share link expired", "ttl", cmd.TTL.String())
+		fmt.Println("✗ Share link expired")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		ctx.Logger.Error("Failed to shut down share server", "error", err)
+		return fmt.Errorf("failed to shut down share server: %w", err)
+	}
+
+	return nil
+}
+
+// newToken returns a random, URL-safe token
+func newToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base62.StdEncoding.EncodeToString(b), nil
+}
+
+// shareHandler serves shared files by token. Unknown, expired and used up
+// tokens are indistinguishable to the client.
+type shareHandler struct {
+	shares cache.Cache
+	logger *slog.Logger
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (sh *shareHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The token is the first path segment, an optional file name may follow
+	token, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	entry, remaining, ok := sh.claim(token)
+	if !ok {
+		// The token is a secret, so it is deliberately left out of the log
+		sh.logger.Warn("Rejected share request", "remote_addr", r.RemoteAddr)
+		http.NotFound(w, r)
+		return
+	}
+
+	if remaining == 0 {
+		defer sh.once.Do(func() { close(sh.done) })
+	}
+
+	file, err := os.Open(entry.path)
+	if err != nil {
+		sh.logger.Error("Failed to open shared file", "file", entry.path, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		sh.logger.Error("Failed to stat shared file", "file", entry.path, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": entry.name}))
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+	w.Header().Set("Cache-Control", "no-store")
+
+	written, err := io.Copy(w, file)
+	if err != nil {
+		sh.logger.Error("Failed to send shared file", "file", entry.path, "error", err)
+		return
+	}
+
+	sh.logger.Info("Shared file downloaded",
+		"file", entry.name,
+		"bytes", written,
+		"remaining_downloads", remaining,
+		"remaining_time", time.Until(entry.expiresAt).Round(time.Second).String(),
+		"remote_addr", r.RemoteAddr,
+	)
+}
+
+// claim reserves a download for token, returning the share entry and the
+// number of downloads left afterwards. Used up links are removed from the cache.
+func (sh *shareHandler) claim(token string) (*shareEntry, int, bool) {
+	if token == "" {
+		return nil, 0, false
+	}
+
+	value, ok := sh.shares.Get(token)
+	if !ok {
+		return nil, 0, false
+	}
+	entry, ok := value.(*shareEntry)
+	if !ok {
+		return nil, 0, false
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	// The cache expiry is approximate, so the deadline is checked explicitly too
+	if entry.remaining <= 0 || !time.Now().Before(entry.expiresAt) {
+		sh.shares.Delete(token)
+		return nil, 0, false
+	}
+
+	entry.remaining--
+	if entry.remaining == 0 {
+		sh.shares.Delete(token)
+	}
+
+	return entry, entry.remaining, true
 }
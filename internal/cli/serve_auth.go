@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// authRealm is the realm announced to clients that need to log in
+const authRealm = "toolshed"
+
+// credential is a user allowed through basicAuthHandler
+type credential struct {
+	user     [sha256.Size]byte
+	password func(password string) bool
+}
+
+// basicAuthHandler wraps an http.Handler to require HTTP basic auth. User
+// names and passwords are compared in constant time so that response times
+// don't reveal how close a guess was.
+type basicAuthHandler struct {
+	handler     http.Handler
+	credentials []credential
+	logger      *slog.Logger
+}
+
+func (ah *basicAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	if ok && ah.allow(user, password) {
+		ah.handler.ServeHTTP(w, r)
+		return
+	}
+
+	if ok {
+		ah.logger.Warn("Rejected credentials", "user", user, "remote_addr", r.RemoteAddr)
+	}
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", authRealm))
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+// allow reports whether user and password match one of the credentials.
+// Every user name is compared, so unknown users take as long as known ones.
+func (ah *basicAuthHandler) allow(user, password string) bool {
+	hashed := sha256.Sum256([]byte(user))
+
+	var match *credential
+	for i := range ah.credentials {
+		if subtle.ConstantTimeCompare(hashed[:], ah.credentials[i].user[:]) == 1 {
+			match = &ah.credentials[i]
+		}
+	}
+	if match == nil {
+		return false
+	}
+	return match.password(password)
+}
+
+// parseAuth parses a user:pass pair given with --auth
+func parseAuth(value string) (credential, error) {
+	user, password, ok := strings.Cut(value, ":")
+	if !ok || user == "" {
+		// The value is left out of the error since it may hold a password
+		return credential{}, fmt.Errorf("auth must be in the form user:pass")
+	}
+
+	hashed := sha256.Sum256([]byte(password))
+	return credential{
+		user: sha256.Sum256([]byte(user)),
+		password: func(password string) bool {
+			given := sha256.Sum256([]byte(password))
+			return subtle.ConstantTimeCompare(given[:], hashed[:]) == 1
+		},
+	}, nil
+}
+
+// loadHtpasswd reads the users of an htpasswd file. Passwords must be hashed
+// with bcrypt (htpasswd -B) or SHA-1 (htpasswd -s); the weaker MD5 and crypt
+// hashes are not supported.
+func loadHtpasswd(path string) ([]credential, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open auth file: %w", err)
+	}
+	defer file.Close()
+
+	var credentials []credential
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		user, hash, ok := strings.Cut(line, ":")
+		if !ok || user == "" {
+			return nil, fmt.Errorf("%s:%d: expected user:hash", path, n)
+		}
+
+		verify, err := htpasswdVerifier(hash)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		credentials = append(credentials, credential{
+			user:     sha256.Sum256([]byte(user)),
+			password: verify,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read auth file: %w", err)
+	}
+	if len(credentials) == 0 {
+		return nil, fmt.Errorf("auth file %s has no users", path)
+	}
+
+	return credentials, nil
+}
+
+// htpasswdVerifier returns a function checking passwords against an htpasswd hash
+func htpasswdVerifier(hash string) (func(password string) bool, error) {
+	switch {
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("invalid bcrypt hash: %w", err)
+		}
+		return func(password string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+		}, nil
+	case strings.HasPrefix(hash, "{SHA}"):
+		expected, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(hash, "{SHA}"))
+		if err != nil || len(expected) != sha1.Size {
+			return nil, fmt.Errorf("invalid SHA hash")
+		}
+		return func(password string) bool {
+			given := sha1.Sum([]byte(password))
+			return subtle.ConstantTimeCompare(given[:], expected) == 1
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported password hash, use bcrypt (htpasswd -B) or SHA (htpasswd -s)")
+	}
+}
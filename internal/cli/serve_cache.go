@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bilte-co/toolshed/hash"
+)
+
+// digestAlgorithm is the hash algorithm file checksums are computed with
+const digestAlgorithm = "sha256"
+
+// cacheHandler sets Cache-Control on every response and an ETag on files, so
+// that browsers can revalidate them. The file server answers If-None-Match
+// with 304 Not Modified once the ETag is set. By default the ETag is weak and
+// derived from the size and modification time of the file, so files are never
+// read to compute it. With checksums enabled, the SHA-256 of files is the
+// (strong) ETag and is also sent in Digest and X-Checksum-SHA256 headers for
+// downloaders to verify.
+type cacheHandler struct {
+	fs           http.FileSystem
+	handler      http.Handler
+	cacheControl string
+	checksums    bool
+	digests      *fileDigests
+	logger       *slog.Logger
+}
+
+func (ch *cacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if ch.cacheControl != "" {
+		w.Header().Set("Cache-Control", ch.cacheControl)
+	}
+
+	if (r.Method == http.MethodGet || r.Method == http.MethodHead) && !strings.HasSuffix(r.URL.Path, "/") {
+		name := path.Clean("/" + r.URL.Path)
+		if ch.checksums {
+			sum, ok, err := ch.digests.sum(ch.fs, name)
+			if err != nil {
+				ch.logger.Error("Failed to hash file", "path", r.URL.Path, "error", err)
+			} else if ok {
+				w.Header().Set("ETag", `"`+hex.EncodeToString(sum)+`"`)
+				w.Header().Set("Digest", "sha-256="+base64.StdEncoding.EncodeToString(sum))
+				w.Header().Set("X-Checksum-SHA256", hex.EncodeToString(sum))
+			}
+		} else if info, ok := statRegular(ch.fs, name); ok {
+			w.Header().Set("ETag", fmt.Sprintf(`W/"%x-%x"`, info.Size(), info.ModTime().UnixNano()))
+		}
+	}
+
+	ch.handler.ServeHTTP(w, r)
+}
+
+// fileDigests caches file hashes by path. A hash is recomputed when the size
+// or modification time of its file changes.
+type fileDigests struct {
+	mu      sync.Mutex
+	entries map[string]fileDigest
+}
+
+// fileDigest is the hash of a file along with what it was computed from
+type fileDigest struct {
+	size    int64
+	modTime time.Time
+	sum     []byte
+}
+
+func newFileDigests() *fileDigests {
+	return &fileDigests{entries: make(map[string]fileDigest)}
+}
+
+// sum returns the SHA-256 hash of the regular file name in fs. It returns
+// false if name does not exist or is not a regular file.
+func (fd *fileDigests) sum(fs http.FileSystem, name string) ([]byte, bool, error) {
+	file, err := fs.Open(name)
+	if err != nil {
+		return nil, false, nil
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return nil, false, nil
+	}
+
+	fd.mu.Lock()
+	entry, ok := fd.entries[name]
+	fd.mu.Unlock()
+	if ok && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+		return entry.sum, true, nil
+	}
+
+	sum, err := hash.HashReader(file, digestAlgorithm)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to hash %s: %w", name, err)
+	}
+
+	fd.mu.Lock()
+	fd.entries[name] = fileDigest{size: info.Size(), modTime: info.ModTime(), sum: sum}
+	fd.mu.Unlock()
+
+	return sum, true, nil
+}
+
+// statRegular returns the file info of the regular file name in fs. It
+// returns false if name does not exist or is not a regular file.
+func statRegular(fs http.FileSystem, name string) (os.FileInfo, bool) {
+	file, err := fs.Open(name)
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return nil, false
+	}
+	return info, true
+}
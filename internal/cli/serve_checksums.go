@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// checksumsPath lists the SHA-256 of the served files
+const checksumsPath = "/__checksums"
+
+// checksumsHandler lists the SHA-256 of every file below a directory in the
+// format of sha256sum, so that downloads can be checked with sha256sum -c.
+// The directory is the request path after checksumsPath, the root by default.
+// Symlinks are skipped.
+type checksumsHandler struct {
+	fs      http.FileSystem
+	digests *fileDigests
+	logger  *slog.Logger
+}
+
+func (ch *checksumsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	dir := path.Clean("/" + strings.TrimPrefix(r.URL.Path, checksumsPath))
+	if isDir, err := ch.isDir(dir); err != nil || !isDir {
+		http.NotFound(w, r)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ch.list(&buf, dir, ""); err != nil {
+		ch.logger.Error("Failed to list checksums", "dir", dir, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(buf.Bytes())
+}
+
+// isDir reports whether dir is a directory
+func (ch *checksumsHandler) isDir(dir string) (bool, error) {
+	file, err := ch.fs.Open(dir)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// list writes a line for every file below dir, naming it by its path
+// relative to the listed directory, which starts with prefix
+func (ch *checksumsHandler) list(buf *bytes.Buffer, dir, prefix string) error {
+	file, err := ch.fs.Open(dir)
+	if err != nil {
+		return err
+	}
+	infos, err := file.Readdir(-1)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	slices.SortFunc(infos, func(a, b os.FileInfo) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	for _, info := range infos {
+		name := path.Join(dir, info.Name())
+		switch {
+		case info.IsDir():
+			if err := ch.list(buf, name, prefix+info.Name()+"/"); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			sum, ok, err := ch.digests.sum(ch.fs, name)
+			if err != nil {
+				return err
+			}
+			if ok {
+				fmt.Fprintf(buf, "%x  %s\n", sum, prefix+info.Name())
+			}
+		}
+	}
+	return nil
+}
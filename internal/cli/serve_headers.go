@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// headerHandler adds fixed headers to every response. They are applied just
+// before the response is written, so they take precedence over headers set
+// by the wrapped handler.
+type headerHandler struct {
+	handler http.Handler
+	headers http.Header
+}
+
+func (hh *headerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	hh.handler.ServeHTTP(&headerWriter{ResponseWriter: w, headers: hh.headers}, r)
+}
+
+// headerWriter sets headers on the first write of the response
+type headerWriter struct {
+	http.ResponseWriter
+	headers     http.Header
+	wroteHeader bool
+}
+
+func (hw *headerWriter) WriteHeader(code int) {
+	if !hw.wroteHeader {
+		hw.wroteHeader = true
+		for key, values := range hw.headers {
+			hw.Header()[key] = values
+		}
+	}
+	hw.ResponseWriter.WriteHeader(code)
+}
+
+func (hw *headerWriter) Write(b []byte) (int, error) {
+	if !hw.wroteHeader {
+		hw.WriteHeader(http.StatusOK)
+	}
+	return hw.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter, so that http.ResponseController
+// can reach optional interfaces such as http.Flusher.
+func (hw *headerWriter) Unwrap() http.ResponseWriter {
+	return hw.ResponseWriter
+}
+
+// parseHeaders parses "Key: Value" pairs given with --header. Repeating a
+// key adds another value.
+func parseHeaders(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t\r\n") {
+			return nil, fmt.Errorf("header must be in the form \"Key: Value\", got: %q", value)
+		}
+		val = strings.TrimSpace(val)
+		if strings.ContainsAny(val, "\r\n") {
+			return nil, fmt.Errorf("header value must not contain line breaks: %q", value)
+		}
+		headers.Add(textproto.CanonicalMIMEHeaderKey(key), val)
+	}
+	return headers, nil
+}
+
+// notFoundHandler replaces the body of 404 responses with a custom page
+type notFoundHandler struct {
+	handler     http.Handler
+	page        []byte
+	contentType string
+}
+
+// newNotFoundHandler reads the page at path, which must exist, for a notFoundHandler
+func newNotFoundHandler(handler http.Handler, path string) (*notFoundHandler, error) {
+	page, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read 404 page: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = "text/html; charset=utf-8"
+	}
+
+	return &notFoundHandler{handler: handler, page: page, contentType: contentType}, nil
+}
+
+func (nh *notFoundHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	nh.handler.ServeHTTP(&notFoundWriter{ResponseWriter: w, handler: nh, method: r.Method}, r)
+}
+
+// notFoundWriter writes the custom page in place of a 404 response and
+// discards the original body
+type notFoundWriter struct {
+	http.ResponseWriter
+	handler     *notFoundHandler
+	method      string
+	wroteHeader bool
+	replaced    bool
+}
+
+func (nw *notFoundWriter) WriteHeader(code int) {
+	if nw.wroteHeader {
+		return
+	}
+	nw.wroteHeader = true
+
+	if code != http.StatusNotFound {
+		nw.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	nw.replaced = true
+	header := nw.Header()
+	header.Del("Content-Encoding")
+	header.Set("Content-Type", nw.handler.contentType)
+	header.Set("Content-Length", strconv.Itoa(len(nw.handler.page)))
+	nw.ResponseWriter.WriteHeader(code)
+	if nw.method != http.MethodHead {
+		nw.ResponseWriter.Write(nw.handler.page)
+	}
+}
+
+func (nw *notFoundWriter) Write(b []byte) (int, error) {
+	if !nw.wroteHeader {
+		nw.WriteHeader(http.StatusOK)
+	}
+	if nw.replaced {
+		// Pretend the original body was written
+		return len(b), nil
+	}
+	return nw.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter, so that http.ResponseController
+// can reach optional interfaces such as http.Flusher.
+func (nw *notFoundWriter) Unwrap() http.ResponseWriter {
+	return nw.ResponseWriter
+}
@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// defaultIndexTemplate renders directory listings unless --index-template is given
+const defaultIndexTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Index of {{.Path}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; padding: 0 1rem; color: #222; }
+h1 { font-size: 1.25rem; font-weight: normal; }
+h1 a { color: #0366d6; text-decoration: none; }
+table { border-collapse: collapse; width: 100%; }
+th, td { padding: 0.35rem 0.75rem; text-align: left; border-bottom: 1px solid #eee; }
+th a { color: inherit; }
+td.size, th.size { text-align: right; font-variant-numeric: tabular-nums; }
+td.mtime { color: #666; white-space: nowrap; }
+a { color: #0366d6; }
+form { margin: 1rem 0; }
+</style>
+</head>
+<body>
+<h1>Index of {{range $i, $c := .Breadcrumbs}}{{if $i}} / {{end}}<a href="{{$c.URL}}">{{$c.Name}}</a>{{end}}</h1>
+{{if .Upload}}<form method="post" enctype="multipart/form-data">
+<input type="hidden" name="redirect" value="1">
+<input type="file" name="file" multiple required>
+<button type="submit">Upload</button>
+</form>
+{{end}}<table>
+<thead>
+<tr>
+<th><a href="{{.SortURL "name"}}">Name</a></th>
+<th class="size"><a href="{{.SortURL "size"}}">Size</a></th>
+<th><a href="{{.SortURL "mtime"}}">Modified</a></th>
+</tr>
+</thead>
+<tbody>
+{{if .Parent}}<tr><td><a href="{{.Parent}}">../</a></td><td class="size"></td><td class="mtime"></td></tr>
+{{end}}{{range .Entries}}<tr><td><a href="{{.URL}}">{{.Name}}{{if .IsDir}}/{{end}}</a></td><td class="size">{{if not .IsDir}}{{.SizeText}}{{end}}</td><td class="mtime">{{.ModTime.Format "2006-01-02 15:04"}}</td></tr>
+{{end}}</tbody>
+</table>
+</body>
+</html>
+`
+
+// directoryListing is the data passed to the index template
+type directoryListing struct {
+	Path        string         // URL path of the directory, ending in a slash
+	Breadcrumbs []breadcrumb   // Links to the directory and each of its parents, starting at the root
+	Parent      string         // URL of the parent directory, empty at the root
+	Entries     []listingEntry // Directory contents, directories first
+	Sort        string         // Sort column: name, size or mtime
+	Order       string         // Sort order: asc or desc
+	Upload      bool           // Whether uploads into the directory are accepted
+}
+
+// breadcrumb links to a directory on the path of a listing
+type breadcrumb struct {
+	Name string
+	URL  string
+}
+
+// listingEntry is a file or directory in a listing
+type listingEntry struct {
+	Name     string
+	URL      string // Relative URL of the entry, escaped
+	IsDir    bool
+	Size     int64
+	SizeText string // Size in human readable form, e.g. 1.5 MiB
+	ModTime  time.Time
+}
+
+// SortURL returns the query string sorting the listing by column, reversing
+// the order if the listing is already sorted by it
+func (dl *directoryListing) SortURL(column string) string {
+	order := "asc"
+	if dl.Sort == column && dl.Order == "asc" {
+		order = "desc"
+	}
+	return "?" + url.Values{"sort": {column}, "order": {order}}.Encode()
+}
+
+// indexHandler renders directory listings for directories without an
+// index.html, or hides them if listings are disabled. Everything else is
+// passed to the file server.
+type indexHandler struct {
+	fs       http.FileSystem
+	files    http.Handler
+	noIndex  bool
+	upload   bool
+	tmpl     *template.Template
+	rootName string
+	logger   *slog.Logger
+}
+
+func (ih *indexHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The file server redirects directories to their slashed URL itself
+	if !strings.HasSuffix(r.URL.Path, "/") {
+		ih.files.ServeHTTP(w, r)
+		return
+	}
+
+	dirPath := path.Clean(r.URL.Path)
+	dir, err := ih.fs.Open(dirPath)
+	if err != nil {
+		ih.files.ServeHTTP(w, r)
+		return
+	}
+	defer dir.Close()
+
+	info, err := dir.Stat()
+	if err != nil || !info.IsDir() || ih.hasIndexFile(dirPath) {
+		ih.files.ServeHTTP(w, r)
+		return
+	}
+
+	if ih.noIndex {
+		http.NotFound(w, r)
+		return
+	}
+
+	infos, err := dir.Readdir(-1)
+	if err != nil {
+		ih.logger.Error("Failed to read directory", "path", dirPath, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	listing := newDirectoryListing(r.URL.Path, ih.rootName, infos, r.URL.Query())
+	listing.Upload = ih.upload
+
+	// Render to a buffer first so template errors still produce a clean response
+	var buf bytes.Buffer
+	if err := ih.tmpl.Execute(&buf, listing); err != nil {
+		ih.logger.Error("Failed to render directory listing", "path", dirPath, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// hasIndexFile reports whether dir contains an index.html for the file server to serve
+func (ih *indexHandler) hasIndexFile(dir string) bool {
+	index, err := ih.fs.Open(path.Join(dir, "index.html"))
+	if err != nil {
+		return false
+	}
+	index.Close()
+	return true
+}
+
+// newDirectoryListing builds the listing of the directory at urlPath, sorted
+// as requested by the sort and order query parameters
+func newDirectoryListing(urlPath, rootName string, infos []os.FileInfo, query url.Values) *directoryListing {
+	listing := &directoryListing{
+		Path:  urlPath,
+		Sort:  query.Get("sort"),
+		Order: query.Get("order"),
+	}
+	if listing.Sort != "size" && listing.Sort != "mtime" {
+		listing.Sort = "name"
+	}
+	if listing.Order != "desc" {
+		listing.Order = "asc"
+	}
+
+	listing.Breadcrumbs = []breadcrumb{{Name: rootName, URL: "/"}}
+	crumbURL := "/"
+	for _, segment := range strings.Split(strings.Trim(urlPath, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		crumbURL += (&url.URL{Path: segment}).EscapedPath() + "/"
+		listing.Breadcrumbs = append(listing.Breadcrumbs, breadcrumb{Name: segment, URL: crumbURL})
+	}
+	if len(listing.Breadcrumbs) > 1 {
+		listing.Parent = "../"
+	}
+
+	for _, info := range infos {
+		name := info.Name()
+		entryURL := (&url.URL{Path: name}).EscapedPath()
+		if info.IsDir() {
+			entryURL += "/"
+		}
+		// A leading ./ keeps names containing a colon from being read as a scheme
+		if strings.Contains(name, ":") {
+			entryURL = "./" + entryURL
+		}
+		listing.Entries = append(listing.Entries, listingEntry{
+			Name:     name,
+			URL:      entryURL,
+			IsDir:    info.IsDir(),
+			Size:     info.Size(),
+			SizeText: formatSize(info.Size()),
+			ModTime:  info.ModTime(),
+		})
+	}
+
+	slices.SortFunc(listing.Entries, func(a, b listingEntry) int {
+		// Directories always come first
+		if a.IsDir != b.IsDir {
+			if a.IsDir {
+				return -1
+			}
+			return 1
+		}
+
+		var c int
+		switch listing.Sort {
+		case "size":
+			c = cmp.Compare(a.Size, b.Size)
+		case "mtime":
+			c = a.ModTime.Compare(b.ModTime)
+		}
+		if c == 0 {
+			c = cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		}
+		if listing.Order == "desc" {
+			c = -c
+		}
+		return c
+	})
+
+	return listing
+}
+
+// formatSize formats a byte count using binary units
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// loadIndexTemplate parses the listing template from path, or the default
+// template if path is empty
+func loadIndexTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return template.Must(template.New("index").Parse(defaultIndexTemplate)), nil
+	}
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index template: %w", err)
+	}
+	tmpl, err := template.New("index").Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse index template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ipFilterHandler rejects clients by IP address before any other handling.
+// Denied ranges win over allowed ones; with no allowed ranges every address
+// that isn't denied may connect.
+type ipFilterHandler struct {
+	handler http.Handler
+	allow   []netip.Prefix
+	deny    []netip.Prefix
+	logger  *slog.Logger
+}
+
+func (fh *ipFilterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	addr, err := clientAddr(r)
+	if err != nil || !fh.permits(addr) {
+		fh.logger.Warn("Rejected client by IP", "remote_addr", r.RemoteAddr)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	fh.handler.ServeHTTP(w, r)
+}
+
+// permits reports whether addr passes the allow and deny lists
+func (fh *ipFilterHandler) permits(addr netip.Addr) bool {
+	for _, prefix := range fh.deny {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	if len(fh.allow) == 0 {
+		return true
+	}
+	for _, prefix := range fh.allow {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientAddr returns the address of the client connection. Forwarding
+// headers are ignored since any client can set them.
+func clientAddr(r *http.Request) (netip.Addr, error) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap(), nil
+}
+
+// parsePrefixes parses CIDR ranges such as 192.168.1.0/24 or fd00::/8. A bare
+// address matches only itself.
+func parsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IP address or CIDR range: %s", value)
+			}
+			addr = addr.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR range: %s", value)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// maxMarkdownSize is the largest markdown file rendered; larger files are served raw
+const maxMarkdownSize = 10 << 20
+
+// markdownTemplate wraps rendered markdown in a styled page
+var markdownTemplate = template.Must(template.New("markdown").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; line-height: 1.6; margin: 2rem auto; max-width: 50rem; padding: 0 1rem; color: #222; }
+nav { font-size: 0.875rem; margin-bottom: 1.5rem; color: #666; }
+a { color: #0366d6; }
+h1, h2 { border-bottom: 1px solid #eee; padding-bottom: 0.3rem; }
+code { font-family: ui-monospace, monospace; background: #f6f8fa; padding: 0.15rem 0.3rem; border-radius: 3px; font-size: 0.875em; }
+pre { background: #f6f8fa; padding: 1rem; overflow-x: auto; border-radius: 6px; }
+pre code { background: none; padding: 0; }
+blockquote { margin: 0; padding: 0 1rem; color: #666; border-left: 0.25rem solid #ddd; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ddd; padding: 0.35rem 0.75rem; }
+img { max-width: 100%; }
+</style>
+</head>
+<body>
+<nav>{{.Title}} &middot; <a href="?raw">raw</a></nav>
+<article>
+{{.Body}}
+</article>
+</body>
+</html>
+`))
+
+// markdownPage is the data passed to markdownTemplate
+type markdownPage struct {
+	Title string
+	Body  template.HTML
+}
+
+// markdownHandler renders markdown files as HTML pages. Appending ?raw to
+// the URL serves the file itself. Raw HTML in the markdown is not rendered,
+// so documents can't inject scripts into the page.
+type markdownHandler struct {
+	fs       http.FileSystem
+	handler  http.Handler
+	markdown goldmark.Markdown
+	logger   *slog.Logger
+}
+
+func newMarkdownHandler(fs http.FileSystem, handler http.Handler, logger *slog.Logger) *markdownHandler {
+	return &markdownHandler{
+		fs:       fs,
+		handler:  handler,
+		markdown: goldmark.New(goldmark.WithExtensions(extension.GFM)),
+		logger:   logger,
+	}
+}
+
+func (mh *markdownHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if (r.Method != http.MethodGet && r.Method != http.MethodHead) || !isMarkdown(r.URL.Path) || r.URL.Query().Has("raw") {
+		mh.handler.ServeHTTP(w, r)
+		return
+	}
+
+	name := path.Clean("/" + r.URL.Path)
+	file, err := mh.fs.Open(name)
+	if err != nil {
+		mh.handler.ServeHTTP(w, r)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxMarkdownSize {
+		mh.handler.ServeHTTP(w, r)
+		return
+	}
+
+	source, err := io.ReadAll(file)
+	if err != nil {
+		mh.logger.Error("Failed to read markdown file", "path", name, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := mh.markdown.Convert(source, &body); err != nil {
+		mh.logger.Error("Failed to render markdown", "path", name, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	var page bytes.Buffer
+	if err := markdownTemplate.Execute(&page, markdownPage{
+		Title: path.Base(name),
+		Body:  template.HTML(body.String()),
+	}); err != nil {
+		mh.logger.Error("Failed to render markdown page", "path", name, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// The ETag and checksums describe the markdown source, not the rendered page
+	w.Header().Del("ETag")
+	w.Header().Del("Digest")
+	w.Header().Del("X-Checksum-SHA256")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page.Bytes())
+}
+
+// isMarkdown reports whether the file at urlPath is markdown, by its extension
+func isMarkdown(urlPath string) bool {
+	switch strings.ToLower(path.Ext(urlPath)) {
+	case ".md", ".markdown":
+		return true
+	default:
+		return false
+	}
+}
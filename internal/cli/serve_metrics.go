@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// metricsPath exposes the Prometheus metrics of the server
+const metricsPath = "/metrics"
+
+// durationBuckets are the upper bounds of the request latency histogram, in seconds
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// metricMethods are the request methods counted by name; others count as OTHER
+// so that clients can't create unlimited label values
+var metricMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodDelete, http.MethodOptions, http.MethodPatch,
+}
+
+// requestKey identifies a request counter
+type requestKey struct {
+	method      string
+	statusClass string
+}
+
+// serveMetrics collects request metrics for the Prometheus text format. It is
+// small enough that pulling in the Prometheus client library isn't worth it.
+type serveMetrics struct {
+	mu            sync.Mutex
+	requests      map[requestKey]uint64
+	buckets       []uint64 // Cumulative counts for durationBuckets
+	durationCount uint64
+	durationSum   float64
+	bytes         uint64
+	inFlight      atomic.Int64
+}
+
+func newServeMetrics() *serveMetrics {
+	return &serveMetrics{
+		requests: make(map[requestKey]uint64),
+		buckets:  make([]uint64, len(durationBuckets)),
+	}
+}
+
+// observe records a finished request
+func (sm *serveMetrics) observe(method string, status int, bytes int64, duration time.Duration) {
+	if !slices.Contains(metricMethods, method) {
+		method = "OTHER"
+	}
+	key := requestKey{method: method, statusClass: strconv.Itoa(status/100) + "xx"}
+	seconds := duration.Seconds()
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.requests[key]++
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			sm.buckets[i]++
+		}
+	}
+	sm.durationCount++
+	sm.durationSum += seconds
+	sm.bytes += uint64(max(bytes, 0))
+}
+
+// metricsHandler wraps an http.Handler to record metrics about its requests
+type metricsHandler struct {
+	handler http.Handler
+	metrics *serveMetrics
+}
+
+func (mh *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mh.metrics.inFlight.Add(1)
+	defer mh.metrics.inFlight.Add(-1)
+
+	recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+	start := time.Now()
+	mh.handler.ServeHTTP(recorder, r)
+	mh.metrics.observe(r.Method, recorder.statusCode, recorder.bytes, time.Since(start))
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (sm *serveMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var buf bytes.Buffer
+	sm.write(&buf)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(buf.Bytes())
+}
+
+// write formats the metrics in the Prometheus text exposition format
+func (sm *serveMetrics) write(buf *bytes.Buffer) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	keys := make([]requestKey, 0, len(sm.requests))
+	for key := range sm.requests {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b requestKey) int {
+		return cmp.Or(cmp.Compare(a.method, b.method), cmp.Compare(a.statusClass, b.statusClass))
+	})
+
+	buf.WriteString("# HELP toolshed_serve_requests_total Requests served, by method and status class.\n")
+	buf.WriteString("# TYPE toolshed_serve_requests_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(buf, "toolshed_serve_requests_total{method=%q,status=%q} %d\n", key.method, key.statusClass, sm.requests[key])
+	}
+
+	buf.WriteString("# HELP toolshed_serve_request_duration_seconds Time taken to serve requests.\n")
+	buf.WriteString("# TYPE toolshed_serve_request_duration_seconds histogram\n")
+	for i, bound := range durationBuckets {
+		fmt.Fprintf(buf, "toolshed_serve_request_duration_seconds_bucket{le=%q} %d\n", strconv.FormatFloat(bound, 'g', -1, 64), sm.buckets[i])
+	}
+	fmt.Fprintf(buf, "toolshed_serve_request_duration_seconds_bucket{le=\"+Inf\"} %d\n", sm.durationCount)
+	fmt.Fprintf(buf, "toolshed_serve_request_duration_seconds_sum %s\n", strconv.FormatFloat(sm.durationSum, 'g', -1, 64))
+	fmt.Fprintf(buf, "toolshed_serve_request_duration_seconds_count %d\n", sm.durationCount)
+
+	buf.WriteString("# HELP toolshed_serve_response_bytes_total Response body bytes served.\n")
+	buf.WriteString("# TYPE toolshed_serve_response_bytes_total counter\n")
+	fmt.Fprintf(buf, "toolshed_serve_response_bytes_total %d\n", sm.bytes)
+
+	buf.WriteString("# HELP toolshed_serve_requests_in_flight Requests currently being served.\n")
+	buf.WriteString("# TYPE toolshed_serve_requests_in_flight gauge\n")
+	fmt.Fprintf(buf, "toolshed_serve_requests_in_flight %d\n", sm.inFlight.Load())
+}
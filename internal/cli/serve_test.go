@@ -1,20 +1,38 @@
 package cli_test
 
 import (
+	"bufio"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
+	"mime/multipart"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/alecthomas/kong"
 	"github.com/bilte-co/toolshed/internal/cli"
 	"github.com/bilte-co/toolshed/internal/testutil"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestServeCmd_DefaultDirectory(t *testing.T) {
@@ -33,7 +51,7 @@ func TestServeCmd_DefaultDirectory(t *testing.T) {
 	err = os.Chdir(tmpDir)
 	require.NoError(t, err)
 
-	cmd := &cli.ServeCmd{}
+	cmd := &cli.ServeFilesCmd{}
 	ctx := testutil.NewTestContext()
 
 	// Start server in goroutine
@@ -64,7 +82,7 @@ func TestServeCmd_ExplicitDirectory(t *testing.T) {
 	err := os.WriteFile(testFile, []byte(testContent), 0o644)
 	require.NoError(t, err)
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir: tmpDir,
 	}
 	ctx := testutil.NewTestContext()
@@ -88,7 +106,7 @@ func TestServeCmd_ExplicitDirectory(t *testing.T) {
 }
 
 func TestServeCmd_NonexistentDirectory(t *testing.T) {
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir: "/nonexistent/directory",
 	}
 	ctx := testutil.NewTestContext()
@@ -103,7 +121,7 @@ func TestServeCmd_FileInsteadOfDirectory(t *testing.T) {
 	err := os.WriteFile(tmpFile, []byte("test"), 0o644)
 	require.NoError(t, err)
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir: tmpFile,
 	}
 	ctx := testutil.NewTestContext()
@@ -122,7 +140,7 @@ func TestServeCmd_SpecificPort(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir:  tmpDir,
 		Port: port,
 	}
@@ -155,7 +173,7 @@ func TestServeCmd_PortInUse(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	defer listener.Close()
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir:  tmpDir,
 		Port: port,
 	}
@@ -168,7 +186,7 @@ func TestServeCmd_PortInUse(t *testing.T) {
 
 func TestServeCmd_GetPortRandomRange(t *testing.T) {
 	tmpDir := t.TempDir()
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir: tmpDir,
 		// Port: 0 (default, should pick random)
 	}
@@ -225,7 +243,7 @@ func TestServeCmd_FullHTTPServerIntegration(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir:  tmpDir,
 		Port: port,
 	}
@@ -325,7 +343,7 @@ func TestServeCmd_SecurityFileSystem(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir:  tmpDir,
 		Port: port,
 	}
@@ -390,7 +408,7 @@ func TestServeCmd_HTTPMethods(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir:  tmpDir,
 		Port: port,
 	}
@@ -447,7 +465,7 @@ func TestServeCmd_LoggingHandler(t *testing.T) {
 	err := os.WriteFile(testFile, []byte("test"), 0o644)
 	require.NoError(t, err)
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir: tmpDir,
 	}
 
@@ -474,7 +492,7 @@ func TestServeCmd_LoggingHandler(t *testing.T) {
 
 func TestServeCmd_PortRange(t *testing.T) {
 	tmpDir := t.TempDir()
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir: tmpDir,
 		// Test with Port: 0 to trigger random port selection
 	}
@@ -514,7 +532,7 @@ func TestServeCmd_RelativePath(t *testing.T) {
 	require.NoError(t, err)
 
 	relDir := "./" + filepath.Base(tmpDir)
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir: relDir,
 	}
 	ctx := testutil.NewTestContext()
@@ -538,7 +556,7 @@ func TestServeCmd_EmptyDirectory(t *testing.T) {
 	tmpDir := t.TempDir()
 	// Empty directory
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir: tmpDir,
 	}
 	ctx := testutil.NewTestContext()
@@ -574,7 +592,7 @@ func TestServeCmd_LargeFile(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir:  tmpDir,
 		Port: port,
 	}
@@ -622,7 +640,7 @@ func TestServeCmd_MultipleClients(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir:  tmpDir,
 		Port: port,
 	}
@@ -703,7 +721,7 @@ func TestServeCmd_InvalidPort(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cmd := &cli.ServeCmd{
+			cmd := &cli.ServeFilesCmd{
 				Dir:  tmpDir,
 				Port: tt.port,
 			}
@@ -760,7 +778,7 @@ func TestServeCmd_ContentTypes(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 
-	cmd := &cli.ServeCmd{
+	cmd := &cli.ServeFilesCmd{
 		Dir:  tmpDir,
 		Port: port,
 	}
@@ -801,3 +819,900 @@ func TestServeCmd_ContentTypes(t *testing.T) {
 	case <-time.After(1 * time.Second):
 	}
 }
+
+// startShare runs the share command and returns the share URL it prints
+// along with a channel that receives the command's result.
+func startShare(t *testing.T, cmd *cli.ServeShareCmd) (string, <-chan error) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	cmd.Port = listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+	t.Cleanup(func() {
+		os.Stdout = oldStdout
+		r.Close()
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		defer w.Close()
+		done <- cmd.Run(testutil.NewTestContext())
+	}()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if shareURL, ok := strings.CutPrefix(scanner.Text(), "Share URL: "); ok {
+			// Keep draining output so the command never blocks on stdout
+			go io.Copy(io.Discard, r)
+			return shareURL, done
+		}
+	}
+	t.Fatalf("share URL was not printed: %v", <-done)
+	return "", nil
+}
+
+func TestServeShareCmd_MaxDownloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report final.bin")
+	content := []byte("shared file content")
+	require.NoError(t, os.WriteFile(path, content, 0o644))
+
+	shareURL, done := startShare(t, &cli.ServeShareCmd{
+		File:         path,
+		TTL:          time.Minute,
+		MaxDownloads: 2,
+	})
+	require.Contains(t, shareURL, "/report%20final.bin")
+
+	for range 2 {
+		resp, err := http.Get(shareURL)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, content, body)
+		require.Contains(t, resp.Header.Get("Content-Disposition"), "report final.bin")
+		require.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	}
+
+	// The command exits once the download limit is reached
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("share command did not exit after the last download")
+	}
+}
+
+func TestServeShareCmd_RejectsUnknownToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.txt")
+	require.NoError(t, os.WriteFile(path, []byte("secret"), 0o644))
+
+	shareURL, done := startShare(t, &cli.ServeShareCmd{
+		File:         path,
+		TTL:          time.Minute,
+		MaxDownloads: 1,
+	})
+
+	parsed, err := url.Parse(shareURL)
+	require.NoError(t, err)
+	base := "http://" + parsed.Host
+
+	for _, path := range []string{"/", "/wrongtoken", "/wrongtoken/secret.txt"} {
+		resp, err := http.Get(base + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+	}
+
+	resp, err := http.Post(shareURL, "text/plain", strings.NewReader("x"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	// Failed attempts don't consume the download
+	resp, err = http.Get(shareURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, <-done)
+}
+
+func TestServeShareCmd_Expires(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o644))
+
+	shareURL, done := startShare(t, &cli.ServeShareCmd{
+		File:         path,
+		TTL:          200 * time.Millisecond,
+		MaxDownloads: 1,
+	})
+	require.NotEmpty(t, shareURL)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("share command did not exit after the TTL")
+	}
+}
+
+func TestServeShareCmd_Validate(t *testing.T) {
+	require.NoError(t, (&cli.ServeShareCmd{TTL: time.Minute, MaxDownloads: 1}).Validate())
+	require.Error(t, (&cli.ServeShareCmd{TTL: 0, MaxDownloads: 1}).Validate())
+	require.Error(t, (&cli.ServeShareCmd{TTL: time.Minute, MaxDownloads: 0}).Validate())
+}
+
+func TestServeShareCmd_NotAFile(t *testing.T) {
+	cmd := &cli.ServeShareCmd{File: t.TempDir(), TTL: time.Minute, MaxDownloads: 1}
+	err := cmd.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not a regular file")
+}
+
+// startServeFiles runs the files command on a free port and returns its base URL
+func startServeFiles(t *testing.T, cmd *cli.ServeFilesCmd) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	cmd.Port = listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- cmd.Run(testutil.NewTestContext())
+	}()
+
+	addr := fmt.Sprintf("127.0.0.1:%d", cmd.Port)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		select {
+		case err := <-serverDone:
+			t.Fatalf("server exited: %v", err)
+		default:
+		}
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server did not start: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	baseURL := "http://" + addr
+	return baseURL
+}
+
+// getWithAuth requests url with the given basic auth credentials, if any
+func getWithAuth(t *testing.T, url, user, password string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestServeCmd_BasicAuth(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "secret.txt"), []byte("secret"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{
+		Dir:  tmpDir,
+		Auth: []string{"alice:wonderland", "bob:pass:with:colons"},
+	})
+	fileURL := baseURL + "/secret.txt"
+
+	resp := getWithAuth(t, fileURL, "", "")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("WWW-Authenticate"), `Basic realm="toolshed"`)
+
+	require.Equal(t, http.StatusUnauthorized, getWithAuth(t, fileURL, "alice", "wrong").StatusCode)
+	require.Equal(t, http.StatusUnauthorized, getWithAuth(t, fileURL, "mallory", "wonderland").StatusCode)
+	require.Equal(t, http.StatusOK, getWithAuth(t, fileURL, "alice", "wonderland").StatusCode)
+	require.Equal(t, http.StatusOK, getWithAuth(t, fileURL, "bob", "pass:with:colons").StatusCode)
+}
+
+func TestServeCmd_BasicAuthFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "secret.txt"), []byte("secret"), 0o644))
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("bcrypt-pass"), bcrypt.MinCost)
+	require.NoError(t, err)
+	sum := sha1.Sum([]byte("sha-pass"))
+	htpasswd := fmt.Sprintf("# users\ncarol:%s\n\ndave:{SHA}%s\n", hash, base64.StdEncoding.EncodeToString(sum[:]))
+	authFile := filepath.Join(t.TempDir(), ".htpasswd")
+	require.NoError(t, os.WriteFile(authFile, []byte(htpasswd), 0o600))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{
+		Dir:      tmpDir,
+		AuthFile: authFile,
+		Auth:     []string{"erin:plain"},
+	})
+	fileURL := baseURL + "/secret.txt"
+
+	require.Equal(t, http.StatusOK, getWithAuth(t, fileURL, "carol", "bcrypt-pass").StatusCode)
+	require.Equal(t, http.StatusOK, getWithAuth(t, fileURL, "dave", "sha-pass").StatusCode)
+	require.Equal(t, http.StatusOK, getWithAuth(t, fileURL, "erin", "plain").StatusCode)
+	require.Equal(t, http.StatusUnauthorized, getWithAuth(t, fileURL, "carol", "sha-pass").StatusCode)
+	require.Equal(t, http.StatusUnauthorized, getWithAuth(t, fileURL, "dave", "bcrypt-pass").StatusCode)
+}
+
+func TestServeCmd_InvalidAuth(t *testing.T) {
+	require.NoError(t, (&cli.ServeFilesCmd{Auth: []string{"user:"}}).Validate())
+	require.Error(t, (&cli.ServeFilesCmd{Auth: []string{"nocolon"}}).Validate())
+	require.Error(t, (&cli.ServeFilesCmd{Auth: []string{":pass"}}).Validate())
+
+	authFile := filepath.Join(t.TempDir(), ".htpasswd")
+	require.NoError(t, os.WriteFile(authFile, []byte("frank:$apr1$salt$hash\n"), 0o600))
+
+	cmd := &cli.ServeFilesCmd{Dir: t.TempDir(), AuthFile: authFile}
+	err := cmd.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported password hash")
+}
+
+// getBody requests url and returns the status code and body
+func getBody(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestServeCmd_DirectoryListing(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "small.txt"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "big.txt"), make([]byte, 2048), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "docs", "my notes"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "docs", "my notes", "a&b.txt"), []byte("x"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir})
+
+	status, body := getBody(t, baseURL+"/")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, body, `<a href="docs/">docs/</a>`)
+	require.Contains(t, body, "2.0 KiB")
+	require.NotContains(t, body, `href="../"`)
+	// Directories come first, then files by name
+	require.Less(t, strings.Index(body, "docs/"), strings.Index(body, "big.txt"))
+	require.Less(t, strings.Index(body, "big.txt"), strings.Index(body, "small.txt"))
+
+	_, body = getBody(t, baseURL+"/?sort=size&order=desc")
+	require.Less(t, strings.Index(body, "big.txt"), strings.Index(body, "small.txt"))
+	require.Contains(t, body, `href="?order=asc&amp;sort=size"`)
+
+	status, body = getBody(t, baseURL+"/docs/my%20notes/")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, body, `<a href="/docs/my%20notes/">my notes</a>`)
+	require.Contains(t, body, `<a href="/docs/">docs</a>`)
+	require.Contains(t, body, `href="../"`)
+	require.Contains(t, body, `<a href="a&amp;b.txt">a&amp;b.txt</a>`)
+}
+
+func TestServeCmd_NoIndex(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("file"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "site"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "site", "index.html"), []byte("<h1>site</h1>"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, NoIndex: true})
+
+	status, _ := getBody(t, baseURL+"/")
+	require.Equal(t, http.StatusNotFound, status)
+
+	status, body := getBody(t, baseURL+"/file.txt")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "file", body)
+
+	// index.html files are still served
+	status, body = getBody(t, baseURL+"/site/")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "<h1>site</h1>", body)
+}
+
+func TestServeCmd_IndexTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "one.txt"), []byte("1"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "two.txt"), []byte("22"), 0o644))
+
+	tmplFile := filepath.Join(t.TempDir(), "index.tmpl")
+	tmpl := `{{.Path}}:{{range .Entries}} {{.Name}}={{.Size}}{{end}}`
+	require.NoError(t, os.WriteFile(tmplFile, []byte(tmpl), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, IndexTemplate: tmplFile})
+
+	status, body := getBody(t, baseURL+"/")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "/: one.txt=1 two.txt=2", body)
+}
+
+func TestServeCmd_InvalidIndexOptions(t *testing.T) {
+	require.Error(t, (&cli.ServeFilesCmd{NoIndex: true, IndexTemplate: "index.tmpl"}).Validate())
+
+	tmplFile := filepath.Join(t.TempDir(), "index.tmpl")
+	require.NoError(t, os.WriteFile(tmplFile, []byte("{{.Broken"), 0o644))
+
+	cmd := &cli.ServeFilesCmd{Dir: t.TempDir(), IndexTemplate: tmplFile}
+	err := cmd.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse index template")
+}
+
+// readAccessLog waits for the access log at path to have at least one line and returns its lines
+func readAccessLog(t *testing.T, path string) []string {
+	t.Helper()
+
+	var data []byte
+	require.Eventually(t, func() bool {
+		data, _ = os.ReadFile(path)
+		return len(data) > 0
+	}, 5*time.Second, 20*time.Millisecond)
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestServeCmd_CombinedAccessLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("hello"), 0o644))
+	accessLog := filepath.Join(t.TempDir(), "access.log")
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{
+		Dir:       tmpDir,
+		LogFormat: "combined",
+		AccessLog: accessLog,
+	})
+
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/file.txt?v=1", nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set("Referer", "http://example.com/")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	lines := readAccessLog(t, accessLog)
+	require.Len(t, lines, 1)
+	require.Regexp(t, `^127\.0\.0\.1 - - \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /file.txt\?v=1 HTTP/1.1" 200 5 "http://example.com/" "test-agent"$`, lines[0])
+}
+
+func TestServeCmd_JSONAccessLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("hello"), 0o644))
+	accessLog := filepath.Join(t.TempDir(), "access.log")
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{
+		Dir:       tmpDir,
+		LogFormat: "json",
+		AccessLog: accessLog,
+	})
+
+	status, _ := getBody(t, baseURL+"/missing.txt")
+	require.Equal(t, http.StatusNotFound, status)
+
+	lines := readAccessLog(t, accessLog)
+	require.Len(t, lines, 1)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	require.Equal(t, "HTTP request", entry["msg"])
+	require.Equal(t, "GET", entry["method"])
+	require.Equal(t, "/missing.txt", entry["path"])
+	require.EqualValues(t, http.StatusNotFound, entry["status"])
+	require.Equal(t, "127.0.0.1", entry["remote_ip"])
+	require.Greater(t, entry["bytes"], float64(0))
+	require.Contains(t, entry, "duration_ms")
+}
+
+func TestServeCmd_InvalidLogFormat(t *testing.T) {
+	require.NoError(t, (&cli.ServeFilesCmd{LogFormat: "combined"}).Validate())
+	require.Error(t, (&cli.ServeFilesCmd{LogFormat: "xml"}).Validate())
+}
+
+func TestServeCmd_ETag(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "app.js")
+	require.NoError(t, os.WriteFile(path, []byte("console.log(1)"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, CacheControl: "public, max-age=60"})
+
+	resp, err := http.Get(baseURL + "/app.js")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "public, max-age=60", resp.Header.Get("Cache-Control"))
+	etag := resp.Header.Get("ETag")
+	require.Regexp(t, `^W/"[0-9a-f]+-[0-9a-f]+"$`, etag)
+
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/app.js", nil)
+	require.NoError(t, err)
+	req.Header.Set("If-None-Match", etag)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	// Changing the file changes the ETag
+	require.NoError(t, os.WriteFile(path, []byte("console.log(2)"), 0o644))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEqual(t, etag, resp.Header.Get("ETag"))
+}
+
+func TestServeCmd_NoCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "index.html"), []byte("hi"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, NoCache: true})
+
+	resp, err := http.Get(baseURL + "/index.html")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	require.Error(t, (&cli.ServeFilesCmd{NoCache: true, CacheControl: "max-age=60"}).Validate())
+}
+
+// put uploads body to url with PUT and returns the response status code
+func put(t *testing.T, url string, body io.Reader) int {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPut, url, body)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestServeCmd_UploadPut(t *testing.T) {
+	tmpDir := t.TempDir()
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, Upload: true, MaxUploadSize: 1})
+
+	require.Equal(t, http.StatusCreated, put(t, baseURL+"/new/dir/file.txt", strings.NewReader("first")))
+	data, err := os.ReadFile(filepath.Join(tmpDir, "new", "dir", "file.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "first", string(data))
+
+	require.Equal(t, http.StatusNoContent, put(t, baseURL+"/new/dir/file.txt", strings.NewReader("second")))
+	status, body := getBody(t, baseURL+"/new/dir/file.txt")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "second", body)
+
+	// Too large uploads are rejected and leave nothing behind
+	require.Equal(t, http.StatusRequestEntityTooLarge, put(t, baseURL+"/big.bin", strings.NewReader(strings.Repeat("x", 1<<20+1))))
+	require.NoFileExists(t, filepath.Join(tmpDir, "big.bin"))
+
+	// A failed replacement keeps the existing file
+	require.Equal(t, http.StatusRequestEntityTooLarge, put(t, baseURL+"/new/dir/file.txt", strings.NewReader(strings.Repeat("x", 1<<20+1))))
+	status, body = getBody(t, baseURL+"/new/dir/file.txt")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "second", body)
+	entries, err := os.ReadDir(filepath.Join(tmpDir, "new", "dir"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary upload files must be removed")
+
+	require.Equal(t, http.StatusBadRequest, put(t, baseURL+"/dir/", strings.NewReader("x")))
+}
+
+func TestServeCmd_UploadTraversal(t *testing.T) {
+	parent := t.TempDir()
+	tmpDir := filepath.Join(parent, "served")
+	require.NoError(t, os.Mkdir(tmpDir, 0o755))
+	require.NoError(t, os.Symlink(parent, filepath.Join(tmpDir, "escape")))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, Upload: true, MaxUploadSize: 1})
+
+	// The client would clean the path, so the request is written by hand
+	conn, err := net.Dial("tcp", strings.TrimPrefix(baseURL, "http://"))
+	require.NoError(t, err)
+	fmt.Fprint(conn, "PUT /../outside.txt HTTP/1.1\r\nHost: x\r\nContent-Length: 1\r\nConnection: close\r\n\r\nx")
+	response, err := io.ReadAll(conn)
+	conn.Close()
+	require.NoError(t, err)
+	require.NotContains(t, string(response), " 201 ")
+	require.NoFileExists(t, filepath.Join(parent, "outside.txt"))
+
+	require.Equal(t, http.StatusBadRequest, put(t, baseURL+"/escape/outside.txt", strings.NewReader("x")))
+	require.NoFileExists(t, filepath.Join(parent, "outside.txt"))
+}
+
+func TestServeCmd_UploadMultipart(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "inbox"), 0o755))
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, Upload: true, MaxUploadSize: 1})
+
+	upload := func(name, content string) int {
+		var buf strings.Builder
+		writer := multipart.NewWriter(&buf)
+		part, err := writer.CreateFormFile("file", name)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		resp, err := http.Post(baseURL+"/inbox/", writer.FormDataContentType(), strings.NewReader(buf.String()))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusCreated, upload("report.csv", "a,b"))
+	data, err := os.ReadFile(filepath.Join(tmpDir, "inbox", "report.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "a,b", string(data))
+
+	// Existing files are not replaced and directories in names are dropped
+	require.Equal(t, http.StatusConflict, upload("report.csv", "changed"))
+	require.Equal(t, http.StatusCreated, upload("../../notes.txt", "notes"))
+	require.FileExists(t, filepath.Join(tmpDir, "inbox", "notes.txt"))
+
+	// The listing offers an upload form
+	_, body := getBody(t, baseURL+"/inbox/")
+	require.Contains(t, body, `enctype="multipart/form-data"`)
+}
+
+func TestServeCmd_UploadDisabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir})
+
+	put(t, baseURL+"/file.txt", strings.NewReader("x"))
+	require.NoFileExists(t, filepath.Join(tmpDir, "file.txt"))
+
+	_, body := getBody(t, baseURL+"/")
+	require.NotContains(t, body, "<form")
+}
+
+func TestServeCmd_RenderMarkdown(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := "# Guide\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n<script>alert(1)</script>\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "README.md"), []byte(source), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, RenderMarkdown: true})
+
+	resp, err := http.Get(baseURL + "/README.md")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	require.Empty(t, resp.Header.Get("ETag"))
+	require.Contains(t, string(body), "<h1>Guide</h1>")
+	require.Contains(t, string(body), "<table>")
+	require.NotContains(t, string(body), "<script>alert(1)</script>")
+
+	status, raw := getBody(t, baseURL+"/README.md?raw")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, source, raw)
+}
+
+func TestServeCmd_MarkdownServedRawByDefault(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "notes.md"), []byte("# Notes\n"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir})
+
+	status, body := getBody(t, baseURL+"/notes.md")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "# Notes\n", body)
+}
+
+func TestServeCmd_IPFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("file"), 0o644))
+
+	tests := []struct {
+		name   string
+		allow  []string
+		deny   []string
+		status int
+	}{
+		{name: "allowed range", allow: []string{"10.0.0.0/8", "127.0.0.0/8"}, status: http.StatusOK},
+		{name: "outside allowed range", allow: []string{"10.0.0.0/8"}, status: http.StatusForbidden},
+		{name: "denied address", deny: []string{"127.0.0.1"}, status: http.StatusForbidden},
+		{name: "deny wins over allow", allow: []string{"127.0.0.0/8"}, deny: []string{"127.0.0.1/32"}, status: http.StatusForbidden},
+		{name: "other address denied", deny: []string{"192.168.0.0/16"}, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, Allow: tt.allow, Deny: tt.deny})
+			status, _ := getBody(t, baseURL+"/file.txt")
+			require.Equal(t, tt.status, status)
+		})
+	}
+}
+
+func TestServeCmd_InvalidIPFilter(t *testing.T) {
+	require.NoError(t, (&cli.ServeFilesCmd{Allow: []string{"192.168.1.0/24", "::1", "fd00::/8"}}).Validate())
+	require.Error(t, (&cli.ServeFilesCmd{Allow: []string{"192.168.1.0/33"}}).Validate())
+	require.Error(t, (&cli.ServeFilesCmd{Deny: []string{"not-an-ip"}}).Validate())
+}
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 and its key,
+// returning their paths
+func writeTestCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "toolshed test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certPath, keyPath
+}
+
+func TestServeCmd_TLSUsesHTTP2(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("secure"), 0o644))
+	certPath, keyPath := writeTestCert(t)
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, TLSCert: certPath, TLSKey: keyPath})
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := client.Get(strings.Replace(baseURL, "http://", "https://", 1) + "/file.txt")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 2, resp.ProtoMajor)
+	require.Equal(t, "secure", string(body))
+}
+
+func TestServeCmd_H2C(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("cleartext"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, H2C: true})
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+	resp, err := client.Get(baseURL + "/file.txt")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 2, resp.ProtoMajor)
+
+	// HTTP/1.1 clients keep working
+	status, body := getBody(t, baseURL+"/file.txt")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "cleartext", body)
+}
+
+func TestServeCmd_InvalidTLSOptions(t *testing.T) {
+	require.Error(t, (&cli.ServeFilesCmd{TLSCert: "cert.pem"}).Validate())
+	require.Error(t, (&cli.ServeFilesCmd{TLSKey: "key.pem"}).Validate())
+	require.Error(t, (&cli.ServeFilesCmd{TLSCert: "cert.pem", TLSKey: "key.pem", H2C: true}).Validate())
+
+	certPath, _ := writeTestCert(t)
+	cmd := &cli.ServeFilesCmd{Dir: t.TempDir(), TLSCert: certPath, TLSKey: certPath}
+	err := cmd.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to load TLS certificate")
+}
+
+func TestServeCmd_H2CFlag(t *testing.T) {
+	var app struct {
+		Serve cli.ServeCmd `cmd:""`
+	}
+	parser, err := kong.New(&app)
+	require.NoError(t, err)
+
+	_, err = parser.Parse([]string{"serve", "--h2c"})
+	require.NoError(t, err)
+	require.True(t, app.Serve.Files.H2C)
+}
+
+func TestServeCmd_AuthFlagKeepsCommas(t *testing.T) {
+	var app struct {
+		Serve cli.ServeCmd `cmd:""`
+	}
+	parser, err := kong.New(&app)
+	require.NoError(t, err)
+
+	_, err = parser.Parse([]string{"serve", "--auth", "alice:pass,word", "--auth", "bob:x"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice:pass,word", "bob:x"}, app.Serve.Files.Auth)
+}
+
+func TestServeCmd_CustomHeaders(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("file"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{
+		Dir:          tmpDir,
+		CacheControl: "no-store",
+		Headers: []string{
+			"X-Served-By: edge-1",
+			"cache-control: public, max-age=31536000",
+			"Link: </style.css>; rel=preload",
+			"Link: </app.js>; rel=preload",
+		},
+	})
+
+	for _, path := range []string{"/file.txt", "/missing.txt"} {
+		resp, err := http.Get(baseURL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, "edge-1", resp.Header.Get("X-Served-By"), path)
+		require.Equal(t, "public, max-age=31536000", resp.Header.Get("Cache-Control"), path)
+		require.Len(t, resp.Header.Values("Link"), 2, path)
+	}
+}
+
+func TestServeCmd_NotFoundPage(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("file"), 0o644))
+	page := filepath.Join(t.TempDir(), "404.html")
+	require.NoError(t, os.WriteFile(page, []byte("<h1>Nothing here</h1>"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, NotFound: page})
+
+	resp, err := http.Get(baseURL + "/missing.txt")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	require.Equal(t, "<h1>Nothing here</h1>", string(body))
+
+	status, body2 := getBody(t, baseURL+"/file.txt")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "file", body2)
+}
+
+func TestServeCmd_HeaderAndNotFoundFlags(t *testing.T) {
+	var app struct {
+		Serve cli.ServeCmd `cmd:""`
+	}
+	parser, err := kong.New(&app)
+	require.NoError(t, err)
+
+	_, err = parser.Parse([]string{"serve", "-H", "X-One: 1", "--header", "Cache-Control: public, max-age=60", "--404", "missing.html"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"X-One: 1", "Cache-Control: public, max-age=60"}, app.Serve.Files.Headers)
+	require.Equal(t, "missing.html", filepath.Base(app.Serve.Files.NotFound))
+
+	require.Error(t, (&cli.ServeFilesCmd{Headers: []string{"no colon"}}).Validate())
+	require.Error(t, (&cli.ServeFilesCmd{Headers: []string{"Bad Key: value"}}).Validate())
+
+	cmd := &cli.ServeFilesCmd{Dir: t.TempDir(), NotFound: filepath.Join(t.TempDir(), "missing.html")}
+	err = cmd.Run(testutil.NewTestContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read 404 page")
+}
+
+func TestServeCmd_ChecksumHeaders(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := []byte("release contents")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "release.tar.gz"), content, 0o644))
+	sum := sha256.Sum256(content)
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, Checksums: true})
+
+	resp, err := http.Get(baseURL + "/release.tar.gz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, hex.EncodeToString(sum[:]), resp.Header.Get("X-Checksum-SHA256"))
+	require.Equal(t, "sha-256="+base64.StdEncoding.EncodeToString(sum[:]), resp.Header.Get("Digest"))
+	require.Equal(t, `"`+hex.EncodeToString(sum[:])+`"`, resp.Header.Get("ETag"))
+}
+
+func TestServeCmd_ChecksumsEndpoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "b.txt"), []byte("b"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "sub", "deep"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "sub", "deep", "a.txt"), []byte("a"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, Checksums: true})
+
+	line := func(content, name string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:]) + "  " + name + "\n"
+	}
+
+	status, body := getBody(t, baseURL+"/__checksums")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, line("b", "b.txt")+line("a", "sub/deep/a.txt"), body)
+
+	status, body = getBody(t, baseURL+"/__checksums/sub")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, line("a", "deep/a.txt"), body)
+
+	status, _ = getBody(t, baseURL+"/__checksums/b.txt")
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestServeCmd_ChecksumsDisabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("file"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir})
+
+	resp, err := http.Get(baseURL + "/file.txt")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Empty(t, resp.Header.Get("X-Checksum-SHA256"))
+	require.Empty(t, resp.Header.Get("Digest"))
+
+	status, _ := getBody(t, baseURL+"/__checksums")
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestServeCmd_Metrics(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("12345"), 0o644))
+
+	baseURL := startServeFiles(t, &cli.ServeFilesCmd{Dir: tmpDir, Metrics: true})
+
+	for range 2 {
+		status, _ := getBody(t, baseURL+"/file.txt")
+		require.Equal(t, http.StatusOK, status)
+	}
+	status, _ := getBody(t, baseURL+"/missing.txt")
+	require.Equal(t, http.StatusNotFound, status)
+
+	resp, err := http.Get(baseURL + "/metrics")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Content-Type"), "version=0.0.4")
+
+	metrics := string(body)
+	require.Contains(t, metrics, "# TYPE toolshed_serve_requests_total counter\n")
+	require.Contains(t, metrics, `toolshed_serve_requests_total{method="GET",status="2xx"} 2`+"\n")
+	require.Contains(t, metrics, `toolshed_serve_requests_total{method="GET",status="4xx"} 1`+"\n")
+	require.Contains(t, metrics, `toolshed_serve_request_duration_seconds_bucket{le="+Inf"} 3`+"\n")
+	require.Contains(t, metrics, "toolshed_serve_request_duration_seconds_count 3\n")
+	require.Regexp(t, `toolshed_serve_response_bytes_total \d+\n`, metrics)
+	// The scrape itself is in flight
+	require.Contains(t, metrics, "toolshed_serve_requests_in_flight 1\n")
+}
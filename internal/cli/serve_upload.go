@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// uploadField is the multipart form field holding uploaded files
+const uploadField = "file"
+
+// uploadHandler accepts files uploaded with PUT to their path or with a
+// multipart POST to their directory, and passes other requests on. Files are
+// written through an os.Root, so neither .. nor symlinks can escape the
+// served directory.
+type uploadHandler struct {
+	root    *os.Root
+	handler http.Handler
+	maxSize int64
+	logger  *slog.Logger
+}
+
+func (uh *uploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		uh.put(w, r)
+	case http.MethodPost:
+		uh.post(w, r)
+	default:
+		uh.handler.ServeHTTP(w, r)
+	}
+}
+
+// put stores the request body at the request path, replacing any existing file
+func (uh *uploadHandler) put(w http.ResponseWriter, r *http.Request) {
+	name, ok := uploadPath(r.URL.Path)
+	if !ok || strings.HasSuffix(r.URL.Path, "/") {
+		http.Error(w, "invalid upload path", http.StatusBadRequest)
+		return
+	}
+
+	_, err := uh.root.Stat(name)
+	existed := err == nil
+
+	body := http.MaxBytesReader(w, r.Body, uh.maxSize)
+	written, err := uh.replace(name, body)
+	if err != nil {
+		uh.fail(w, r, name, err)
+		return
+	}
+
+	uh.logger.Info("File uploaded", "file", name, "bytes", written, "remote_addr", r.RemoteAddr)
+	if existed {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Location", r.URL.Path)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// post stores the files of a multipart form in the directory at the request
+// path. Existing files are never replaced.
+func (uh *uploadHandler) post(w http.ResponseWriter, r *http.Request) {
+	dir, ok := uploadPath(r.URL.Path)
+	if !ok && path.Clean(r.URL.Path) != "/" {
+		http.Error(w, "invalid upload path", http.StatusBadRequest)
+		return
+	}
+	if dir == "" {
+		dir = "."
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, uh.maxSize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "expected a multipart/form-data upload", http.StatusBadRequest)
+		return
+	}
+
+	var saved []string
+	redirect := false
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			uh.fail(w, r, dir, err)
+			return
+		}
+
+		if part.FormName() == "redirect" {
+			redirect = true
+			continue
+		}
+		if part.FormName() != uploadField || part.FileName() == "" {
+			continue
+		}
+
+		name, err := uh.create(dir, part)
+		if err != nil {
+			uh.fail(w, r, path.Join(dir, part.FileName()), err)
+			return
+		}
+		saved = append(saved, name)
+	}
+
+	if len(saved) == 0 {
+		http.Error(w, fmt.Sprintf("no files in form field %q", uploadField), http.StatusBadRequest)
+		return
+	}
+
+	// Uploads from the directory listing go back to it
+	if redirect {
+		http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusCreated)
+	for _, name := range saved {
+		fmt.Fprintln(w, "/"+name)
+	}
+}
+
+// create stores a multipart file in dir under its base name, failing with
+// fs.ErrExist if a file of that name exists
+func (uh *uploadHandler) create(dir string, part *multipart.Part) (string, error) {
+	base := path.Base(strings.ReplaceAll(part.FileName(), "\\", "/"))
+	if base == "." || base == "/" || base == ".." {
+		return "", fmt.Errorf("invalid file name %q", part.FileName())
+	}
+
+	name := path.Join(dir, base)
+	if err := uh.mkdirAll(dir); err != nil {
+		return "", err
+	}
+	written, err := uh.write(name, part)
+	if err != nil {
+		return "", err
+	}
+	uh.logger.Info("File uploaded", "file", name, "bytes", written)
+	return name, nil
+}
+
+// replace writes src to a temporary file next to name and renames it into
+// place once complete, creating missing parent directories. The file being
+// replaced is left untouched if the upload fails, and readers never see it
+// partially written.
+func (uh *uploadHandler) replace(name string, src io.Reader) (int64, error) {
+	if err := uh.mkdirAll(path.Dir(name)); err != nil {
+		return 0, err
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return 0, err
+	}
+	tmp := path.Join(path.Dir(name), "."+path.Base(name)+".upload-"+hex.EncodeToString(suffix))
+
+	written, err := uh.write(tmp, src)
+	if err != nil {
+		return 0, err
+	}
+
+	// os.Root cannot rename before Go 1.25, so rename by path. Both names
+	// were just resolved inside the root, and uploads cannot create symlinks
+	// that would lead the rename out of it.
+	err = os.Rename(filepath.Join(uh.root.Name(), filepath.FromSlash(tmp)), filepath.Join(uh.root.Name(), filepath.FromSlash(name)))
+	if err != nil {
+		uh.root.Remove(tmp)
+		return 0, err
+	}
+	return written, nil
+}
+
+// write copies src to name, failing with fs.ErrExist if name exists. A
+// partially written file is removed.
+func (uh *uploadHandler) write(name string, src io.Reader) (int64, error) {
+	file, err := uh.root.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+
+	written, err := io.Copy(file, src)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		uh.root.Remove(name)
+		return 0, err
+	}
+	return written, nil
+}
+
+// mkdirAll creates dir and its missing parents inside the root
+func (uh *uploadHandler) mkdirAll(dir string) error {
+	if dir == "." {
+		return nil
+	}
+	current := ""
+	for _, segment := range strings.Split(dir, "/") {
+		current = path.Join(current, segment)
+		if err := uh.root.Mkdir(current, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// fail reports a failed upload to the client with a matching status code
+func (uh *uploadHandler) fail(w http.ResponseWriter, r *http.Request, name string, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		uh.logger.Warn("Upload too large", "file", name, "limit", maxBytesErr.Limit, "remote_addr", r.RemoteAddr)
+		http.Error(w, fmt.Sprintf("upload exceeds the limit of %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, fs.ErrExist):
+		http.Error(w, "file already exists", http.StatusConflict)
+	default:
+		uh.logger.Error("Upload failed", "file", name, "error", err, "remote_addr", r.RemoteAddr)
+		http.Error(w, "upload failed", http.StatusBadRequest)
+	}
+}
+
+// uploadPath converts a URL path to a slash-separated path relative to the
+// served directory, rejecting paths that would leave it
+func uploadPath(urlPath string) (string, bool) {
+	if strings.Contains(urlPath, "\x00") {
+		return "", false
+	}
+	for _, segment := range strings.Split(urlPath, "/") {
+		if segment == ".." {
+			return "", false
+		}
+	}
+	name := strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+	if name == "" {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bilte-co/toolshed/snowflake"
+)
+
+// SnowflakeCmd represents the snowflake command group
+type SnowflakeCmd struct {
+	Create  SnowflakeCreateCmd  `cmd:"" help:"Generate Snowflake IDs"`
+	Inspect SnowflakeInspectCmd `cmd:"" help:"Show the timestamp, node and sequence of a Snowflake ID as JSON"`
+}
+
+// snowflakeLayout holds the flags describing the bit layout of Snowflake IDs
+type snowflakeLayout struct {
+	Epoch        string `long:"epoch" help:"Epoch the timestamp counts from (RFC3339, defaults to the Twitter epoch)"`
+	NodeBits     uint8  `long:"node-bits" default:"10" help:"Number of bits for the node ID"`
+	SequenceBits uint8  `long:"sequence-bits" default:"12" help:"Number of bits for the sequence number"`
+}
+
+// config converts the layout flags to a snowflake.Config
+func (l *snowflakeLayout) config() (snowflake.Config, error) {
+	config := snowflake.Config{
+		Epoch:        snowflake.DefaultEpoch,
+		NodeBits:     l.NodeBits,
+		SequenceBits: l.SequenceBits,
+	}
+	if l.Epoch != "" {
+		epoch, err := time.Parse(time.RFC3339, l.Epoch)
+		if err != nil {
+			return config, fmt.Errorf("invalid epoch format (expected RFC3339): %w", err)
+		}
+		config.Epoch = epoch
+	}
+	return config, config.Validate()
+}
+
+// SnowflakeCreateCmd generates Snowflake IDs
+type SnowflakeCreateCmd struct {
+	snowflakeLayout `embed:""`
+	Node            int64 `short:"n" default:"0" env:"TOOLSHED_SNOWFLAKE_NODE" help:"Node ID, unique per generating process"`
+	Count           int   `short:"c" default:"1" help:"Number of IDs to generate"`
+}
+
+// Run executes the snowflake create command
+func (cmd *SnowflakeCreateCmd) Run(ctx *CLIContext) error {
+	ctx.Logger.Debug("Creating Snowflake IDs", "node", cmd.Node, "count", cmd.Count)
+
+	if cmd.Count < 1 {
+		ctx.Logger.Error("Invalid count", "count", cmd.Count)
+		return fmt.Errorf("count must be at least 1")
+	}
+
+	config, err := cmd.config()
+	if err != nil {
+		ctx.Logger.Error("Invalid layout", "error", err)
+		return err
+	}
+
+	gen, err := snowflake.NewGenerator(cmd.Node, config)
+	if err != nil {
+		ctx.Logger.Error("Failed to create generator", "error", err)
+		return fmt.Errorf("failed to create generator: %w", err)
+	}
+
+	for i := 0; i < cmd.Count; i++ {
+		id, err := gen.Generate()
+		if err != nil {
+			ctx.Logger.Error("Failed to generate Snowflake ID", "error", err)
+			return fmt.Errorf("failed to generate Snowflake ID: %w", err)
+		}
+		fmt.Println(id)
+	}
+
+	ctx.Logger.Info("Snowflake IDs created successfully", "count", cmd.Count, "node", cmd.Node)
+	return nil
+}
+
+// SnowflakeInspectCmd breaks a Snowflake ID down into its components
+type SnowflakeInspectCmd struct {
+	snowflakeLayout `embed:""`
+	Text            string `arg:"" help:"Snowflake ID to inspect (use '-' for stdin)"`
+}
+
+// snowflakeInspection is the JSON output of the snowflake inspect command
+type snowflakeInspection struct {
+	ID        string      `json:"id"`
+	Timestamp idTimestamp `json:"timestamp"`
+	Node      int64       `json:"node"`
+	Sequence  int64       `json:"sequence"`
+	Epoch     string      `json:"epoch"`
+}
+
+// Run executes the snowflake inspect command
+func (cmd *SnowflakeInspectCmd) Run(ctx *CLIContext) error {
+	input := cmd.Text
+	if input == "-" {
+		data, err := readStdin()
+		if err != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", err)
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		input = strings.TrimSpace(string(data))
+	}
+
+	id, err := snowflake.Parse(input)
+	if err != nil {
+		ctx.Logger.Error("Invalid Snowflake ID", "id", input, "error", err)
+		return err
+	}
+
+	config, err := cmd.config()
+	if err != nil {
+		ctx.Logger.Error("Invalid layout", "error", err)
+		return err
+	}
+
+	parts := config.Decompose(id)
+	inspection := snowflakeInspection{
+		ID:        id.String(),
+		Timestamp: newIDTimestamp(parts.Time),
+		Node:      parts.Node,
+		Sequence:  parts.Sequence,
+		Epoch:     config.Epoch.UTC().Format(time.RFC3339Nano),
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(inspection); err != nil {
+		ctx.Logger.Error("Failed to encode inspection", "error", err)
+		return fmt.Errorf("failed to encode inspection: %w", err)
+	}
+	return nil
+}
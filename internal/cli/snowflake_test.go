@@ -0,0 +1,94 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+	"github.com/bilte-co/toolshed/snowflake"
+)
+
+// runSnowflake parses and runs a snowflake command, returning its output
+func runSnowflake(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var app struct {
+		Snowflake cli.SnowflakeCmd `cmd:""`
+	}
+	parser, err := kong.New(&app)
+	require.NoError(t, err)
+
+	kctx, err := parser.Parse(append([]string{"snowflake"}, args...))
+	require.NoError(t, err)
+
+	var runErr error
+	output := captureStdout(t, func() {
+		runErr = kctx.Run(testutil.NewTestContext())
+	})
+	return output, runErr
+}
+
+func TestSnowflakeCreateCmd(t *testing.T) {
+	output, err := runSnowflake(t, "create", "--node", "5", "--count", "3")
+	require.NoError(t, err)
+
+	lines := strings.Fields(output)
+	require.Len(t, lines, 3)
+
+	var last snowflake.ID
+	for _, line := range lines {
+		id, err := snowflake.Parse(line)
+		require.NoError(t, err)
+		require.Greater(t, id, last)
+		require.Equal(t, int64(5), snowflake.DefaultConfig.Decompose(id).Node)
+		last = id
+	}
+}
+
+func TestSnowflakeCreateCmd_Invalid(t *testing.T) {
+	_, err := runSnowflake(t, "create", "--node", "1024")
+	require.ErrorIs(t, err, snowflake.ErrInvalidNode)
+
+	_, err = runSnowflake(t, "create", "--node-bits", "20", "--sequence-bits", "10")
+	require.ErrorIs(t, err, snowflake.ErrInvalidConfig)
+
+	_, err = runSnowflake(t, "create", "--count", "0")
+	require.ErrorContains(t, err, "count must be at least 1")
+
+	_, err = runSnowflake(t, "create", "--epoch", "yesterday")
+	require.ErrorContains(t, err, "invalid epoch format")
+}
+
+func TestSnowflakeInspectCmd(t *testing.T) {
+	created, err := runSnowflake(t, "create", "--node", "9", "--node-bits", "5", "--sequence-bits", "5", "--epoch", "2020-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	output, err := runSnowflake(t, "inspect", strings.TrimSpace(created), "--node-bits", "5", "--sequence-bits", "5", "--epoch", "2020-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	var inspection struct {
+		ID        string `json:"id"`
+		Node      int64  `json:"node"`
+		Sequence  int64  `json:"sequence"`
+		Epoch     string `json:"epoch"`
+		Timestamp struct {
+			UnixMilli int64 `json:"unix_milli"`
+		} `json:"timestamp"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &inspection))
+	require.Equal(t, strings.TrimSpace(created), inspection.ID)
+	require.Equal(t, int64(9), inspection.Node)
+	require.Equal(t, int64(0), inspection.Sequence)
+	require.Equal(t, "2020-01-01T00:00:00Z", inspection.Epoch)
+	require.Positive(t, inspection.Timestamp.UnixMilli)
+}
+
+func TestSnowflakeInspectCmd_Invalid(t *testing.T) {
+	_, err := runSnowflake(t, "inspect", "not-a-number")
+	require.ErrorContains(t, err, "invalid snowflake ID")
+}
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -13,6 +15,8 @@ import (
 type ULIDCmd struct {
 	Create    ULIDCreateCmd    `cmd:"" help:"Create a new ULID"`
 	Timestamp ULIDTimestampCmd `cmd:"" help:"Extract timestamp from ULID"`
+	Validate  ULIDValidateCmd  `cmd:"" help:"Validate ULIDs, exiting non-zero if any are invalid"`
+	Inspect   ULIDInspectCmd   `cmd:"" help:"Show the prefix, timestamp and entropy of a ULID as JSON"`
 }
 
 // ULIDCreateCmd creates a new ULID
@@ -43,13 +47,9 @@ func (cmd *ULIDCreateCmd) Run(ctx *CLIContext) error {
 
 	// Validate prefix (basic sanitization)
 	if cmd.Prefix != "" {
-		if strings.ContainsAny(cmd.Prefix, " \t\n\r_") {
-			ctx.Logger.Error("Invalid prefix", "prefix", cmd.Prefix)
-			return fmt.Errorf("prefix cannot contain whitespace or underscore characters")
-		}
-		if len(cmd.Prefix) > 32 {
-			ctx.Logger.Error("Prefix too long", "prefix", cmd.Prefix, "length", len(cmd.Prefix))
-			return fmt.Errorf("prefix cannot exceed 32 characters")
+		if err := ulid.ValidatePrefix(cmd.Prefix); err != nil {
+			ctx.Logger.Error("Invalid prefix", "prefix", cmd.Prefix, "error", err)
+			return err
 		}
 	}
 
@@ -120,6 +120,137 @@ func (cmd *ULIDTimestampCmd) Run(ctx *CLIContext) error {
 	return nil
 }
 
+// ULIDValidateCmd validates ULIDs from arguments or stdin
+type ULIDValidateCmd struct {
+	IDs   []string `arg:"" optional:"" help:"ULIDs to validate (use '-' or omit to read one per line from stdin)"`
+	Quiet bool     `short:"q" help:"Print nothing, only set the exit code"`
+}
+
+// Run executes the ULID validate command
+func (cmd *ULIDValidateCmd) Run(ctx *CLIContext) error {
+	ids := cmd.IDs
+	if len(ids) == 0 || (len(ids) == 1 && ids[0] == "-") {
+		data, err := readStdin()
+		if err != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", err)
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		ids = nil
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				ids = append(ids, line)
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		ctx.Logger.Error("Empty ULID input")
+		return fmt.Errorf("ULID cannot be empty")
+	}
+
+	invalid := 0
+	for _, id := range ids {
+		err := ulid.Validate(id)
+		if err != nil {
+			invalid++
+		}
+		if cmd.Quiet {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", id, err)
+		} else {
+			fmt.Printf("✓ %s\n", id)
+		}
+	}
+
+	if invalid == 0 {
+		ctx.Logger.Info("All ULIDs are valid", "count", len(ids))
+		return nil
+	}
+
+	ctx.Logger.Info("Invalid ULIDs found", "invalid", invalid, "count", len(ids))
+	ExitFunc(1)
+	return nil
+}
+
+// ULIDInspectCmd breaks a ULID down into its components
+type ULIDInspectCmd struct {
+	Text string `arg:"" help:"ULID string to inspect (use '-' for stdin)"`
+}
+
+// ulidInspection is the JSON output of the ULID inspect command
+type ulidInspection struct {
+	ULID        string      `json:"ulid"`
+	Prefix      string      `json:"prefix"`
+	Encoded     string      `json:"encoded"`
+	Canonical   string      `json:"canonical"`
+	Timestamp   idTimestamp `json:"timestamp"`
+	Entropy     string      `json:"entropy"`
+	EntropyBits int         `json:"entropy_bits"`
+}
+
+// idTimestamp is the creation time of an ID in several formats
+type idTimestamp struct {
+	RFC3339   string `json:"rfc3339"`
+	RFC1123   string `json:"rfc1123"`
+	Unix      int64  `json:"unix"`
+	UnixMilli int64  `json:"unix_milli"`
+}
+
+// newIDTimestamp formats a millisecond precision timestamp in UTC
+func newIDTimestamp(t time.Time) idTimestamp {
+	t = t.UTC()
+	return idTimestamp{
+		RFC3339:   t.Format("2006-01-02T15:04:05.000Z07:00"),
+		RFC1123:   t.Format(time.RFC1123),
+		Unix:      t.Unix(),
+		UnixMilli: t.UnixMilli(),
+	}
+}
+
+// Run executes the ULID inspect command
+func (cmd *ULIDInspectCmd) Run(ctx *CLIContext) error {
+	input := cmd.Text
+	if input == "-" {
+		data, err := readStdin()
+		if err != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", err)
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		input = strings.TrimSpace(string(data))
+	}
+
+	if input == "" {
+		ctx.Logger.Error("Empty ULID input")
+		return fmt.Errorf("ULID cannot be empty")
+	}
+
+	details, err := ulid.Inspect(input)
+	if err != nil {
+		ctx.Logger.Error("Failed to inspect ULID", "ulid", input, "error", err)
+		return fmt.Errorf("failed to inspect ULID: %w", err)
+	}
+
+	inspection := ulidInspection{
+		ULID:        input,
+		Prefix:      details.Prefix,
+		Encoded:     details.Encoded,
+		Canonical:   details.Canonical(),
+		Timestamp:   newIDTimestamp(details.Time),
+		Entropy:     hex.EncodeToString(details.Entropy),
+		EntropyBits: len(details.Entropy) * 8,
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(inspection); err != nil {
+		ctx.Logger.Error("Failed to encode inspection", "error", err)
+		return fmt.Errorf("failed to encode inspection: %w", err)
+	}
+	return nil
+}
+
 // readStdin reads data from standard input
 func readStdin() ([]byte, error) {
 	stat, err := os.Stdin.Stat()
@@ -1,6 +1,7 @@
 package cli_test
 
 import (
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -489,3 +490,95 @@ func TestULIDCreateCmd_BoundaryPrefixLength(t *testing.T) {
 		})
 	}
 }
+
+func TestULIDValidateCmd_Args(t *testing.T) {
+	valid, err := ulid.CreateULID("user", time.Now())
+	require.NoError(t, err)
+
+	oldExit := cli.ExitFunc
+	exitCode := 0
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	cmd := &cli.ULIDValidateCmd{IDs: []string{valid}}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext()))
+	})
+	require.Equal(t, 0, exitCode)
+	require.Contains(t, output, "✓ "+valid)
+
+	cmd = &cli.ULIDValidateCmd{IDs: []string{valid, "user_not-valid"}}
+	output = captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext()))
+	})
+	require.Equal(t, 1, exitCode)
+	require.Contains(t, output, "✗ user_not-valid: invalid ULID character")
+}
+
+func TestULIDValidateCmd_Stdin(t *testing.T) {
+	valid, err := ulid.CreateULID("", time.Now())
+	require.NoError(t, err)
+
+	oldExit := cli.ExitFunc
+	exitCode := 0
+	cli.ExitFunc = func(code int) { exitCode = code }
+	defer func() { cli.ExitFunc = oldExit }()
+
+	restore := replaceStdin(t, valid+"\n\n"+valid+"\n")
+	defer restore()
+
+	cmd := &cli.ULIDValidateCmd{Quiet: true}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext()))
+	})
+	require.Equal(t, 0, exitCode)
+	require.Empty(t, output)
+}
+
+func TestULIDValidateCmd_Empty(t *testing.T) {
+	restore := replaceStdin(t, "\n")
+	defer restore()
+
+	cmd := &cli.ULIDValidateCmd{IDs: []string{"-"}}
+	err := cmd.Run(testutil.NewTestContext())
+	require.ErrorContains(t, err, "ULID cannot be empty")
+}
+
+func TestULIDInspectCmd_JSON(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 123_000_000, time.UTC)
+	id, err := ulid.CreateULID("order", ts)
+	require.NoError(t, err)
+
+	cmd := &cli.ULIDInspectCmd{Text: id}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext()))
+	})
+
+	var inspection struct {
+		ULID      string `json:"ulid"`
+		Prefix    string `json:"prefix"`
+		Canonical string `json:"canonical"`
+		Timestamp struct {
+			RFC3339   string `json:"rfc3339"`
+			Unix      int64  `json:"unix"`
+			UnixMilli int64  `json:"unix_milli"`
+		} `json:"timestamp"`
+		Entropy     string `json:"entropy"`
+		EntropyBits int    `json:"entropy_bits"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &inspection))
+	require.Equal(t, id, inspection.ULID)
+	require.Equal(t, "order", inspection.Prefix)
+	require.Len(t, inspection.Canonical, 26)
+	require.Equal(t, "2024-05-06T07:08:09.123Z", inspection.Timestamp.RFC3339)
+	require.Equal(t, ts.Unix(), inspection.Timestamp.Unix)
+	require.Equal(t, ts.UnixMilli(), inspection.Timestamp.UnixMilli)
+	require.Len(t, inspection.Entropy, 20)
+	require.Equal(t, 80, inspection.EntropyBits)
+}
+
+func TestULIDInspectCmd_Invalid(t *testing.T) {
+	cmd := &cli.ULIDInspectCmd{Text: "user_not-valid"}
+	err := cmd.Run(testutil.NewTestContext())
+	require.ErrorContains(t, err, "failed to inspect ULID")
+}
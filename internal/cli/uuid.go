@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bilte-co/toolshed/uuid"
+)
+
+// UUIDCmd represents the UUID command group
+type UUIDCmd struct {
+	Create  UUIDCreateCmd  `cmd:"" default:"withargs" help:"Generate UUIDs (default)"`
+	Inspect UUIDInspectCmd `cmd:"" help:"Show the version, variant and timestamp of a UUID as JSON"`
+}
+
+// UUIDCreateCmd generates UUIDs
+type UUIDCreateCmd struct {
+	Type   string `short:"t" default:"v4" enum:"v4,v7" help:"UUID version to generate (v4, v7)"`
+	Count  int    `short:"c" default:"1" help:"Number of UUIDs to generate"`
+	Format string `short:"f" default:"canonical" help:"Output format (canonical, hex, urn, braces, base62)"`
+	Upper  bool   `short:"u" help:"Output uppercase hex digits"`
+}
+
+// Validate validates the command arguments
+func (cmd *UUIDCreateCmd) Validate() error {
+	if cmd.Count < 1 {
+		return fmt.Errorf("count must be at least 1")
+	}
+	format := uuid.Format(strings.ToLower(cmd.Format))
+	if _, err := uuid.Nil.Format(format); err != nil {
+		return err
+	}
+	if cmd.Upper && format == uuid.FormatBase62 {
+		return fmt.Errorf("--upper cannot be used with the case-sensitive base62 format")
+	}
+	return nil
+}
+
+// Run executes the UUID create command
+func (cmd *UUIDCreateCmd) Run(ctx *CLIContext) error {
+	ctx.Logger.Debug("Creating UUIDs", "type", cmd.Type, "count", cmd.Count, "format", cmd.Format)
+
+	if err := cmd.Validate(); err != nil {
+		ctx.Logger.Error("Invalid UUID options", "error", err)
+		return err
+	}
+
+	generate := uuid.NewV4
+	if cmd.Type == "v7" {
+		generate = uuid.NewV7
+	}
+
+	for i := 0; i < cmd.Count; i++ {
+		id, err := generate()
+		if err != nil {
+			ctx.Logger.Error("Failed to generate UUID", "error", err)
+			return fmt.Errorf("failed to generate UUID: %w", err)
+		}
+
+		output, err := id.Format(uuid.Format(strings.ToLower(cmd.Format)))
+		if err != nil {
+			return err
+		}
+		if cmd.Upper {
+			output = strings.ToUpper(output)
+		}
+		fmt.Println(output)
+	}
+
+	ctx.Logger.Info("UUIDs created successfully", "type", cmd.Type, "count", cmd.Count)
+	return nil
+}
+
+// UUIDInspectCmd shows the components of a UUID
+type UUIDInspectCmd struct {
+	Text string `arg:"" help:"UUID to inspect (use '-' for stdin)"`
+}
+
+// uuidInspection is the JSON output of the UUID inspect command
+type uuidInspection struct {
+	UUID      string       `json:"uuid"`
+	Version   int          `json:"version"`
+	Variant   string       `json:"variant"`
+	Nil       bool         `json:"nil"`
+	Timestamp *idTimestamp `json:"timestamp,omitempty"`
+}
+
+// Run executes the UUID inspect command
+func (cmd *UUIDInspectCmd) Run(ctx *CLIContext) error {
+	input := cmd.Text
+	if input == "-" {
+		data, err := readStdin()
+		if err != nil {
+			ctx.Logger.Error("Failed to read from stdin", "error", err)
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		input = string(data)
+	}
+
+	id, err := uuid.Parse(input)
+	if err != nil {
+		ctx.Logger.Error("Failed to parse UUID", "error", err)
+		return err
+	}
+
+	inspection := uuidInspection{
+		UUID:    id.String(),
+		Version: int(id.Version()),
+		Variant: id.Variant().String(),
+		Nil:     id.IsNil(),
+	}
+	if created, ok := id.Time(); ok {
+		ts := newIDTimestamp(created)
+		inspection.Timestamp = &ts
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(inspection); err != nil {
+		ctx.Logger.Error("Failed to encode inspection", "error", err)
+		return fmt.Errorf("failed to encode inspection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+	"github.com/bilte-co/toolshed/uuid"
+)
+
+func TestUUIDCreateCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     cli.UUIDCreateCmd
+		version uuid.Version
+		prefix  string
+		length  int
+	}{
+		{"v4 canonical", cli.UUIDCreateCmd{Type: "v4", Count: 3, Format: "canonical"}, uuid.V4, "", 36},
+		{"v7 hex", cli.UUIDCreateCmd{Type: "v7", Count: 2, Format: "hex"}, uuid.V7, "", 32},
+		{"v7 urn upper", cli.UUIDCreateCmd{Type: "v7", Count: 1, Format: "urn", Upper: true}, uuid.V7, "URN:UUID:", 45},
+		{"v4 braces", cli.UUIDCreateCmd{Type: "v4", Count: 1, Format: "braces"}, uuid.V4, "{", 38},
+		{"v7 base62", cli.UUIDCreateCmd{Type: "v7", Count: 2, Format: "base62"}, uuid.V7, "", 22},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				require.NoError(t, tt.cmd.Run(testutil.NewTestContext()))
+			})
+
+			lines := strings.Fields(output)
+			require.Len(t, lines, tt.cmd.Count)
+			for _, line := range lines {
+				require.Len(t, line, tt.length)
+				require.True(t, strings.HasPrefix(line, tt.prefix))
+				id, err := uuid.Parse(line)
+				require.NoError(t, err)
+				require.Equal(t, tt.version, id.Version())
+			}
+		})
+	}
+}
+
+func TestUUIDCreateCmd_Invalid(t *testing.T) {
+	cmd := &cli.UUIDCreateCmd{Type: "v4", Count: 1, Format: "base64"}
+	require.ErrorContains(t, cmd.Run(testutil.NewTestContext()), "unsupported UUID format")
+
+	cmd = &cli.UUIDCreateCmd{Type: "v4", Count: 0, Format: "canonical"}
+	require.ErrorContains(t, cmd.Run(testutil.NewTestContext()), "count must be at least 1")
+
+	cmd = &cli.UUIDCreateCmd{Type: "v4", Count: 1, Format: "base62", Upper: true}
+	require.ErrorContains(t, cmd.Run(testutil.NewTestContext()), "--upper cannot be used")
+}
+
+func TestUUIDInspectCmd(t *testing.T) {
+	cmd := &cli.UUIDInspectCmd{Text: "urn:uuid:0192A8B4-9C3F-7B1A-8E2D-3F4A5B6C7D8E"}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext()))
+	})
+
+	var inspection struct {
+		UUID      string `json:"uuid"`
+		Version   int    `json:"version"`
+		Variant   string `json:"variant"`
+		Timestamp *struct {
+			UnixMilli int64 `json:"unix_milli"`
+		} `json:"timestamp"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &inspection))
+	require.Equal(t, "0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8e", inspection.UUID)
+	require.Equal(t, 7, inspection.Version)
+	require.Equal(t, "rfc9562", inspection.Variant)
+	require.NotNil(t, inspection.Timestamp)
+	require.Equal(t, int64(0x0192a8b49c3f), inspection.Timestamp.UnixMilli)
+}
+
+func TestUUIDInspectCmd_V4HasNoTimestamp(t *testing.T) {
+	cmd := &cli.UUIDInspectCmd{Text: "d308ee70-5af4-48be-bbf5-4ae52b6e899e"}
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext()))
+	})
+	require.Contains(t, output, `"version": 4`)
+	require.NotContains(t, output, "timestamp")
+}
+
+func TestUUIDInspectCmd_Invalid(t *testing.T) {
+	cmd := &cli.UUIDInspectCmd{Text: "not-a-uuid"}
+	require.ErrorIs(t, cmd.Run(testutil.NewTestContext()), uuid.ErrInvalidUUID)
+}
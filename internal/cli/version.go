@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/bilte-co/toolshed/buildinfo"
+)
+
+// VersionCmd prints version and build information, including the SHA-256 of
+// the running binary so it can be verified against a release manifest
+type VersionCmd struct {
+	JSON bool `long:"json" help:"Output version information as JSON"`
+}
+
+// versionReport is the machine-readable output of the version command
+type versionReport struct {
+	Version    string             `json:"version"`
+	Commit     string             `json:"commit"`
+	Date       string             `json:"date"`
+	Modified   bool               `json:"modified"`
+	GoVersion  string             `json:"go_version"`
+	OS         string             `json:"os"`
+	Arch       string             `json:"arch"`
+	Executable string             `json:"executable"`
+	SHA256     string             `json:"sha256"`
+	Module     buildinfo.Module   `json:"module"`
+	Modules    []buildinfo.Module `json:"modules"`
+}
+
+func (cmd *VersionCmd) Run(ctx *CLIContext, vars kong.Vars) error {
+	report := versionReport{
+		Version:   vars["version"],
+		Commit:    vars["commit"],
+		Date:      vars["date"],
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Modules:   []buildinfo.Module{},
+	}
+
+	info, err := buildinfo.Read()
+	if err != nil {
+		ctx.Logger.Debug("Build information unavailable", "error", err)
+	} else {
+		report.Module = info.Main
+		report.Modules = info.Deps
+		report.Modified = info.Modified
+		// Fall back to the VCS stamp when the release metadata was not injected
+		if report.Commit == "" || report.Commit == "unknown" {
+			report.Commit = info.Revision
+		}
+		if report.Date == "" || report.Date == "unknown" {
+			report.Date = info.Time
+		}
+	}
+
+	ctx.Logger.Debug("Hashing executable")
+	path, sum, err := buildinfo.SelfHash()
+	if err != nil {
+		ctx.Logger.Error("Failed to hash executable", "error", err)
+		return fmt.Errorf("failed to hash executable: %w", err)
+	}
+	report.Executable = path
+	report.SHA256 = sum
+
+	if cmd.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			ctx.Logger.Error("Failed to encode version information", "error", err)
+			return fmt.Errorf("failed to encode version information: %w", err)
+		}
+		return nil
+	}
+
+	commit := report.Commit
+	if report.Modified {
+		commit += " (modified)"
+	}
+	fmt.Printf("Version:    %s\n", report.Version)
+	fmt.Printf("Commit:     %s\n", commit)
+	fmt.Printf("Date:       %s\n", report.Date)
+	fmt.Printf("Go:         %s %s/%s\n", report.GoVersion, report.OS, report.Arch)
+	fmt.Printf("Executable: %s\n", report.Executable)
+	fmt.Printf("SHA-256:    %s\n", report.SHA256)
+	if len(report.Modules) > 0 {
+		fmt.Println("Modules:")
+		for _, module := range report.Modules {
+			if module.Replace != nil {
+				fmt.Printf("  %s %s => %s %s\n", module.Path, module.Version, module.Replace.Path, module.Replace.Version)
+				continue
+			}
+			fmt.Printf("  %s %s\n", module.Path, module.Version)
+		}
+	}
+
+	return nil
+}
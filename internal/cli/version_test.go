@@ -0,0 +1,58 @@
+package cli_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/internal/cli"
+	"github.com/bilte-co/toolshed/internal/testutil"
+)
+
+func TestVersionCmd_JSON(t *testing.T) {
+	cmd := &cli.VersionCmd{JSON: true}
+	vars := kong.Vars{"version": "v1.2.3", "commit": "abc1234", "date": "2025-01-01"}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext(), vars))
+	})
+
+	var report struct {
+		Version    string `json:"version"`
+		Commit     string `json:"commit"`
+		Date       string `json:"date"`
+		GoVersion  string `json:"go_version"`
+		Executable string `json:"executable"`
+		SHA256     string `json:"sha256"`
+		Modules    []struct {
+			Path    string `json:"path"`
+			Version string `json:"version"`
+		} `json:"modules"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &report))
+	require.Equal(t, "v1.2.3", report.Version)
+	require.Equal(t, "abc1234", report.Commit)
+	require.Equal(t, "2025-01-01", report.Date)
+	require.NotEmpty(t, report.GoVersion)
+	require.NotNil(t, report.Modules)
+
+	data, err := os.ReadFile(report.Executable)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	require.Equal(t, hex.EncodeToString(sum[:]), report.SHA256)
+}
+
+func TestVersionCmd_Text(t *testing.T) {
+	cmd := &cli.VersionCmd{}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, cmd.Run(testutil.NewTestContext(), kong.Vars{"version": "dev", "commit": "unknown"}))
+	})
+	require.Contains(t, output, "Version:    dev")
+	require.Contains(t, output, "SHA-256:")
+}
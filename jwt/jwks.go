@@ -0,0 +1,204 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxJWKSSize is the largest JWKS document FetchJWKS reads
+const maxJWKSSize = 1 << 20
+
+// ErrKeyNotFound is returned when a JWKS has no key that verifies a token.
+var ErrKeyNotFound = errors.New("no matching key in JWKS")
+
+// JWKS is a JSON Web Key Set, as published by identity providers.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a JSON Web Key. Only the public parameters are read.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC and OKP
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// ParseJWKS decodes a JSON Web Key Set
+func ParseJWKS(data []byte) (*JWKS, error) {
+	var set JWKS
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+	return &set, nil
+}
+
+// FetchJWKS downloads and decodes the JSON Web Key Set at url. If client is
+// nil, a client with a 10 second timeout is used.
+func FetchJWKS(ctx context.Context, client *http.Client, url string) (*JWKS, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+// Verify checks the token's signature against the keys in the set. If the
+// token names a key ID, only keys with that ID are tried.
+func (s *JWKS) Verify(t *Token) error {
+	kid := t.KeyID()
+	tried := false
+
+	for _, jwk := range s.Keys {
+		if (kid != "" && jwk.KeyID != kid) || (jwk.Algorithm != "" && jwk.Algorithm != t.Algorithm()) || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+
+		tried = true
+		err = t.Verify(key)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrUnsupportedAlgorithm) {
+			return err
+		}
+	}
+
+	if !tried {
+		if kid != "" {
+			return fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+		}
+		return ErrKeyNotFound
+	}
+	return ErrInvalidSignature
+}
+
+// PublicKey returns the key as an *rsa.PublicKey, *ecdsa.PublicKey or
+// ed25519.PublicKey, ready to pass to Token.Verify
+func (k JWK) PublicKey() (any, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeParameter("n", k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeParameter("e", k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 || exponent.Int64() < 2 {
+			return nil, fmt.Errorf("invalid RSA exponent in JWK")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		var ecdhCurve ecdh.Curve
+		switch k.Curve {
+		case "P-256":
+			curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported JWK curve %q", k.Curve)
+		}
+		x, err := decodeParameter("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeParameter("y", k.Y)
+		if err != nil {
+			return nil, err
+		}
+		// crypto/ecdh rejects points that are not on the curve
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) > size || len(y) > size {
+			return nil, fmt.Errorf("invalid EC point in JWK")
+		}
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid EC point in JWK: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported JWK curve %q", k.Curve)
+		}
+		x, err := decodeParameter("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key size %d in JWK", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported JWK key type %q", k.KeyType)
+	}
+}
+
+// decodeParameter decodes a base64url key parameter
+func decodeParameter(name, value string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("missing JWK parameter %q", name)
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK parameter %q: %w", name, err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,117 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bilte-co/toolshed/jwt"
+)
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestJWKS_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	set := jwt.JWKS{Keys: []jwt.JWK{
+		{KeyType: "RSA", KeyID: "rsa-1", Algorithm: "RS256", Use: "sig", N: b64(rsaKey.N.Bytes()), E: b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{KeyType: "EC", KeyID: "ec-1", Curve: "P-256", X: b64(ecKey.X.FillBytes(make([]byte, 32))), Y: b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+		{KeyType: "OKP", KeyID: "ed-1", Curve: "Ed25519", X: b64(edPub)},
+	}}
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	parsed, err := jwt.ParseJWKS(data)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		header map[string]any
+		key    any
+	}{
+		{name: "rsa by kid", header: map[string]any{"alg": "RS256", "kid": "rsa-1"}, key: rsaKey},
+		{name: "ec by kid", header: map[string]any{"alg": "ES256", "kid": "ec-1"}, key: ecKey},
+		{name: "ed25519 without kid", header: map[string]any{"alg": "EdDSA"}, key: edKey},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := jwt.Parse(sign(t, tc.header, map[string]any{"sub": "alice"}, tc.key))
+			require.NoError(t, err)
+			require.NoError(t, parsed.Verify(token))
+		})
+	}
+
+	t.Run("unknown kid", func(t *testing.T) {
+		token, err := jwt.Parse(sign(t, map[string]any{"alg": "RS256", "kid": "rsa-2"}, map[string]any{}, rsaKey))
+		require.NoError(t, err)
+		require.ErrorIs(t, parsed.Verify(token), jwt.ErrKeyNotFound)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		token, err := jwt.Parse(sign(t, map[string]any{"alg": "RS256", "kid": "rsa-1"}, map[string]any{}, other))
+		require.NoError(t, err)
+		require.ErrorIs(t, parsed.Verify(token), jwt.ErrInvalidSignature)
+	})
+}
+
+func TestJWK_PublicKey_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		jwk  jwt.JWK
+	}{
+		{name: "unknown key type", jwk: jwt.JWK{KeyType: "oct"}},
+		{name: "missing modulus", jwk: jwt.JWK{KeyType: "RSA", E: "AQAB"}},
+		{name: "unknown curve", jwk: jwt.JWK{KeyType: "EC", Curve: "secp256k1", X: "AA", Y: "AA"}},
+		{name: "point not on curve", jwk: jwt.JWK{KeyType: "EC", Curve: "P-256", X: b64([]byte{1}), Y: b64([]byte{1})}},
+		{name: "short ed25519 key", jwk: jwt.JWK{KeyType: "OKP", Curve: "Ed25519", X: "AAAA"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.jwk.PublicKey()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFetchJWKS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/jwks.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"keys":[{"kty":"OKP","kid":"k1","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`))
+	}))
+	defer server.Close()
+
+	set, err := jwt.FetchJWKS(context.Background(), nil, server.URL+"/.well-known/jwks.json")
+	require.NoError(t, err)
+	require.Len(t, set.Keys, 1)
+	require.Equal(t, "k1", set.Keys[0].KeyID)
+
+	_, err = jwt.FetchJWKS(context.Background(), nil, server.URL+"/missing")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 404")
+}
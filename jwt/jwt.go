@@ -0,0 +1,268 @@
+// Package jwt decodes JSON Web Tokens and verifies their signatures. It is
+// meant for inspecting and debugging tokens rather than issuing them, so it
+// has no signing API.
+//
+// Supported algorithms are HS256/384/512, RS256/384/512, PS256/384/512,
+// ES256/384/512 and EdDSA (Ed25519). Tokens with the "none" algorithm can be
+// decoded but never verify.
+//
+// Example usage:
+//
+//	token, err := jwt.Parse(raw)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if token.Expired(time.Now()) {
+//		fmt.Println("token expired")
+//	}
+//
+//	// Check an HMAC signature
+//	if err := token.Verify([]byte("secret")); err != nil {
+//		log.Fatal(err)
+//	}
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // Register SHA-256 for crypto.Hash
+	_ "crypto/sha512" // Register SHA-384 and SHA-512 for crypto.Hash
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMalformed is returned when a token is not three base64url encoded
+	// segments with JSON header and claims.
+	ErrMalformed = errors.New("malformed JWT")
+
+	// ErrUnsupportedAlgorithm is returned when verifying a token whose
+	// algorithm is unknown or "none".
+	ErrUnsupportedAlgorithm = errors.New("unsupported JWT algorithm")
+
+	// ErrInvalidKey is returned when the key passed to Verify does not suit
+	// the token's algorithm.
+	ErrInvalidKey = errors.New("invalid key for JWT algorithm")
+
+	// ErrInvalidSignature is returned when a token's signature does not match.
+	ErrInvalidSignature = errors.New("invalid JWT signature")
+)
+
+// Token is a decoded JWT. Its signature has not been verified until Verify
+// returns nil.
+type Token struct {
+	// Header is the decoded JOSE header.
+	Header map[string]any
+	// Claims is the decoded payload. Numbers are json.Number values, so
+	// large integers keep their precision.
+	Claims map[string]any
+	// RawHeader and RawClaims are the JSON exactly as it appears in the token.
+	RawHeader json.RawMessage
+	RawClaims json.RawMessage
+	// Signature is the decoded signature segment.
+	Signature []byte
+
+	signingInput string
+}
+
+// Parse decodes a JWT in compact serialization without verifying it.
+// Surrounding whitespace and a "Bearer " prefix are ignored.
+func Parse(raw string) (*Token, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) > 7 && strings.EqualFold(raw[:7], "bearer ") {
+		raw = strings.TrimSpace(raw[7:])
+	}
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 segments, got %d", ErrMalformed, len(parts))
+	}
+
+	token := &Token{signingInput: parts[0] + "." + parts[1]}
+
+	var err error
+	if token.RawHeader, err = decodeSegment(parts[0]); err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrMalformed, err)
+	}
+	if token.RawClaims, err = decodeSegment(parts[1]); err != nil {
+		return nil, fmt.Errorf("%w: payload: %w", ErrMalformed, err)
+	}
+	if token.Signature, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[2], "=")); err != nil {
+		return nil, fmt.Errorf("%w: signature: %w", ErrMalformed, err)
+	}
+
+	if err := unmarshalObject(token.RawHeader, &token.Header); err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrMalformed, err)
+	}
+	if err := unmarshalObject(token.RawClaims, &token.Claims); err != nil {
+		return nil, fmt.Errorf("%w: payload: %w", ErrMalformed, err)
+	}
+
+	return token, nil
+}
+
+// decodeSegment decodes a base64url segment, tolerating padding
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+}
+
+// unmarshalObject decodes a JSON object, keeping numbers as json.Number
+func unmarshalObject(data []byte, v *map[string]any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if *v == nil {
+		return errors.New("not a JSON object")
+	}
+	return nil
+}
+
+// Algorithm returns the "alg" header parameter
+func (t *Token) Algorithm() string {
+	alg, _ := t.Header["alg"].(string)
+	return alg
+}
+
+// KeyID returns the "kid" header parameter, or "" if there is none
+func (t *Token) KeyID() string {
+	kid, _ := t.Header["kid"].(string)
+	return kid
+}
+
+// Time returns the numeric date claim name, such as "exp", "iat" or "nbf".
+// It returns false if the claim is missing or not a number.
+func (t *Token) Time(name string) (time.Time, bool) {
+	number, ok := t.Claims[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Float64()
+	if err != nil || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return time.Time{}, false
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*1e9)).UTC(), true
+}
+
+// Expired reports whether the token has an "exp" claim at or before now
+func (t *Token) Expired(now time.Time) bool {
+	exp, ok := t.Time("exp")
+	return ok && !now.Before(exp)
+}
+
+// NotYetValid reports whether the token has an "nbf" claim after now
+func (t *Token) NotYetValid(now time.Time) bool {
+	nbf, ok := t.Time("nbf")
+	return ok && now.Before(nbf)
+}
+
+// Verify checks the token's signature with key, which must suit the token's
+// algorithm: a []byte secret for HS*, an *rsa.PublicKey for RS* and PS*, an
+// *ecdsa.PublicKey on the matching curve for ES* and an ed25519.PublicKey for
+// EdDSA. Verify does not check the time based claims.
+func (t *Token) Verify(key any) error {
+	alg := t.Algorithm()
+	input := []byte(t.signingInput)
+
+	switch alg {
+	case "HS256", "HS384", "HS512":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("%w: %s requires a secret, got %T", ErrInvalidKey, alg, key)
+		}
+		mac := hmac.New(algorithmHash(alg).New, secret)
+		mac.Write(input)
+		if !hmac.Equal(mac.Sum(nil), t.Signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: %s requires an RSA public key, got %T", ErrInvalidKey, alg, key)
+		}
+		hash := algorithmHash(alg)
+		digest := hashInput(hash, input)
+		var err error
+		if strings.HasPrefix(alg, "PS") {
+			err = rsa.VerifyPSS(pub, hash, digest, t.Signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			err = rsa.VerifyPKCS1v15(pub, hash, digest, t.Signature)
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	case "ES256", "ES384", "ES512":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: %s requires an ECDSA public key, got %T", ErrInvalidKey, alg, key)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if pub.Curve.Params().Name != ecdsaCurves[alg] {
+			return fmt.Errorf("%w: %s requires curve %s, got %s", ErrInvalidKey, alg, ecdsaCurves[alg], pub.Curve.Params().Name)
+		}
+		// JWS ECDSA signatures are r and s as fixed size big-endian integers
+		if len(t.Signature) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(t.Signature[:size])
+		s := new(big.Int).SetBytes(t.Signature[size:])
+		if !ecdsa.Verify(pub, hashInput(algorithmHash(alg), input), r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	case "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: %s requires an Ed25519 public key, got %T", ErrInvalidKey, alg, key)
+		}
+		if !ed25519.Verify(pub, input, t.Signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, alg)
+	}
+}
+
+// ecdsaCurves maps the ES algorithms to the curve they require
+var ecdsaCurves = map[string]string{
+	"ES256": "P-256",
+	"ES384": "P-384",
+	"ES512": "P-521",
+}
+
+// algorithmHash returns the hash function named by the size suffix of alg
+func algorithmHash(alg string) crypto.Hash {
+	switch alg[len(alg)-3:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+func hashInput(hash crypto.Hash, input []byte) []byte {
+	h := hash.New()
+	h.Write(input)
+	return h.Sum(nil)
+}
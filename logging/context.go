@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// TraceIDKey is the attribute key under which WithTrace records the trace ID.
+	TraceIDKey = "trace_id"
+
+	// SpanIDKey is the attribute key under which WithTrace records the span ID.
+	SpanIDKey = "span_id"
+
+	// TraceparentHeader is the W3C Trace Context header read by Middleware.
+	TraceparentHeader = "traceparent"
+)
+
+// traceKey points to the value in the context where the trace is stored.
+const traceKey = contextKey("trace")
+
+// trace holds the IDs set by WithTrace
+type trace struct {
+	traceID, spanID string
+}
+
+// With returns a copy of ctx whose FromContext logger carries the given
+// attributes, as alternating keys and values or slog.Attr values like
+// slog.Logger.With. Request-scoped fields such as user_id then appear on
+// every record logged through the context.
+//
+// Example usage:
+//
+//	ctx = logging.With(ctx, "user_id", user.ID, "tenant", user.Tenant)
+//	logging.FromContext(ctx).Info("Order placed") // includes user_id and tenant
+func With(ctx context.Context, args ...any) context.Context {
+	if len(args) == 0 {
+		return ctx
+	}
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
+
+// WithTrace returns a copy of ctx carrying a trace and span ID, such as those
+// of an OpenTelemetry span or a traceparent header. Its FromContext logger
+// records them as trace_id and span_id, and the OTLP handler exports them
+// with the records logged with the context.
+func WithTrace(ctx context.Context, traceID, spanID string) context.Context {
+	var args []any
+	if traceID != "" {
+		args = append(args, TraceIDKey, traceID)
+	}
+	if spanID != "" {
+		args = append(args, SpanIDKey, spanID)
+	}
+	return context.WithValue(With(ctx, args...), traceKey, trace{traceID: traceID, spanID: spanID})
+}
+
+// TraceFromContext returns the trace and span ID stored in the context by
+// WithTrace, or empty strings if there are none.
+func TraceFromContext(ctx context.Context) (traceID, spanID string) {
+	t, _ := ctx.Value(traceKey).(trace)
+	return t.traceID, t.spanID
+}
+
+// ParseTraceparent returns the trace and span ID of a W3C Trace Context
+// traceparent header, such as
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01". It returns false
+// if the header is invalid or its IDs are all zeros.
+func ParseTraceparent(header string) (traceID, spanID string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", "", false
+	}
+	// Version 00 has exactly four fields; later versions may add more
+	if parts[0] == "00" && len(parts) != 4 {
+		return "", "", false
+	}
+
+	traceID, spanID = parts[1], parts[2]
+	if !isTraceID(traceID, 32) || !isTraceID(spanID, 16) {
+		return "", "", false
+	}
+	return traceID, spanID, true
+}
+
+// isTraceID reports whether id is a valid trace or span ID of n lowercase hex
+// digits, which must not all be zero
+func isTraceID(id string, n int) bool {
+	if len(id) != n || strings.ToLower(id) != id || strings.Trim(id, "0") == "" {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
@@ -0,0 +1,124 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bilte-co/toolshed/logging"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := logging.WithLogger(t.Context(), logging.NewJSONLogger(&buf, "info"))
+
+	ctx = logging.With(ctx, "user_id", 42)
+	ctx = logging.With(ctx, slog.String("tenant", "acme"))
+	logging.FromContext(ctx).Info("Order placed")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.EqualValues(t, 42, record["user_id"])
+	require.Equal(t, "acme", record["tenant"])
+}
+
+func TestWith_NoAttributes(t *testing.T) {
+	ctx := t.Context()
+	require.Equal(t, ctx, logging.With(ctx))
+}
+
+func TestWithTrace(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := logging.WithLogger(t.Context(), logging.NewJSONLogger(&buf, "info"))
+
+	ctx = logging.WithTrace(ctx, testTraceID, testSpanID)
+	logging.FromContext(ctx).Info("Traced")
+
+	traceID, spanID := logging.TraceFromContext(ctx)
+	require.Equal(t, testTraceID, traceID)
+	require.Equal(t, testSpanID, spanID)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, testTraceID, record[logging.TraceIDKey])
+	require.Equal(t, testSpanID, record[logging.SpanIDKey])
+}
+
+func TestTraceFromContext_Missing(t *testing.T) {
+	traceID, spanID := logging.TraceFromContext(t.Context())
+	require.Empty(t, traceID)
+	require.Empty(t, spanID)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		ok     bool
+	}{
+		{"valid", "00-" + testTraceID + "-" + testSpanID + "-01", true},
+		{"future version with extra fields", "01-" + testTraceID + "-" + testSpanID + "-01-extra", true},
+		{"version 00 with extra fields", "00-" + testTraceID + "-" + testSpanID + "-01-extra", false},
+		{"invalid version", "ff-" + testTraceID + "-" + testSpanID + "-01", false},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + testSpanID + "-01", false},
+		{"zero trace", "00-00000000000000000000000000000000-" + testSpanID + "-01", false},
+		{"zero span", "00-" + testTraceID + "-0000000000000000-01", false},
+		{"short span", "00-" + testTraceID + "-00f067aa-01", false},
+		{"not hex", "00-" + testTraceID + "-00f067aa0ba902bz-01", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traceID, spanID, ok := logging.ParseTraceparent(tt.header)
+			require.Equal(t, tt.ok, ok)
+			if tt.ok {
+				require.Equal(t, testTraceID, traceID)
+				require.Equal(t, testSpanID, spanID)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Traceparent(t *testing.T) {
+	var traceID string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID, _ = logging.TraceFromContext(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(logging.TraceparentHeader, "00-"+testTraceID+"-"+testSpanID+"-01")
+	_, records := serveLogged(t, handler, req)
+
+	require.Equal(t, testTraceID, traceID)
+	require.Equal(t, testTraceID, records[0][logging.TraceIDKey])
+	require.Equal(t, testSpanID, records[0][logging.SpanIDKey])
+}
+
+func TestOTLPHandler_Trace(t *testing.T) {
+	c, server := newCollector(t)
+
+	handler, err := logging.NewOTLPHandler(server.URL, nil)
+	require.NoError(t, err)
+
+	logger := slog.New(handler)
+	logger.InfoContext(logging.WithTrace(t.Context(), testTraceID, testSpanID), "Traced")
+	logger.InfoContext(t.Context(), "Untraced")
+	require.NoError(t, handler.Close(t.Context()))
+
+	records := c.records()
+	require.Len(t, records, 2)
+	require.Equal(t, testTraceID, records[0]["traceId"])
+	require.Equal(t, testSpanID, records[0]["spanId"])
+	require.NotContains(t, records[1], "traceId")
+	require.NotContains(t, records[1], "spanId")
+}
@@ -0,0 +1,158 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// ComponentKey is the attribute key under which Named records the component name.
+const ComponentKey = "component"
+
+// Levels holds a default log level together with per-component overrides.
+// Components are matched by name; a dotted name such as "database.migrate"
+// falls back to the override for "database" if it has none of its own.
+type Levels struct {
+	Default   slog.Level
+	Overrides map[string]slog.Level
+}
+
+// ParseLevels parses a level specification such as "info,database=debug,serve=warn".
+// The first entry without a component sets the default level. Unknown levels
+// are ignored, and the default falls back to info, matching NewLogger.
+func ParseLevels(spec string) Levels {
+	levels := Levels{
+		Default:   slog.LevelInfo,
+		Overrides: make(map[string]slog.Level),
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		component, name, scoped := strings.Cut(part, "=")
+		if !scoped {
+			if level, ok := parseLevel(part); ok {
+				levels.Default = level
+			}
+			continue
+		}
+
+		component = strings.TrimSpace(component)
+		level, ok := parseLevel(strings.TrimSpace(name))
+		if component == "" || !ok {
+			continue
+		}
+		levels.Overrides[component] = level
+	}
+
+	return levels
+}
+
+// Level returns the effective level for a component.
+func (l Levels) Level(component string) slog.Level {
+	for name := component; name != ""; {
+		if level, ok := l.Overrides[name]; ok {
+			return level
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return l.Default
+}
+
+// minLevel returns the most verbose level in use by any component.
+func (l Levels) minLevel() slog.Level {
+	level := l.Default
+	for _, override := range l.Overrides {
+		level = min(level, override)
+	}
+	return level
+}
+
+// parseLevel converts a level name accepted by NewLogger to a slog.Level.
+func parseLevel(name string) (slog.Level, bool) {
+	switch name {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return slog.LevelInfo, false
+	}
+}
+
+// Named returns a child of the default logger for the given component. Records
+// carry a component attribute and are filtered by the component's level from LOG_LEVEL.
+func Named(component string) *slog.Logger {
+	return NamedFrom(DefaultLogger(), component)
+}
+
+// NamedFrom returns a child of logger for the given component. If logger was
+// created by this package its per-component level overrides apply, also to
+// the loggers combined with MultiHandler; otherwise only the component
+// attribute is added.
+func NamedFrom(logger *slog.Logger, component string) *slog.Logger {
+	return slog.New(namedHandler(logger.Handler(), component)).With(ComponentKey, component)
+}
+
+// namedHandler returns handler with the level of component, for handlers
+// created by this package
+func namedHandler(handler slog.Handler, component string) slog.Handler {
+	switch h := handler.(type) {
+	case *levelHandler:
+		return &levelHandler{
+			base:   h.base,
+			levels: h.levels,
+			level:  h.levels.Level(component),
+		}
+	case *levelFilter:
+		return &levelFilter{base: namedHandler(h.base, component), level: h.level}
+	case *multiHandler:
+		return h.each(func(handler slog.Handler) slog.Handler { return namedHandler(handler, component) })
+	default:
+		return handler
+	}
+}
+
+// levelHandler filters records by level before passing them to a base handler
+// configured with the most verbose level of any component. The level set by
+// SetLevel takes precedence over both.
+type levelHandler struct {
+	base   slog.Handler
+	levels Levels
+	level  slog.Level
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	minimum := h.level
+	if runtime, ok := RuntimeLevel(); ok {
+		minimum = runtime
+	}
+	return level >= minimum && h.base.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.base.Handle(ctx, record)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{base: h.base.WithAttrs(attrs), levels: h.levels, level: h.level}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{base: h.base.WithGroup(name), levels: h.levels, level: h.level}
+}
+
+func (h *levelHandler) unwrap() slog.Handler {
+	return h.base
+}
@@ -0,0 +1,128 @@
+package logging_test
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/bilte-co/toolshed/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevels(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		def       slog.Level
+		overrides map[string]slog.Level
+	}{
+		{"empty", "", slog.LevelInfo, map[string]slog.Level{}},
+		{"default only", "warn", slog.LevelWarn, map[string]slog.Level{}},
+		{"overrides", "info,database=debug,serve=warn", slog.LevelInfo,
+			map[string]slog.Level{"database": slog.LevelDebug, "serve": slog.LevelWarn}},
+		{"overrides only", "database=debug", slog.LevelInfo,
+			map[string]slog.Level{"database": slog.LevelDebug}},
+		{"whitespace", " error , cache = debug ", slog.LevelError,
+			map[string]slog.Level{"cache": slog.LevelDebug}},
+		{"invalid entries ignored", "loud,serve=verbose,=debug,db=warn", slog.LevelInfo,
+			map[string]slog.Level{"db": slog.LevelWarn}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := logging.ParseLevels(tt.spec)
+			require.Equal(t, tt.def, levels.Default)
+			require.Equal(t, tt.overrides, levels.Overrides)
+		})
+	}
+}
+
+func TestLevels_Level(t *testing.T) {
+	levels := logging.ParseLevels("warn,database=debug,database.pool=error")
+
+	require.Equal(t, slog.LevelDebug, levels.Level("database"))
+	require.Equal(t, slog.LevelDebug, levels.Level("database.migrate"))
+	require.Equal(t, slog.LevelError, levels.Level("database.pool"))
+	require.Equal(t, slog.LevelError, levels.Level("database.pool.conn"))
+	require.Equal(t, slog.LevelWarn, levels.Level("serve"))
+	require.Equal(t, slog.LevelWarn, levels.Level(""))
+}
+
+// ansiEscape matches the color codes written by the tint handler
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// captureStderr runs fn with os.Stderr redirected and returns what was written without colors
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+
+	oldStderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+
+	fn()
+
+	os.Stderr = oldStderr
+	require.NoError(t, w.Close())
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	require.NoError(t, err)
+	return ansiEscape.ReplaceAllString(buf.String(), "")
+}
+
+func TestNamedFrom_PerComponentLevels(t *testing.T) {
+	output := captureStderr(t, func() {
+		logger := logging.NewLogger("warn,database=debug,serve=error", false)
+
+		logger.Info("root info")
+		logger.Warn("root warn")
+
+		db := logging.NamedFrom(logger, "database")
+		db.Debug("database debug")
+
+		serve := logging.NamedFrom(logger, "serve")
+		serve.Warn("serve warn")
+		serve.Error("serve error")
+
+		logging.NamedFrom(logger, "cache").Info("cache info")
+	})
+
+	require.NotContains(t, output, "root info")
+	require.Contains(t, output, "root warn")
+	require.Contains(t, output, "database debug")
+	require.Contains(t, output, "component=database")
+	require.NotContains(t, output, "serve warn")
+	require.Contains(t, output, "serve error")
+	require.NotContains(t, output, "cache info")
+}
+
+func TestNamedFrom_KeepsAttributes(t *testing.T) {
+	output := captureStderr(t, func() {
+		logger := logging.NewLogger("info", false).With("request_id", "abc123")
+		logging.NamedFrom(logger, "serve").Info("handled")
+	})
+
+	require.Contains(t, output, "handled")
+	require.Contains(t, output, "request_id=abc123")
+	require.Contains(t, output, "component=serve")
+}
+
+func TestNamedFrom_ForeignLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	logging.NamedFrom(logger, "hash").Info("hashed")
+	require.Contains(t, buf.String(), "component=hash")
+}
+
+func TestNamed_UsesDefaultLogger(t *testing.T) {
+	logger := logging.Named("database")
+	require.NotNil(t, logger)
+	require.NotPanics(t, func() {
+		logger.Info("named info")
+	})
+}
@@ -12,11 +12,50 @@
 //	envLogger := logging.NewLoggerFromEnv()
 //	envLogger.Warn("This is a warning")
 //
+//	// Write JSON records, e.g. for access logs
+//	jsonLogger := logging.NewJSONLogger(os.Stdout, "info")
+//	jsonLogger.Info("HTTP request", "status", 200)
+//
+//	// Name fields after the Elastic Common Schema, or LOG_SCHEMA=ecs from the environment
+//	ecsLogger := logging.NewJSONLogger(os.Stdout, "info", logging.WithSchema(logging.SchemaECS))
+//
+//	// Tune subsystems independently with LOG_LEVEL="info,database=debug,serve=warn"
+//	dbLogger := logging.Named("database")
+//	dbLogger.Debug("Connection acquired")
+//
 //	// Use context-aware logging
 //	ctx := logging.WithLogger(context.Background(), logger)
 //	ctxLogger := logging.FromContext(ctx)
 //	ctxLogger.Error("Error from context")
 //
+//	// Secrets are masked, e.g. password=xxxxx; wrap other handlers to do the same
+//	logger.Info("Connecting", "password", password)
+//	redacted := slog.New(logging.NewRedactHandler(handler, nil))
+//
+//	// Log to a file rotated daily or at 50 MiB, keeping a week of compressed files
+//	file, err := logging.NewRotatingFile("/var/log/app.log", logging.RotateOptions{
+//		MaxSize: 50 << 20, MaxAge: 24 * time.Hour, MaxBackups: 7, Compress: true,
+//	})
+//	fileLogger := logging.NewLogger("info", false, logging.WithWriter(file))
+//
+//	// Write to several outputs, each with its own level
+//	multiLogger := slog.New(logging.MultiHandler(logger.Handler(), logging.LevelFilter(fileLogger.Handler(), slog.LevelWarn)))
+//
+//	// Export to an OpenTelemetry collector with LOG_EXPORTER=otlp, flushing before exit
+//	defer logging.Flush(context.Background(), envLogger)
+//
+//	// Raise verbosity at runtime: directly, on SIGHUP or from an admin endpoint
+//	logging.SetLevel(slog.LevelDebug)
+//	defer logging.ToggleDebugOnSignal()()
+//	adminMux.Handle("/log/level", logging.LevelAdminHandler())
+//
+//	// Attach request-scoped fields to every record logged through the context
+//	ctx = logging.With(ctx, "user_id", userID)
+//	ctx = logging.WithTrace(ctx, span.TraceID, span.SpanID)
+//
+//	// Log HTTP requests with request IDs propagated to handler loggers
+//	http.ListenAndServe(":8080", logging.Middleware(mux))
+//
 //	// Use default logger
 //	defaultLogger := logging.DefaultLogger()
 //	defaultLogger.Info("Using default logger")
@@ -24,6 +63,7 @@ package logging
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -47,37 +87,97 @@ var (
 	defaultLoggerOnce sync.Once
 )
 
+// Option configures a logger created by NewLogger or NewJSONLogger.
+type Option func(*loggerConfig)
+
+// loggerConfig holds the settings of NewLogger
+type loggerConfig struct {
+	w      io.Writer
+	schema Schema
+}
+
+// WithWriter writes the records of NewLogger to w instead of stderr, without
+// colors. Use it with a RotatingFile to log to a file that is rotated as it grows.
+func WithWriter(w io.Writer) Option {
+	return func(c *loggerConfig) {
+		c.w = w
+	}
+}
+
 // NewLogger creates a new structured logger with the specified log level and development mode.
-// The level parameter accepts "debug", "info", "warn", or "error" (defaults to "info" if invalid).
+// The level parameter accepts "debug", "info", "warn", or "error" (defaults to "info" if invalid),
+// optionally followed by per-component overrides such as "info,database=debug,serve=warn"
+// that apply to loggers derived with NamedFrom.
 // The development parameter determines if the logger should use development-friendly output formatting.
 // Returns a configured slog.Logger instance with colored output using the tint handler.
-func NewLogger(level string, development bool) *slog.Logger {
-	w := os.Stderr
+// Secrets are masked as by NewRedactHandler.
+func NewLogger(level string, development bool, opts ...Option) *slog.Logger {
+	config := loggerConfig{w: os.Stderr}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	w := config.w
+	levels := ParseLevels(level)
+
+	// The base handler lets through everything any component may log;
+	// levelHandler applies the level of each logger
 	options := &tint.Options{
 		TimeFormat: time.RFC3339,
+		Level:      baseLevel{levels.minLevel()},
+		NoColor:    w != io.Writer(os.Stderr),
 	}
 
-	switch level {
-	case "debug":
-		options.Level = slog.LevelDebug
-	case "info":
-		options.Level = slog.LevelInfo
-	case "warn":
-		options.Level = slog.LevelWarn
-	case "error":
-		options.Level = slog.LevelError
-	default:
-		options.Level = slog.LevelInfo
+	logger := slog.New(&levelHandler{
+		base:   NewRedactHandler(tint.NewHandler(w, options), nil),
+		levels: levels,
+		level:  levels.Default,
+	})
+	return logger
+}
+
+// NewJSONLogger creates a structured logger that writes one JSON object per record to w,
+// for output that is read by machines rather than people, such as access logs.
+// The level parameter accepts the same values as in NewLogger, including per-component overrides.
+// Field names follow the schema set with WithSchema.
+// Secrets are masked as by NewRedactHandler.
+func NewJSONLogger(w io.Writer, level string, opts ...Option) *slog.Logger {
+	config := loggerConfig{}
+	for _, opt := range opts {
+		opt(&config)
 	}
+	levels := ParseLevels(level)
 
-	logger := slog.New(tint.NewHandler(w, options))
+	options := &slog.HandlerOptions{
+		Level:       baseLevel{levels.minLevel()},
+		ReplaceAttr: config.schema.replaceAttr(),
+	}
 
-	return logger
+	var handler slog.Handler = slog.NewJSONHandler(w, options)
+	if attrs := config.schema.attrs(); len(attrs) > 0 {
+		handler = handler.WithAttrs(attrs)
+	}
+
+	return slog.New(&levelHandler{
+		base:   NewRedactHandler(handler, nil),
+		levels: levels,
+		level:  levels.Default,
+	})
 }
 
 // NewLoggerFromEnv creates a new logger from environment variables.
 // It reads LOG_LEVEL to determine the logging level and APP_ENV to determine development mode.
+// LOG_LEVEL may include per-component overrides, e.g. "info,database=debug,serve=warn".
 // If APP_ENV is set to "development", development mode is enabled for better formatting.
+// If LOG_EXPORTER is set to "otlp", records are also exported with NewOTLPHandler to the
+// collector set by OTEL_EXPORTER_OTLP_LOGS_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT (default
+// http://localhost:4318), with OTEL_EXPORTER_OTLP_HEADERS, OTEL_SERVICE_NAME and
+// OTEL_RESOURCE_ATTRIBUTES, combined with the local output by MultiHandler. Loggers with the
+// same settings share one exporter; call Flush on any of them before exiting.
+// If LOG_FILE is set, records are written to that file instead of stderr, rotated at 100 MiB
+// with the last 10 rotated files kept compressed. Loggers created for the same LOG_FILE
+// share one open file.
+// If LOG_SCHEMA is set to "ecs" or "gcp", records are written as JSON with the fields of that
+// schema, as by NewJSONLogger with WithSchema, instead of console output.
 // Automatically loads environment variables from .env file if present.
 func NewLoggerFromEnv() *slog.Logger {
 	_ = godotenv.Load()
@@ -85,7 +185,42 @@ func NewLoggerFromEnv() *slog.Logger {
 	level := os.Getenv("LOG_LEVEL")
 	development := strings.ToLower(strings.TrimSpace(os.Getenv("APP_ENV"))) == "development"
 
-	return NewLogger(level, development)
+	var opts []Option
+	var w io.Writer = os.Stderr
+	if path := os.Getenv("LOG_FILE"); path != "" {
+		file, err := envRotatingFile(path)
+		if err != nil {
+			NewLogger(level, development).Warn("Logging to stderr", "error", err)
+		} else {
+			opts = append(opts, WithWriter(file))
+			w = file
+		}
+	}
+
+	schema, err := ParseSchema(os.Getenv("LOG_SCHEMA"))
+	if err != nil {
+		NewLogger(level, development, opts...).Warn("Unknown LOG_SCHEMA, logging to the console", "error", err)
+	}
+
+	local := NewLogger(level, development, opts...)
+	if schema != SchemaDefault {
+		local = NewJSONLogger(w, level, WithSchema(schema))
+	}
+
+	switch exporter := strings.ToLower(strings.TrimSpace(os.Getenv("LOG_EXPORTER"))); exporter {
+	case "", "console":
+	case "otlp":
+		logger, err := newOTLPLoggerFromEnv(level)
+		if err != nil {
+			local.Warn("OTLP log export disabled", "error", err)
+			break
+		}
+		return slog.New(MultiHandler(logger.Handler(), local.Handler()))
+	default:
+		local.Warn("Unknown LOG_EXPORTER, logging to the console", "exporter", exporter)
+	}
+
+	return local
 }
 
 // DefaultLogger returns the default logger for the package.
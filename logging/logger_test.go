@@ -1,7 +1,9 @@
 package logging_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"log/slog"
 	"os"
 	"testing"
@@ -32,6 +34,28 @@ func TestNewLogger_DevelopmentMode(t *testing.T) {
 	require.IsType(t, &slog.Logger{}, logger)
 }
 
+func TestNewJSONLogger_WritesJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewJSONLogger(&buf, "info,database=debug")
+
+	logger.Debug("hidden")
+	logger.Info("request", "status", 200)
+	logging.NamedFrom(logger, "database").Debug("query")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &record))
+	require.Equal(t, "request", record["msg"])
+	require.Equal(t, "INFO", record["level"])
+	require.EqualValues(t, 200, record["status"])
+
+	require.NoError(t, json.Unmarshal(lines[1], &record))
+	require.Equal(t, "query", record["msg"])
+	require.Equal(t, "database", record[logging.ComponentKey])
+}
+
 func TestNewLoggerFromEnv_WithoutEnv(t *testing.T) {
 	logger := logging.NewLoggerFromEnv()
 	require.NotNil(t, logger)
@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bilte-co/toolshed/uuid"
+)
+
+const (
+	// RequestIDHeader is the header that carries the request ID in requests
+	// and responses.
+	RequestIDHeader = "X-Request-ID"
+
+	// RequestIDKey is the attribute key under which Middleware records the
+	// request ID.
+	RequestIDKey = "request_id"
+)
+
+// maxRequestIDLength bounds the request IDs accepted from clients
+const maxRequestIDLength = 128
+
+// requestIDKey points to the value in the context where the request ID is stored.
+const requestIDKey = contextKey("request_id")
+
+// Middleware logs every request handled by next with its method, path,
+// status, bytes written and duration. Each request gets an ID, taken from its
+// X-Request-ID header if it has a valid one and generated otherwise, which is
+// returned in the X-Request-ID response header. The handler's context carries
+// the ID, available from RequestID, and a logger with the ID attached,
+// available from FromContext, so that every record of the request can be
+// correlated. The trace and span of a valid traceparent header are attached
+// as by WithTrace.
+//
+// Records go to the logger in the request context, or the default logger.
+//
+// Example usage:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+//		logging.FromContext(r.Context()).Info("Handling request")
+//	})
+//	http.ListenAndServe(":8080", logging.Middleware(mux))
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := context.WithValue(With(r.Context(), RequestIDKey, id), requestIDKey, id)
+		if traceID, spanID, ok := ParseTraceparent(r.Header.Get(TraceparentHeader)); ok {
+			ctx = WithTrace(ctx, traceID, spanID)
+		}
+		logger := FromContext(ctx)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		logger.InfoContext(ctx, "HTTP request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", recorder.status,
+			"bytes", recorder.bytes,
+			"duration", time.Since(start).String(),
+		)
+	})
+}
+
+// RequestID returns the request ID stored in the context by Middleware, or
+// an empty string if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// newRequestID returns a new time-ordered request ID
+func newRequestID() string {
+	// NewV7 only fails if crypto/rand does, which it no longer can since Go 1.24
+	id, _ := uuid.NewV7()
+	return id.String()
+}
+
+// validRequestID reports whether a client-supplied request ID is safe to log
+// and echo: not empty, not too long and only letters, digits and "-_.:"
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// statusRecorder captures the status code and body size of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (sr *statusRecorder) WriteHeader(code int) {
+	if !sr.wroteHeader {
+		sr.status = code
+		sr.wroteHeader = true
+	}
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	sr.wroteHeader = true
+	n, err := sr.ResponseWriter.Write(b)
+	sr.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap returns the underlying ResponseWriter, so that http.ResponseController
+// can reach optional interfaces such as http.Flusher.
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
@@ -0,0 +1,123 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bilte-co/toolshed/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// serveLogged runs req through Middleware with a JSON logger in its context
+// and returns the response and the logged records
+func serveLogged(t *testing.T, handler http.Handler, req *http.Request) (*httptest.ResponseRecorder, []map[string]any) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger := logging.NewJSONLogger(&buf, "debug")
+	req = req.WithContext(logging.WithLogger(req.Context(), logger))
+
+	rec := httptest.NewRecorder()
+	logging.Middleware(handler).ServeHTTP(rec, req)
+
+	var records []map[string]any
+	decoder := json.NewDecoder(&buf)
+	for {
+		var record map[string]any
+		if err := decoder.Decode(&record); err == io.EOF {
+			break
+		} else {
+			require.NoError(t, err)
+		}
+		records = append(records, record)
+	}
+	return rec, records
+}
+
+func TestMiddleware_LogsRequest(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Debug("handling")
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("short and stout"))
+	})
+
+	rec, records := serveLogged(t, handler, httptest.NewRequest(http.MethodPost, "/tea?cup=1", nil))
+
+	require.Equal(t, http.StatusTeapot, rec.Code)
+	id := rec.Header().Get(logging.RequestIDHeader)
+	require.Len(t, id, 36)
+
+	require.Len(t, records, 2)
+	require.Equal(t, "handling", records[0]["msg"])
+	require.Equal(t, id, records[0][logging.RequestIDKey])
+
+	access := records[1]
+	require.Equal(t, "HTTP request", access["msg"])
+	require.Equal(t, id, access[logging.RequestIDKey])
+	require.Equal(t, "POST", access["method"])
+	require.Equal(t, "/tea", access["path"])
+	require.EqualValues(t, http.StatusTeapot, access["status"])
+	require.EqualValues(t, len("short and stout"), access["bytes"])
+	require.NotEmpty(t, access["duration"])
+}
+
+func TestMiddleware_PropagatesRequestID(t *testing.T) {
+	var seen string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logging.RequestID(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(logging.RequestIDHeader, "upstream-42")
+	rec, records := serveLogged(t, handler, req)
+
+	require.Equal(t, "upstream-42", seen)
+	require.Equal(t, "upstream-42", rec.Header().Get(logging.RequestIDHeader))
+	require.Equal(t, "upstream-42", records[0][logging.RequestIDKey])
+	require.EqualValues(t, http.StatusOK, records[0]["status"])
+}
+
+func TestMiddleware_ReplacesInvalidRequestID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{"log injection", "abc\nlevel=ERROR"},
+		{"spaces", "a b"},
+		{"too long", strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(logging.RequestIDHeader, tt.id)
+			rec, _ := serveLogged(t, http.NotFoundHandler(), req)
+
+			id := rec.Header().Get(logging.RequestIDHeader)
+			require.NotEqual(t, tt.id, id)
+			require.Len(t, id, 36)
+		})
+	}
+}
+
+func TestMiddleware_UniqueRequestIDs(t *testing.T) {
+	handler := logging.Middleware(http.NotFoundHandler())
+	seen := make(map[string]bool)
+	for range 100 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(
+			logging.WithLogger(t.Context(), logging.NewJSONLogger(io.Discard, "info"))))
+		id := rec.Header().Get(logging.RequestIDHeader)
+		require.False(t, seen[id], "duplicate request ID %s", id)
+		seen[id] = true
+	}
+}
+
+func TestRequestID_Missing(t *testing.T) {
+	require.Empty(t, logging.RequestID(t.Context()))
+}
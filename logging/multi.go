@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// MultiHandler returns a handler that passes each record to all of handlers
+// that are enabled for its level, so that a logger can write to the console
+// and to a JSON file or an OTLP collector at once. Each handler keeps its own
+// level; wrap a handler with LevelFilter to give it a different one. Errors of
+// the handlers are joined. Nil handlers are ignored.
+//
+// Example usage:
+//
+//	file, err := logging.NewRotatingFile("/var/log/app.json", logging.RotateOptions{})
+//	logger := slog.New(logging.MultiHandler(
+//		logging.NewLogger("info", true).Handler(),
+//		logging.LevelFilter(logging.NewJSONLogger(file, "debug").Handler(), slog.LevelWarn),
+//	))
+func MultiHandler(handlers ...slog.Handler) slog.Handler {
+	h := &multiHandler{}
+	for _, handler := range handlers {
+		if handler != nil {
+			h.handlers = append(h.handlers, handler)
+		}
+	}
+	return h
+}
+
+// multiHandler fans records out to several handlers
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *multiHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		// Each handler gets its own copy, as handlers may add attributes
+		if err := handler.Handle(ctx, record.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.each(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *multiHandler) WithGroup(name string) slog.Handler {
+	return h.each(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+// each returns a multiHandler of the results of fn for each handler
+func (h *multiHandler) each(fn func(slog.Handler) slog.Handler) *multiHandler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = fn(handler)
+	}
+	return &multiHandler{handlers: handlers}
+}
+
+// LevelFilter returns a handler that passes records of level or above to
+// handler, for example to keep debug records out of one output of a
+// MultiHandler. The level may be a *slog.LevelVar to change it at runtime.
+func LevelFilter(handler slog.Handler, level slog.Leveler) slog.Handler {
+	return &levelFilter{base: handler, level: level}
+}
+
+// levelFilter drops records below a level before a base handler
+type levelFilter struct {
+	base  slog.Handler
+	level slog.Leveler
+}
+
+func (h *levelFilter) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.base.Enabled(ctx, level)
+}
+
+func (h *levelFilter) Handle(ctx context.Context, record slog.Record) error {
+	return h.base.Handle(ctx, record)
+}
+
+func (h *levelFilter) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelFilter{base: h.base.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelFilter) WithGroup(name string) slog.Handler {
+	return &levelFilter{base: h.base.WithGroup(name), level: h.level}
+}
+
+func (h *levelFilter) unwrap() slog.Handler {
+	return h.base
+}
@@ -0,0 +1,109 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// failingHandler accepts every record and fails to handle it
+type failingHandler struct{ slog.Handler }
+
+func (failingHandler) Handle(context.Context, slog.Record) error {
+	return errors.New("disk full")
+}
+
+func TestMultiHandler_PerHandlerLevels(t *testing.T) {
+	var console, file bytes.Buffer
+	logger := slog.New(logging.MultiHandler(
+		logging.NewJSONLogger(&console, "info").Handler(),
+		logging.LevelFilter(logging.NewJSONLogger(&file, "debug").Handler(), slog.LevelWarn),
+		nil,
+	))
+
+	logger.Debug("dropped")
+	logger.Info("console only")
+	logger.With("order", 7).WithGroup("payment").Warn("both", "amount", 9.5)
+
+	require.NotContains(t, console.String(), "dropped")
+	require.Contains(t, console.String(), "console only")
+	require.Contains(t, console.String(), `"msg":"both","order":7,"payment":{"amount":9.5}`)
+
+	require.NotContains(t, file.String(), "console only")
+	require.Contains(t, file.String(), `"msg":"both","order":7,"payment":{"amount":9.5}`)
+}
+
+func TestMultiHandler_Enabled(t *testing.T) {
+	discard := slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug})
+	handler := logging.MultiHandler(
+		logging.LevelFilter(discard, slog.LevelWarn),
+		logging.LevelFilter(discard, slog.LevelError),
+	)
+
+	require.False(t, handler.Enabled(t.Context(), slog.LevelInfo))
+	require.True(t, handler.Enabled(t.Context(), slog.LevelWarn))
+	require.False(t, logging.MultiHandler().Enabled(t.Context(), slog.LevelError))
+}
+
+func TestMultiHandler_JoinsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	handler := logging.MultiHandler(
+		failingHandler{slog.NewJSONHandler(&buf, nil)},
+		slog.NewJSONHandler(&buf, nil),
+	)
+
+	err := handler.Handle(t.Context(), slog.NewRecord(time.Now(), slog.LevelInfo, "saved", 0))
+	require.ErrorContains(t, err, "disk full")
+	require.Contains(t, buf.String(), "saved")
+}
+
+func TestLevelFilter_LevelVar(t *testing.T) {
+	var buf bytes.Buffer
+	var level slog.LevelVar
+	level.Set(slog.LevelError)
+	logger := slog.New(logging.LevelFilter(slog.NewJSONHandler(&buf, nil), &level))
+
+	logger.Warn("hidden")
+	level.Set(slog.LevelWarn)
+	logger.Warn("shown")
+
+	require.NotContains(t, buf.String(), "hidden")
+	require.Contains(t, buf.String(), "shown")
+}
+
+func TestNamedFrom_MultiHandler(t *testing.T) {
+	var console, file bytes.Buffer
+	logger := slog.New(logging.MultiHandler(
+		logging.NewJSONLogger(&console, "info,database=debug").Handler(),
+		logging.LevelFilter(logging.NewJSONLogger(&file, "info").Handler(), slog.LevelInfo),
+	))
+
+	logging.NamedFrom(logger, "database").Debug("query")
+
+	require.Contains(t, console.String(), `"component":"database"`)
+	require.Empty(t, file.String())
+}
+
+func TestFlush_MultiHandler(t *testing.T) {
+	c, server := newCollector(t)
+
+	handler, err := logging.NewOTLPHandler(server.URL, &logging.OTLPOptions{FlushInterval: time.Hour})
+	require.NoError(t, err)
+	defer handler.Close(t.Context())
+
+	logger := slog.New(logging.MultiHandler(
+		logging.NewJSONLogger(&bytes.Buffer{}, "info").Handler(),
+		logging.LevelFilter(handler, slog.LevelInfo),
+	))
+	logger.Info("exported")
+
+	require.NoError(t, logging.Flush(t.Context(), logger))
+	require.Len(t, c.records(), 1)
+}
@@ -0,0 +1,579 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// otlpLogsPath is the standard path of the OTLP/HTTP logs endpoint
+	otlpLogsPath = "/v1/logs"
+
+	// otlpScope names this package as the instrumentation scope of records
+	otlpScope = "github.com/bilte-co/toolshed/logging"
+
+	defaultOTLPEndpoint      = "http://localhost:4318"
+	defaultOTLPBatchSize     = 512
+	defaultOTLPFlushInterval = 5 * time.Second
+	defaultOTLPTimeout       = 10 * time.Second
+
+	// otlpQueueBatches is the number of full batches buffered before records
+	// are dropped, for collectors that cannot keep up
+	otlpQueueBatches = 4
+)
+
+// OTLPOptions configures NewOTLPHandler.
+type OTLPOptions struct {
+	// Level is the minimum level of exported records (default: info)
+	Level slog.Leveler
+	// Resource describes the service sending the records, such as
+	// service.name and deployment.environment (default: service.name from
+	// OTEL_SERVICE_NAME or the program name, and OTEL_RESOURCE_ATTRIBUTES)
+	Resource []slog.Attr
+	// Headers are added to export requests, e.g. for authentication
+	Headers map[string]string
+	// BatchSize is the number of records per export request. A full batch is
+	// exported right away (default: 512)
+	BatchSize int
+	// FlushInterval is the longest time records wait to be exported
+	// (default: 5s)
+	FlushInterval time.Duration
+	// Client sends export requests (default: a client with a 10s timeout)
+	Client *http.Client
+	// OnError is called when a background export fails (default: print to
+	// stderr)
+	OnError func(error)
+}
+
+// OTLPHandler is a slog.Handler that exports records to an OpenTelemetry
+// collector. Handlers derived with WithAttrs and WithGroup share its batches.
+type OTLPHandler struct {
+	exporter *otlpExporter
+	level    slog.Leveler
+	goas     []groupOrAttrs
+}
+
+// groupOrAttrs is a group opened by WithGroup or attributes added by WithAttrs
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// NewOTLPHandler returns a handler that ships records to the OpenTelemetry
+// collector at endpoint using OTLP/HTTP with JSON encoding. An endpoint
+// without a path, such as "http://localhost:4318", gets the standard
+// "/v1/logs" path. Records are batched and exported in the background; call
+// Close, or Flush on the logger, before the program exits so that the last
+// batch is not lost.
+//
+// NewLoggerFromEnv uses this handler when LOG_EXPORTER is "otlp".
+//
+// Example usage:
+//
+//	handler, err := logging.NewOTLPHandler("http://collector:4318", &logging.OTLPOptions{
+//		Resource: []slog.Attr{slog.String("service.name", "billing")},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer handler.Close(context.Background())
+//	slog.New(handler).Info("Invoice sent", "invoice_id", 42)
+func NewOTLPHandler(endpoint string, opts *OTLPOptions) (*OTLPHandler, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: must be an http or https URL", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpLogsPath
+	}
+
+	if opts == nil {
+		opts = &OTLPOptions{}
+	}
+	e := &otlpExporter{
+		url:       u.String(),
+		headers:   opts.Headers,
+		batchSize: opts.BatchSize,
+		client:    opts.Client,
+		onError:   opts.OnError,
+		full:      make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	if e.batchSize <= 0 {
+		e.batchSize = defaultOTLPBatchSize
+	}
+	if e.client == nil {
+		e.client = &http.Client{Timeout: defaultOTLPTimeout}
+	}
+	if e.onError == nil {
+		e.onError = func(err error) {
+			fmt.Fprintf(os.Stderr, "logging: %v\n", err)
+		}
+	}
+
+	resource := opts.Resource
+	if resource == nil {
+		resource = otlpResourceFromEnv()
+	}
+	e.resource = otlpAttributes(resource)
+
+	interval := opts.FlushInterval
+	if interval <= 0 {
+		interval = defaultOTLPFlushInterval
+	}
+	go e.run(interval)
+
+	level := opts.Level
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &OTLPHandler{exporter: e, level: level}, nil
+}
+
+func (h *OTLPHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *OTLPHandler) Handle(ctx context.Context, record slog.Record) error {
+	var attrs []slog.Attr
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+
+	// Nest the record attributes in the open groups, innermost first
+	kvs := otlpAttributes(attrs)
+	for i := len(h.goas) - 1; i >= 0; i-- {
+		if goa := h.goas[i]; goa.group != "" {
+			if len(kvs) > 0 {
+				kvs = []otlpKeyValue{{Key: goa.group, Value: otlpAnyValue{KvlistValue: &otlpKvlist{Values: kvs}}}}
+			}
+		} else {
+			kvs = append(otlpAttributes(goa.attrs), kvs...)
+		}
+	}
+
+	observed := time.Now()
+	timestamp := record.Time
+	if timestamp.IsZero() {
+		timestamp = observed
+	}
+	logRecord := otlpLogRecord{
+		TimeUnixNano:         strconv.FormatInt(timestamp.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(observed.UnixNano(), 10),
+		SeverityNumber:       otlpSeverity(record.Level),
+		SeverityText:         record.Level.String(),
+		Body:                 otlpString(record.Message),
+		Attributes:           kvs,
+	}
+
+	// Correlate the record with the trace set by WithTrace
+	if traceID, spanID := TraceFromContext(ctx); isTraceID(traceID, 32) {
+		logRecord.TraceID = traceID
+		if isTraceID(spanID, 16) {
+			logRecord.SpanID = spanID
+		}
+	}
+
+	h.exporter.add(logRecord)
+	return nil
+}
+
+func (h *OTLPHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(groupOrAttrs{attrs: attrs})
+}
+
+func (h *OTLPHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(groupOrAttrs{group: name})
+}
+
+// with returns a copy of h with a group or attributes added
+func (h *OTLPHandler) with(goa groupOrAttrs) *OTLPHandler {
+	goas := make([]groupOrAttrs, len(h.goas), len(h.goas)+1)
+	copy(goas, h.goas)
+	return &OTLPHandler{exporter: h.exporter, level: h.level, goas: append(goas, goa)}
+}
+
+// Flush exports the buffered records.
+func (h *OTLPHandler) Flush(ctx context.Context) error {
+	return h.exporter.flush(ctx)
+}
+
+// Close stops the background exports and exports the buffered records. It
+// is safe to call more than once.
+func (h *OTLPHandler) Close(ctx context.Context) error {
+	h.exporter.closeOnce.Do(func() {
+		close(h.exporter.done)
+		<-h.exporter.stopped
+	})
+	return h.exporter.flush(ctx)
+}
+
+// Flush exports the records buffered by the handlers of logger, such as the
+// OTLP handler of a logger from NewLoggerFromEnv with LOG_EXPORTER=otlp.
+// Call it before the program exits. All handlers of a MultiHandler are
+// flushed. It does nothing for other loggers.
+func Flush(ctx context.Context, logger *slog.Logger) error {
+	return flushHandler(ctx, logger.Handler())
+}
+
+// flushHandler flushes handler or the handlers it wraps
+func flushHandler(ctx context.Context, handler slog.Handler) error {
+	for handler != nil {
+		switch h := handler.(type) {
+		case interface{ Flush(context.Context) error }:
+			return h.Flush(ctx)
+		case *multiHandler:
+			var errs []error
+			for _, handler := range h.handlers {
+				errs = append(errs, flushHandler(ctx, handler))
+			}
+			return errors.Join(errs...)
+		case interface{ unwrap() slog.Handler }:
+			handler = h.unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// envOTLPHandlers are the handlers created for LOG_EXPORTER=otlp, by their
+// settings. Loggers from NewLoggerFromEnv share them, so that each collector
+// gets a single exporter, which Flush on any of those loggers reaches.
+var (
+	envOTLPMu       sync.Mutex
+	envOTLPHandlers = make(map[string]*OTLPHandler)
+)
+
+// newOTLPLoggerFromEnv creates a logger exporting to the collector configured
+// by the standard OTEL_EXPORTER_OTLP_* environment variables
+func newOTLPLoggerFromEnv(level string) (*slog.Logger, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT")
+	if endpoint == "" {
+		base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			base = defaultOTLPEndpoint
+		}
+		endpoint = strings.TrimRight(base, "/") + otlpLogsPath
+	}
+
+	levels := ParseLevels(level)
+	headers := os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")
+	key := strings.Join([]string{
+		endpoint, headers, os.Getenv("OTEL_SERVICE_NAME"), os.Getenv("OTEL_RESOURCE_ATTRIBUTES"), levels.minLevel().String(),
+	}, "\x00")
+
+	envOTLPMu.Lock()
+	defer envOTLPMu.Unlock()
+
+	handler, ok := envOTLPHandlers[key]
+	if !ok {
+		var err error
+		handler, err = NewOTLPHandler(endpoint, &OTLPOptions{
+			Level:   baseLevel{levels.minLevel()},
+			Headers: parseOTLPPairs(headers),
+		})
+		if err != nil {
+			return nil, err
+		}
+		envOTLPHandlers[key] = handler
+	}
+
+	return slog.New(&levelHandler{
+		base:   NewRedactHandler(handler, nil),
+		levels: levels,
+		level:  levels.Default,
+	}), nil
+}
+
+// otlpResourceFromEnv returns the resource attributes of OTEL_RESOURCE_ATTRIBUTES
+// and OTEL_SERVICE_NAME, naming the service after the program by default
+func otlpResourceFromEnv() []slog.Attr {
+	pairs := parseOTLPPairs(os.Getenv("OTEL_RESOURCE_ATTRIBUTES"))
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		pairs["service.name"] = name
+	}
+	if pairs["service.name"] == "" {
+		pairs["service.name"] = filepath.Base(os.Args[0])
+	}
+
+	resource := make([]slog.Attr, 0, len(pairs))
+	for key, value := range pairs {
+		resource = append(resource, slog.String(key, value))
+	}
+	return resource
+}
+
+// parseOTLPPairs parses the "key1=value1,key2=value2" lists of the OTEL_*
+// environment variables, whose values may be URL-encoded
+func parseOTLPPairs(s string) map[string]string {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(strings.TrimSpace(value)); err == nil {
+			value = unescaped
+		}
+		pairs[key] = strings.TrimSpace(value)
+	}
+	return pairs
+}
+
+// otlpExporter batches records and posts them to a collector
+type otlpExporter struct {
+	url       string
+	headers   map[string]string
+	resource  []otlpKeyValue
+	batchSize int
+	client    *http.Client
+	onError   func(error)
+
+	mu      sync.Mutex
+	records []otlpLogRecord
+	dropped int
+
+	sendMu    sync.Mutex    // Serializes exports, keeping records in order
+	full      chan struct{} // Signals a full batch
+	done      chan struct{} // Closed to stop run
+	stopped   chan struct{} // Closed when run returns
+	closeOnce sync.Once
+}
+
+// run exports records every interval and whenever a batch fills up
+func (e *otlpExporter) run(interval time.Duration) {
+	defer close(e.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-e.full:
+		case <-e.done:
+			return
+		}
+		if err := e.flush(context.Background()); err != nil {
+			e.onError(err)
+		}
+	}
+}
+
+// add queues a record, dropping it if the queue is full
+func (e *otlpExporter) add(record otlpLogRecord) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.records) >= otlpQueueBatches*e.batchSize {
+		e.dropped++
+		return
+	}
+	e.records = append(e.records, record)
+	if len(e.records) >= e.batchSize {
+		select {
+		case e.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// flush exports the queued records in batches
+func (e *otlpExporter) flush(ctx context.Context) error {
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
+
+	e.mu.Lock()
+	records, dropped := e.records, e.dropped
+	e.records, e.dropped = nil, 0
+	e.mu.Unlock()
+
+	var errs []error
+	if dropped > 0 {
+		errs = append(errs, fmt.Errorf("OTLP export queue full, dropped %d records", dropped))
+	}
+	for start := 0; start < len(records); start += e.batchSize {
+		batch := records[start:min(start+e.batchSize, len(records))]
+		if err := e.send(ctx, batch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to export %d records: %w", len(batch), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// send posts a batch of records to the collector
+func (e *otlpExporter) send(ctx context.Context, records []otlpLogRecord) error {
+	body, err := json.Marshal(otlpLogsRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource:  otlpResource{Attributes: e.resource},
+		ScopeLogs: []otlpScopeLogs{{Scope: otlpScopeInfo{Name: otlpScope}, LogRecords: records}},
+	}}})
+	if err != nil {
+		return fmt.Errorf("failed to encode OTLP request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("OTLP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("OTLP collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// otlpSeverity maps a slog level to an OTLP severity number, as the
+// OpenTelemetry slog bridge does: DEBUG is 5, INFO 9, WARN 13 and ERROR 17
+func otlpSeverity(level slog.Level) int {
+	return min(max(int(level)+9, 1), 24)
+}
+
+// otlpAttributes converts slog attributes to OTLP key-values
+func otlpAttributes(attrs []slog.Attr) []otlpKeyValue {
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		attr.Value = attr.Value.Resolve()
+		if attr.Equal(slog.Attr{}) {
+			continue
+		}
+		// Attributes of groups without a key belong to the parent
+		if attr.Key == "" && attr.Value.Kind() == slog.KindGroup {
+			kvs = append(kvs, otlpAttributes(attr.Value.Group())...)
+			continue
+		}
+		kvs = append(kvs, otlpKeyValue{Key: attr.Key, Value: otlpValue(attr.Value)})
+	}
+	return kvs
+}
+
+// otlpValue converts a resolved slog value to an OTLP value
+func otlpValue(v slog.Value) otlpAnyValue {
+	switch v.Kind() {
+	case slog.KindString:
+		return otlpString(v.String())
+	case slog.KindInt64:
+		return otlpInt(v.Int64())
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return otlpInt(int64(u))
+		}
+		return otlpString(strconv.FormatUint(v.Uint64(), 10))
+	case slog.KindFloat64:
+		f := v.Float64()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			// JSON has no NaN or infinity
+			return otlpString(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		return otlpAnyValue{DoubleValue: &f}
+	case slog.KindBool:
+		b := v.Bool()
+		return otlpAnyValue{BoolValue: &b}
+	case slog.KindDuration:
+		return otlpInt(int64(v.Duration()))
+	case slog.KindTime:
+		return otlpString(v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		return otlpAnyValue{KvlistValue: &otlpKvlist{Values: otlpAttributes(v.Group())}}
+	default:
+		if err, ok := v.Any().(error); ok {
+			return otlpString(err.Error())
+		}
+		return otlpString(fmt.Sprint(v.Any()))
+	}
+}
+
+func otlpString(s string) otlpAnyValue {
+	return otlpAnyValue{StringValue: &s}
+}
+
+func otlpInt(i int64) otlpAnyValue {
+	// 64-bit integers are strings in the JSON encoding of protobuf
+	s := strconv.FormatInt(i, 10)
+	return otlpAnyValue{IntValue: &s}
+}
+
+// OTLP/HTTP JSON request types, following opentelemetry-proto's logs service
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScopeInfo   `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScopeInfo struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otlpAnyValue   `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes,omitempty"`
+	TraceID              string         `json:"traceId,omitempty"` // Hex, unlike other bytes
+	SpanID               string         `json:"spanId,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	BoolValue   *bool       `json:"boolValue,omitempty"`
+	IntValue    *string     `json:"intValue,omitempty"`
+	DoubleValue *float64    `json:"doubleValue,omitempty"`
+	KvlistValue *otlpKvlist `json:"kvlistValue,omitempty"`
+}
+
+type otlpKvlist struct {
+	Values []otlpKeyValue `json:"values"`
+}
@@ -0,0 +1,230 @@
+package logging_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collector is a fake OTLP/HTTP collector that records export requests
+type collector struct {
+	mu       sync.Mutex
+	requests []map[string]any
+	headers  []http.Header
+	status   int
+}
+
+func newCollector(t *testing.T) (*collector, *httptest.Server) {
+	t.Helper()
+
+	c := &collector{status: http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]any
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.requests = append(c.requests, body)
+		c.headers = append(c.headers, r.Header.Clone())
+		w.WriteHeader(c.status)
+	}))
+	t.Cleanup(server.Close)
+	return c, server
+}
+
+// records returns the log records of all requests, in order
+func (c *collector) records() []map[string]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var records []map[string]any
+	for _, request := range c.requests {
+		resourceLogs := request["resourceLogs"].([]any)[0].(map[string]any)
+		scopeLogs := resourceLogs["scopeLogs"].([]any)[0].(map[string]any)
+		for _, record := range scopeLogs["logRecords"].([]any) {
+			records = append(records, record.(map[string]any))
+		}
+	}
+	return records
+}
+
+// attributes converts OTLP key-values to a map of their JSON values
+func attributes(kvs any) map[string]any {
+	attrs := make(map[string]any)
+	list, _ := kvs.([]any)
+	for _, kv := range list {
+		kv := kv.(map[string]any)
+		attrs[kv["key"].(string)] = kv["value"]
+	}
+	return attrs
+}
+
+func TestOTLPHandler_ExportsRecords(t *testing.T) {
+	c, server := newCollector(t)
+
+	handler, err := logging.NewOTLPHandler(server.URL, &logging.OTLPOptions{
+		Level:    slog.LevelDebug,
+		Resource: []slog.Attr{slog.String("service.name", "billing")},
+		Headers:  map[string]string{"Authorization": "Bearer abc"},
+	})
+	require.NoError(t, err)
+
+	logger := slog.New(handler).With("region", "eu").WithGroup("invoice")
+	logger.Debug("Rendering")
+	logger.Error("Invoice sent", "id", 42, "paid", true, "amount", 9.5, "took", time.Second, "err", errors.New("late"))
+	require.NoError(t, handler.Close(t.Context()))
+
+	require.Len(t, c.requests, 1)
+	require.Equal(t, "Bearer abc", c.headers[0].Get("Authorization"))
+
+	resource := c.requests[0]["resourceLogs"].([]any)[0].(map[string]any)["resource"].(map[string]any)
+	require.Equal(t, map[string]any{"stringValue": "billing"}, attributes(resource["attributes"])["service.name"])
+
+	records := c.records()
+	require.Len(t, records, 2)
+
+	debug := records[0]
+	require.EqualValues(t, 5, debug["severityNumber"])
+	require.Equal(t, "DEBUG", debug["severityText"])
+	require.Equal(t, map[string]any{"stringValue": "Rendering"}, debug["body"])
+	require.Equal(t, map[string]any{"region": map[string]any{"stringValue": "eu"}}, attributes(debug["attributes"]))
+	require.NotEmpty(t, debug["timeUnixNano"])
+
+	sent := records[1]
+	require.EqualValues(t, 17, sent["severityNumber"])
+	attrs := attributes(sent["attributes"])
+	require.Equal(t, map[string]any{"stringValue": "eu"}, attrs["region"])
+	invoice := attributes(attrs["invoice"].(map[string]any)["kvlistValue"].(map[string]any)["values"])
+	require.Equal(t, map[string]any{"intValue": "42"}, invoice["id"])
+	require.Equal(t, map[string]any{"boolValue": true}, invoice["paid"])
+	require.Equal(t, map[string]any{"doubleValue": 9.5}, invoice["amount"])
+	require.Equal(t, map[string]any{"intValue": "1000000000"}, invoice["took"])
+	require.Equal(t, map[string]any{"stringValue": "late"}, invoice["err"])
+}
+
+func TestOTLPHandler_Batching(t *testing.T) {
+	c, server := newCollector(t)
+
+	handler, err := logging.NewOTLPHandler(server.URL, &logging.OTLPOptions{BatchSize: 2, FlushInterval: time.Hour})
+	require.NoError(t, err)
+	defer handler.Close(t.Context())
+
+	logger := slog.New(handler)
+	logger.Info("one")
+	logger.Debug("filtered")
+	logger.Info("two")
+
+	// A full batch is exported without waiting for the interval
+	require.Eventually(t, func() bool { return len(c.records()) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	logger.Info("three")
+	require.NoError(t, handler.Flush(t.Context()))
+	require.Len(t, c.records(), 3)
+}
+
+func TestOTLPHandler_Errors(t *testing.T) {
+	_, err := logging.NewOTLPHandler("localhost:4318", nil)
+	require.ErrorContains(t, err, "invalid OTLP endpoint")
+
+	c, server := newCollector(t)
+	c.status = http.StatusServiceUnavailable
+
+	handler, err := logging.NewOTLPHandler(server.URL, &logging.OTLPOptions{FlushInterval: time.Hour})
+	require.NoError(t, err)
+	defer handler.Close(t.Context())
+
+	slog.New(handler).Info("lost")
+	require.ErrorContains(t, handler.Flush(t.Context()), "503 Service Unavailable")
+}
+
+func TestNewLoggerFromEnv_OTLP(t *testing.T) {
+	c, server := newCollector(t)
+	t.Setenv("LOG_EXPORTER", "otlp")
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL+"/")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "x-api-key=abc%20def")
+	t.Setenv("OTEL_SERVICE_NAME", "toolshed-test")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=ci")
+
+	logger := logging.NewLoggerFromEnv()
+	logger.Info("filtered")
+	logger.Warn("Disk almost full", "password", "hunter2")
+	require.NoError(t, logging.Flush(t.Context(), logger.With("request_id", "abc")))
+
+	records := c.records()
+	require.Len(t, records, 1)
+	require.Equal(t, map[string]any{"stringValue": "Disk almost full"}, records[0]["body"])
+	require.Equal(t, map[string]any{"stringValue": logging.Redacted}, attributes(records[0]["attributes"])["password"])
+	require.Equal(t, "abc def", c.headers[0].Get("X-Api-Key"))
+
+	resource := attributes(c.requests[0]["resourceLogs"].([]any)[0].(map[string]any)["resource"].(map[string]any)["attributes"])
+	require.Equal(t, map[string]any{"stringValue": "toolshed-test"}, resource["service.name"])
+	require.Equal(t, map[string]any{"stringValue": "ci"}, resource["deployment.environment"])
+}
+
+func TestNewLoggerFromEnv_OTLPWithFile(t *testing.T) {
+	c, server := newCollector(t)
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv("LOG_EXPORTER", "otlp")
+	t.Setenv("LOG_LEVEL", "info,database=debug")
+	t.Setenv("LOG_FILE", path)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+
+	logger := logging.NewLoggerFromEnv()
+	logger.Info("to both outputs")
+	logging.NamedFrom(logger, "database").Debug("query")
+	require.NoError(t, logging.Flush(t.Context(), logger))
+
+	records := c.records()
+	require.Len(t, records, 2)
+	require.Equal(t, map[string]any{"stringValue": "to both outputs"}, records[0]["body"])
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "to both outputs")
+	require.Contains(t, string(data), "query")
+}
+
+func TestNewLoggerFromEnv_OTLPConsole(t *testing.T) {
+	c, server := newCollector(t)
+	t.Setenv("LOG_EXPORTER", "otlp")
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_FILE", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+
+	var first, second *slog.Logger
+	output := captureStderr(t, func() {
+		first = logging.NewLoggerFromEnv()
+		second = logging.NewLoggerFromEnv()
+		first.Info("from the first logger")
+		second.Info("from the second logger")
+	})
+	require.Contains(t, output, "from the first logger")
+	require.Contains(t, output, "from the second logger")
+
+	// The loggers share one exporter, so flushing either exports both records
+	require.NoError(t, logging.Flush(t.Context(), second))
+	require.Len(t, c.records(), 2)
+}
+
+func TestFlush_OtherLoggers(t *testing.T) {
+	require.NoError(t, logging.Flush(t.Context(), logging.NewLogger("info", false)))
+	require.NoError(t, logging.Flush(t.Context(), slog.Default()))
+}
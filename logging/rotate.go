@@ -0,0 +1,331 @@
+package logging
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxSize is the size at which log files are rotated by default
+	defaultMaxSize = 100 << 20
+
+	// envMaxBackups is the number of rotated files kept for LOG_FILE
+	envMaxBackups = 10
+
+	// backupTimeFormat is the timestamp in rotated file names, which sorts
+	// in time order
+	backupTimeFormat = "2006-01-02T15-04-05.000000"
+)
+
+// RotateOptions configures the rotation of a RotatingFile.
+type RotateOptions struct {
+	// MaxSize is the size in bytes a file may reach before it is rotated
+	// (default: 100 MiB)
+	MaxSize int64
+	// MaxAge is the age at which a file is rotated, counted from when it was
+	// opened or last rotated (default: none)
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files kept; older ones are deleted
+	// (default: all)
+	MaxBackups int
+	// Compress gzips rotated files
+	Compress bool
+}
+
+// RotatingFile is a log file that is rotated when it grows too large or too
+// old: it is renamed with a timestamp, as in app-2026-01-02T15-04-05.000000.log
+// for app.log, optionally compressed, and a new file is started. It lets
+// daemons without a log shipper log to disk without filling it. It is safe
+// for concurrent use.
+//
+// Example usage:
+//
+//	file, err := logging.NewRotatingFile("/var/log/app.log", logging.RotateOptions{
+//		MaxSize:    50 << 20,
+//		MaxAge:     24 * time.Hour,
+//		MaxBackups: 7,
+//		Compress:   true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer file.Close()
+//	logger := logging.NewLogger("info", false, logging.WithWriter(file))
+type RotatingFile struct {
+	path string
+	opts RotateOptions
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	closed   bool
+
+	cleanupMu sync.Mutex     // Serializes compression and deletion of backups
+	cleanups  sync.WaitGroup // Background compression and deletion
+}
+
+// NewRotatingFile opens a log file for appending, creating it and its
+// directory if needed.
+func NewRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = defaultMaxSize
+	}
+
+	f := &RotatingFile{path: path, opts: opts}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// envFiles are the files opened for LOG_FILE, by absolute path. Loggers from
+// NewLoggerFromEnv share them, as several RotatingFiles rotating the same path
+// would keep writing to files renamed or deleted by the others.
+var (
+	envFilesMu sync.Mutex
+	envFiles   = make(map[string]*RotatingFile)
+)
+
+// envRotatingFile returns the RotatingFile for LOG_FILE, opening it the first
+// time path is used.
+func envRotatingFile(path string) (*RotatingFile, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve log file path: %w", err)
+	}
+
+	envFilesMu.Lock()
+	defer envFilesMu.Unlock()
+
+	if f, ok := envFiles[abs]; ok {
+		return f, nil
+	}
+	f, err := NewRotatingFile(abs, RotateOptions{MaxBackups: envMaxBackups, Compress: true})
+	if err != nil {
+		return nil, err
+	}
+	envFiles[abs] = f
+	return f, nil
+}
+
+// Write appends p to the file, rotating it first if p would make it larger
+// than MaxSize or if it is older than MaxAge. Records are never split across
+// files, so a record larger than MaxSize gets a file of its own.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+
+	tooLarge := f.size+int64(len(p)) > f.opts.MaxSize
+	tooOld := f.opts.MaxAge > 0 && time.Since(f.openedAt) >= f.opts.MaxAge
+	if f.size > 0 && (tooLarge || tooOld) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate starts a new file, e.g. on a signal from an external scheduler.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+// Close closes the file and waits for rotated files to be compressed.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	var err error
+	if !f.closed {
+		f.closed = true
+		err = f.file.Close()
+	}
+	f.mu.Unlock()
+
+	f.cleanups.Wait()
+	return err
+}
+
+// open opens the log file and records its size
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	f.file, f.size, f.openedAt = file, info.Size(), time.Now()
+	return nil
+}
+
+// rotate renames the current file to a backup and opens a new one. The
+// backup is compressed and old backups deleted in the background.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	backup := f.backupName(time.Now())
+	if err := os.Rename(f.path, backup); err != nil {
+		// Keep logging to the current file rather than losing records
+		if openErr := f.open(); openErr != nil {
+			return errors.Join(fmt.Errorf("failed to rotate log file: %w", err), openErr)
+		}
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.cleanups.Add(1)
+	go func() {
+		defer f.cleanups.Done()
+		f.cleanupMu.Lock()
+		defer f.cleanupMu.Unlock()
+
+		// A later rotation may have already deleted the backup as too old
+		if f.opts.Compress {
+			if err := compressFile(backup); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				fmt.Fprintf(os.Stderr, "logging: %v\n", err)
+			}
+		}
+		if err := f.removeOldBackups(); err != nil {
+			fmt.Fprintf(os.Stderr, "logging: %v\n", err)
+		}
+	}()
+	return nil
+}
+
+// backupName returns an unused name for a file rotated at t
+func (f *RotatingFile) backupName(t time.Time) string {
+	prefix, ext := f.backupPrefix()
+	for {
+		name := prefix + t.Format(backupTimeFormat) + ext
+		if !fileExists(name) && !fileExists(name+".gz") {
+			return name
+		}
+		t = t.Add(time.Microsecond)
+	}
+}
+
+// backupPrefix returns what the names of rotated files start and end with
+func (f *RotatingFile) backupPrefix() (prefix, ext string) {
+	ext = filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-", ext
+}
+
+// removeOldBackups deletes the oldest rotated files beyond MaxBackups
+func (f *RotatingFile) removeOldBackups() error {
+	if f.opts.MaxBackups <= 0 {
+		return nil
+	}
+
+	prefix, ext := f.backupPrefix()
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return fmt.Errorf("failed to list rotated log files: %w", err)
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		name := filepath.Join(filepath.Dir(f.path), entry.Name())
+		stamp, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		stamp = strings.TrimSuffix(strings.TrimSuffix(stamp, ".gz"), ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, name)
+		}
+	}
+	if len(backups) <= f.opts.MaxBackups {
+		return nil
+	}
+
+	// Timestamps sort in time order, whether compressed or not
+	slices.SortFunc(backups, func(a, b string) int {
+		return strings.Compare(strings.TrimSuffix(a, ".gz"), strings.TrimSuffix(b, ".gz"))
+	})
+	var errs []error
+	for _, name := range backups[:len(backups)-f.opts.MaxBackups] {
+		if err := os.Remove(name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove rotated log file: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// compressFile gzips a file to name.gz and removes it
+func compressFile(name string) (err error) {
+	src, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+	defer src.Close()
+
+	tmp := name + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			dst.Close()
+			os.Remove(tmp)
+		}
+	}()
+
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+	if err := os.Rename(tmp, name+".gz"); err != nil {
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+	src.Close()
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("failed to remove compressed log file: %w", err)
+	}
+	return nil
+}
+
+// fileExists reports whether a file exists
+func fileExists(name string) bool {
+	_, err := os.Lstat(name)
+	return err == nil
+}
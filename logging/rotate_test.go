@@ -0,0 +1,165 @@
+package logging_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// backups returns the names of the rotated files in dir, oldest first
+func backups(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		if entry.Name() != "app.log" {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRotatingFile_RotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "app.log")
+
+	file, err := logging.NewRotatingFile(path, logging.RotateOptions{MaxSize: 10})
+	require.NoError(t, err)
+
+	for _, record := range []string{"first\n", "second\n", "3\n", "a record longer than MaxSize\n"} {
+		_, err := file.Write([]byte(record))
+		require.NoError(t, err)
+	}
+	require.NoError(t, file.Close())
+
+	rotated := backups(t, filepath.Join(dir, "logs"))
+	require.Len(t, rotated, 2)
+	for _, name := range rotated {
+		require.Regexp(t, `^app-\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}\.\d{6}\.log$`, name)
+	}
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, "logs", name))
+		require.NoError(t, err)
+		return string(data)
+	}
+	require.Equal(t, "first\n", read(rotated[0]))
+	require.Equal(t, "second\n3\n", read(rotated[1]))
+	require.Equal(t, "a record longer than MaxSize\n", read("app.log"))
+
+	_, err = file.Write([]byte("after close\n"))
+	require.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestRotatingFile_RotatesByAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	file, err := logging.NewRotatingFile(path, logging.RotateOptions{MaxAge: 50 * time.Millisecond})
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.Write([]byte("old\n"))
+	require.NoError(t, err)
+	_, err = file.Write([]byte("still young\n"))
+	require.NoError(t, err)
+	require.Empty(t, backups(t, dir))
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = file.Write([]byte("new\n"))
+	require.NoError(t, err)
+	require.Len(t, backups(t, dir), 1)
+}
+
+func TestRotatingFile_CompressesAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	// Appends to an existing file
+	require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app-notes.log"), []byte("unrelated"), 0o644))
+
+	file, err := logging.NewRotatingFile(path, logging.RotateOptions{MaxBackups: 2, Compress: true})
+	require.NoError(t, err)
+
+	for _, record := range []string{"one\n", "two\n", "three\n"} {
+		_, err := file.Write([]byte(record))
+		require.NoError(t, err)
+		require.NoError(t, file.Rotate())
+	}
+	require.NoError(t, file.Close())
+
+	rotated := backups(t, dir)
+	require.Len(t, rotated, 3)
+	require.Equal(t, "app-notes.log", rotated[2])
+
+	for i, want := range []string{"two\n", "three\n"} {
+		require.True(t, strings.HasSuffix(rotated[i], ".log.gz"), rotated[i])
+
+		f, err := os.Open(filepath.Join(dir, rotated[i]))
+		require.NoError(t, err)
+		zr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		data, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.Equal(t, want, string(data))
+	}
+}
+
+func TestNewLogger_WithWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := logging.NewRotatingFile(path, logging.RotateOptions{})
+	require.NoError(t, err)
+
+	logger := logging.NewLogger("info", false, logging.WithWriter(file))
+	logger.Debug("hidden")
+	logger.Info("written to file", "password", "hunter2")
+	require.NoError(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "written to file")
+	require.Contains(t, string(data), "password=xxxxx")
+	require.NotContains(t, string(data), "hidden")
+	require.NotContains(t, string(data), "\x1b[", "file output has no colors")
+}
+
+func TestNewLoggerFromEnv_LogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv("LOG_FILE", path)
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_EXPORTER", "")
+
+	logging.NewLoggerFromEnv().Info("from env")
+	logging.NewLoggerFromEnv().Info("again from env")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "from env")
+	require.Contains(t, string(data), "again from env")
+
+	// Loggers share the file, so none keeps writing to a file another rotated
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return
+	}
+	open := 0
+	for _, fd := range fds {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); err == nil && target == path {
+			open++
+		}
+	}
+	require.Equal(t, 1, open)
+}
@@ -0,0 +1,152 @@
+package logging
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+var (
+	// runtimeLevel is the level set by SetLevel
+	runtimeLevel slog.LevelVar
+
+	// runtimeLevelSet reports whether runtimeLevel replaces the configured levels
+	runtimeLevelSet atomic.Bool
+)
+
+// SetLevel sets the level of every logger created by this package, replacing
+// the levels they were created with, including per-component overrides, until
+// ResetLevel is called. It lets long-running services raise verbosity without
+// restarting.
+func SetLevel(level slog.Level) {
+	runtimeLevel.Set(level)
+	runtimeLevelSet.Store(true)
+}
+
+// ResetLevel restores the levels loggers were created with.
+func ResetLevel() {
+	runtimeLevelSet.Store(false)
+}
+
+// RuntimeLevel returns the level set by SetLevel, and false if loggers use
+// the levels they were created with.
+func RuntimeLevel() (slog.Level, bool) {
+	return runtimeLevel.Level(), runtimeLevelSet.Load()
+}
+
+// baseLevel is the level of the handlers wrapped by levelHandler: the most
+// verbose level of any component, or the level set by SetLevel
+type baseLevel struct {
+	min slog.Level
+}
+
+func (l baseLevel) Level() slog.Level {
+	if level, ok := RuntimeLevel(); ok {
+		return level
+	}
+	return l.min
+}
+
+// ToggleDebugOnSignal switches all loggers to debug when the process
+// receives one of sigs, SIGHUP by default, and back to the levels they were
+// created with on the next one. It returns a function that stops watching
+// for the signals.
+//
+// Example usage:
+//
+//	stop := logging.ToggleDebugOnSignal()
+//	defer stop()
+//	// kill -HUP <pid> now toggles debug logging
+func ToggleDebugOnSignal(sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, sigs...)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-received:
+				toggleDebug(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+		})
+	}
+}
+
+// toggleDebug switches between debug and the configured levels
+func toggleDebug(sig os.Signal) {
+	if level, ok := RuntimeLevel(); ok && level == slog.LevelDebug {
+		DefaultLogger().Info("Restoring configured log levels", "signal", sig.String())
+		ResetLevel()
+		return
+	}
+	SetLevel(slog.LevelDebug)
+	DefaultLogger().Info("Debug logging enabled", "signal", sig.String())
+}
+
+// levelStatus is the response of LevelAdminHandler
+type levelStatus struct {
+	Level    string `json:"level,omitempty"`
+	Override bool   `json:"override"`
+}
+
+// LevelAdminHandler returns an HTTP handler to inspect and change the log
+// level at runtime. GET reports the level set by SetLevel, if any; PUT or
+// POST with a level parameter (debug, info, warn or error), in the query or
+// a form body, calls SetLevel; DELETE calls ResetLevel. Mount it on an admin
+// listener that only operators can reach.
+//
+// Example usage:
+//
+//	admin := http.NewServeMux()
+//	admin.Handle("/log/level", logging.LevelAdminHandler())
+//	go http.ListenAndServe("127.0.0.1:9090", admin)
+//	// curl -X PUT '127.0.0.1:9090/log/level?level=debug'
+func LevelAdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut, http.MethodPost:
+			name := strings.ToLower(strings.TrimSpace(r.FormValue("level")))
+			level, ok := parseLevel(name)
+			if !ok {
+				http.Error(w, "level must be one of debug, info, warn or error", http.StatusBadRequest)
+				return
+			}
+			SetLevel(level)
+			DefaultLogger().Info("Log level changed", "level", name, "remote_addr", r.RemoteAddr)
+		case http.MethodDelete:
+			ResetLevel()
+			DefaultLogger().Info("Restoring configured log levels", "remote_addr", r.RemoteAddr)
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PUT, POST, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var status levelStatus
+		if level, ok := RuntimeLevel(); ok {
+			status = levelStatus{Level: strings.ToLower(level.String()), Override: true}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
@@ -0,0 +1,114 @@
+package logging_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLevel(t *testing.T) {
+	t.Cleanup(logging.ResetLevel)
+
+	var buf bytes.Buffer
+	logger := logging.NewJSONLogger(&buf, "info,database=warn")
+	db := logging.NamedFrom(logger, "database")
+
+	logging.SetLevel(slog.LevelDebug)
+	level, ok := logging.RuntimeLevel()
+	require.True(t, ok)
+	require.Equal(t, slog.LevelDebug, level)
+
+	logger.Debug("root debug")
+	db.Info("database info")
+
+	logging.SetLevel(slog.LevelError)
+	logger.Warn("root warn")
+
+	logging.ResetLevel()
+	_, ok = logging.RuntimeLevel()
+	require.False(t, ok)
+	logger.Debug("root debug again")
+	db.Info("database info again")
+	db.Warn("database warn")
+
+	output := buf.String()
+	require.Contains(t, output, "root debug")
+	require.Contains(t, output, "database info")
+	require.NotContains(t, output, "root warn")
+	require.NotContains(t, output, "root debug again")
+	require.NotContains(t, output, "database info again")
+	require.Contains(t, output, "database warn")
+}
+
+func TestToggleDebugOnSignal(t *testing.T) {
+	t.Cleanup(logging.ResetLevel)
+
+	stop := logging.ToggleDebugOnSignal()
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Skipf("cannot send SIGHUP: %v", err)
+	}
+	require.Eventually(t, func() bool {
+		level, ok := logging.RuntimeLevel()
+		return ok && level == slog.LevelDebug
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+	require.Eventually(t, func() bool {
+		_, ok := logging.RuntimeLevel()
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stop()
+	require.NotPanics(t, stop)
+}
+
+func TestLevelAdminHandler(t *testing.T) {
+	t.Cleanup(logging.ResetLevel)
+	handler := logging.LevelAdminHandler()
+
+	serve := func(method, target string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"override":false}`, rec.Body.String())
+
+	rec = serve(http.MethodPut, "/?level=debug", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"debug","override":true}`, rec.Body.String())
+
+	rec = serve(http.MethodPost, "/", "level=WARN")
+	require.JSONEq(t, `{"level":"warn","override":true}`, rec.Body.String())
+	level, _ := logging.RuntimeLevel()
+	require.Equal(t, slog.LevelWarn, level)
+
+	rec = serve(http.MethodPut, "/?level=loud", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(http.MethodDelete, "/", "")
+	require.JSONEq(t, `{"override":false}`, rec.Body.String())
+
+	rec = serve(http.MethodPatch, "/", "")
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Equal(t, "GET, HEAD, PUT, POST, DELETE", rec.Header().Get("Allow"))
+}
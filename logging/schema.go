@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// A Schema is the naming of the standard fields of JSON records, chosen with
+// WithSchema to match what a log ingestion pipeline expects.
+type Schema int
+
+// Field schemas.
+const (
+	// SchemaDefault keeps the field names of slog: time, level and msg.
+	SchemaDefault Schema = iota
+
+	// SchemaECS follows the Elastic Common Schema: @timestamp, log.level,
+	// message, log.logger for the component, error.message, trace.id and
+	// span.id, and adds ecs.version to every record.
+	SchemaECS
+
+	// SchemaGCP follows Google Cloud Logging: time, severity with its DEBUG,
+	// INFO, WARNING, ERROR, CRITICAL, ALERT and EMERGENCY values, message and
+	// logging.googleapis.com/spanId.
+	SchemaGCP
+)
+
+// ECSVersion is the Elastic Common Schema version recorded by SchemaECS.
+const ECSVersion = "8.11.0"
+
+// String returns the name of the schema.
+func (s Schema) String() string {
+	switch s {
+	case SchemaDefault:
+		return "default"
+	case SchemaECS:
+		return "ecs"
+	case SchemaGCP:
+		return "gcp"
+	default:
+		return "Schema(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// ParseSchema returns the schema with the given name, as returned by
+// Schema.String. An empty name is the default schema.
+func ParseSchema(name string) (Schema, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return SchemaDefault, nil
+	}
+	for _, s := range []Schema{SchemaDefault, SchemaECS, SchemaGCP} {
+		if strings.EqualFold(name, s.String()) {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log schema %q: must be default, ecs or gcp", name)
+}
+
+// WithSchema names the fields of the records of NewJSONLogger after schema,
+// so that they are ingested without rewriting. The console output of
+// NewLogger is not affected.
+//
+// Example usage:
+//
+//	logger := logging.NewJSONLogger(os.Stdout, "info", logging.WithSchema(logging.SchemaECS))
+//	logger.Info("Order placed") // {"@timestamp":"...","log.level":"info","message":"Order placed",...}
+func WithSchema(schema Schema) Option {
+	return func(c *loggerConfig) {
+		c.schema = schema
+	}
+}
+
+// replaceAttr returns the slog.HandlerOptions.ReplaceAttr function renaming
+// the fields of the schema, or nil for the default schema
+func (s Schema) replaceAttr() func(groups []string, a slog.Attr) slog.Attr {
+	var keys map[string]string
+	var level func(slog.Level) string
+	switch s {
+	case SchemaECS:
+		keys = map[string]string{
+			slog.TimeKey:    "@timestamp",
+			slog.LevelKey:   "log.level",
+			slog.MessageKey: "message",
+			ComponentKey:    "log.logger",
+			"error":         "error.message",
+			TraceIDKey:      "trace.id",
+			SpanIDKey:       "span.id",
+		}
+		level = func(l slog.Level) string { return strings.ToLower(l.String()) }
+	case SchemaGCP:
+		keys = map[string]string{
+			slog.LevelKey:   "severity",
+			slog.MessageKey: "message",
+			SpanIDKey:       "logging.googleapis.com/spanId",
+		}
+		level = gcpSeverity
+	default:
+		return nil
+	}
+
+	return func(groups []string, a slog.Attr) slog.Attr {
+		// Attributes in groups keep their names
+		if len(groups) > 0 {
+			return a
+		}
+		if a.Key == slog.LevelKey {
+			if l, ok := a.Value.Any().(slog.Level); ok {
+				a.Value = slog.StringValue(level(l))
+			}
+		}
+		if key, ok := keys[a.Key]; ok && a.Value.Kind() != slog.KindGroup {
+			a.Key = key
+		}
+		return a
+	}
+}
+
+// attrs returns the attributes the schema adds to every record
+func (s Schema) attrs() []slog.Attr {
+	if s == SchemaECS {
+		return []slog.Attr{slog.String("ecs.version", ECSVersion)}
+	}
+	return nil
+}
+
+// gcpSeverity returns the Cloud Logging severity of a level, mapping levels
+// above error to the more severe values in steps of 4 like the slog levels
+func gcpSeverity(l slog.Level) string {
+	switch {
+	case l < slog.LevelInfo:
+		return "DEBUG"
+	case l < slog.LevelWarn:
+		return "INFO"
+	case l < slog.LevelError:
+		return "WARNING"
+	case l < slog.LevelError+4:
+		return "ERROR"
+	case l < slog.LevelError+8:
+		return "CRITICAL"
+	case l < slog.LevelError+12:
+		return "ALERT"
+	default:
+		return "EMERGENCY"
+	}
+}
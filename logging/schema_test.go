@@ -0,0 +1,116 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bilte-co/toolshed/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// logRecord logs one record at level with a JSON logger in schema and returns it
+func logRecord(t *testing.T, schema logging.Schema, level slog.Level, args ...any) map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger := logging.NewJSONLogger(&buf, "debug", logging.WithSchema(schema))
+	logger.Log(t.Context(), level, "Order placed", args...)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return record
+}
+
+func TestWithSchema_ECS(t *testing.T) {
+	record := logRecord(t, logging.SchemaECS, slog.LevelWarn,
+		logging.ComponentKey, "billing",
+		"error", errors.New("card declined"),
+		logging.TraceIDKey, testTraceID,
+		slog.Group("order", "message", "keep"))
+
+	require.Equal(t, "Order placed", record["message"])
+	require.Equal(t, "warn", record["log.level"])
+	require.NotEmpty(t, record["@timestamp"])
+	require.Equal(t, logging.ECSVersion, record["ecs.version"])
+	require.Equal(t, "billing", record["log.logger"])
+	require.Equal(t, "card declined", record["error.message"])
+	require.Equal(t, testTraceID, record["trace.id"])
+	require.Equal(t, map[string]any{"message": "keep"}, record["order"])
+
+	for _, key := range []string{"msg", "level", "time", logging.ComponentKey, "error", logging.TraceIDKey} {
+		require.NotContains(t, record, key)
+	}
+}
+
+func TestWithSchema_GCP(t *testing.T) {
+	tests := []struct {
+		level    slog.Level
+		severity string
+	}{
+		{slog.LevelDebug, "DEBUG"},
+		{slog.LevelInfo, "INFO"},
+		{slog.LevelWarn, "WARNING"},
+		{slog.LevelError, "ERROR"},
+		{slog.LevelError + 4, "CRITICAL"},
+		{slog.LevelError + 8, "ALERT"},
+		{slog.LevelError + 12, "EMERGENCY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			record := logRecord(t, logging.SchemaGCP, tt.level, logging.SpanIDKey, testSpanID)
+			require.Equal(t, tt.severity, record["severity"])
+			require.Equal(t, "Order placed", record["message"])
+			require.NotEmpty(t, record["time"])
+			require.Equal(t, testSpanID, record["logging.googleapis.com/spanId"])
+			require.NotContains(t, record, "level")
+			require.NotContains(t, record, "msg")
+		})
+	}
+}
+
+func TestWithSchema_Default(t *testing.T) {
+	record := logRecord(t, logging.SchemaDefault, slog.LevelInfo)
+	require.Equal(t, "Order placed", record["msg"])
+	require.Equal(t, "INFO", record["level"])
+	require.NotContains(t, record, "ecs.version")
+}
+
+func TestParseSchema(t *testing.T) {
+	for name, want := range map[string]logging.Schema{
+		"":        logging.SchemaDefault,
+		"default": logging.SchemaDefault,
+		"ECS":     logging.SchemaECS,
+		" gcp ":   logging.SchemaGCP,
+	} {
+		schema, err := logging.ParseSchema(name)
+		require.NoError(t, err)
+		require.Equal(t, want, schema)
+	}
+
+	_, err := logging.ParseSchema("splunk")
+	require.ErrorContains(t, err, "unknown log schema")
+}
+
+func TestNewLoggerFromEnv_Schema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv("LOG_FILE", path)
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_EXPORTER", "")
+	t.Setenv("LOG_SCHEMA", "ecs")
+
+	logging.NewLoggerFromEnv().Info("from env")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(data, &record))
+	require.Equal(t, "from env", record["message"])
+	require.Equal(t, "info", record["log.level"])
+}
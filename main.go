@@ -19,16 +19,25 @@ var (
 
 // CLI represents the main command line interface
 type CLI struct {
-	Verbose  bool             `short:"v" help:"Enable verbose logging"`
-	Version  kong.VersionFlag `help:"Show version information"`
-	AES      cli.AESCmd       `cmd:"" help:"AES encryption operations"`
-	Bishop   cli.BishopCmd    `cmd:"" help:"Generate ASCII art using drunken bishop algorithm"`
-	Encode   cli.EncodeCmd    `cmd:"" help:"Text encoding/decoding operations"`
-	Haiku    cli.HaikuCmd     `cmd:"" help:"Haiku commands"`
-	Hash     cli.HashCmd      `cmd:"" help:"Hash operations"`
-	Password cli.PasswordCmd  `cmd:"" help:"Password operations"`
-	Serve    cli.ServeCmd     `cmd:"" help:"Start HTTP static file server"`
-	ULID     cli.ULIDCmd      `cmd:"" help:"ULID operations"`
+	Verbose      bool                `short:"v" help:"Enable verbose logging"`
+	VersionFlag  kong.VersionFlag    `name:"version" help:"Show version information"`
+	AES          cli.AESCmd          `cmd:"" help:"AES encryption operations"`
+	Audit        cli.AuditCmd        `cmd:"" help:"Tamper-evident audit log operations"`
+	Bishop       cli.BishopCmd       `cmd:"" help:"Generate ASCII art using drunken bishop algorithm"`
+	Capabilities cli.CapabilitiesCmd `cmd:"" help:"List the features supported by this build"`
+	DB           cli.DBCmd           `cmd:"" name:"db" help:"Database operations"`
+	Decode       cli.DecodeCmd       `cmd:"" help:"Decode and inspect tokens such as JWTs"`
+	Encode       cli.EncodeCmd       `cmd:"" help:"Text encoding/decoding operations"`
+	Entropy      cli.EntropyCmd      `cmd:"" help:"Estimate entropy and test randomness of data"`
+	Haiku        cli.HaikuCmd        `cmd:"" help:"Haiku commands"`
+	Hash         cli.HashCmd         `cmd:"" help:"Hash operations"`
+	Password     cli.PasswordCmd     `cmd:"" help:"Password operations"`
+	Paste        cli.PasteCmd        `cmd:"" help:"End-to-end encrypted pastebin client"`
+	Snowflake    cli.SnowflakeCmd    `cmd:"" help:"Snowflake ID operations"`
+	Serve        cli.ServeCmd        `cmd:"" help:"Serve or share files over HTTP"`
+	ULID         cli.ULIDCmd         `cmd:"" help:"ULID operations"`
+	UUID         cli.UUIDCmd         `cmd:"" help:"UUID operations"`
+	Version      cli.VersionCmd      `cmd:"" help:"Show version and build information, including the binary's SHA-256"`
 }
 
 func main() {
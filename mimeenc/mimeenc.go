@@ -0,0 +1,76 @@
+// Package mimeenc provides the encodings used in email: quoted-printable
+// (RFC 2045) for message bodies and encoded-words (RFC 2047) for headers such
+// as Subject and From. It is a thin layer over mime and mime/quotedprintable
+// that works on strings, which is convenient when debugging raw messages.
+//
+// Example usage:
+//
+//	// Encode a subject line
+//	subject := mimeenc.EncodeHeader("Café menu", mimeenc.QEncoding)
+//	fmt.Println(subject) // =?utf-8?q?Caf=C3=A9_menu?=
+//
+//	// Decode a From header
+//	from, err := mimeenc.DecodeHeader("=?ISO-8859-1?Q?Andr=E9?= <andre@example.com>")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(from) // André <andre@example.com>
+package mimeenc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"strings"
+)
+
+var (
+	// QEncoding writes encoded-words in the Q encoding, which keeps ASCII
+	// readable. It suits mostly Latin text.
+	QEncoding = mime.QEncoding
+
+	// BEncoding writes encoded-words in base64, which is shorter for
+	// mostly non-Latin text.
+	BEncoding = mime.BEncoding
+)
+
+// EncodeQuotedPrintable encodes data as quoted-printable, wrapping lines at
+// 76 characters with soft line breaks
+func EncodeQuotedPrintable(data []byte) string {
+	var buf bytes.Buffer
+	writer := quotedprintable.NewWriter(&buf)
+	// Writes to a bytes.Buffer do not fail
+	writer.Write(data)
+	writer.Close()
+	return buf.String()
+}
+
+// DecodeQuotedPrintable decodes quoted-printable text, joining soft line
+// breaks. Like most mail readers it is lenient, keeping malformed escapes as
+// they are.
+func DecodeQuotedPrintable(encoded string) ([]byte, error) {
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid quoted-printable input: %w", err)
+	}
+	return decoded, nil
+}
+
+// EncodeHeader encodes s as UTF-8 encoded-words for use in a header. ASCII
+// text without special characters is returned unchanged.
+func EncodeHeader(s string, encoding mime.WordEncoder) string {
+	return encoding.Encode("utf-8", s)
+}
+
+// DecodeHeader decodes the encoded-words in a header value, leaving the rest
+// of the text as is. The UTF-8, ISO-8859-1 and US-ASCII charsets are supported.
+func DecodeHeader(header string) (string, error) {
+	var decoder mime.WordDecoder
+	decoded, err := decoder.DecodeHeader(header)
+	if err != nil {
+		return "", fmt.Errorf("invalid encoded-word: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,85 @@
+package mimeenc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotedPrintable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "ascii", input: "Hello, World!", expected: "Hello, World!"},
+		{name: "utf-8", input: "Café = 3€", expected: "Caf=C3=A9 =3D 3=E2=82=AC"},
+		{name: "trailing space", input: "end ", expected: "end=20"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded := EncodeQuotedPrintable([]byte(tc.input))
+			require.Equal(t, tc.expected, encoded)
+
+			decoded, err := DecodeQuotedPrintable(encoded)
+			require.NoError(t, err)
+			require.Equal(t, tc.input, string(decoded))
+		})
+	}
+}
+
+func TestQuotedPrintable_LongLines(t *testing.T) {
+	input := strings.Repeat("abcdefghij", 20)
+	encoded := EncodeQuotedPrintable([]byte(input))
+
+	for _, line := range strings.Split(encoded, "\r\n") {
+		require.LessOrEqual(t, len(line), 76)
+	}
+
+	decoded, err := DecodeQuotedPrintable(encoded)
+	require.NoError(t, err)
+	require.Equal(t, input, string(decoded))
+}
+
+func TestDecodeQuotedPrintable_Lenient(t *testing.T) {
+	// Malformed escapes, as written by some mailers, are kept as they are
+	decoded, err := DecodeQuotedPrintable("bad =ZZ escape, soft=\r\nbreak")
+	require.NoError(t, err)
+	require.Equal(t, "bad =ZZ escape, softbreak", string(decoded))
+}
+
+func TestEncodeHeader(t *testing.T) {
+	require.Equal(t, "=?utf-8?q?Caf=C3=A9_menu?=", EncodeHeader("Café menu", QEncoding))
+	require.Equal(t, "=?utf-8?b?Q2Fmw6kgbWVudQ==?=", EncodeHeader("Café menu", BEncoding))
+	require.Equal(t, "Plain subject", EncodeHeader("Plain subject", QEncoding))
+}
+
+func TestDecodeHeader(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "q utf-8", input: "=?utf-8?q?Caf=C3=A9_menu?=", expected: "Café menu"},
+		{name: "b utf-8", input: "=?UTF-8?B?Q2Fmw6kgbWVudQ==?=", expected: "Café menu"},
+		{name: "latin-1 with address", input: "=?ISO-8859-1?Q?Andr=E9?= <andre@example.com>", expected: "André <andre@example.com>"},
+		{name: "adjacent words join", input: "=?utf-8?q?a?= =?utf-8?q?b?=", expected: "ab"},
+		{name: "plain", input: "Hello", expected: "Hello"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := DecodeHeader(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, decoded)
+		})
+	}
+}
+
+func TestDecodeHeader_UnknownCharset(t *testing.T) {
+	_, err := DecodeHeader("=?koi8-r?q?abc?=")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid encoded-word")
+}
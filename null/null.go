@@ -1,4 +1,5 @@
-// Package null provides utilities for converting sql.Null* types to pointers.
+// Package null provides utilities for converting between sql.Null* types, the generic
+// sql.Null[T], pgx's pgtype values and pointers.
 // These functions help bridge the gap between database NULL values and Go's pointer types,
 // making it easier to work with optional fields in structs and JSON serialization.
 //
@@ -14,6 +15,15 @@
 //	} else {
 //		fmt.Println("Value is NULL")
 //	}
+//
+//	// Bind an optional field as a query argument, NULL if nil
+//	_, err := db.ExecContext(ctx, "UPDATE users SET nickname = $1 WHERE id = $2",
+//		null.PtrToString(user.Nickname), user.ID)
+//
+//	// Or with the pgtype values of pgx
+//	var nickname pgtype.Text
+//	err = pool.QueryRow(ctx, "SELECT nickname FROM users WHERE id = $1", id).Scan(&nickname)
+//	user.Nickname = null.PgTextToPtr(nickname)
 package null
 
 import (
@@ -94,3 +104,76 @@ func BoolToPtr(nb sql.NullBool) *bool {
 	}
 	return nil
 }
+
+// PtrToString converts a *string to a sql.NullString.
+// Returns a valid sql.NullString holding the value if s is not nil, NULL otherwise.
+func PtrToString(s *string) sql.NullString {
+	if s != nil {
+		return sql.NullString{String: *s, Valid: true}
+	}
+	return sql.NullString{}
+}
+
+// PtrToInt64 converts a *int64 to a sql.NullInt64.
+// Returns a valid sql.NullInt64 holding the value if i is not nil, NULL otherwise.
+func PtrToInt64(i *int64) sql.NullInt64 {
+	if i != nil {
+		return sql.NullInt64{Int64: *i, Valid: true}
+	}
+	return sql.NullInt64{}
+}
+
+// PtrToInt32 converts a *int32 to a sql.NullInt32.
+// Returns a valid sql.NullInt32 holding the value if i is not nil, NULL otherwise.
+func PtrToInt32(i *int32) sql.NullInt32 {
+	if i != nil {
+		return sql.NullInt32{Int32: *i, Valid: true}
+	}
+	return sql.NullInt32{}
+}
+
+// PtrToInt16 converts a *int16 to a sql.NullInt16.
+// Returns a valid sql.NullInt16 holding the value if i is not nil, NULL otherwise.
+func PtrToInt16(i *int16) sql.NullInt16 {
+	if i != nil {
+		return sql.NullInt16{Int16: *i, Valid: true}
+	}
+	return sql.NullInt16{}
+}
+
+// PtrToFloat64 converts a *float64 to a sql.NullFloat64.
+// Returns a valid sql.NullFloat64 holding the value if f is not nil, NULL otherwise.
+func PtrToFloat64(f *float64) sql.NullFloat64 {
+	if f != nil {
+		return sql.NullFloat64{Float64: *f, Valid: true}
+	}
+	return sql.NullFloat64{}
+}
+
+// PtrToFloat32 converts a *float32 to a sql.NullFloat64 with type conversion.
+// Returns a valid sql.NullFloat64 holding the value if f is not nil, NULL otherwise.
+// The conversion to float64 is exact, so Float32ToPtr returns the original value.
+func PtrToFloat32(f *float32) sql.NullFloat64 {
+	if f != nil {
+		return sql.NullFloat64{Float64: float64(*f), Valid: true}
+	}
+	return sql.NullFloat64{}
+}
+
+// PtrToTime converts a *time.Time to a sql.NullTime.
+// Returns a valid sql.NullTime holding the value if t is not nil, NULL otherwise.
+func PtrToTime(t *time.Time) sql.NullTime {
+	if t != nil {
+		return sql.NullTime{Time: *t, Valid: true}
+	}
+	return sql.NullTime{}
+}
+
+// PtrToBool converts a *bool to a sql.NullBool.
+// Returns a valid sql.NullBool holding the value if b is not nil, NULL otherwise.
+func PtrToBool(b *bool) sql.NullBool {
+	if b != nil {
+		return sql.NullBool{Bool: *b, Valid: true}
+	}
+	return sql.NullBool{}
+}
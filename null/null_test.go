@@ -327,3 +327,140 @@ func TestBoolToPtr(t *testing.T) {
 		require.Nil(t, result)
 	})
 }
+
+func TestPtrToString(t *testing.T) {
+	t.Run("valid string", func(t *testing.T) {
+		result := null.PtrToString(ptr("hello"))
+		require.Equal(t, sql.NullString{String: "hello", Valid: true}, result)
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		// An empty string is a value, not NULL
+		result := null.PtrToString(ptr(""))
+		require.Equal(t, sql.NullString{String: "", Valid: true}, result)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		result := null.PtrToString(nil)
+		require.False(t, result.Valid)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		s := ptr("gopher")
+		require.Equal(t, s, null.StringToPtr(null.PtrToString(s)))
+		require.Nil(t, null.StringToPtr(null.PtrToString(nil)))
+	})
+}
+
+func TestPtrToInt64(t *testing.T) {
+	t.Run("valid value", func(t *testing.T) {
+		result := null.PtrToInt64(ptr(int64(9223372036854775807)))
+		require.Equal(t, sql.NullInt64{Int64: 9223372036854775807, Valid: true}, result)
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		result := null.PtrToInt64(ptr(int64(0)))
+		require.Equal(t, sql.NullInt64{Int64: 0, Valid: true}, result)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.False(t, null.PtrToInt64(nil).Valid)
+	})
+}
+
+func TestPtrToInt32(t *testing.T) {
+	t.Run("valid value", func(t *testing.T) {
+		result := null.PtrToInt32(ptr(int32(-42)))
+		require.Equal(t, sql.NullInt32{Int32: -42, Valid: true}, result)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.False(t, null.PtrToInt32(nil).Valid)
+	})
+}
+
+func TestPtrToInt16(t *testing.T) {
+	t.Run("valid value", func(t *testing.T) {
+		result := null.PtrToInt16(ptr(int16(32767)))
+		require.Equal(t, sql.NullInt16{Int16: 32767, Valid: true}, result)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.False(t, null.PtrToInt16(nil).Valid)
+	})
+}
+
+func TestPtrToFloat64(t *testing.T) {
+	t.Run("valid value", func(t *testing.T) {
+		result := null.PtrToFloat64(ptr(3.14159))
+		require.Equal(t, sql.NullFloat64{Float64: 3.14159, Valid: true}, result)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.False(t, null.PtrToFloat64(nil).Valid)
+	})
+}
+
+func TestPtrToFloat32(t *testing.T) {
+	t.Run("valid value", func(t *testing.T) {
+		val := float32(123.456)
+		result := null.PtrToFloat32(&val)
+		require.True(t, result.Valid)
+		require.Equal(t, float64(val), result.Float64)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		val := float32(-789.123)
+		require.Equal(t, val, *null.Float32ToPtr(null.PtrToFloat32(&val)))
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.False(t, null.PtrToFloat32(nil).Valid)
+	})
+}
+
+func TestPtrToTime(t *testing.T) {
+	t.Run("valid time", func(t *testing.T) {
+		now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		result := null.PtrToTime(&now)
+		require.Equal(t, sql.NullTime{Time: now, Valid: true}, result)
+	})
+
+	t.Run("zero time", func(t *testing.T) {
+		// The zero time is a value, not NULL
+		result := null.PtrToTime(&time.Time{})
+		require.True(t, result.Valid)
+		require.True(t, result.Time.IsZero())
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.False(t, null.PtrToTime(nil).Valid)
+	})
+}
+
+func TestPtrToBool(t *testing.T) {
+	t.Run("false value", func(t *testing.T) {
+		result := null.PtrToBool(ptr(false))
+		require.Equal(t, sql.NullBool{Bool: false, Valid: true}, result)
+	})
+
+	t.Run("true value", func(t *testing.T) {
+		result := null.PtrToBool(ptr(true))
+		require.Equal(t, sql.NullBool{Bool: true, Valid: true}, result)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.False(t, null.PtrToBool(nil).Valid)
+	})
+}
+
+func TestPtrToNullValues(t *testing.T) {
+	// The results bind as query arguments: the value, or nil for NULL
+	value, err := null.PtrToInt64(ptr(int64(7))).Value()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), value)
+
+	value, err = null.PtrToString(nil).Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
@@ -0,0 +1,212 @@
+package null
+
+import (
+	"time"
+
+	"github.com/bilte-co/toolshed/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PgTextToPtr converts a pgtype.Text to a *string.
+// Returns a pointer to the string value if Valid is true, nil otherwise.
+func PgTextToPtr(t pgtype.Text) *string {
+	if t.Valid {
+		return &t.String
+	}
+	return nil
+}
+
+// PtrToPgText converts a *string to a pgtype.Text.
+// Returns a valid pgtype.Text holding the value if s is not nil, NULL otherwise.
+func PtrToPgText(s *string) pgtype.Text {
+	if s != nil {
+		return pgtype.Text{String: *s, Valid: true}
+	}
+	return pgtype.Text{}
+}
+
+// PgInt8ToPtr converts a pgtype.Int8 (bigint) to a *int64.
+// Returns a pointer to the int64 value if Valid is true, nil otherwise.
+func PgInt8ToPtr(i pgtype.Int8) *int64 {
+	if i.Valid {
+		return &i.Int64
+	}
+	return nil
+}
+
+// PtrToPgInt8 converts a *int64 to a pgtype.Int8 (bigint).
+// Returns a valid pgtype.Int8 holding the value if i is not nil, NULL otherwise.
+func PtrToPgInt8(i *int64) pgtype.Int8 {
+	if i != nil {
+		return pgtype.Int8{Int64: *i, Valid: true}
+	}
+	return pgtype.Int8{}
+}
+
+// PgInt4ToPtr converts a pgtype.Int4 (integer) to a *int32.
+// Returns a pointer to the int32 value if Valid is true, nil otherwise.
+func PgInt4ToPtr(i pgtype.Int4) *int32 {
+	if i.Valid {
+		return &i.Int32
+	}
+	return nil
+}
+
+// PtrToPgInt4 converts a *int32 to a pgtype.Int4 (integer).
+// Returns a valid pgtype.Int4 holding the value if i is not nil, NULL otherwise.
+func PtrToPgInt4(i *int32) pgtype.Int4 {
+	if i != nil {
+		return pgtype.Int4{Int32: *i, Valid: true}
+	}
+	return pgtype.Int4{}
+}
+
+// PgInt2ToPtr converts a pgtype.Int2 (smallint) to a *int16.
+// Returns a pointer to the int16 value if Valid is true, nil otherwise.
+func PgInt2ToPtr(i pgtype.Int2) *int16 {
+	if i.Valid {
+		return &i.Int16
+	}
+	return nil
+}
+
+// PtrToPgInt2 converts a *int16 to a pgtype.Int2 (smallint).
+// Returns a valid pgtype.Int2 holding the value if i is not nil, NULL otherwise.
+func PtrToPgInt2(i *int16) pgtype.Int2 {
+	if i != nil {
+		return pgtype.Int2{Int16: *i, Valid: true}
+	}
+	return pgtype.Int2{}
+}
+
+// PgFloat8ToPtr converts a pgtype.Float8 (double precision) to a *float64.
+// Returns a pointer to the float64 value if Valid is true, nil otherwise.
+func PgFloat8ToPtr(f pgtype.Float8) *float64 {
+	if f.Valid {
+		return &f.Float64
+	}
+	return nil
+}
+
+// PtrToPgFloat8 converts a *float64 to a pgtype.Float8 (double precision).
+// Returns a valid pgtype.Float8 holding the value if f is not nil, NULL otherwise.
+func PtrToPgFloat8(f *float64) pgtype.Float8 {
+	if f != nil {
+		return pgtype.Float8{Float64: *f, Valid: true}
+	}
+	return pgtype.Float8{}
+}
+
+// PgFloat4ToPtr converts a pgtype.Float4 (real) to a *float32.
+// Returns a pointer to the float32 value if Valid is true, nil otherwise.
+func PgFloat4ToPtr(f pgtype.Float4) *float32 {
+	if f.Valid {
+		return &f.Float32
+	}
+	return nil
+}
+
+// PtrToPgFloat4 converts a *float32 to a pgtype.Float4 (real).
+// Returns a valid pgtype.Float4 holding the value if f is not nil, NULL otherwise.
+func PtrToPgFloat4(f *float32) pgtype.Float4 {
+	if f != nil {
+		return pgtype.Float4{Float32: *f, Valid: true}
+	}
+	return pgtype.Float4{}
+}
+
+// PgBoolToPtr converts a pgtype.Bool to a *bool.
+// Returns a pointer to the bool value if Valid is true, nil otherwise.
+func PgBoolToPtr(b pgtype.Bool) *bool {
+	if b.Valid {
+		return &b.Bool
+	}
+	return nil
+}
+
+// PtrToPgBool converts a *bool to a pgtype.Bool.
+// Returns a valid pgtype.Bool holding the value if b is not nil, NULL otherwise.
+func PtrToPgBool(b *bool) pgtype.Bool {
+	if b != nil {
+		return pgtype.Bool{Bool: *b, Valid: true}
+	}
+	return pgtype.Bool{}
+}
+
+// PgTimestamptzToPtr converts a pgtype.Timestamptz (timestamp with time zone) to a *time.Time.
+// Returns a pointer to the time.Time value if Valid is true, nil otherwise.
+// The infinity and -infinity timestamps have no time.Time equivalent and also return nil.
+func PgTimestamptzToPtr(t pgtype.Timestamptz) *time.Time {
+	if t.Valid && t.InfinityModifier == pgtype.Finite {
+		return &t.Time
+	}
+	return nil
+}
+
+// PtrToPgTimestamptz converts a *time.Time to a pgtype.Timestamptz (timestamp with time zone).
+// Returns a valid pgtype.Timestamptz holding the value if t is not nil, NULL otherwise.
+func PtrToPgTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t != nil {
+		return pgtype.Timestamptz{Time: *t, Valid: true}
+	}
+	return pgtype.Timestamptz{}
+}
+
+// PgTimestampToPtr converts a pgtype.Timestamp (timestamp without time zone) to a *time.Time.
+// Returns a pointer to the time.Time value if Valid is true, nil otherwise.
+// The infinity and -infinity timestamps have no time.Time equivalent and also return nil.
+func PgTimestampToPtr(t pgtype.Timestamp) *time.Time {
+	if t.Valid && t.InfinityModifier == pgtype.Finite {
+		return &t.Time
+	}
+	return nil
+}
+
+// PtrToPgTimestamp converts a *time.Time to a pgtype.Timestamp (timestamp without time zone).
+// Returns a valid pgtype.Timestamp holding the value if t is not nil, NULL otherwise.
+// The time is stored as its wall clock reading, discarding its location.
+func PtrToPgTimestamp(t *time.Time) pgtype.Timestamp {
+	if t != nil {
+		return pgtype.Timestamp{Time: *t, Valid: true}
+	}
+	return pgtype.Timestamp{}
+}
+
+// PgDateToPtr converts a pgtype.Date to a *time.Time.
+// Returns a pointer to the time.Time value if Valid is true, nil otherwise.
+// The infinity and -infinity dates have no time.Time equivalent and also return nil.
+func PgDateToPtr(d pgtype.Date) *time.Time {
+	if d.Valid && d.InfinityModifier == pgtype.Finite {
+		return &d.Time
+	}
+	return nil
+}
+
+// PtrToPgDate converts a *time.Time to a pgtype.Date.
+// Returns a valid pgtype.Date holding the value if t is not nil, NULL otherwise.
+// Only the year, month and day of the time are stored.
+func PtrToPgDate(t *time.Time) pgtype.Date {
+	if t != nil {
+		return pgtype.Date{Time: *t, Valid: true}
+	}
+	return pgtype.Date{}
+}
+
+// PgUUIDToPtr converts a pgtype.UUID to a *uuid.UUID.
+// Returns a pointer to the UUID value if Valid is true, nil otherwise.
+func PgUUIDToPtr(u pgtype.UUID) *uuid.UUID {
+	if u.Valid {
+		id := uuid.UUID(u.Bytes)
+		return &id
+	}
+	return nil
+}
+
+// PtrToPgUUID converts a *uuid.UUID to a pgtype.UUID.
+// Returns a valid pgtype.UUID holding the value if u is not nil, NULL otherwise.
+func PtrToPgUUID(u *uuid.UUID) pgtype.UUID {
+	if u != nil {
+		return pgtype.UUID{Bytes: *u, Valid: true}
+	}
+	return pgtype.UUID{}
+}
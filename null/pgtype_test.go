@@ -0,0 +1,126 @@
+package null_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/null"
+	"github.com/bilte-co/toolshed/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPgText(t *testing.T) {
+	t.Run("valid text", func(t *testing.T) {
+		result := null.PgTextToPtr(pgtype.Text{String: "hello", Valid: true})
+		require.NotNil(t, result)
+		require.Equal(t, "hello", *result)
+		require.Equal(t, pgtype.Text{String: "hello", Valid: true}, null.PtrToPgText(result))
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		result := null.PgTextToPtr(pgtype.Text{Valid: true})
+		require.NotNil(t, result)
+		require.Empty(t, *result)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		require.Nil(t, null.PgTextToPtr(pgtype.Text{String: "ignored"}))
+		require.False(t, null.PtrToPgText(nil).Valid)
+	})
+}
+
+func TestPgIntegers(t *testing.T) {
+	t.Run("int8", func(t *testing.T) {
+		require.Equal(t, ptr(int64(-9223372036854775808)), null.PgInt8ToPtr(pgtype.Int8{Int64: -9223372036854775808, Valid: true}))
+		require.Equal(t, pgtype.Int8{Int64: 42, Valid: true}, null.PtrToPgInt8(ptr(int64(42))))
+		require.Nil(t, null.PgInt8ToPtr(pgtype.Int8{}))
+		require.False(t, null.PtrToPgInt8(nil).Valid)
+	})
+
+	t.Run("int4", func(t *testing.T) {
+		require.Equal(t, ptr(int32(0)), null.PgInt4ToPtr(pgtype.Int4{Valid: true}))
+		require.Equal(t, pgtype.Int4{Int32: 42, Valid: true}, null.PtrToPgInt4(ptr(int32(42))))
+		require.Nil(t, null.PgInt4ToPtr(pgtype.Int4{Int32: 1}))
+		require.False(t, null.PtrToPgInt4(nil).Valid)
+	})
+
+	t.Run("int2", func(t *testing.T) {
+		require.Equal(t, ptr(int16(32767)), null.PgInt2ToPtr(pgtype.Int2{Int16: 32767, Valid: true}))
+		require.Equal(t, pgtype.Int2{Int16: -1, Valid: true}, null.PtrToPgInt2(ptr(int16(-1))))
+		require.Nil(t, null.PgInt2ToPtr(pgtype.Int2{}))
+		require.False(t, null.PtrToPgInt2(nil).Valid)
+	})
+}
+
+func TestPgFloats(t *testing.T) {
+	t.Run("float8", func(t *testing.T) {
+		require.Equal(t, ptr(3.14159), null.PgFloat8ToPtr(pgtype.Float8{Float64: 3.14159, Valid: true}))
+		require.Equal(t, pgtype.Float8{Float64: 1e100, Valid: true}, null.PtrToPgFloat8(ptr(1e100)))
+		require.Nil(t, null.PgFloat8ToPtr(pgtype.Float8{}))
+		require.False(t, null.PtrToPgFloat8(nil).Valid)
+	})
+
+	t.Run("float4", func(t *testing.T) {
+		require.Equal(t, ptr(float32(1.5)), null.PgFloat4ToPtr(pgtype.Float4{Float32: 1.5, Valid: true}))
+		require.Equal(t, pgtype.Float4{Float32: -2.25, Valid: true}, null.PtrToPgFloat4(ptr(float32(-2.25))))
+		require.Nil(t, null.PgFloat4ToPtr(pgtype.Float4{}))
+		require.False(t, null.PtrToPgFloat4(nil).Valid)
+	})
+}
+
+func TestPgBool(t *testing.T) {
+	require.Equal(t, ptr(false), null.PgBoolToPtr(pgtype.Bool{Valid: true}))
+	require.Equal(t, pgtype.Bool{Bool: true, Valid: true}, null.PtrToPgBool(ptr(true)))
+	require.Nil(t, null.PgBoolToPtr(pgtype.Bool{Bool: true}))
+	require.False(t, null.PtrToPgBool(nil).Valid)
+}
+
+func TestPgTimes(t *testing.T) {
+	ts := time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	t.Run("timestamptz", func(t *testing.T) {
+		require.Equal(t, &ts, null.PgTimestamptzToPtr(pgtype.Timestamptz{Time: ts, Valid: true}))
+		require.Equal(t, pgtype.Timestamptz{Time: ts, Valid: true}, null.PtrToPgTimestamptz(&ts))
+		require.Nil(t, null.PgTimestamptzToPtr(pgtype.Timestamptz{}))
+		require.False(t, null.PtrToPgTimestamptz(nil).Valid)
+	})
+
+	t.Run("timestamp", func(t *testing.T) {
+		require.Equal(t, &ts, null.PgTimestampToPtr(pgtype.Timestamp{Time: ts, Valid: true}))
+		require.Equal(t, pgtype.Timestamp{Time: ts, Valid: true}, null.PtrToPgTimestamp(&ts))
+		require.Nil(t, null.PgTimestampToPtr(pgtype.Timestamp{}))
+		require.False(t, null.PtrToPgTimestamp(nil).Valid)
+	})
+
+	t.Run("date", func(t *testing.T) {
+		day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, &day, null.PgDateToPtr(pgtype.Date{Time: day, Valid: true}))
+		require.Equal(t, pgtype.Date{Time: day, Valid: true}, null.PtrToPgDate(&day))
+		require.Nil(t, null.PgDateToPtr(pgtype.Date{}))
+		require.False(t, null.PtrToPgDate(nil).Valid)
+	})
+
+	t.Run("infinity", func(t *testing.T) {
+		require.Nil(t, null.PgTimestamptzToPtr(pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}))
+		require.Nil(t, null.PgTimestampToPtr(pgtype.Timestamp{InfinityModifier: pgtype.NegativeInfinity, Valid: true}))
+		require.Nil(t, null.PgDateToPtr(pgtype.Date{InfinityModifier: pgtype.Infinity, Valid: true}))
+	})
+}
+
+func TestPgUUID(t *testing.T) {
+	id, err := uuid.NewV7()
+	require.NoError(t, err)
+
+	t.Run("valid uuid", func(t *testing.T) {
+		result := null.PgUUIDToPtr(pgtype.UUID{Bytes: id, Valid: true})
+		require.NotNil(t, result)
+		require.Equal(t, id, *result)
+		require.Equal(t, pgtype.UUID{Bytes: id, Valid: true}, null.PtrToPgUUID(&id))
+	})
+
+	t.Run("null", func(t *testing.T) {
+		require.Nil(t, null.PgUUIDToPtr(pgtype.UUID{Bytes: id}))
+		require.False(t, null.PtrToPgUUID(nil).Valid)
+	})
+}
@@ -0,0 +1,100 @@
+package null
+
+import (
+	"database/sql"
+	"time"
+)
+
+// NullToPtr converts a sql.Null[T] to a *T.
+// Returns a pointer to the value if Valid is true, nil otherwise.
+func NullToPtr[T any](n sql.Null[T]) *T {
+	if n.Valid {
+		return &n.V
+	}
+	return nil
+}
+
+// PtrToNull converts a *T to a sql.Null[T].
+// Returns a valid sql.Null[T] holding the value if p is not nil, NULL otherwise.
+//
+// Example usage:
+//
+//	// Bind an optional field, or a pgtype value by way of a pointer
+//	nickname := null.PtrToNull(user.Nickname)
+//	deleted := null.PtrToNull(null.PgTimestamptzToPtr(row.DeletedAt))
+func PtrToNull[T any](p *T) sql.Null[T] {
+	if p != nil {
+		return sql.Null[T]{V: *p, Valid: true}
+	}
+	return sql.Null[T]{}
+}
+
+// StringToNull converts a sql.NullString to a sql.Null[string].
+func StringToNull(ns sql.NullString) sql.Null[string] {
+	return sql.Null[string]{V: ns.String, Valid: ns.Valid}
+}
+
+// NullToString converts a sql.Null[string] to a sql.NullString.
+func NullToString(n sql.Null[string]) sql.NullString {
+	return sql.NullString{String: n.V, Valid: n.Valid}
+}
+
+// Int64ToNull converts a sql.NullInt64 to a sql.Null[int64].
+func Int64ToNull(ni sql.NullInt64) sql.Null[int64] {
+	return sql.Null[int64]{V: ni.Int64, Valid: ni.Valid}
+}
+
+// NullToInt64 converts a sql.Null[int64] to a sql.NullInt64.
+func NullToInt64(n sql.Null[int64]) sql.NullInt64 {
+	return sql.NullInt64{Int64: n.V, Valid: n.Valid}
+}
+
+// Int32ToNull converts a sql.NullInt32 to a sql.Null[int32].
+func Int32ToNull(ni sql.NullInt32) sql.Null[int32] {
+	return sql.Null[int32]{V: ni.Int32, Valid: ni.Valid}
+}
+
+// NullToInt32 converts a sql.Null[int32] to a sql.NullInt32.
+func NullToInt32(n sql.Null[int32]) sql.NullInt32 {
+	return sql.NullInt32{Int32: n.V, Valid: n.Valid}
+}
+
+// Int16ToNull converts a sql.NullInt16 to a sql.Null[int16].
+func Int16ToNull(ni sql.NullInt16) sql.Null[int16] {
+	return sql.Null[int16]{V: ni.Int16, Valid: ni.Valid}
+}
+
+// NullToInt16 converts a sql.Null[int16] to a sql.NullInt16.
+func NullToInt16(n sql.Null[int16]) sql.NullInt16 {
+	return sql.NullInt16{Int16: n.V, Valid: n.Valid}
+}
+
+// Float64ToNull converts a sql.NullFloat64 to a sql.Null[float64].
+func Float64ToNull(nf sql.NullFloat64) sql.Null[float64] {
+	return sql.Null[float64]{V: nf.Float64, Valid: nf.Valid}
+}
+
+// NullToFloat64 converts a sql.Null[float64] to a sql.NullFloat64.
+func NullToFloat64(n sql.Null[float64]) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: n.V, Valid: n.Valid}
+}
+
+// TimeToNull converts a sql.NullTime to a sql.Null[time.Time].
+func TimeToNull(nt sql.NullTime) sql.Null[time.Time] {
+	return sql.Null[time.Time]{V: nt.Time, Valid: nt.Valid}
+}
+
+// NullToTime converts a sql.Null[time.Time] to a sql.NullTime.
+func NullToTime(n sql.Null[time.Time]) sql.NullTime {
+	return sql.NullTime{Time: n.V, Valid: n.Valid}
+}
+
+// BoolToNull converts a sql.NullBool to a sql.Null[bool].
+func BoolToNull(nb sql.NullBool) sql.Null[bool] {
+	return sql.Null[bool]{V: nb.Bool, Valid: nb.Valid}
+}
+
+// NullToBool converts a sql.Null[bool] to a sql.NullBool.
+func NullToBool(n sql.Null[bool]) sql.NullBool {
+	return sql.NullBool{Bool: n.V, Valid: n.Valid}
+}
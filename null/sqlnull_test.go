@@ -0,0 +1,107 @@
+package null_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullToPtr(t *testing.T) {
+	t.Run("valid value", func(t *testing.T) {
+		result := null.NullToPtr(sql.Null[string]{V: "hello", Valid: true})
+		require.NotNil(t, result)
+		require.Equal(t, "hello", *result)
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		result := null.NullToPtr(sql.Null[int]{Valid: true})
+		require.NotNil(t, result)
+		require.Zero(t, *result)
+	})
+
+	t.Run("null value", func(t *testing.T) {
+		require.Nil(t, null.NullToPtr(sql.Null[string]{V: "stale"}))
+	})
+}
+
+func TestPtrToNull(t *testing.T) {
+	t.Run("non-nil pointer", func(t *testing.T) {
+		require.Equal(t, sql.Null[int64]{V: 42, Valid: true}, null.PtrToNull(ptr(int64(42))))
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.Equal(t, sql.Null[time.Time]{}, null.PtrToNull[time.Time](nil))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		p := ptr("gopher")
+		require.Equal(t, p, null.NullToPtr(null.PtrToNull(p)))
+	})
+
+	t.Run("binds as query argument", func(t *testing.T) {
+		value, err := null.PtrToNull(ptr("gopher")).Value()
+		require.NoError(t, err)
+		require.Equal(t, "gopher", value)
+
+		value, err = null.PtrToNull[string](nil).Value()
+		require.NoError(t, err)
+		require.Nil(t, value)
+	})
+}
+
+func TestSQLNullAdapters(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("string", func(t *testing.T) {
+		ns := sql.NullString{String: "hello", Valid: true}
+		require.Equal(t, sql.Null[string]{V: "hello", Valid: true}, null.StringToNull(ns))
+		require.Equal(t, ns, null.NullToString(null.StringToNull(ns)))
+		require.False(t, null.StringToNull(sql.NullString{}).Valid)
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		ni := sql.NullInt64{Int64: -7, Valid: true}
+		require.Equal(t, sql.Null[int64]{V: -7, Valid: true}, null.Int64ToNull(ni))
+		require.Equal(t, ni, null.NullToInt64(null.Int64ToNull(ni)))
+	})
+
+	t.Run("int32", func(t *testing.T) {
+		ni := sql.NullInt32{Int32: 32, Valid: true}
+		require.Equal(t, sql.Null[int32]{V: 32, Valid: true}, null.Int32ToNull(ni))
+		require.Equal(t, ni, null.NullToInt32(null.Int32ToNull(ni)))
+	})
+
+	t.Run("int16", func(t *testing.T) {
+		ni := sql.NullInt16{Int16: 16, Valid: true}
+		require.Equal(t, sql.Null[int16]{V: 16, Valid: true}, null.Int16ToNull(ni))
+		require.Equal(t, ni, null.NullToInt16(null.Int16ToNull(ni)))
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		nf := sql.NullFloat64{Float64: 2.5, Valid: true}
+		require.Equal(t, sql.Null[float64]{V: 2.5, Valid: true}, null.Float64ToNull(nf))
+		require.Equal(t, nf, null.NullToFloat64(null.Float64ToNull(nf)))
+	})
+
+	t.Run("time", func(t *testing.T) {
+		nt := sql.NullTime{Time: now, Valid: true}
+		require.Equal(t, sql.Null[time.Time]{V: now, Valid: true}, null.TimeToNull(nt))
+		require.Equal(t, nt, null.NullToTime(null.TimeToNull(nt)))
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		nb := sql.NullBool{Bool: true, Valid: true}
+		require.Equal(t, sql.Null[bool]{V: true, Valid: true}, null.BoolToNull(nb))
+		require.Equal(t, nb, null.NullToBool(null.BoolToNull(nb)))
+	})
+
+	t.Run("null values stay null", func(t *testing.T) {
+		require.False(t, null.NullToString(sql.Null[string]{}).Valid)
+		require.False(t, null.NullToInt64(sql.Null[int64]{V: 1}).Valid)
+		require.False(t, null.NullToTime(sql.Null[time.Time]{V: now}).Valid)
+		require.False(t, null.BoolToNull(sql.NullBool{Bool: true}).Valid)
+	})
+}
@@ -0,0 +1,85 @@
+package null
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ValueOrZero returns the value p points to, or the zero value of T if p is nil.
+func ValueOrZero[T any](p *T) T {
+	if p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Coalesce returns the value p points to, or fallback if p is nil, like the
+// SQL COALESCE function.
+//
+// Example usage:
+//
+//	limit := null.Coalesce(req.Limit, 50)
+//	name := null.Coalesce(null.StringToPtr(row.Nickname), row.Username)
+func Coalesce[T any](p *T, fallback T) T {
+	if p != nil {
+		return *p
+	}
+	return fallback
+}
+
+// StringOrZero returns the value of a sql.NullString, or "" if it is NULL.
+func StringOrZero(ns sql.NullString) string {
+	if ns.Valid {
+		return ns.String
+	}
+	return ""
+}
+
+// Int64OrZero returns the value of a sql.NullInt64, or 0 if it is NULL.
+func Int64OrZero(ni sql.NullInt64) int64 {
+	if ni.Valid {
+		return ni.Int64
+	}
+	return 0
+}
+
+// Int32OrZero returns the value of a sql.NullInt32, or 0 if it is NULL.
+func Int32OrZero(ni sql.NullInt32) int32 {
+	if ni.Valid {
+		return ni.Int32
+	}
+	return 0
+}
+
+// Int16OrZero returns the value of a sql.NullInt16, or 0 if it is NULL.
+func Int16OrZero(ni sql.NullInt16) int16 {
+	if ni.Valid {
+		return ni.Int16
+	}
+	return 0
+}
+
+// Float64OrZero returns the value of a sql.NullFloat64, or 0 if it is NULL.
+func Float64OrZero(nf sql.NullFloat64) float64 {
+	if nf.Valid {
+		return nf.Float64
+	}
+	return 0
+}
+
+// TimeOrZero returns the value of a sql.NullTime, or the zero time.Time if it is NULL.
+func TimeOrZero(nt sql.NullTime) time.Time {
+	if nt.Valid {
+		return nt.Time
+	}
+	return time.Time{}
+}
+
+// BoolOrZero returns the value of a sql.NullBool, or false if it is NULL.
+func BoolOrZero(nb sql.NullBool) bool {
+	if nb.Valid {
+		return nb.Bool
+	}
+	return false
+}
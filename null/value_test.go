@@ -0,0 +1,69 @@
+package null_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueOrZero(t *testing.T) {
+	t.Run("non-nil pointer", func(t *testing.T) {
+		require.Equal(t, "hello", null.ValueOrZero(ptr("hello")))
+		require.Equal(t, 42, null.ValueOrZero(ptr(42)))
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.Equal(t, "", null.ValueOrZero[string](nil))
+		require.Equal(t, time.Time{}, null.ValueOrZero[time.Time](nil))
+	})
+
+	t.Run("composes with sql.Null conversions", func(t *testing.T) {
+		require.Equal(t, int64(7), null.ValueOrZero(null.Int64ToPtr(sql.NullInt64{Int64: 7, Valid: true})))
+		require.Zero(t, null.ValueOrZero(null.Int64ToPtr(sql.NullInt64{Int64: 7})))
+	})
+}
+
+func TestCoalesce(t *testing.T) {
+	t.Run("non-nil pointer", func(t *testing.T) {
+		require.Equal(t, 10, null.Coalesce(ptr(10), 50))
+	})
+
+	t.Run("zero value is kept", func(t *testing.T) {
+		// Only nil falls back, not zero values
+		require.Equal(t, 0, null.Coalesce(ptr(0), 50))
+		require.Equal(t, "", null.Coalesce(ptr(""), "anonymous"))
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		require.Equal(t, 50, null.Coalesce(nil, 50))
+		require.Equal(t, "anonymous", null.Coalesce(null.StringToPtr(sql.NullString{}), "anonymous"))
+	})
+}
+
+func TestOrZero(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("valid values", func(t *testing.T) {
+		require.Equal(t, "hello", null.StringOrZero(sql.NullString{String: "hello", Valid: true}))
+		require.Equal(t, int64(-5), null.Int64OrZero(sql.NullInt64{Int64: -5, Valid: true}))
+		require.Equal(t, int32(32), null.Int32OrZero(sql.NullInt32{Int32: 32, Valid: true}))
+		require.Equal(t, int16(16), null.Int16OrZero(sql.NullInt16{Int16: 16, Valid: true}))
+		require.Equal(t, 2.5, null.Float64OrZero(sql.NullFloat64{Float64: 2.5, Valid: true}))
+		require.Equal(t, now, null.TimeOrZero(sql.NullTime{Time: now, Valid: true}))
+		require.True(t, null.BoolOrZero(sql.NullBool{Bool: true, Valid: true}))
+	})
+
+	t.Run("null values", func(t *testing.T) {
+		// Values left in invalid fields are ignored
+		require.Empty(t, null.StringOrZero(sql.NullString{String: "stale"}))
+		require.Zero(t, null.Int64OrZero(sql.NullInt64{Int64: 1}))
+		require.Zero(t, null.Int32OrZero(sql.NullInt32{Int32: 1}))
+		require.Zero(t, null.Int16OrZero(sql.NullInt16{Int16: 1}))
+		require.Zero(t, null.Float64OrZero(sql.NullFloat64{Float64: 1}))
+		require.True(t, null.TimeOrZero(sql.NullTime{Time: now}).IsZero())
+		require.False(t, null.BoolOrZero(sql.NullBool{Bool: true}))
+	})
+}
@@ -0,0 +1,133 @@
+package password
+
+import (
+	"fmt"
+	"math"
+	"unicode"
+
+	passwordvalidator "github.com/wagslane/go-password-validator"
+)
+
+// GuessesPerSecond is the attacker guess rate assumed by crack time estimates.
+// It models an offline attack against a fast, unsalted hash on commodity GPUs.
+const GuessesPerSecond = 1e10
+
+// CharacterClasses counts the characters of a password by class.
+type CharacterClasses struct {
+	Lowercase int `json:"lowercase"`
+	Uppercase int `json:"uppercase"`
+	Digits    int `json:"digits"`
+	Symbols   int `json:"symbols"`
+	Spaces    int `json:"spaces"`
+	Other     int `json:"other"`
+}
+
+// Count returns the number of distinct character classes present.
+func (c CharacterClasses) Count() int {
+	count := 0
+	for _, n := range []int{c.Lowercase, c.Uppercase, c.Digits, c.Symbols, c.Spaces, c.Other} {
+		if n > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// Analysis describes the strength of a password. It never contains the password itself.
+type Analysis struct {
+	Length           int              `json:"length"`
+	Entropy          float64          `json:"entropy"`
+	CrackTimeSeconds float64          `json:"crack_time_seconds"`
+	CrackTime        string           `json:"crack_time"`
+	Classes          CharacterClasses `json:"character_classes"`
+}
+
+// Analyze computes the entropy, estimated crack time and character class
+// breakdown of a password. The entropy is the same value used by Check.
+func Analyze(password string) Analysis {
+	entropy := passwordvalidator.GetEntropy(password)
+	seconds := CrackTimeSeconds(entropy)
+
+	return Analysis{
+		Length:           len([]rune(password)),
+		Entropy:          entropy,
+		CrackTimeSeconds: seconds,
+		CrackTime:        FormatCrackTime(seconds),
+		Classes:          classify(password),
+	}
+}
+
+// CrackTimeSeconds estimates the average time in seconds needed to guess a
+// password with the given entropy at GuessesPerSecond. On average an attacker
+// searches half of the keyspace.
+func CrackTimeSeconds(entropy float64) float64 {
+	if entropy <= 0 {
+		return 0
+	}
+	seconds := math.Pow(2, entropy-1) / GuessesPerSecond
+	if math.IsInf(seconds, 0) {
+		return math.MaxFloat64
+	}
+	return seconds
+}
+
+// FormatCrackTime renders a crack time in seconds as a rounded human-readable duration.
+func FormatCrackTime(seconds float64) string {
+	const (
+		minute  = 60.0
+		hour    = 60 * minute
+		day     = 24 * hour
+		month   = 30 * day
+		year    = 365 * day
+		century = 100 * year
+	)
+
+	switch {
+	case seconds < 1:
+		return "less than a second"
+	case seconds < minute:
+		return pluralize(seconds, "second")
+	case seconds < hour:
+		return pluralize(seconds/minute, "minute")
+	case seconds < day:
+		return pluralize(seconds/hour, "hour")
+	case seconds < month:
+		return pluralize(seconds/day, "day")
+	case seconds < year:
+		return pluralize(seconds/month, "month")
+	case seconds < century:
+		return pluralize(seconds/year, "year")
+	default:
+		return "centuries"
+	}
+}
+
+func pluralize(value float64, unit string) string {
+	n := int64(math.Round(value))
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// classify counts the characters of a password by class.
+func classify(password string) CharacterClasses {
+	var classes CharacterClasses
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			classes.Lowercase++
+		case r >= 'A' && r <= 'Z':
+			classes.Uppercase++
+		case r >= '0' && r <= '9':
+			classes.Digits++
+		case r == ' ':
+			classes.Spaces++
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			classes.Symbols++
+		default:
+			classes.Other++
+		}
+	}
+	return classes
+}
@@ -0,0 +1,83 @@
+package password
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_CharacterClasses(t *testing.T) {
+	a := Analyze("abcDE12!? é")
+
+	require.Equal(t, 11, a.Length)
+	require.Equal(t, CharacterClasses{
+		Lowercase: 3,
+		Uppercase: 2,
+		Digits:    2,
+		Symbols:   2,
+		Spaces:    1,
+		Other:     1,
+	}, a.Classes)
+	require.Equal(t, 6, a.Classes.Count())
+}
+
+func TestAnalyze_EntropyMatchesCheck(t *testing.T) {
+	weak := Analyze("password")
+	ok, _ := Check("password")
+	require.False(t, ok)
+	require.Less(t, weak.Entropy, DefaultEntropy)
+
+	strong := Analyze("G@7e*vS93^8!bdT2")
+	ok, _ = Check("G@7e*vS93^8!bdT2")
+	require.True(t, ok)
+	require.GreaterOrEqual(t, strong.Entropy, DefaultEntropy)
+	require.Greater(t, strong.CrackTimeSeconds, weak.CrackTimeSeconds)
+}
+
+func TestAnalyze_Empty(t *testing.T) {
+	a := Analyze("")
+	require.Zero(t, a.Length)
+	require.Zero(t, a.Entropy)
+	require.Zero(t, a.CrackTimeSeconds)
+	require.Equal(t, "less than a second", a.CrackTime)
+}
+
+func TestCrackTimeSeconds(t *testing.T) {
+	require.Zero(t, CrackTimeSeconds(0))
+	require.InDelta(t, math.Pow(2, 39)/GuessesPerSecond, CrackTimeSeconds(40), 1e-9)
+
+	// Huge entropy must stay finite so the result can be JSON-encoded
+	huge := CrackTimeSeconds(5000)
+	require.False(t, math.IsInf(huge, 0))
+	long := make([]byte, 0, 400)
+	for i := 0; i < 400; i++ {
+		long = append(long, byte('!'+i%90))
+	}
+	_, err := json.Marshal(Analyze(string(long)))
+	require.NoError(t, err)
+}
+
+func TestFormatCrackTime(t *testing.T) {
+	tests := []struct {
+		seconds  float64
+		expected string
+	}{
+		{0.5, "less than a second"},
+		{1, "1 second"},
+		{45, "45 seconds"},
+		{90, "2 minutes"},
+		{3 * 3600, "3 hours"},
+		{86400, "1 day"},
+		{60 * 86400, "2 months"},
+		{5 * 365 * 86400, "5 years"},
+		{1000 * 365 * 86400, "centuries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			require.Equal(t, tt.expected, FormatCrackTime(tt.seconds))
+		})
+	}
+}
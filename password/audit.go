@@ -0,0 +1,149 @@
+package password
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// AuditOptions configures a bulk password audit.
+type AuditOptions struct {
+	// MinEntropy is the minimum entropy a password must have. Defaults to DefaultEntropy.
+	MinEntropy float64
+	// Workers is the number of concurrent workers. Defaults to the number of CPU cores.
+	Workers int
+	// Pwned, if set, is used to check each password against known data breaches.
+	Pwned *PwnedClient
+}
+
+// AuditResult is the outcome of auditing a single password. It never contains
+// the password itself; Index identifies its position in the input.
+type AuditResult struct {
+	Index      int       `json:"index"`
+	Valid      bool      `json:"valid"`
+	Entropy    float64   `json:"entropy"`
+	Patterns   []Pattern `json:"patterns"`
+	PwnedCount int       `json:"pwned_count,omitempty"`
+	Reused     bool      `json:"reused,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Compromised reports whether the password failed any check.
+func (r AuditResult) Compromised() bool {
+	return !r.Valid || r.PwnedCount > 0 || r.Reused
+}
+
+// AuditReport summarises a bulk password audit.
+type AuditReport struct {
+	MinEntropy  float64       `json:"min_entropy"`
+	BreachCheck bool          `json:"breach_check"`
+	Total       int           `json:"total"`
+	Weak        int           `json:"weak"`
+	Pwned       int           `json:"pwned"`
+	Reused      int           `json:"reused"`
+	Errors      int           `json:"errors"`
+	Results     []AuditResult `json:"results"`
+}
+
+// Passed reports whether every password passed every check.
+func (r *AuditReport) Passed() bool {
+	return r.Weak == 0 && r.Pwned == 0 && r.Reused == 0 && r.Errors == 0
+}
+
+// Audit checks many passwords concurrently for sufficient entropy, predictable
+// patterns, reuse within the list and, if opts.Pwned is set, known breaches.
+// Results are returned in input order.
+func Audit(ctx context.Context, passwords []string, opts AuditOptions) *AuditReport {
+	minEntropy := opts.MinEntropy
+	if minEntropy <= 0 {
+		minEntropy = DefaultEntropy
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	report := &AuditReport{
+		MinEntropy:  minEntropy,
+		BreachCheck: opts.Pwned != nil,
+		Total:       len(passwords),
+		Results:     make([]AuditResult, len(passwords)),
+	}
+
+	// Reuse is detected up front so each distinct password is only sent for a breach check once
+	seen := make(map[string]int, len(passwords))
+	for i, pw := range passwords {
+		if first, ok := seen[pw]; ok {
+			report.Results[i].Reused = true
+			report.Results[first].Reused = true
+			continue
+		}
+		seen[pw] = i
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				auditOne(ctx, passwords[i], i, minEntropy, opts.Pwned, seen, &report.Results[i])
+			}
+		}()
+	}
+
+	for i := range passwords {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Duplicates share the breach result of the first occurrence
+	for i, pw := range passwords {
+		if first := seen[pw]; first != i {
+			report.Results[i].PwnedCount = report.Results[first].PwnedCount
+			report.Results[i].Error = report.Results[first].Error
+		}
+	}
+
+	for _, r := range report.Results {
+		if !r.Valid {
+			report.Weak++
+		}
+		if r.PwnedCount > 0 {
+			report.Pwned++
+		}
+		if r.Reused {
+			report.Reused++
+		}
+		if r.Error != "" {
+			report.Errors++
+		}
+	}
+
+	return report
+}
+
+// auditOne fills in the audit result for a single password.
+func auditOne(ctx context.Context, pw string, index int, minEntropy float64, pwned *PwnedClient, seen map[string]int, result *AuditResult) {
+	analysis := Analyze(pw)
+	valid, _ := CheckEntropy(pw, minEntropy)
+
+	result.Index = index
+	result.Valid = valid
+	result.Entropy = analysis.Entropy
+	result.Patterns = GetFeedback(pw).Patterns
+
+	// Only the first occurrence of a password is checked against the API
+	if pwned == nil || seen[pw] != index {
+		return
+	}
+
+	count, err := pwned.Check(ctx, pw)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	result.PwnedCount = count
+}
@@ -0,0 +1,82 @@
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit_WeakAndStrong(t *testing.T) {
+	passwords := []string{"password", "MyStr0ng!P@ssw0rd2024xyz", "abc123"}
+
+	report := Audit(context.Background(), passwords, AuditOptions{Workers: 2})
+	require.Equal(t, 3, report.Total)
+	require.Equal(t, 2, report.Weak)
+	require.Zero(t, report.Reused)
+	require.False(t, report.BreachCheck)
+	require.Equal(t, DefaultEntropy, report.MinEntropy)
+	require.False(t, report.Passed())
+
+	require.Len(t, report.Results, 3)
+	for i, r := range report.Results {
+		require.Equal(t, i, r.Index)
+	}
+	require.False(t, report.Results[0].Valid)
+	require.Contains(t, report.Results[0].Patterns, PatternDictionary)
+	require.True(t, report.Results[1].Valid)
+	require.False(t, report.Results[2].Valid)
+}
+
+func TestAudit_Reused(t *testing.T) {
+	strong := "correct horse battery staple 42!"
+	passwords := []string{strong, "Another$trongOne-9182736", strong}
+
+	report := Audit(context.Background(), passwords, AuditOptions{})
+	require.Zero(t, report.Weak)
+	require.Equal(t, 2, report.Reused)
+	require.True(t, report.Results[0].Reused)
+	require.False(t, report.Results[1].Reused)
+	require.True(t, report.Results[2].Reused)
+	require.True(t, report.Results[0].Compromised())
+	require.False(t, report.Passed())
+}
+
+func TestAudit_CustomEntropy(t *testing.T) {
+	report := Audit(context.Background(), []string{"simplepass"}, AuditOptions{MinEntropy: 20})
+	require.Equal(t, 20.0, report.MinEntropy)
+	require.True(t, report.Passed())
+}
+
+func TestAudit_Pwned(t *testing.T) {
+	server := newPwnedServer(t, map[string]int{"Tr0ub4dor&3-horse-staple": 42}, nil)
+	defer server.Close()
+
+	passwords := []string{"Tr0ub4dor&3-horse-staple", "k8#Vq!zR2@wLp9$mXn4&", "Tr0ub4dor&3-horse-staple"}
+	client := &PwnedClient{BaseURL: server.URL + "/range/"}
+
+	report := Audit(context.Background(), passwords, AuditOptions{Pwned: client})
+	require.True(t, report.BreachCheck)
+	require.Equal(t, 2, report.Pwned)
+	require.Equal(t, 42, report.Results[0].PwnedCount)
+	require.Zero(t, report.Results[1].PwnedCount)
+	// Duplicates share the result of the first occurrence
+	require.Equal(t, 42, report.Results[2].PwnedCount)
+	require.Zero(t, report.Errors)
+}
+
+func TestAudit_PwnedErrors(t *testing.T) {
+	client := &PwnedClient{Offline: true}
+
+	report := Audit(context.Background(), []string{"k8#Vq!zR2@wLp9$mXn4&"}, AuditOptions{Pwned: client})
+	require.Equal(t, 1, report.Errors)
+	require.Contains(t, report.Results[0].Error, ErrOffline.Error())
+	require.False(t, report.Passed())
+}
+
+func TestAudit_Empty(t *testing.T) {
+	report := Audit(context.Background(), nil, AuditOptions{})
+	require.Zero(t, report.Total)
+	require.Empty(t, report.Results)
+	require.True(t, report.Passed())
+}
@@ -0,0 +1,231 @@
+package password
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Pattern identifies a weakness detected in a password.
+type Pattern string
+
+const (
+	// PatternDictionary is a common password or word, possibly with l33t substitutions.
+	PatternDictionary Pattern = "dictionary"
+	// PatternDate is a year or calendar date.
+	PatternDate Pattern = "date"
+	// PatternKeyboard is a straight walk along a keyboard row, such as "qwerty".
+	PatternKeyboard Pattern = "keyboard"
+	// PatternSequence is a run of consecutive characters, such as "abcd" or "4321".
+	PatternSequence Pattern = "sequence"
+	// PatternRepeat is a repeated character or chunk, such as "aaa" or "abcabc".
+	PatternRepeat Pattern = "repeat"
+	// PatternShort is a password shorter than MinRecommendedLength.
+	PatternShort Pattern = "short"
+)
+
+// MinRecommendedLength is the length below which feedback suggests adding more words.
+const MinRecommendedLength = 12
+
+// Feedback contains human-readable guidance for strengthening a password.
+type Feedback struct {
+	// Warning explains the most significant weakness, if any.
+	Warning string `json:"warning,omitempty"`
+	// Suggestions lists concrete ways to improve the password.
+	Suggestions []string `json:"suggestions"`
+	// Patterns lists the weaknesses that were detected.
+	Patterns []Pattern `json:"patterns"`
+}
+
+// commonWords are frequently used passwords and password fragments.
+var commonWords = []string{
+	"password", "passwd", "qwerty", "letmein", "welcome", "admin", "login",
+	"master", "dragon", "monkey", "shadow", "sunshine", "princess", "football",
+	"baseball", "soccer", "hockey", "superman", "batman", "starwars", "iloveyou",
+	"trustno1", "secret", "freedom", "whatever", "michael", "jordan", "charlie",
+	"summer", "winter", "spring", "autumn", "hello", "love", "angel", "flower",
+	"computer", "internet", "pokemon", "cheese", "cookie", "killer", "hunter",
+	"ranger", "buster", "tigger", "ginger", "pepper", "orange", "banana",
+	"google", "apple", "test", "guest", "user", "root", "changeme", "default",
+}
+
+// leetReplacer undoes common character substitutions.
+var leetReplacer = strings.NewReplacer(
+	"@", "a", "4", "a", "8", "b", "(", "c", "3", "e", "6", "g", "1", "i",
+	"!", "i", "|", "l", "0", "o", "$", "s", "5", "s", "7", "t", "+", "t", "2", "z",
+)
+
+// keyboardRows are the rows of a US QWERTY keyboard, unshifted.
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+}
+
+var (
+	yearPattern = regexp.MustCompile(`(19|20)\d\d`)
+	datePattern = regexp.MustCompile(`\d{1,4}[-/._]\d{1,2}[-/._]\d{1,4}|(0[1-9]|[12]\d|3[01])(0[1-9]|1[0-2])\d\d|(0[1-9]|1[0-2])(0[1-9]|[12]\d|3[01])\d\d`)
+)
+
+// minPatternLength is the minimum run length for keyboard walks and sequences.
+const minPatternLength = 4
+
+// GetFeedback analyses a password for predictable patterns such as dictionary
+// words, dates, keyboard walks, sequences and repeats, and returns
+// suggestions for making it stronger in the style of zxcvbn.
+func GetFeedback(password string) Feedback {
+	feedback := Feedback{Suggestions: []string{}, Patterns: []Pattern{}}
+	if password == "" {
+		feedback.Warning = "The password is empty"
+		feedback.Suggestions = append(feedback.Suggestions, "Use a few words, avoid common phrases")
+		return feedback
+	}
+
+	lower := strings.ToLower(password)
+	unleet := leetReplacer.Replace(lower)
+
+	addPattern := func(p Pattern, warning string, suggestions ...string) {
+		feedback.Patterns = append(feedback.Patterns, p)
+		if feedback.Warning == "" {
+			feedback.Warning = warning
+		}
+		feedback.Suggestions = append(feedback.Suggestions, suggestions...)
+	}
+
+	if word, leet := containsCommonWord(lower, unleet); word != "" {
+		suggestions := []string{"Avoid common words and passwords"}
+		if leet {
+			suggestions = append(suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much")
+		}
+		if startsUpperOnly(password) {
+			suggestions = append(suggestions, "Capitalization doesn't help very much")
+		}
+		addPattern(PatternDictionary, "This is similar to a commonly used password", suggestions...)
+	}
+
+	if datePattern.MatchString(password) || yearPattern.MatchString(password) {
+		addPattern(PatternDate, "Dates are often easy to guess",
+			"Avoid dates and years that are associated with you")
+	}
+
+	if hasKeyboardWalk(lower) {
+		addPattern(PatternKeyboard, "Straight rows of keys are easy to guess",
+			"Avoid keyboard patterns like \"qwerty\" or \"asdf\"")
+	}
+
+	if hasSequence(lower) {
+		addPattern(PatternSequence, "Sequences like \"abc\" or \"6543\" are easy to guess",
+			"Avoid sequential characters")
+	}
+
+	if hasRepeat(lower) {
+		addPattern(PatternRepeat, "Repeats like \"aaa\" or \"abcabc\" are easy to guess",
+			"Avoid repeated words and characters")
+	}
+
+	if len([]rune(password)) < MinRecommendedLength {
+		addPattern(PatternShort, "Short passwords are easy to guess",
+			"Add another word or two. Uncommon words are better")
+	}
+
+	return feedback
+}
+
+// containsCommonWord returns the first common word found in the password and
+// whether it was only found after undoing l33t substitutions.
+func containsCommonWord(lower, unleet string) (string, bool) {
+	for _, word := range commonWords {
+		if strings.Contains(lower, word) {
+			return word, false
+		}
+	}
+	for _, word := range commonWords {
+		if strings.Contains(unleet, word) {
+			return word, true
+		}
+	}
+	return "", false
+}
+
+// startsUpperOnly reports whether only the first letter is capitalised.
+func startsUpperOnly(password string) bool {
+	for i, r := range password {
+		if i == 0 {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			continue
+		}
+		if unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasKeyboardWalk reports whether the password contains minPatternLength or
+// more adjacent keys from one keyboard row, in either direction.
+func hasKeyboardWalk(lower string) bool {
+	for _, row := range keyboardRows {
+		reversed := reverse(row)
+		for i := 0; i+minPatternLength <= len(row); i++ {
+			if strings.Contains(lower, row[i:i+minPatternLength]) ||
+				strings.Contains(lower, reversed[i:i+minPatternLength]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSequence reports whether the password contains minPatternLength or more
+// characters with consecutive code points, ascending or descending.
+func hasSequence(lower string) bool {
+	runes := []rune(lower)
+	run := 1
+	delta := rune(0)
+	for i := 1; i < len(runes); i++ {
+		d := runes[i] - runes[i-1]
+		switch {
+		case d != 1 && d != -1:
+			run, delta = 1, 0
+		case d == delta:
+			run++
+		default:
+			run, delta = 2, d
+		}
+		if run >= minPatternLength {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRepeat reports whether the password contains a character repeated three
+// times in a row or a chunk of two or more characters repeated back to back.
+func hasRepeat(lower string) bool {
+	runes := []rune(lower)
+	for i := 2; i < len(runes); i++ {
+		if runes[i] == runes[i-1] && runes[i] == runes[i-2] {
+			return true
+		}
+	}
+
+	for size := 2; size*2 <= len(runes); size++ {
+		for i := 0; i+size*2 <= len(runes); i++ {
+			if string(runes[i:i+size]) == string(runes[i+size:i+size*2]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,64 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFeedback_Patterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		pattern  Pattern
+	}{
+		{"dictionary word", "correcthorsepassword", PatternDictionary},
+		{"l33t dictionary word", "Gr3@tP@$$w0rdXyz", PatternDictionary},
+		{"year", "correcthorse1987", PatternDate},
+		{"slash date", "born 12/31/99 here", PatternDate},
+		{"compact date", "zebra311299mango", PatternDate},
+		{"keyboard walk", "zebra-asdf-mango", PatternKeyboard},
+		{"reverse keyboard walk", "zebra-fdsa-mango", PatternKeyboard},
+		{"ascending sequence", "zebra-lmno-mango", PatternSequence},
+		{"descending sequence", "zebra-9876-mango", PatternSequence},
+		{"repeated character", "zebraaaamango", PatternRepeat},
+		{"repeated chunk", "zebraxyzxyzmango", PatternRepeat},
+		{"short", "Zq8!", PatternShort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedback := GetFeedback(tt.password)
+			require.Contains(t, feedback.Patterns, tt.pattern)
+			require.NotEmpty(t, feedback.Warning)
+			require.NotEmpty(t, feedback.Suggestions)
+		})
+	}
+}
+
+func TestGetFeedback_StrongPassword(t *testing.T) {
+	feedback := GetFeedback("G@7e*vS93^8!bdT2wq")
+	require.Empty(t, feedback.Patterns)
+	require.Empty(t, feedback.Warning)
+	require.Empty(t, feedback.Suggestions)
+}
+
+func TestGetFeedback_Suggestions(t *testing.T) {
+	feedback := GetFeedback("P@ssword")
+	require.Equal(t, "This is similar to a commonly used password", feedback.Warning)
+	require.Contains(t, feedback.Suggestions, "Avoid common words and passwords")
+	require.Contains(t, feedback.Suggestions, "Capitalization doesn't help very much")
+	require.Contains(t, feedback.Suggestions, "Add another word or two. Uncommon words are better")
+
+	feedback = GetFeedback("p4ssw0rdzebramango")
+	require.Contains(t, feedback.Suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much")
+
+	feedback = GetFeedback("qwerty123456")
+	require.Contains(t, feedback.Suggestions, "Avoid sequential characters")
+}
+
+func TestGetFeedback_Empty(t *testing.T) {
+	feedback := GetFeedback("")
+	require.Equal(t, "The password is empty", feedback.Warning)
+	require.NotEmpty(t, feedback.Suggestions)
+}
@@ -15,6 +15,27 @@
 //	if !valid {
 //		fmt.Printf("Password validation failed: %s\n", msg)
 //	}
+//
+//	// Get suggestions for making a password harder to guess
+//	feedback := password.GetFeedback("Summer2024!")
+//	for _, suggestion := range feedback.Suggestions {
+//		fmt.Println(suggestion)
+//	}
+//
+//	// Check whether the password appears in known data breaches
+//	count, err := password.CheckPwned(ctx, "myPassword")
+//	if err == nil && count > 0 {
+//		fmt.Printf("Password has been seen %d times in breaches\n", count)
+//	}
+//
+//	// Check compliance with NIST SP 800-63B, including breached password screening
+//	result, err := password.NIST.Evaluate(ctx, "myPassword", nil)
+//	if err == nil && !result.Compliant {
+//		fmt.Println(result.Violations[0].Message)
+//	}
+//
+//	// Generate a 6 digit one-time code
+//	pin, err := password.GeneratePIN(6)
 package password
 
 import (
@@ -0,0 +1,45 @@
+package password
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// MaxPINDigits is the maximum number of digits GeneratePIN accepts.
+const MaxPINDigits = 64
+
+// pinRandReader is the source of randomness for GeneratePIN. It is a variable so tests can replace it.
+var pinRandReader io.Reader = rand.Reader
+
+// pinRejectThreshold is the largest multiple of 10 that fits in a byte. Bytes at
+// or above it are discarded so that every digit is equally likely.
+const pinRejectThreshold = 250
+
+// GeneratePIN returns a random numeric code with the given number of digits,
+// suitable for one-time codes and PINs. Digits are drawn from crypto/rand using
+// rejection sampling, so there is no modulo bias. Leading zeros are preserved.
+func GeneratePIN(digits int) (string, error) {
+	if digits < 1 || digits > MaxPINDigits {
+		return "", fmt.Errorf("invalid PIN length: %d (must be between 1 and %d)", digits, MaxPINDigits)
+	}
+
+	pin := make([]byte, 0, digits)
+	buf := make([]byte, digits)
+	for len(pin) < digits {
+		if _, err := io.ReadFull(pinRandReader, buf); err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
+		}
+		for _, b := range buf {
+			if b >= pinRejectThreshold {
+				continue
+			}
+			pin = append(pin, '0'+b%10)
+			if len(pin) == digits {
+				break
+			}
+		}
+	}
+
+	return string(pin), nil
+}
@@ -0,0 +1,73 @@
+package password
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePIN_Length(t *testing.T) {
+	for _, digits := range []int{1, 4, 6, 8, MaxPINDigits} {
+		pin, err := GeneratePIN(digits)
+		require.NoError(t, err)
+		require.Len(t, pin, digits)
+		for _, c := range pin {
+			require.True(t, c >= '0' && c <= '9', "PIN should only contain digits, got %q", pin)
+		}
+	}
+}
+
+func TestGeneratePIN_InvalidLength(t *testing.T) {
+	for _, digits := range []int{0, -1, MaxPINDigits + 1} {
+		_, err := GeneratePIN(digits)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid PIN length")
+	}
+}
+
+func TestGeneratePIN_RejectsBiasedBytes(t *testing.T) {
+	old := pinRandReader
+	defer func() { pinRandReader = old }()
+
+	// 250-255 would favour the digits 0-5 and must be skipped
+	pinRandReader = bytes.NewReader([]byte{255, 250, 9, 252, 10, 123, 0, 0})
+
+	pin, err := GeneratePIN(3)
+	require.NoError(t, err)
+	require.Equal(t, "903", pin)
+}
+
+func TestGeneratePIN_ReadError(t *testing.T) {
+	old := pinRandReader
+	defer func() { pinRandReader = old }()
+
+	pinRandReader = errReader{}
+	_, err := GeneratePIN(6)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read random bytes")
+}
+
+func TestGeneratePIN_Distribution(t *testing.T) {
+	const samples = 20000
+	var counts [10]int
+	for range samples / 10 {
+		pin, err := GeneratePIN(10)
+		require.NoError(t, err)
+		for _, c := range pin {
+			counts[c-'0']++
+		}
+	}
+
+	// Each digit should appear roughly 10% of the time
+	for digit, count := range counts {
+		require.InDelta(t, samples/10, count, samples/50, "digit %d appeared %d times", digit, count)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy source unavailable")
+}
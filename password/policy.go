@@ -0,0 +1,215 @@
+package password
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Policy describes the requirements a password must meet to be compliant.
+type Policy struct {
+	// Name identifies the policy, e.g. "nist".
+	Name string
+	// Description is a human-readable title for the policy.
+	Description string
+	// MinLength is the minimum number of characters (Unicode code points).
+	MinLength int
+	// MinEntropy is the minimum entropy in bits. Zero disables the requirement.
+	MinEntropy float64
+	// RejectCommon rejects commonly used passwords and passwords made up
+	// entirely of repeated, sequential or keyboard-adjacent characters.
+	RejectCommon bool
+	// RejectBreached rejects passwords that appear in known data breaches.
+	RejectBreached bool
+}
+
+// NIST implements the memorized secret guidance of NIST SP 800-63B: at least
+// 8 characters, no composition rules, and screening against commonly used and
+// breached passwords.
+var NIST = Policy{
+	Name:           "nist",
+	Description:    "NIST SP 800-63B",
+	MinLength:      8,
+	RejectCommon:   true,
+	RejectBreached: true,
+}
+
+// presets are the policies selectable by name.
+var presets = map[string]Policy{
+	NIST.Name: NIST,
+}
+
+// Preset returns the policy preset with the given name.
+func Preset(name string) (Policy, error) {
+	policy, ok := presets[strings.ToLower(name)]
+	if !ok {
+		return Policy{}, fmt.Errorf("unknown policy preset: %s (supported: %s)", name, strings.Join(Presets(), ", "))
+	}
+	return policy, nil
+}
+
+// Presets returns the names of the available policy presets in sorted order.
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Rule identifies the policy requirement a password violated.
+type Rule string
+
+const (
+	// RuleMinLength is violated by passwords shorter than Policy.MinLength.
+	RuleMinLength Rule = "min_length"
+	// RuleEntropy is violated by passwords with less than Policy.MinEntropy bits.
+	RuleEntropy Rule = "entropy"
+	// RuleCommon is violated by commonly used or trivially predictable passwords.
+	RuleCommon Rule = "common"
+	// RuleBreached is violated by passwords found in known data breaches.
+	RuleBreached Rule = "breached"
+)
+
+// Violation describes a single failed policy requirement.
+type Violation struct {
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyResult is the outcome of evaluating a password against a policy.
+// It never contains the password itself.
+type PolicyResult struct {
+	Policy        string      `json:"policy"`
+	Compliant     bool        `json:"compliant"`
+	Violations    []Violation `json:"violations"`
+	BreachChecked bool        `json:"breach_checked"`
+	PwnedCount    int         `json:"pwned_count"`
+}
+
+// Evaluate checks a password against the policy. If the policy rejects
+// breached passwords, client is used for the check, or DefaultPwnedClient if
+// client is nil; an error is returned if the breach check cannot be completed.
+func (p Policy) Evaluate(ctx context.Context, password string, client *PwnedClient) (*PolicyResult, error) {
+	if password == "" {
+		return nil, ErrEmptyPassword
+	}
+
+	result := &PolicyResult{
+		Policy:     p.Name,
+		Violations: []Violation{},
+	}
+
+	if length := len([]rune(password)); length < p.MinLength {
+		result.Violations = append(result.Violations, Violation{
+			Rule:    RuleMinLength,
+			Message: fmt.Sprintf("must be at least %d characters (has %d)", p.MinLength, length),
+		})
+	}
+
+	if p.MinEntropy > 0 {
+		if ok, message := CheckEntropy(password, p.MinEntropy); !ok {
+			result.Violations = append(result.Violations, Violation{Rule: RuleEntropy, Message: message})
+		}
+	}
+
+	if p.RejectCommon && isCommonPassword(password) {
+		result.Violations = append(result.Violations, Violation{
+			Rule:    RuleCommon,
+			Message: "is a commonly used or easily guessed password",
+		})
+	}
+
+	if p.RejectBreached {
+		if client == nil {
+			client = DefaultPwnedClient
+		}
+		count, err := client.Check(ctx, password)
+		if err != nil {
+			return nil, fmt.Errorf("breached password screening failed: %w", err)
+		}
+		result.BreachChecked = true
+		result.PwnedCount = count
+		if count > 0 {
+			result.Violations = append(result.Violations, Violation{
+				Rule:    RuleBreached,
+				Message: fmt.Sprintf("has appeared in known data breaches (%d times)", count),
+			})
+		}
+	}
+
+	result.Compliant = len(result.Violations) == 0
+	return result, nil
+}
+
+// isCommonPassword reports whether a password, ignoring case, l33t
+// substitutions and trailing digits or symbols, is a common password, or
+// consists entirely of a repeated, sequential or keyboard-walk pattern.
+// Unlike GetFeedback it only considers the password as a whole, so
+// passphrases that merely contain a common word are accepted.
+func isCommonPassword(password string) bool {
+	lower := strings.ToLower(password)
+	core := strings.TrimRightFunc(lower, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z')
+	})
+
+	for _, candidate := range []string{lower, core, leetReplacer.Replace(lower), leetReplacer.Replace(core)} {
+		for _, word := range commonWords {
+			if candidate == word {
+				return true
+			}
+		}
+	}
+
+	return isRepeated(lower) || isSequence(lower) || isKeyboardWalk(lower)
+}
+
+// isRepeated reports whether s is a single character or chunk repeated
+// back to back, such as "aaaaaaaa" or "abcabcabc".
+func isRepeated(s string) bool {
+	runes := []rune(s)
+	for size := 1; size*2 <= len(runes); size++ {
+		if len(runes)%size != 0 {
+			continue
+		}
+		chunk := string(runes[:size])
+		if strings.Repeat(chunk, len(runes)/size) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// isSequence reports whether s is made up entirely of consecutive
+// characters in one direction, such as "12345678" or "hgfedcba".
+func isSequence(s string) bool {
+	runes := []rune(s)
+	if len(runes) < minPatternLength {
+		return false
+	}
+	delta := runes[1] - runes[0]
+	if delta != 1 && delta != -1 {
+		return false
+	}
+	for i := 2; i < len(runes); i++ {
+		if runes[i]-runes[i-1] != delta {
+			return false
+		}
+	}
+	return true
+}
+
+// isKeyboardWalk reports whether s is entirely a straight walk along one keyboard row.
+func isKeyboardWalk(s string) bool {
+	if len(s) < minPatternLength {
+		return false
+	}
+	for _, row := range keyboardRows {
+		if strings.Contains(row, s) || strings.Contains(reverse(row), s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreset(t *testing.T) {
+	policy, err := Preset("NIST")
+	require.NoError(t, err)
+	require.Equal(t, NIST, policy)
+	require.Equal(t, 8, policy.MinLength)
+	require.Zero(t, policy.MinEntropy, "NIST guidance has no composition or entropy rules")
+
+	_, err = Preset("pci")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown policy preset")
+
+	require.Equal(t, []string{"nist"}, Presets())
+}
+
+func TestPolicy_Evaluate_NIST(t *testing.T) {
+	server := newPwnedServer(t, map[string]int{"Tr0ub4dor&3": 1234}, nil)
+	defer server.Close()
+	client := &PwnedClient{BaseURL: server.URL + "/range/"}
+
+	tests := []struct {
+		name     string
+		password string
+		rules    []Rule
+	}{
+		{"long passphrase", "correct horse battery staple", nil},
+		{"passphrase containing common word", "my password is a secret sentence", nil},
+		{"lowercase only is fine", "zebrapianoclouds", nil},
+		{"unicode counts characters", "ñandú-über-café", nil},
+		{"too short", "k9#Vq2", []Rule{RuleMinLength}},
+		{"common password", "password", []Rule{RuleCommon}},
+		{"common with suffix", "Password123!", []Rule{RuleCommon}},
+		{"common with leet", "p@ssw0rd", []Rule{RuleCommon}},
+		{"repeated", "aaaaaaaaaa", []Rule{RuleCommon}},
+		{"repeated chunk", "abcabcabcabc", []Rule{RuleCommon}},
+		{"sequence", "123456789", []Rule{RuleCommon}},
+		{"keyboard walk", "qwertyuiop", []Rule{RuleCommon}},
+		{"short and common", "qwerty", []Rule{RuleMinLength, RuleCommon}},
+		{"breached", "Tr0ub4dor&3", []Rule{RuleBreached}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NIST.Evaluate(context.Background(), tt.password, client)
+			require.NoError(t, err)
+			require.Equal(t, "nist", result.Policy)
+			require.True(t, result.BreachChecked)
+
+			var rules []Rule
+			for _, v := range result.Violations {
+				require.NotEmpty(t, v.Message)
+				rules = append(rules, v.Rule)
+			}
+			require.Equal(t, tt.rules, rules)
+			require.Equal(t, len(tt.rules) == 0, result.Compliant)
+		})
+	}
+}
+
+func TestPolicy_Evaluate_BreachCount(t *testing.T) {
+	server := newPwnedServer(t, map[string]int{"Tr0ub4dor&3": 1234}, nil)
+	defer server.Close()
+
+	result, err := NIST.Evaluate(context.Background(), "Tr0ub4dor&3", &PwnedClient{BaseURL: server.URL + "/range/"})
+	require.NoError(t, err)
+	require.Equal(t, 1234, result.PwnedCount)
+	require.Contains(t, result.Violations[0].Message, "1234 times")
+}
+
+func TestPolicy_Evaluate_OfflineFails(t *testing.T) {
+	_, err := NIST.Evaluate(context.Background(), "correct horse battery staple", &PwnedClient{Offline: true})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrOffline))
+}
+
+func TestPolicy_Evaluate_WithoutBreachCheck(t *testing.T) {
+	policy := Policy{Name: "custom", MinLength: 4, MinEntropy: 50}
+
+	result, err := policy.Evaluate(context.Background(), "abcd", nil)
+	require.NoError(t, err)
+	require.False(t, result.BreachChecked)
+	require.False(t, result.Compliant)
+	require.Len(t, result.Violations, 1)
+	require.Equal(t, RuleEntropy, result.Violations[0].Rule)
+}
+
+func TestPolicy_Evaluate_Empty(t *testing.T) {
+	_, err := NIST.Evaluate(context.Background(), "", nil)
+	require.ErrorIs(t, err, ErrEmptyPassword)
+}
@@ -0,0 +1,119 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPwnedURL is the Have I Been Pwned range API endpoint.
+const DefaultPwnedURL = "https://api.pwnedpasswords.com/range/"
+
+var (
+	// ErrOffline is returned by CheckPwned when the client is in offline mode.
+	ErrOffline = errors.New("pwned password check unavailable in offline mode")
+
+	// ErrEmptyPassword is returned when an empty password is checked.
+	ErrEmptyPassword = errors.New("password cannot be empty")
+)
+
+// PwnedClient queries the Have I Been Pwned Pwned Passwords range API using
+// k-anonymity: only the first 5 characters of the password's SHA-1 hash are
+// sent over the network, and the match is performed locally.
+type PwnedClient struct {
+	// HTTPClient is used for requests. If nil, a client with a 10 second timeout is used.
+	HTTPClient *http.Client
+	// BaseURL is the range API endpoint. If empty, DefaultPwnedURL is used.
+	BaseURL string
+	// UserAgent is sent with each request, as required by the API.
+	UserAgent string
+	// Offline disables network access. Check returns ErrOffline when set.
+	Offline bool
+}
+
+// DefaultPwnedClient is the client used by CheckPwned.
+var DefaultPwnedClient = &PwnedClient{}
+
+// CheckPwned reports how many times the password appears in known data breaches
+// using DefaultPwnedClient. A count of zero means the password was not found.
+func CheckPwned(ctx context.Context, password string) (int, error) {
+	return DefaultPwnedClient.Check(ctx, password)
+}
+
+// Check reports how many times the password appears in known data breaches.
+// A count of zero means the password was not found.
+func (c *PwnedClient) Check(ctx context.Context, password string) (int, error) {
+	if password == "" {
+		return 0, ErrEmptyPassword
+	}
+	if c.Offline {
+		return 0, ErrOffline
+	}
+
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := digest[:5], digest[5:]
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultPwnedURL
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+prefix, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	userAgent := c.UserAgent
+	if userAgent == "" {
+		userAgent = "toolshed-password-check"
+	}
+	req.Header.Set("User-Agent", userAgent)
+	// Padding hides the real number of suffixes in the response from observers
+	req.Header.Set("Add-Padding", "true")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query pwned passwords API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("pwned passwords API returned status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		hashSuffix, countStr, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(hashSuffix, suffix) {
+			continue
+		}
+
+		count, err := strconv.Atoi(countStr)
+		if err != nil {
+			return 0, fmt.Errorf("invalid count in API response: %w", err)
+		}
+		// Padding entries have a count of zero
+		return count, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read API response: %w", err)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,103 @@
+package password
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newPwnedServer returns a test server that knows about the given passwords
+// and records the range prefixes it was asked for.
+func newPwnedServer(t *testing.T, known map[string]int, prefixes *[]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := strings.TrimPrefix(r.URL.Path, "/range/")
+		if prefixes != nil {
+			*prefixes = append(*prefixes, prefix)
+		}
+		require.Equal(t, "true", r.Header.Get("Add-Padding"))
+		require.NotEmpty(t, r.Header.Get("User-Agent"))
+
+		// Padding entry that must never match
+		fmt.Fprintf(w, "%s:0\r\n", strings.Repeat("0", 35))
+		for pw, count := range known {
+			sum := sha1.Sum([]byte(pw))
+			digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+			if digest[:5] == prefix {
+				fmt.Fprintf(w, "%s:%d\r\n", digest[5:], count)
+			}
+		}
+	}))
+}
+
+func TestPwnedClient_Found(t *testing.T) {
+	var prefixes []string
+	server := newPwnedServer(t, map[string]int{"password": 9659365}, &prefixes)
+	defer server.Close()
+
+	client := &PwnedClient{BaseURL: server.URL + "/range/"}
+	count, err := client.Check(context.Background(), "password")
+	require.NoError(t, err)
+	require.Equal(t, 9659365, count)
+
+	// Only the 5 character prefix of the SHA-1 hash is sent
+	require.Equal(t, []string{"5BAA6"}, prefixes)
+}
+
+func TestPwnedClient_NotFound(t *testing.T) {
+	server := newPwnedServer(t, map[string]int{"password": 10}, nil)
+	defer server.Close()
+
+	client := &PwnedClient{BaseURL: server.URL + "/range"}
+	count, err := client.Check(context.Background(), "G@7e*vS93^8!bdT2")
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestPwnedClient_Offline(t *testing.T) {
+	client := &PwnedClient{Offline: true}
+	_, err := client.Check(context.Background(), "password")
+	require.ErrorIs(t, err, ErrOffline)
+}
+
+func TestPwnedClient_EmptyPassword(t *testing.T) {
+	client := &PwnedClient{Offline: true}
+	_, err := client.Check(context.Background(), "")
+	require.ErrorIs(t, err, ErrEmptyPassword)
+}
+
+func TestPwnedClient_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &PwnedClient{BaseURL: server.URL}
+	_, err := client.Check(context.Background(), "password")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 503")
+}
+
+func TestPwnedClient_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	client := &PwnedClient{BaseURL: server.URL}
+	_, err := client.Check(ctx, "password")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to query pwned passwords API")
+}
@@ -0,0 +1,211 @@
+// Package snowflake generates Snowflake IDs: 63-bit integers made up of a
+// millisecond timestamp, a node ID and a per-millisecond sequence number.
+// Snowflake IDs are ordered by creation time and fit in an int64, which makes
+// them a compact alternative to ULIDs for databases and APIs that expect
+// integer keys.
+//
+// The number of bits for the node ID and sequence and the epoch the timestamp
+// is measured from are configurable; the defaults match the original Twitter
+// layout (41 bit timestamp, 10 bit node ID, 12 bit sequence).
+//
+// Example usage:
+//
+//	gen, err := snowflake.NewGenerator(1, snowflake.DefaultConfig)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	id, err := gen.Generate()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(id)
+//
+//	// Split an ID back into its components
+//	parts := snowflake.DefaultConfig.Decompose(id)
+//	fmt.Println(parts.Time, parts.Node, parts.Sequence)
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultNodeBits is the number of bits for the node ID in DefaultConfig.
+	DefaultNodeBits = 10
+	// DefaultSequenceBits is the number of bits for the sequence in DefaultConfig.
+	DefaultSequenceBits = 12
+	// MinTimestampBits is the fewest timestamp bits a configuration may leave,
+	// enough for roughly 69 years of milliseconds.
+	MinTimestampBits = 41
+	// totalBits is the number of usable bits in a non-negative int64.
+	totalBits = 63
+)
+
+// DefaultEpoch is the Twitter Snowflake epoch, 2010-11-04T01:42:54.657Z.
+var DefaultEpoch = time.UnixMilli(1288834974657).UTC()
+
+// DefaultConfig uses the original Twitter Snowflake layout.
+var DefaultConfig = Config{
+	Epoch:        DefaultEpoch,
+	NodeBits:     DefaultNodeBits,
+	SequenceBits: DefaultSequenceBits,
+}
+
+var (
+	// ErrInvalidConfig is returned for a configuration whose bit layout does not fit in 63 bits.
+	ErrInvalidConfig = errors.New("invalid snowflake configuration")
+	// ErrInvalidNode is returned for a node ID outside the range allowed by NodeBits.
+	ErrInvalidNode = errors.New("invalid snowflake node ID")
+	// ErrTimeOutOfRange is returned when the current time is before the epoch
+	// or too far after it to be represented.
+	ErrTimeOutOfRange = errors.New("time out of range for snowflake epoch")
+)
+
+// ID is a Snowflake ID.
+type ID int64
+
+// String returns the ID in decimal.
+func (id ID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// Parse parses a decimal Snowflake ID.
+func Parse(s string) (ID, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid snowflake ID %q", s)
+	}
+	return ID(n), nil
+}
+
+// Config describes the layout of a Snowflake ID. From most to least
+// significant, an ID holds the milliseconds since Epoch, the node ID and the
+// sequence number. The timestamp gets whatever bits remain of 63.
+type Config struct {
+	Epoch        time.Time
+	NodeBits     uint8
+	SequenceBits uint8
+}
+
+// Validate checks that the layout leaves at least MinTimestampBits for the timestamp.
+func (c Config) Validate() error {
+	if int(c.NodeBits)+int(c.SequenceBits) > totalBits-MinTimestampBits {
+		return fmt.Errorf("%w: node and sequence bits total %d, at most %d allowed",
+			ErrInvalidConfig, int(c.NodeBits)+int(c.SequenceBits), totalBits-MinTimestampBits)
+	}
+	return nil
+}
+
+// TimestampBits returns the number of bits used for the timestamp.
+func (c Config) TimestampBits() uint8 {
+	return totalBits - c.NodeBits - c.SequenceBits
+}
+
+// MaxNode returns the largest node ID the layout can hold.
+func (c Config) MaxNode() int64 {
+	return 1<<c.NodeBits - 1
+}
+
+// MaxSequence returns the largest sequence number the layout can hold,
+// which is one less than the number of IDs a node can generate per millisecond.
+func (c Config) MaxSequence() int64 {
+	return 1<<c.SequenceBits - 1
+}
+
+// maxTimestamp returns the largest timestamp, in milliseconds since the epoch.
+func (c Config) maxTimestamp() int64 {
+	return 1<<c.TimestampBits() - 1
+}
+
+// Parts are the components of a Snowflake ID.
+type Parts struct {
+	Time     time.Time
+	Node     int64
+	Sequence int64
+}
+
+// Decompose splits an ID generated with this configuration into its components.
+func (c Config) Decompose(id ID) Parts {
+	n := int64(id)
+	ms := n >> (c.NodeBits + c.SequenceBits)
+	return Parts{
+		Time:     c.Epoch.Add(time.Duration(ms) * time.Millisecond),
+		Node:     (n >> c.SequenceBits) & c.MaxNode(),
+		Sequence: n & c.MaxSequence(),
+	}
+}
+
+// compose builds an ID from a timestamp in milliseconds since the epoch, a node and a sequence.
+func (c Config) compose(ms, node, seq int64) ID {
+	return ID(ms<<(c.NodeBits+c.SequenceBits) | node<<c.SequenceBits | seq)
+}
+
+// Generator creates Snowflake IDs for one node. It is safe for concurrent use.
+type Generator struct {
+	config Config
+	node   int64
+	now    func() time.Time
+
+	mu       sync.Mutex
+	lastMs   int64
+	sequence int64
+}
+
+// NewGenerator creates a generator for the given node ID, which must be
+// unique among all generators producing IDs for the same system.
+func NewGenerator(node int64, config Config) (*Generator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if node < 0 || node > config.MaxNode() {
+		return nil, fmt.Errorf("%w: %d (must be between 0 and %d)", ErrInvalidNode, node, config.MaxNode())
+	}
+	return &Generator{
+		config: config,
+		node:   node,
+		now:    time.Now,
+		lastMs: -1,
+	}, nil
+}
+
+// Config returns the layout used by the generator.
+func (g *Generator) Config() Config {
+	return g.config
+}
+
+// Generate returns a new ID, greater than any previously returned by this
+// generator. If the sequence for the current millisecond is exhausted, or the
+// clock moved backwards, the timestamp is advanced past the last one used
+// instead of waiting, so IDs stay unique and ordered.
+func (g *Generator) Generate() (ID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := g.now().Sub(g.config.Epoch).Milliseconds()
+	if ms < 0 {
+		return 0, fmt.Errorf("%w: current time is before epoch %s", ErrTimeOutOfRange, g.config.Epoch.Format(time.RFC3339))
+	}
+
+	if ms > g.lastMs {
+		g.sequence = 0
+	} else {
+		ms = g.lastMs
+		g.sequence++
+		if g.sequence > g.config.MaxSequence() {
+			ms++
+			g.sequence = 0
+		}
+	}
+
+	if ms > g.config.maxTimestamp() {
+		return 0, fmt.Errorf("%w: timestamp exceeds %d bits", ErrTimeOutOfRange, g.config.TimestampBits())
+	}
+
+	g.lastMs = ms
+	return g.config.compose(ms, g.node, g.sequence), nil
+}
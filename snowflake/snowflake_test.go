@@ -0,0 +1,140 @@
+package snowflake
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClock returns a clock that always reports t.
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+func TestGenerate_Decompose(t *testing.T) {
+	gen, err := NewGenerator(42, DefaultConfig)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	gen.now = fixedClock(now)
+
+	id, err := gen.Generate()
+	require.NoError(t, err)
+	require.Positive(t, int64(id))
+
+	parts := DefaultConfig.Decompose(id)
+	require.True(t, now.Equal(parts.Time), "got %s", parts.Time)
+	require.Equal(t, int64(42), parts.Node)
+	require.Equal(t, int64(0), parts.Sequence)
+
+	id2, err := gen.Generate()
+	require.NoError(t, err)
+	require.Greater(t, id2, id)
+	require.Equal(t, int64(1), DefaultConfig.Decompose(id2).Sequence)
+}
+
+func TestGenerate_SequenceExhausted(t *testing.T) {
+	config := Config{Epoch: DefaultEpoch, NodeBits: 4, SequenceBits: 2}
+	gen, err := NewGenerator(3, config)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen.now = fixedClock(now)
+
+	var last ID
+	for i := 0; i < 10; i++ {
+		id, err := gen.Generate()
+		require.NoError(t, err)
+		require.Greater(t, id, last)
+		last = id
+	}
+
+	// 4 IDs per millisecond, so the 10th borrows two milliseconds ahead
+	parts := config.Decompose(last)
+	require.Equal(t, now.Add(2*time.Millisecond), parts.Time)
+	require.Equal(t, int64(1), parts.Sequence)
+}
+
+func TestGenerate_ClockBackwards(t *testing.T) {
+	gen, err := NewGenerator(1, DefaultConfig)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen.now = fixedClock(now)
+	first, err := gen.Generate()
+	require.NoError(t, err)
+
+	gen.now = fixedClock(now.Add(-time.Second))
+	second, err := gen.Generate()
+	require.NoError(t, err)
+	require.Greater(t, second, first)
+}
+
+func TestGenerate_OutOfRange(t *testing.T) {
+	gen, err := NewGenerator(1, DefaultConfig)
+	require.NoError(t, err)
+
+	gen.now = fixedClock(DefaultEpoch.Add(-time.Millisecond))
+	_, err = gen.Generate()
+	require.ErrorIs(t, err, ErrTimeOutOfRange)
+
+	gen.now = fixedClock(DefaultEpoch.Add(time.Duration(DefaultConfig.maxTimestamp()+1) * time.Millisecond))
+	_, err = gen.Generate()
+	require.ErrorIs(t, err, ErrTimeOutOfRange)
+}
+
+func TestGenerate_Concurrent(t *testing.T) {
+	gen, err := NewGenerator(7, DefaultConfig)
+	require.NoError(t, err)
+
+	const workers, perWorker = 8, 1000
+	var mu sync.Mutex
+	seen := make(map[ID]bool, workers*perWorker)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id, err := gen.Generate()
+				require.NoError(t, err)
+				mu.Lock()
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seen, workers*perWorker)
+}
+
+func TestNewGenerator_Invalid(t *testing.T) {
+	_, err := NewGenerator(1024, DefaultConfig)
+	require.ErrorIs(t, err, ErrInvalidNode)
+
+	_, err = NewGenerator(-1, DefaultConfig)
+	require.ErrorIs(t, err, ErrInvalidNode)
+
+	_, err = NewGenerator(0, Config{Epoch: DefaultEpoch, NodeBits: 12, SequenceBits: 12})
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestConfig_Bits(t *testing.T) {
+	require.Equal(t, uint8(41), DefaultConfig.TimestampBits())
+	require.Equal(t, int64(1023), DefaultConfig.MaxNode())
+	require.Equal(t, int64(4095), DefaultConfig.MaxSequence())
+}
+
+func TestParse(t *testing.T) {
+	id, err := Parse("1789278612537372672")
+	require.NoError(t, err)
+	require.Equal(t, "1789278612537372672", id.String())
+
+	for _, input := range []string{"", "abc", "-5", "99999999999999999999"} {
+		_, err := Parse(input)
+		require.Error(t, err, input)
+	}
+}
@@ -0,0 +1,52 @@
+package ulid
+
+import (
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Details describes the components of a ULID.
+type Details struct {
+	// Prefix is the prefix before the underscore, or empty if there is none.
+	Prefix string
+	// Encoded is the base62-encoded ULID without the prefix.
+	Encoded string
+	// ULID is the decoded 16-byte ULID.
+	ULID ulid.ULID
+	// Time is the creation time stored in the first 48 bits.
+	Time time.Time
+	// Entropy is the 80-bit random component.
+	Entropy []byte
+}
+
+// Canonical returns the ULID in the standard 26-character Crockford base32 form.
+func (d *Details) Canonical() string {
+	return d.ULID.String()
+}
+
+// Inspect validates a ULID and splits it into its components.
+func Inspect(id string) (*Details, error) {
+	if err := Validate(id); err != nil {
+		return nil, err
+	}
+
+	decoded, err := Decode(id)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &Details{
+		Encoded: id,
+		ULID:    decoded,
+		Time:    ulid.Time(decoded.Time()),
+		Entropy: decoded.Entropy(),
+	}
+	if prefix, encoded, found := strings.Cut(id, "_"); found {
+		details.Prefix = prefix
+		details.Encoded = encoded
+	}
+
+	return details, nil
+}
@@ -0,0 +1,38 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 123_000_000, time.UTC)
+	id, err := CreateULID("order", ts)
+	require.NoError(t, err)
+
+	details, err := Inspect(id)
+	require.NoError(t, err)
+	require.Equal(t, "order", details.Prefix)
+	require.Equal(t, id, "order_"+details.Encoded)
+	require.True(t, ts.Equal(details.Time))
+	require.Len(t, details.Entropy, 10)
+	require.Len(t, details.Canonical(), 26)
+	require.Equal(t, details.ULID.Entropy(), details.Entropy)
+}
+
+func TestInspect_NoPrefix(t *testing.T) {
+	id, err := CreateULID("", time.Now())
+	require.NoError(t, err)
+
+	details, err := Inspect(id)
+	require.NoError(t, err)
+	require.Empty(t, details.Prefix)
+	require.Equal(t, id, details.Encoded)
+}
+
+func TestInspect_Invalid(t *testing.T) {
+	_, err := Inspect("user_***")
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+}
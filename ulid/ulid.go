@@ -20,6 +20,17 @@
 //		log.Fatal(err)
 //	}
 //	fmt.Println("Created at:", timestamp)
+//
+//	// Validate an untrusted ULID before use
+//	if err := ulid.Validate(input); err != nil {
+//		fmt.Println("Invalid ULID:", err)
+//	}
+//
+//	// Break a ULID down into its prefix, timestamp and entropy
+//	details, err := ulid.Inspect(id)
+//	if err == nil {
+//		fmt.Println(details.Prefix, details.Time, details.Canonical())
+//	}
 package ulid
 
 import (
@@ -0,0 +1,87 @@
+package ulid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/bilte-co/toolshed/base62"
+)
+
+// MaxPrefixLength is the longest prefix accepted by ValidatePrefix.
+const MaxPrefixLength = 32
+
+// encodedLen is the longest base62 encoding of the 16 bytes of a ULID.
+var encodedLen = base62.MaxEncodedLen(16)
+
+// alphabet is the set of characters used to encode the ULID portion.
+const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var (
+	// ErrInvalidPrefix is returned for a prefix that is empty, too long, or
+	// contains whitespace or an underscore.
+	ErrInvalidPrefix = errors.New("invalid ULID prefix")
+	// ErrInvalidLength is returned when the ULID portion does not encode exactly 16 bytes.
+	ErrInvalidLength = errors.New("invalid ULID length")
+	// ErrInvalidCharacter is returned when the ULID portion contains a character
+	// outside the base62 alphabet.
+	ErrInvalidCharacter = errors.New("invalid ULID character")
+	// ErrTimestampOverflow is returned when the encoded value exceeds 128 bits,
+	// which would put the timestamp beyond its 48-bit maximum.
+	ErrTimestampOverflow = errors.New("ULID timestamp overflow")
+)
+
+// ValidatePrefix checks that a prefix can be used with CreateULID and later
+// be separated from the ULID: it must be at most MaxPrefixLength characters
+// and contain neither whitespace nor an underscore.
+func ValidatePrefix(prefix string) error {
+	if prefix == "" {
+		return fmt.Errorf("%w: prefix is empty", ErrInvalidPrefix)
+	}
+	if len(prefix) > MaxPrefixLength {
+		return fmt.Errorf("%w: prefix cannot exceed %d characters", ErrInvalidPrefix, MaxPrefixLength)
+	}
+	if strings.ContainsFunc(prefix, func(r rune) bool { return r == '_' || unicode.IsSpace(r) }) {
+		return fmt.Errorf("%w: prefix cannot contain whitespace or underscore characters", ErrInvalidPrefix)
+	}
+	return nil
+}
+
+// Validate checks that s is a well-formed ULID as produced by CreateULID. It
+// checks the prefix, if any, the length and alphabet of the encoded portion,
+// and that the encoded value fits in a ULID. The returned error wraps one of
+// ErrInvalidPrefix, ErrInvalidLength, ErrInvalidCharacter or ErrTimestampOverflow.
+// A nil error guarantees that Decode and Timestamp succeed.
+func Validate(s string) error {
+	encoded := s
+	if pos := strings.Index(s, "_"); pos != -1 {
+		if err := ValidatePrefix(s[:pos]); err != nil {
+			return err
+		}
+		encoded = s[pos+1:]
+	}
+
+	for i, r := range encoded {
+		if !strings.ContainsRune(alphabet, r) {
+			return fmt.Errorf("%w: %q at position %d", ErrInvalidCharacter, r, i)
+		}
+	}
+
+	if encoded == "" || len(encoded) > encodedLen {
+		return fmt.Errorf("%w: got %d characters, expected at most %d", ErrInvalidLength, len(encoded), encodedLen)
+	}
+
+	decoded, err := base62.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCharacter, err)
+	}
+	switch {
+	case len(decoded) > 16:
+		return fmt.Errorf("%w: value exceeds 128 bits", ErrTimestampOverflow)
+	case len(decoded) < 16:
+		return fmt.Errorf("%w: decodes to %d bytes, expected 16", ErrInvalidLength, len(decoded))
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package ulid
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bilte-co/toolshed/base62"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_Valid(t *testing.T) {
+	for _, prefix := range []string{"", "user", strings.Repeat("a", MaxPrefixLength)} {
+		id, err := CreateULID(prefix, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, Validate(id), id)
+
+		_, err = Decode(id)
+		require.NoError(t, err)
+	}
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	id, err := CreateULID("", time.Now())
+	require.NoError(t, err)
+
+	max := make([]byte, 16)
+	for i := range max {
+		max[i] = 0xff
+	}
+	maxEncoded := base62.StdEncoding.EncodeToString(max)
+	require.Len(t, maxEncoded, encodedLen)
+
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"empty", "", ErrInvalidLength},
+		{"only prefix", "user_", ErrInvalidLength},
+		{"empty prefix", "_" + id, ErrInvalidPrefix},
+		{"prefix too long", strings.Repeat("a", MaxPrefixLength+1) + "_" + id, ErrInvalidPrefix},
+		{"prefix with space", "us er_" + id, ErrInvalidPrefix},
+		{"multiple underscores", "user_x_" + id, ErrInvalidCharacter},
+		{"too long", strings.Repeat("1", encodedLen+1), ErrInvalidLength},
+		{"too short", "abc", ErrInvalidLength},
+		{"bad character", id[:len(id)-1] + "-", ErrInvalidCharacter},
+		{"non-ascii", id[:len(id)-1] + "é", ErrInvalidCharacter},
+		{"overflow", strings.Repeat("z", encodedLen), ErrTimestampOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, Validate(tt.input), tt.err)
+		})
+	}
+
+	require.NoError(t, Validate(maxEncoded))
+}
+
+func TestValidatePrefix(t *testing.T) {
+	require.NoError(t, ValidatePrefix("user"))
+	require.ErrorIs(t, ValidatePrefix(""), ErrInvalidPrefix)
+	require.ErrorContains(t, ValidatePrefix("user_id"), "cannot contain whitespace")
+	require.ErrorContains(t, ValidatePrefix("user\tid"), "cannot contain whitespace")
+	require.ErrorContains(t, ValidatePrefix(strings.Repeat("a", 33)), "cannot exceed 32 characters")
+}
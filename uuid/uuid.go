@@ -0,0 +1,256 @@
+// Package uuid generates and parses RFC 9562 UUIDs. It supports random
+// version 4 UUIDs and time-ordered version 7 UUIDs, which sort by creation
+// time and make better database keys than version 4.
+//
+// Example usage:
+//
+//	id, err := uuid.NewV7()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(id)
+//
+//	// Parse and inspect a UUID
+//	parsed, err := uuid.Parse("urn:uuid:0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8e")
+//	if err == nil {
+//		fmt.Println(parsed.Version(), parsed.Variant())
+//		if created, ok := parsed.Time(); ok {
+//			fmt.Println("Created at:", created)
+//		}
+//	}
+package uuid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bilte-co/toolshed/base62"
+)
+
+// UUID is a 128-bit universally unique identifier.
+type UUID [16]byte
+
+// Nil is the all-zero UUID.
+var Nil UUID
+
+// Version is the UUID version stored in the high nibble of byte 6.
+type Version byte
+
+const (
+	V4 Version = 4 // Random
+	V7 Version = 7 // Unix timestamp and random
+)
+
+// Variant is the UUID layout identified by the high bits of byte 8.
+type Variant byte
+
+const (
+	VariantNCS       Variant = iota // Reserved, NCS backward compatibility
+	VariantRFC9562                  // The layout specified by RFC 9562 (formerly RFC 4122)
+	VariantMicrosoft                // Reserved, Microsoft backward compatibility
+	VariantFuture                   // Reserved for future definition
+)
+
+// String returns the name of the variant.
+func (v Variant) String() string {
+	switch v {
+	case VariantNCS:
+		return "ncs"
+	case VariantRFC9562:
+		return "rfc9562"
+	case VariantMicrosoft:
+		return "microsoft"
+	default:
+		return "future"
+	}
+}
+
+// Format is the textual representation of a UUID.
+type Format string
+
+const (
+	FormatCanonical Format = "canonical" // xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+	FormatHex       Format = "hex"       // 32 hex digits without hyphens
+	FormatURN       Format = "urn"       // urn:uuid: followed by the canonical form
+	FormatBraces    Format = "braces"    // Canonical form in curly braces
+	FormatBase62    Format = "base62"    // 22 base62 digits, for shorter URLs
+)
+
+// ErrInvalidUUID is returned by Parse for malformed input.
+var ErrInvalidUUID = errors.New("invalid UUID")
+
+// randReader is the source of randomness, replaceable in tests.
+var randReader io.Reader = rand.Reader
+
+// NewV4 returns a random version 4 UUID.
+func NewV4() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(randReader, u[:]); err != nil {
+		return Nil, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	u.setVersion(V4)
+	return u, nil
+}
+
+// v7 holds the state used to keep version 7 UUIDs from this process ordered.
+var v7 = struct {
+	sync.Mutex
+	now     func() time.Time
+	lastMs  int64
+	counter uint16
+}{now: time.Now}
+
+// maxV7Counter is the largest value of the 12-bit rand_a field.
+const maxV7Counter = 1<<12 - 1
+
+// NewV7 returns a version 7 UUID holding the current Unix time in
+// milliseconds. UUIDs generated by one process are strictly increasing: within
+// a millisecond the 12-bit rand_a field is used as a counter, as described in
+// RFC 9562 section 6.2, and the timestamp is advanced if the counter overflows
+// or the clock moves backwards.
+func NewV7() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(randReader, u[6:]); err != nil {
+		return Nil, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	v7.Lock()
+	ms := v7.now().UnixMilli()
+	if ms > v7.lastMs {
+		// Seed the counter with random bits, leaving room to count up
+		v7.counter = binary.BigEndian.Uint16(u[6:8]) & (maxV7Counter >> 1)
+	} else {
+		ms = v7.lastMs
+		v7.counter++
+		if v7.counter > maxV7Counter {
+			ms++
+			v7.counter = 0
+		}
+	}
+	v7.lastMs = ms
+	counter := v7.counter
+	v7.Unlock()
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(ms))
+	copy(u[0:6], buf[2:])
+	binary.BigEndian.PutUint16(u[6:8], counter)
+	u.setVersion(V7)
+	return u, nil
+}
+
+// setVersion sets the version nibble and the RFC 9562 variant bits.
+func (u *UUID) setVersion(v Version) {
+	u[6] = u[6]&0x0f | byte(v)<<4
+	u[8] = u[8]&0x3f | 0x80
+}
+
+// Parse parses a UUID in canonical, hex, URN or braced form, ignoring case,
+// or in the case-sensitive base62 form.
+func Parse(s string) (UUID, error) {
+	str := strings.TrimSpace(s)
+	if len(str) == base62.IDLen {
+		u, err := base62.DecodeID[UUID](str)
+		if err != nil {
+			return Nil, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+		}
+		return u, nil
+	}
+	if len(str) >= 9 && strings.EqualFold(str[:9], "urn:uuid:") {
+		str = str[9:]
+	} else if strings.HasPrefix(str, "{") && strings.HasSuffix(str, "}") {
+		str = str[1 : len(str)-1]
+	}
+
+	switch len(str) {
+	case 36:
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return Nil, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+		}
+		str = str[:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	case 32:
+	default:
+		return Nil, fmt.Errorf("%w: %q has wrong length", ErrInvalidUUID, s)
+	}
+
+	var u UUID
+	if _, err := hex.Decode(u[:], []byte(str)); err != nil {
+		return Nil, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	return u, nil
+}
+
+// String returns the canonical form of the UUID.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Format returns the UUID in the given representation.
+func (u UUID) Format(f Format) (string, error) {
+	switch f {
+	case FormatCanonical, "":
+		return u.String(), nil
+	case FormatHex:
+		return hex.EncodeToString(u[:]), nil
+	case FormatURN:
+		return "urn:uuid:" + u.String(), nil
+	case FormatBraces:
+		return "{" + u.String() + "}", nil
+	case FormatBase62:
+		return base62.EncodeID(u), nil
+	default:
+		return "", fmt.Errorf("unsupported UUID format: %s (supported: canonical, hex, urn, braces, base62)", f)
+	}
+}
+
+// IsNil reports whether u is the all-zero UUID.
+func (u UUID) IsNil() bool {
+	return u == Nil
+}
+
+// Version returns the version of the UUID.
+func (u UUID) Version() Version {
+	return Version(u[6] >> 4)
+}
+
+// Variant returns the variant of the UUID.
+func (u UUID) Variant() Variant {
+	switch {
+	case u[8]&0x80 == 0:
+		return VariantNCS
+	case u[8]&0xc0 == 0x80:
+		return VariantRFC9562
+	case u[8]&0xe0 == 0xc0:
+		return VariantMicrosoft
+	default:
+		return VariantFuture
+	}
+}
+
+// Time returns the creation time of a version 7 UUID. The second result is
+// false for other versions, which carry no Unix timestamp.
+func (u UUID) Time() (time.Time, bool) {
+	if u.Version() != V7 || u.Variant() != VariantRFC9562 {
+		return time.Time{}, false
+	}
+	var buf [8]byte
+	copy(buf[2:], u[0:6])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(buf[:]))), true
+}
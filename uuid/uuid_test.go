@@ -0,0 +1,151 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewV4(t *testing.T) {
+	seen := make(map[UUID]bool)
+	for i := 0; i < 1000; i++ {
+		u, err := NewV4()
+		require.NoError(t, err)
+		require.Equal(t, V4, u.Version())
+		require.Equal(t, VariantRFC9562, u.Variant())
+		require.False(t, seen[u])
+		seen[u] = true
+
+		_, ok := u.Time()
+		require.False(t, ok)
+	}
+}
+
+func TestNewV7_Time(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	u, err := NewV7()
+	require.NoError(t, err)
+
+	require.Equal(t, V7, u.Version())
+	require.Equal(t, VariantRFC9562, u.Variant())
+
+	created, ok := u.Time()
+	require.True(t, ok)
+	require.False(t, created.Before(before))
+	require.WithinDuration(t, time.Now(), created, time.Second)
+}
+
+func TestNewV7_Monotonic(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	v7.Lock()
+	v7.now = func() time.Time { return now }
+	v7.Unlock()
+	defer func() {
+		v7.Lock()
+		v7.now = time.Now
+		v7.Unlock()
+	}()
+
+	var last UUID
+	// Enough UUIDs in one millisecond to overflow the 12-bit counter
+	for i := 0; i < 5000; i++ {
+		u, err := NewV7()
+		require.NoError(t, err)
+		require.Equal(t, 1, bytes.Compare(u[:], last[:]), "UUID %d not increasing", i)
+		require.Equal(t, V7, u.Version())
+		last = u
+	}
+
+	created, ok := last.Time()
+	require.True(t, ok)
+	require.True(t, created.After(now))
+}
+
+func TestNew_RandError(t *testing.T) {
+	old := randReader
+	randReader = bytes.NewReader(nil)
+	defer func() { randReader = old }()
+
+	_, err := NewV4()
+	require.Error(t, err)
+	_, err = NewV7()
+	require.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	const canonical = "0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8e"
+
+	for _, input := range []string{
+		canonical,
+		"0192A8B4-9C3F-7B1A-8E2D-3F4A5B6C7D8E",
+		"0192a8b49c3f7b1a8e2d3f4a5b6c7d8e",
+		"urn:uuid:" + canonical,
+		"URN:UUID:" + canonical,
+		"{" + canonical + "}",
+		"  " + canonical + "\n",
+		"02y0yGz3Hr1be8pYQHxNHS",
+	} {
+		u, err := Parse(input)
+		require.NoError(t, err, input)
+		require.Equal(t, canonical, u.String())
+		require.Equal(t, V7, u.Version())
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"not-a-uuid",
+		"0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8",
+		"0192a8b4x9c3f-7b1a-8e2d-3f4a5b6c7d8e",
+		"0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8g",
+		"{0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8e",
+		"02y0yGz3Hr1be8pYQHxNH!",
+		"7n42DGM5Tflk9n8mt7Fhc8",
+	} {
+		_, err := Parse(input)
+		require.True(t, errors.Is(err, ErrInvalidUUID), input)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	u, err := Parse("0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8e")
+	require.NoError(t, err)
+
+	tests := map[Format]string{
+		FormatCanonical: "0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8e",
+		FormatHex:       "0192a8b49c3f7b1a8e2d3f4a5b6c7d8e",
+		FormatURN:       "urn:uuid:0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8e",
+		FormatBraces:    "{0192a8b4-9c3f-7b1a-8e2d-3f4a5b6c7d8e}",
+		FormatBase62:    "02y0yGz3Hr1be8pYQHxNHS",
+	}
+	for format, expected := range tests {
+		out, err := u.Format(format)
+		require.NoError(t, err)
+		require.Equal(t, expected, out)
+
+		parsed, err := Parse(out)
+		require.NoError(t, err)
+		require.Equal(t, u, parsed)
+	}
+
+	_, err = u.Format("base64")
+	require.ErrorContains(t, err, "unsupported UUID format")
+}
+
+func TestVariant(t *testing.T) {
+	var u UUID
+	require.True(t, u.IsNil())
+	require.Equal(t, VariantNCS, u.Variant())
+
+	u[8] = 0x80
+	require.Equal(t, VariantRFC9562, u.Variant())
+	u[8] = 0xc0
+	require.Equal(t, VariantMicrosoft, u.Variant())
+	u[8] = 0xe0
+	require.Equal(t, VariantFuture, u.Variant())
+	require.Equal(t, "future", u.Variant().String())
+}